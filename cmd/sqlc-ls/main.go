@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kyleconroy/sqlc/internal/lsp"
+)
+
+func main() {
+	if err := lsp.Serve(os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "sqlc-ls: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -3,6 +3,7 @@
 package booktest
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"time"
 )
@@ -26,6 +27,45 @@ func (e *BookTypeType) Scan(src interface{}) error {
 	return nil
 }
 
+type NullBookTypeType struct {
+	BookTypeType BookTypeType
+	Valid        bool // Valid is true if BookTypeType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBookTypeType) Scan(value interface{}) error {
+	if value == nil {
+		ns.BookTypeType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BookTypeType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBookTypeType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BookTypeType), nil
+}
+
+func (e BookTypeType) Valid() bool {
+	switch e {
+	case FICTION,
+		NONFICTION:
+		return true
+	}
+	return false
+}
+
+func AllBookTypeTypeValues() []BookTypeType {
+	return []BookTypeType{
+		FICTION,
+		NONFICTION,
+	}
+}
+
 type Author struct {
 	AuthorID int
 	Name     string
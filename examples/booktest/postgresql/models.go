@@ -3,6 +3,7 @@
 package booktest
 
 import (
+	"database/sql/driver"
 	"fmt"
 	"time"
 )
@@ -26,6 +27,45 @@ func (e *BookType) Scan(src interface{}) error {
 	return nil
 }
 
+type NullBookType struct {
+	BookType BookType
+	Valid    bool // Valid is true if BookType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBookType) Scan(value interface{}) error {
+	if value == nil {
+		ns.BookType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BookType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBookType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BookType), nil
+}
+
+func (e BookType) Valid() bool {
+	switch e {
+	case BookTypeFICTION,
+		BookTypeNONFICTION:
+		return true
+	}
+	return false
+}
+
+func AllBookTypeValues() []BookType {
+	return []BookType{
+		BookTypeFICTION,
+		BookTypeNONFICTION,
+	}
+}
+
 type Author struct {
 	AuthorID int32
 	Name     string
@@ -0,0 +1,160 @@
+// Package cache keeps the results of earlier runs on disk, keyed by a hash of
+// their inputs
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Dir returns the root of the cache. It defaults to sqlc within the user's
+// cache directory, and can be changed with $SQLC_CACHE_DIR.
+func Dir() (string, error) {
+	if dir := os.Getenv("SQLC_CACHE_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sqlc"), nil
+}
+
+// Enabled reports whether the compilation cache should be used. Setting
+// $SQLC_CACHE to off disables it.
+func Enabled() bool {
+	return os.Getenv("SQLC_CACHE") != "off"
+}
+
+// Key returns the hex SHA-256 of parts, each of which is length prefixed so
+// that different splits can't collide
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		blob, _ := json.Marshal(len(p))
+		h.Write(blob)
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Queries stores the queries parsed from each query file, implementing
+// dinosql.QueryCache. Entries which can't be read are treated as missing, and
+// failures to write them are ignored, so a broken cache only costs time.
+type Queries struct {
+	dir string
+}
+
+// NewQueries returns the query cache within the cache directory
+func NewQueries() (*Queries, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Queries{dir: filepath.Join(dir, "queries")}, nil
+}
+
+func (c *Queries) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+func (c *Queries) Get(key string) ([]*dinosql.Query, bool) {
+	blob, err := ioutil.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var queries []*dinosql.Query
+	if err := json.Unmarshal(blob, &queries); err != nil {
+		return nil, false
+	}
+	return queries, true
+}
+
+func (c *Queries) Put(key string, queries []*dinosql.Query) {
+	blob, err := json.Marshal(queries)
+	if err != nil {
+		return
+	}
+	writeEntry(c.path(key), blob)
+}
+
+// writeEntry writes blob to path, ignoring failures
+func writeEntry(path string, blob []byte) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	// Write to a temporary file first, so concurrent runs never read half an
+	// entry
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(blob)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// An Output records how a generated file was made: the key of its inputs,
+// and the Key of the code generated from them
+type Output struct {
+	Input string `json:"input"`
+	Hash  string `json:"hash"`
+}
+
+// Outputs stores the Output of each generated file of an output directory,
+// so files whose inputs haven't changed can be kept as they are. Like
+// Queries, it treats unreadable entries as missing and ignores failed
+// writes.
+type Outputs struct {
+	dir string
+}
+
+// NewOutputs returns the output cache within the cache directory
+func NewOutputs() (*Outputs, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return &Outputs{dir: filepath.Join(dir, "outputs")}, nil
+}
+
+// Get returns the outputs recorded for the directory out, keyed by file name
+func (c *Outputs) Get(out string) map[string]Output {
+	blob, err := ioutil.ReadFile(c.path(out))
+	if err != nil {
+		return nil
+	}
+	var files map[string]Output
+	if err := json.Unmarshal(blob, &files); err != nil {
+		return nil
+	}
+	return files
+}
+
+// Put replaces the outputs recorded for the directory out
+func (c *Outputs) Put(out string, files map[string]Output) {
+	blob, err := json.Marshal(files)
+	if err != nil {
+		return
+	}
+	writeEntry(c.path(out), blob)
+}
+
+func (c *Outputs) path(out string) string {
+	key := Key(out)
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
@@ -0,0 +1,79 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func TestQueries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("SQLC_CACHE_DIR", dir)
+	defer os.Unsetenv("SQLC_CACHE_DIR")
+
+	c, err := NewQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := Key("one", "two")
+	if _, ok := c.Get(key); ok {
+		t.Fatal("expected a miss on an empty cache")
+	}
+	queries := []*dinosql.Query{
+		{
+			SQL:     "SELECT id FROM authors WHERE id = $1",
+			Name:    "GetAuthor",
+			Cmd:     ":one",
+			Columns: []pg.Column{{Name: "id", DataType: "pg_catalog.int8", NotNull: true, Table: pg.FQN{Rel: "authors"}}},
+			Params:  []dinosql.Parameter{{Number: 1, Column: pg.Column{Name: "id", DataType: "pg_catalog.int8"}}},
+			Tables:  []pg.FQN{{Rel: "authors"}},
+		},
+	}
+	c.Put(key, queries)
+	got, ok := c.Get(key)
+	if !ok {
+		t.Fatal("expected a hit after Put")
+	}
+	if diff := cmp.Diff(queries, got); diff != "" {
+		t.Errorf("cached queries differ (-want +got):\n%s", diff)
+	}
+	if Key("one", "two") == Key("onet", "wo") {
+		t.Error("keys of different parts collide")
+	}
+}
+
+func TestOutputs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("SQLC_CACHE_DIR", dir)
+	defer os.Unsetenv("SQLC_CACHE_DIR")
+
+	c, err := NewOutputs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files := c.Get("/app/db"); files != nil {
+		t.Fatalf("expected no outputs, got %v", files)
+	}
+	files := map[string]Output{
+		"query.sql.go": {Input: Key("query.sql"), Hash: Key("package db")},
+	}
+	c.Put("/app/db", files)
+	if diff := cmp.Diff(files, c.Get("/app/db")); diff != "" {
+		t.Errorf("cached outputs differ (-want +got):\n%s", diff)
+	}
+	if got := c.Get("/app/other"); got != nil {
+		t.Errorf("expected no outputs for another directory, got %v", got)
+	}
+}
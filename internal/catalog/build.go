@@ -133,10 +133,16 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				switch cmd.Subtype {
 				case nodes.AT_AddColumn:
 					implemented = true
+				case nodes.AT_AddConstraint:
+					implemented = true
 				case nodes.AT_AlterColumnType:
 					implemented = true
+				case nodes.AT_ColumnDefault:
+					implemented = true
 				case nodes.AT_DropColumn:
 					implemented = true
+				case nodes.AT_DropConstraint:
+					implemented = true
 				case nodes.AT_DropNotNull:
 					implemented = true
 				case nodes.AT_SetNotNull:
@@ -169,6 +175,7 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				// Lookup column names for column-related commands
 				switch cmd.Subtype {
 				case nodes.AT_AlterColumnType,
+					nodes.AT_ColumnDefault,
 					nodes.AT_DropColumn,
 					nodes.AT_DropNotNull,
 					nodes.AT_SetNotNull:
@@ -198,20 +205,71 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 						}
 					}
 					table.Columns = append(table.Columns, pg.Column{
-						Name:     *d.Colname,
-						DataType: join(d.TypeName.Names, "."),
-						NotNull:  isNotNull(d),
-						IsArray:  isArray(d.TypeName),
-						Table:    fqn,
+						OID:        c.NewOID(),
+						Name:       *d.Colname,
+						DataType:   join(d.TypeName.Names, "."),
+						Typmods:    typmods(d.TypeName),
+						NotNull:    isNotNull(d) || domainNotNull(c, d.TypeName),
+						IsArray:    isArray(d.TypeName),
+						HasDefault: hasDefault(d),
+						Table:      fqn,
 					})
+					fks, err := columnForeignKeys(d)
+					if err != nil {
+						return err
+					}
+					table.ForeignKeys = append(table.ForeignKeys, fks...)
+					table.Indexes = append(table.Indexes, columnIndexes(table.Name, d)...)
+
+				case nodes.AT_AddConstraint:
+					if d, ok := cmd.Def.(nodes.Constraint); ok && d.Contype == nodes.CONSTR_FOREIGN {
+						fk, err := foreignKey(d, stringSlice(d.FkAttrs))
+						if err != nil {
+							return err
+						}
+						table.ForeignKeys = append(table.ForeignKeys, fk)
+					}
+					if d, ok := cmd.Def.(nodes.Constraint); ok {
+						if index, ok := constraintIndex(table.Name, d, stringSlice(d.Keys)); ok {
+							table.Indexes = append(table.Indexes, index)
+						}
+					}
 
 				case nodes.AT_AlterColumnType:
 					d := cmd.Def.(nodes.ColumnDef)
 					table.Columns[idx].DataType = join(d.TypeName.Names, ".")
+					table.Columns[idx].Typmods = typmods(d.TypeName)
 					table.Columns[idx].IsArray = isArray(d.TypeName)
 
+				case nodes.AT_ColumnDefault:
+					// DROP DEFAULT has no expression
+					table.Columns[idx].HasDefault = cmd.Def != nil
+
 				case nodes.AT_DropColumn:
 					table.Columns = append(table.Columns[:idx], table.Columns[idx+1:]...)
+					var fks []pg.ForeignKey
+					for _, fk := range table.ForeignKeys {
+						if !contains(fk.Columns, *cmd.Name) {
+							fks = append(fks, fk)
+						}
+					}
+					table.ForeignKeys = fks
+					// Dropping a column drops the indexes which use it
+					var indexes []pg.Index
+					for _, index := range table.Indexes {
+						if !contains(index.Columns, *cmd.Name) {
+							indexes = append(indexes, index)
+						}
+					}
+					table.Indexes = indexes
+
+				case nodes.AT_DropConstraint:
+					for i, index := range table.Indexes {
+						if index.Name == *cmd.Name {
+							table.Indexes = append(table.Indexes[:i], table.Indexes[i+1:]...)
+							break
+						}
+					}
 
 				case nodes.AT_DropNotNull:
 					table.Columns[idx].NotNull = false
@@ -245,6 +303,7 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			return wrap(pg.ErrorRelationAlreadyExists(fqn.Rel), raw.StmtLocation)
 		}
 		schema.Types[fqn.Rel] = pg.CompositeType{
+			OID:  c.NewOID(),
 			Name: fqn.Rel,
 		}
 
@@ -261,23 +320,114 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			return wrap(pg.ErrorRelationAlreadyExists(fqn.Rel), raw.StmtLocation)
 		}
 		table := pg.Table{
+			OID:  c.NewOID(),
 			Name: fqn.Rel,
 		}
 		for _, elt := range n.TableElts.Items {
 			switch n := elt.(type) {
 			case nodes.ColumnDef:
+				// The columns of a partition or typed table come from its
+				// parent or type; a definition without a type only sets
+				// options on one of them
+				if n.TypeName == nil {
+					continue
+				}
 				colName := *n.Colname
 				table.Columns = append(table.Columns, pg.Column{
-					Name:     colName,
-					DataType: join(n.TypeName.Names, "."),
-					NotNull:  isNotNull(n),
-					IsArray:  isArray(n.TypeName),
-					Table:    fqn,
+					OID:        c.NewOID(),
+					Name:       colName,
+					DataType:   join(n.TypeName.Names, "."),
+					Typmods:    typmods(n.TypeName),
+					NotNull:    isNotNull(n) || domainNotNull(c, n.TypeName),
+					IsArray:    isArray(n.TypeName),
+					HasDefault: hasDefault(n),
+					Table:      fqn,
 				})
+				fks, err := columnForeignKeys(n)
+				if err != nil {
+					return err
+				}
+				table.ForeignKeys = append(table.ForeignKeys, fks...)
+				table.Indexes = append(table.Indexes, columnIndexes(table.Name, n)...)
+			case nodes.Constraint:
+				if n.Contype == nodes.CONSTR_FOREIGN {
+					fk, err := foreignKey(n, stringSlice(n.FkAttrs))
+					if err != nil {
+						return err
+					}
+					table.ForeignKeys = append(table.ForeignKeys, fk)
+				}
+				if index, ok := constraintIndex(table.Name, n, stringSlice(n.Keys)); ok {
+					table.Indexes = append(table.Indexes, index)
+				}
+			}
+		}
+		schema.Tables[fqn.Rel] = table
+
+	case nodes.IndexStmt:
+		fqn, err := ParseRange(n.Relation)
+		if err != nil {
+			return err
+		}
+		schema, exists := c.Schemas[fqn.Schema]
+		if !exists {
+			return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
+		}
+		table, exists := schema.Tables[fqn.Rel]
+		if !exists {
+			return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
+		}
+		index := pg.Index{Unique: n.Unique, Primary: n.Primary}
+		for _, item := range n.IndexParams.Items {
+			if elem, ok := item.(nodes.IndexElem); ok {
+				var name string
+				if elem.Name != nil {
+					name = *elem.Name
+				}
+				index.Columns = append(index.Columns, name)
+			}
+		}
+		if n.Idxname != nil {
+			index.Name = *n.Idxname
+		} else {
+			index.Name = indexName(table.Name, index.Columns, "idx")
+		}
+		for _, existing := range table.Indexes {
+			if existing.Name == index.Name {
+				if n.IfNotExists {
+					return nil
+				}
+				return wrap(pg.ErrorRelationAlreadyExists(index.Name), raw.StmtLocation)
 			}
 		}
+		table.Indexes = append(table.Indexes, index)
 		schema.Tables[fqn.Rel] = table
 
+	case nodes.CreateDomainStmt:
+		fqn, err := ParseList(n.Domainname)
+		if err != nil {
+			return err
+		}
+		schema, exists := c.Schemas[fqn.Schema]
+		if !exists {
+			return wrap(pg.ErrorSchemaDoesNotExist(fqn.Schema), raw.StmtLocation)
+		}
+		if _, exists := schema.Types[fqn.Rel]; exists {
+			return wrap(pg.ErrorTypeAlreadyExists(fqn.Rel), raw.StmtLocation)
+		}
+		domain := pg.Domain{
+			OID:      c.NewOID(),
+			Name:     fqn.Rel,
+			BaseType: join(n.TypeName.Names, "."),
+			IsArray:  isArray(n.TypeName),
+		}
+		for _, item := range n.Constraints.Items {
+			if con, ok := item.(nodes.Constraint); ok && con.Contype == nodes.CONSTR_NOTNULL {
+				domain.NotNull = true
+			}
+		}
+		schema.Types[fqn.Rel] = domain
+
 	case nodes.CreateEnumStmt:
 		fqn, err := ParseList(n.TypeName)
 		if err != nil {
@@ -298,10 +448,52 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 			return wrap(pg.ErrorTypeAlreadyExists(fqn.Rel), raw.StmtLocation)
 		}
 		schema.Types[fqn.Rel] = pg.Enum{
+			OID:  c.NewOID(),
 			Name: fqn.Rel,
 			Vals: stringSlice(n.Vals),
 		}
 
+	case nodes.CreateExtensionStmt:
+		ext, ok := pg.LookupExtension(*n.Extname)
+		if !ok {
+			return nil
+		}
+		name := "public"
+		for _, item := range n.Options.Items {
+			if opt, ok := item.(nodes.DefElem); ok && *opt.Defname == "schema" {
+				name = opt.Arg.(nodes.String).Str
+			}
+		}
+		schema, exists := c.Schemas[name]
+		if !exists {
+			return wrap(pg.ErrorSchemaDoesNotExist(name), raw.StmtLocation)
+		}
+		if _, exists := c.Extensions[*n.Extname]; exists {
+			if n.IfNotExists {
+				return nil
+			}
+			return wrap(pg.ErrorExtensionAlreadyExists(*n.Extname), raw.StmtLocation)
+		}
+		for _, typ := range ext.Types {
+			if _, exists := schema.Types[typ]; exists {
+				return wrap(pg.ErrorTypeAlreadyExists(typ), raw.StmtLocation)
+			}
+			schema.Types[typ] = pg.BaseType{
+				OID:       c.NewOID(),
+				Name:      typ,
+				Extension: *n.Extname,
+			}
+		}
+		for _, fn := range ext.Funcs {
+			schema.Funcs[fn.Name] = append(schema.Funcs[fn.Name], fn)
+		}
+		schema.Operators = append(schema.Operators, ext.Operators...)
+		c.Schemas[name] = schema
+		if c.Extensions == nil {
+			c.Extensions = map[string]string{}
+		}
+		c.Extensions[*n.Extname] = name
+
 	case nodes.CreateSchemaStmt:
 		name := *n.Schemaname
 		if _, exists := c.Schemas[name]; exists {
@@ -309,12 +501,14 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				return wrap(pg.ErrorSchemaAlreadyExists(name), raw.StmtLocation)
 			}
 		} else {
-			c.Schemas[name] = pg.NewSchema()
+			schema := pg.NewSchema()
+			schema.OID = c.NewOID()
+			c.Schemas[name] = schema
 		}
 
 	case nodes.DropStmt:
 		for _, obj := range n.Objects.Items {
-			if n.RemoveType == nodes.OBJECT_TABLE || n.RemoveType == nodes.OBJECT_TYPE {
+			if n.RemoveType == nodes.OBJECT_TABLE || n.RemoveType == nodes.OBJECT_TYPE || n.RemoveType == nodes.OBJECT_DOMAIN {
 				var fqn pg.FQN
 				var err error
 
@@ -343,7 +537,7 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 						return wrap(pg.ErrorRelationDoesNotExist(fqn.Rel), raw.StmtLocation)
 					}
 
-				case nodes.OBJECT_TYPE:
+				case nodes.OBJECT_TYPE, nodes.OBJECT_DOMAIN:
 					if _, exists := schema.Types[fqn.Rel]; exists {
 						delete(schema.Types, fqn.Rel)
 					} else if !n.MissingOk {
@@ -354,6 +548,20 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 
 			}
 
+			if n.RemoveType == nodes.OBJECT_INDEX {
+				list, ok := obj.(nodes.List)
+				if !ok {
+					return fmt.Errorf("nodes.DropStmt: unknown node in objects list: %T", obj)
+				}
+				fqn, err := ParseList(list)
+				if err != nil {
+					return err
+				}
+				// Indexes created by statements the catalog doesn't
+				// understand aren't recorded, so missing indexes are ignored
+				dropIndex(c.Schemas[fqn.Schema], fqn.Rel)
+			}
+
 			if n.RemoveType == nodes.OBJECT_SCHEMA {
 				var name string
 				switch o := obj.(type) {
@@ -398,6 +606,13 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 				return wrap(pg.ErrorColumnDoesNotExist(table.Name, *n.Subname), raw.StmtLocation)
 			}
 			table.Columns[idx].Name = *n.Newname
+			for i := range table.Indexes {
+				for j, col := range table.Indexes[i].Columns {
+					if col == *n.Subname {
+						table.Indexes[i].Columns[j] = *n.Newname
+					}
+				}
+			}
 
 		case nodes.OBJECT_TABLE:
 			fqn, err := ParseRange(n.Relation)
@@ -449,10 +664,16 @@ func Update(c *pg.Catalog, stmt nodes.Node) error {
 		}
 		// TODO: support return parameter:
 		// CREATE FUNCTION foo(bar TEXT, OUT quz bool) AS $$ SELECT true $$ LANGUAGE sql;
+		// A function with OUT parameters may leave out RETURNS, returning a
+		// record of them
+		returnType := "record"
+		if n.ReturnType != nil {
+			returnType = join(n.ReturnType.Names, ".")
+		}
 		schema.Funcs[fqn.Rel] = append(schema.Funcs[fqn.Rel], pg.Function{
 			Name:       fqn.Rel,
 			Arguments:  args,
-			ReturnType: join(n.ReturnType.Names, "."),
+			ReturnType: returnType,
 		})
 
 	case nodes.CommentStmt:
@@ -581,6 +802,19 @@ func isArray(n *nodes.TypeName) bool {
 	return len(n.ArrayBounds.Items) > 0
 }
 
+// typmods returns the integer type modifiers of n, e.g. 255 for varchar(255)
+func typmods(n *nodes.TypeName) []int32 {
+	var mods []int32
+	for _, item := range n.Typmods.Items {
+		if c, ok := item.(nodes.A_Const); ok {
+			if i, ok := c.Val.(nodes.Integer); ok {
+				mods = append(mods, int32(i.Ival))
+			}
+		}
+	}
+	return mods
+}
+
 func isNotNull(n nodes.ColumnDef) bool {
 	if n.IsNotNull {
 		return true
@@ -599,6 +833,125 @@ func isNotNull(n nodes.ColumnDef) bool {
 	return false
 }
 
+// domainNotNull reports whether n names a domain declared NOT NULL, whose
+// columns can't hold NULL either. Arrays of such a domain may still be NULL.
+func domainNotNull(c *pg.Catalog, n *nodes.TypeName) bool {
+	if n == nil || isArray(n) {
+		return false
+	}
+	fqn, err := ParseList(n.Names)
+	if err != nil {
+		return false
+	}
+	domain, ok := c.Schemas[fqn.Schema].Types[fqn.Rel].(pg.Domain)
+	return ok && domain.NotNull
+}
+
+func hasDefault(n nodes.ColumnDef) bool {
+	if n.RawDefault != nil {
+		return true
+	}
+	for _, c := range n.Constraints.Items {
+		if c, ok := c.(nodes.Constraint); ok && c.Contype == nodes.CONSTR_DEFAULT {
+			return true
+		}
+	}
+	return false
+}
+
+// columnForeignKeys returns the foreign keys declared by REFERENCES
+// constraints on a column definition.
+func columnForeignKeys(n nodes.ColumnDef) ([]pg.ForeignKey, error) {
+	var fks []pg.ForeignKey
+	for _, c := range n.Constraints.Items {
+		if c, ok := c.(nodes.Constraint); ok && c.Contype == nodes.CONSTR_FOREIGN {
+			fk, err := foreignKey(c, []string{*n.Colname})
+			if err != nil {
+				return nil, err
+			}
+			fks = append(fks, fk)
+		}
+	}
+	return fks, nil
+}
+
+func foreignKey(c nodes.Constraint, columns []string) (pg.ForeignKey, error) {
+	ref, err := ParseRange(c.Pktable)
+	if err != nil {
+		return pg.ForeignKey{}, err
+	}
+	return pg.ForeignKey{
+		Columns:    columns,
+		RefTable:   ref,
+		RefColumns: stringSlice(c.PkAttrs),
+	}, nil
+}
+
+// columnIndexes returns the indexes created by PRIMARY KEY and UNIQUE
+// constraints on a column definition.
+func columnIndexes(table string, n nodes.ColumnDef) []pg.Index {
+	var indexes []pg.Index
+	for _, c := range n.Constraints.Items {
+		if c, ok := c.(nodes.Constraint); ok {
+			if index, ok := constraintIndex(table, c, []string{*n.Colname}); ok {
+				indexes = append(indexes, index)
+			}
+		}
+	}
+	return indexes
+}
+
+// constraintIndex returns the index PostgreSQL creates for a PRIMARY KEY or
+// UNIQUE constraint on columns, named as PostgreSQL would name it
+func constraintIndex(table string, c nodes.Constraint, columns []string) (pg.Index, bool) {
+	var index pg.Index
+	switch c.Contype {
+	case nodes.CONSTR_PRIMARY:
+		index = pg.Index{Name: table + "_pkey", Columns: columns, Unique: true, Primary: true}
+	case nodes.CONSTR_UNIQUE:
+		index = pg.Index{Name: indexName(table, columns, "key"), Columns: columns, Unique: true}
+	default:
+		return index, false
+	}
+	if c.Conname != nil {
+		index.Name = *c.Conname
+	}
+	return index, true
+}
+
+// indexName returns the default name of an index, e.g. users_email_key
+func indexName(table string, columns []string, suffix string) string {
+	parts := []string{table}
+	for _, col := range columns {
+		if col == "" {
+			col = "expr"
+		}
+		parts = append(parts, col)
+	}
+	return strings.Join(append(parts, suffix), "_")
+}
+
+func dropIndex(schema pg.Schema, name string) {
+	for rel, table := range schema.Tables {
+		for i, index := range table.Indexes {
+			if index.Name == name {
+				table.Indexes = append(table.Indexes[:i], table.Indexes[i+1:]...)
+				schema.Tables[rel] = table
+				return
+			}
+		}
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 func ToColumn(n *nodes.TypeName) pg.Column {
 	if n == nil {
 		panic("can't build column for nil type name")
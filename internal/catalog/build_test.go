@@ -25,6 +25,17 @@ func buildCatalog(stmt string) (pg.Catalog, error) {
 	return c, nil
 }
 
+// ignoreOIDs leaves out OIDs when comparing catalogs; TestOIDs covers them
+var ignoreOIDs = cmp.Options{
+	cmpopts.IgnoreFields(pg.Catalog{}, "NextOID"),
+	cmpopts.IgnoreFields(pg.Schema{}, "OID"),
+	cmpopts.IgnoreFields(pg.Table{}, "OID"),
+	cmpopts.IgnoreFields(pg.Column{}, "OID"),
+	cmpopts.IgnoreFields(pg.Enum{}, "OID"),
+	cmpopts.IgnoreFields(pg.CompositeType{}, "OID"),
+	cmpopts.IgnoreFields(pg.Domain{}, "OID"),
+}
+
 func TestUpdate(t *testing.T) {
 	for i, tc := range []struct {
 		stmt string
@@ -288,6 +299,58 @@ func TestUpdate(t *testing.T) {
 			`,
 			pg.NewCatalog(),
 		},
+		{
+			"CREATE DOMAIN email AS text NOT NULL CHECK (VALUE LIKE '%@%');",
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Types: map[string]pg.Type{
+							"email": pg.Domain{
+								Name:     "email",
+								BaseType: "text",
+								NotNull:  true,
+							},
+						},
+						Tables: map[string]pg.Table{},
+					},
+				},
+			},
+		},
+		{
+			`
+			CREATE DOMAIN email AS text;
+			DROP DOMAIN email;
+			`,
+			pg.NewCatalog(),
+		},
+		{
+			`
+			CREATE DOMAIN positive AS integer NOT NULL;
+			CREATE TABLE counts (n positive, ns positive[]);
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Types: map[string]pg.Type{
+							"positive": pg.Domain{
+								Name:     "positive",
+								BaseType: "pg_catalog.int4",
+								NotNull:  true,
+							},
+						},
+						Tables: map[string]pg.Table{
+							"counts": pg.Table{
+								Name: "counts",
+								Columns: []pg.Column{
+									{Name: "n", DataType: "positive", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "counts"}},
+									{Name: "ns", DataType: "positive", IsArray: true, Table: pg.FQN{Schema: "public", Rel: "counts"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			`
 			CREATE TABLE venues ();
@@ -344,6 +407,9 @@ func TestUpdate(t *testing.T) {
 								Columns: []pg.Column{
 									{Name: "id", DataType: "serial", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "venues"}},
 								},
+								Indexes: []pg.Index{
+									{Name: "venues_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+								},
 							},
 						},
 					},
@@ -375,6 +441,35 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{ // OUT parameters without RETURNS
+			`
+			CREATE FUNCTION foo(bar TEXT, OUT baz bool) AS $$ SELECT true $$ LANGUAGE sql;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Funcs: map[string][]pg.Function{
+							"foo": []pg.Function{
+								{
+									Name: "foo",
+									Arguments: []pg.Argument{
+										{
+											Name:     "bar",
+											DataType: "text",
+										},
+										{
+											Name:     "baz",
+											DataType: "bool",
+										},
+									},
+									ReturnType: "record",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		{ // same name, different arity
 			`
 			CREATE FUNCTION foo(bar TEXT) RETURNS bool AS $$ SELECT true $$ LANGUAGE sql;
@@ -567,6 +662,105 @@ func TestUpdate(t *testing.T) {
 				},
 			},
 		},
+		{
+			`
+			CREATE TABLE users (id integer PRIMARY KEY);
+			CREATE TABLE pets (owner integer REFERENCES users, sitter integer, FOREIGN KEY (sitter) REFERENCES users (id));
+			ALTER TABLE pets ADD COLUMN walker integer;
+			ALTER TABLE pets ADD CONSTRAINT pets_walker_fkey FOREIGN KEY (walker) REFERENCES users (id);
+			ALTER TABLE pets DROP COLUMN sitter;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"users": pg.Table{
+								Name: "users",
+								Columns: []pg.Column{
+									{Name: "id", DataType: "pg_catalog.int4", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "users"}},
+								},
+								Indexes: []pg.Index{
+									{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+								},
+							},
+							"pets": pg.Table{
+								Name: "pets",
+								Columns: []pg.Column{
+									{Name: "owner", DataType: "pg_catalog.int4", Table: pg.FQN{Schema: "public", Rel: "pets"}},
+									{Name: "walker", DataType: "pg_catalog.int4", Table: pg.FQN{Schema: "public", Rel: "pets"}},
+								},
+								ForeignKeys: []pg.ForeignKey{
+									{Columns: []string{"owner"}, RefTable: pg.FQN{Schema: "public", Rel: "users"}},
+									{Columns: []string{"walker"}, RefTable: pg.FQN{Schema: "public", Rel: "users"}, RefColumns: []string{"id"}},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{},
+					},
+				},
+			},
+		},
+		{
+			`
+			CREATE TABLE venues (id serial, name text DEFAULT 'unknown', city text, region text DEFAULT 'us');
+			ALTER TABLE venues ALTER COLUMN city SET DEFAULT 'nyc';
+			ALTER TABLE venues ALTER COLUMN region DROP DEFAULT;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"venues": pg.Table{
+								Name: "venues",
+								Columns: []pg.Column{
+									{Name: "id", DataType: "serial", Table: pg.FQN{Schema: "public", Rel: "venues"}},
+									{Name: "name", DataType: "text", HasDefault: true, Table: pg.FQN{Schema: "public", Rel: "venues"}},
+									{Name: "city", DataType: "text", HasDefault: true, Table: pg.FQN{Schema: "public", Rel: "venues"}},
+									{Name: "region", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "venues"}},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{},
+					},
+				},
+			},
+		},
+		{
+			`
+			CREATE TABLE users (id integer PRIMARY KEY, email text UNIQUE, org integer, name text, UNIQUE (org, name));
+			CREATE INDEX ON users (lower(email));
+			CREATE INDEX users_by_name ON users (name);
+			CREATE UNIQUE INDEX IF NOT EXISTS users_by_name ON users (name);
+			ALTER TABLE users RENAME COLUMN email TO mail;
+			ALTER TABLE users DROP COLUMN org;
+			ALTER TABLE users DROP CONSTRAINT users_pkey;
+			DROP INDEX users_expr_idx;
+			`,
+			pg.Catalog{
+				Schemas: map[string]pg.Schema{
+					"public": {
+						Tables: map[string]pg.Table{
+							"users": pg.Table{
+								Name: "users",
+								Columns: []pg.Column{
+									{Name: "id", DataType: "pg_catalog.int4", NotNull: true, Table: pg.FQN{Schema: "public", Rel: "users"}},
+									{Name: "mail", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "users"}},
+									{Name: "name", DataType: "text", Table: pg.FQN{Schema: "public", Rel: "users"}},
+								},
+								Indexes: []pg.Index{
+									{Name: "users_email_key", Columns: []string{"mail"}, Unique: true},
+									{Name: "users_by_name", Columns: []string{"name"}},
+								},
+							},
+						},
+						Types: map[string]pg.Type{},
+						Funcs: map[string][]pg.Function{},
+					},
+				},
+			},
+		},
 	} {
 		test := tc
 		t.Run(strconv.Itoa(i), func(t *testing.T) {
@@ -581,7 +775,7 @@ func TestUpdate(t *testing.T) {
 				expected.Schemas[name] = schema
 			}
 
-			if diff := cmp.Diff(expected, c, cmpopts.EquateEmpty()); diff != "" {
+			if diff := cmp.Diff(expected, c, cmpopts.EquateEmpty(), ignoreOIDs); diff != "" {
 				t.Log(test.stmt)
 				t.Errorf("catalog mismatch:\n%s", diff)
 			}
@@ -608,6 +802,33 @@ func TestUpdateErrors(t *testing.T) {
 			`,
 			pg.Error{Code: "42710", Message: "type \"foo\" already exists"},
 		},
+		{
+			`
+			CREATE TYPE foo AS ENUM ('bar');
+			CREATE DOMAIN foo AS text;
+			`,
+			pg.Error{Code: "42710", Message: "type \"foo\" already exists"},
+		},
+		{
+			`
+			CREATE EXTENSION postgis;
+			CREATE EXTENSION postgis;
+			`,
+			pg.Error{Code: "42710", Message: "extension \"postgis\" already exists"},
+		},
+		{
+			`
+			CREATE EXTENSION pgcrypto;
+			CREATE EXTENSION pgcrypto;
+			`,
+			pg.Error{Code: "42710", Message: "extension \"pgcrypto\" already exists"},
+		},
+		{
+			`
+			CREATE EXTENSION postgis WITH SCHEMA gis;
+			`,
+			pg.Error{Code: "3F000", Message: "schema \"gis\" does not exist"},
+		},
 		{
 			`
 			DROP TABLE foo;
@@ -750,3 +971,49 @@ func TestUpdateErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDs(t *testing.T) {
+	const ddl = `
+	CREATE SCHEMA audit;
+	CREATE TYPE status AS ENUM ('open', 'closed');
+	CREATE TABLE venues (id serial, name text);
+	ALTER TABLE venues RENAME TO places;
+	ALTER TABLE places RENAME COLUMN name TO title;
+	ALTER TABLE places ADD COLUMN status status;
+	ALTER TABLE places SET SCHEMA audit;
+	`
+	c, err := buildCatalog(ddl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oid := c.Schemas["public"].OID; oid != 2200 {
+		t.Errorf("public has OID %d; expected PostgreSQL's 2200", oid)
+	}
+	if oid := c.Schemas["audit"].OID; oid != pg.FirstUserOID {
+		t.Errorf("audit has OID %d; expected %d", oid, pg.FirstUserOID)
+	}
+	if oid := c.Schemas["public"].Types["status"].(pg.Enum).OID; oid != pg.FirstUserOID+1 {
+		t.Errorf("status has OID %d; expected %d", oid, pg.FirstUserOID+1)
+	}
+
+	// Renamed and moved objects keep the OIDs they were created with
+	places := c.Schemas["audit"].Tables["places"]
+	var oids []uint32
+	oids = append(oids, places.OID)
+	for _, col := range places.Columns {
+		oids = append(oids, col.OID)
+	}
+	first := pg.FirstUserOID
+	expected := []uint32{first + 2, first + 3, first + 4, first + 5}
+	if diff := cmp.Diff(expected, oids); diff != "" {
+		t.Errorf("table and column OIDs differed (-want +got):\n%s", diff)
+	}
+
+	again, err := buildCatalog(ddl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(c, again, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("building the same schema twice gave different catalogs:\n%s", diff)
+	}
+}
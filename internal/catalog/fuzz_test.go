@@ -0,0 +1,29 @@
+//go:build go1.18
+// +build go1.18
+
+package catalog
+
+import (
+	"testing"
+)
+
+// FuzzUpdate feeds the PostgreSQL catalog the types, domains, functions,
+// partitions and renames it tracks alongside tables
+func FuzzUpdate(f *testing.F) {
+	for _, seed := range []string{
+		"CREATE TABLE venues (id serial PRIMARY KEY, name text NOT NULL, tags text[])",
+		"CREATE TABLE a (id int); ALTER TABLE a ADD COLUMN b text, ALTER COLUMN id TYPE bigint, DROP COLUMN b",
+		"ALTER TABLE a ALTER COLUMN id SET NOT NULL; ALTER TABLE a RENAME COLUMN id TO b; ALTER TABLE a RENAME TO b",
+		"CREATE SCHEMA s; CREATE TYPE s.mood AS ENUM ('sad', 'ok'); ALTER TYPE s.mood RENAME TO feeling; DROP SCHEMA s CASCADE",
+		"CREATE TYPE point3 AS (x float8, y float8, z float8); CREATE DOMAIN email AS text NOT NULL CHECK (VALUE LIKE '%@%')",
+		"CREATE FUNCTION f(a int, VARIADIC b text[]) RETURNS SETOF record AS $$ SELECT 1 $$ LANGUAGE sql",
+		"CREATE TABLE p (id int) PARTITION BY RANGE (id); CREATE TABLE p1 PARTITION OF p (id NOT NULL) FOR VALUES FROM (1) TO (10)",
+		"COMMENT ON COLUMN a.b IS 'x'; COMMENT ON TABLE a IS NULL; CREATE EXTENSION IF NOT EXISTS citext",
+		"CREATE TABLE b (LIKE a INCLUDING ALL); CREATE TABLE c OF point3; CREATE VIEW v AS SELECT 1",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		buildCatalog(sql)
+	})
+}
@@ -6,21 +6,45 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
 
 	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/pg"
 )
 
 // Do runs the command logic.
 func Do(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
 	rootCmd := &cobra.Command{Use: "sqlc", SilenceUsage: true}
+	rootCmd.PersistentFlags().StringSlice("debug", nil, "trace how schemas and queries are parsed: catalog, inference")
+	rootCmd.PersistentFlags().String("cpuprofile", "", "write a CPU profile to `file`")
+	rootCmd.PersistentFlags().String("memprofile", "", "write a memory profile to `file` on exit")
+	rootCmd.PersistentFlags().String("trace", "", "write an execution trace to `file`")
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		debug, err := cmd.Flags().GetStringSlice("debug")
+		if err != nil {
+			return err
+		}
+		if err := dinosql.SetTrace(debug, cmd.ErrOrStderr()); err != nil {
+			return err
+		}
+		return startProfiling(cmd)
+	}
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(genCmd)
+	rootCmd.AddCommand(fmtCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(introspectCmd)
+	rootCmd.AddCommand(migrateDiffCmd)
+	rootCmd.AddCommand(diagramCmd)
+	rootCmd.AddCommand(verifyCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(vetCmd)
 
 	rootCmd.SetArgs(args)
 	rootCmd.SetIn(stdin)
@@ -28,6 +52,7 @@ func Do(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int
 	rootCmd.SetErr(stderr)
 
 	err := rootCmd.Execute()
+	stopProfiling(stderr)
 	if err == nil {
 		return 0
 	}
@@ -55,58 +80,367 @@ var versionCmd = &cobra.Command{
 
 var initCmd = &cobra.Command{
 	Use:   "init",
-	Short: "Create an empty sqlc.yaml settings file",
+	Short: "Create a starter sqlc.yaml with an example schema and queries",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if _, err := os.Stat("sqlc.yaml"); !os.IsNotExist(err) {
-			return nil
+		engine, err := cmd.Flags().GetString("engine")
+		if err != nil {
+			return err
 		}
-		blob, err := yaml.Marshal(config.V1GenerateSettings{Version: "1"})
+		dir, err := os.Getwd()
 		if err != nil {
 			return err
 		}
-		return ioutil.WriteFile("sqlc.yaml", blob, 0644)
+		created, err := initProject(dir, config.Engine(engine))
+		for _, name := range created {
+			fmt.Fprintf(cmd.OutOrStdout(), "created %s\n", name)
+		}
+		return err
 	},
 }
 
 var genCmd = &cobra.Command{
-	Use:   "generate",
+	Use:   "generate [dir | dir/...]...",
 	Short: "Generate Go code from SQL",
 	Run: func(cmd *cobra.Command, args []string) {
 		stderr := cmd.ErrOrStderr()
-		dir, err := os.Getwd()
+		dirs, err := workspaceDirs(args)
 		if err != nil {
-			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-			os.Exit(1)
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+
+		filter, err := filterFlags(cmd)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		watch, err := cmd.Flags().GetBool("watch")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		var report *Report
+		switch format {
+		case "text":
+		case "json":
+			if watch || dryRun || (filter != nil && len(filter.Queries) > 0) {
+				fmt.Fprintln(stderr, "--format json can't be used with --watch, --dry-run or --query")
+				exit(1)
+			}
+			report = &Report{}
+		default:
+			fmt.Fprintf(stderr, "unknown format %q: must be text or json\n", format)
+			exit(1)
+		}
+		start := time.Now()
+
+		if watch {
+			if dryRun {
+				fmt.Fprintln(stderr, "--watch can't be used with --dry-run")
+				exit(1)
+			}
+			if filter != nil {
+				fmt.Fprintln(stderr, "--watch can't be used with --package or --query")
+				exit(1)
+			}
+			if len(dirs) != 1 {
+				fmt.Fprintln(stderr, "--watch only supports a single project directory")
+				exit(1)
+			}
+			interval, err := cmd.Flags().GetDuration("watch-interval")
+			if err != nil {
+				fmt.Fprintln(stderr, err)
+				exit(1)
+			}
+			stop := make(chan struct{})
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt)
+			go func() {
+				<-interrupt
+				close(stop)
+			}()
+			Watch(dirs[0], interval, cmd.OutOrStdout(), stderr, stop)
+			return
+		}
+
+		// Code for some of a package's queries would replace the code for
+		// all of them, so --query only checks the queries it names
+		if filter != nil && len(filter.Queries) > 0 {
+			if err := CompileWorkspace(dirs, filter, stderr); err != nil {
+				exit(exitCode(err))
+			}
+			return
 		}
 
-		output, err := Generate(dir, stderr)
+		output, err := generateWorkspace(dirs, filter, report, stderr)
 		if err != nil {
-			os.Exit(1)
+			exit(exitCode(err))
 		}
 
+		if dryRun {
+			wd, err := os.Getwd()
+			if err == nil {
+				err = printDiff(output, wd, cmd.OutOrStdout())
+			}
+			if err != nil {
+				fmt.Fprintln(stderr, err)
+				exit(ExitIO)
+			}
+			return
+		}
+
+		written := time.Now()
 		for filename, source := range output {
+			if unchangedOnDisk(filename, source) {
+				continue
+			}
 			os.MkdirAll(filepath.Dir(filename), 0755)
 			if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
 				fmt.Fprintf(stderr, "%s: %s\n", filename, err)
-				os.Exit(1)
+				exit(ExitIO)
+			}
+		}
+		if report != nil {
+			report.Timings.Write.since(written)
+			report.Timings.Total.since(start)
+			if err := report.Write(cmd.OutOrStdout()); err != nil {
+				fmt.Fprintln(stderr, err)
+				exit(1)
 			}
 		}
 	},
 }
 
 var checkCmd = &cobra.Command{
-	Use:   "compile",
+	Use:   "compile [dir | dir/...]...",
 	Short: "Statically check SQL for syntax and type errors",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		stderr := cmd.ErrOrStderr()
+		dirs, err := workspaceDirs(args)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		filter, err := filterFlags(cmd)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			exit(1)
+		}
+		engine, schema, queries := cmd.Flag("engine").Value.String(), cmd.Flag("schema").Value.String(), cmd.Flag("queries").Value.String()
+		if schema != "" || queries != "" {
+			switch {
+			case len(args) > 0:
+				fmt.Fprintln(stderr, "--schema and --queries can't be used with project directories")
+				exit(1)
+			case schema == "" || queries == "":
+				fmt.Fprintln(stderr, "--schema and --queries must be used together")
+				exit(1)
+			case engine == "":
+				fmt.Fprintln(stderr, "--engine is required with --schema and --queries")
+				exit(1)
+			}
+			if err := CompileFiles(config.Engine(engine), schema, queries, filter, cmd.InOrStdin(), stderr); err != nil {
+				exit(exitCode(err))
+			}
+			return nil
+		}
+		if err := CompileWorkspace(dirs, filter, stderr); err != nil {
+			exit(exitCode(err))
+		}
+		return nil
+	},
+}
+
+// filterFlags returns the filter set by the --package and --query flags, or
+// nil if neither is set
+func filterFlags(cmd *cobra.Command) (*Filter, error) {
+	packages, err := cmd.Flags().GetStringSlice("package")
+	if err != nil {
+		return nil, err
+	}
+	queries, err := cmd.Flags().GetStringSlice("query")
+	if err != nil {
+		return nil, err
+	}
+	if len(packages) == 0 && len(queries) == 0 {
+		return nil, nil
+	}
+	return &Filter{Packages: packages, Queries: queries}, nil
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt [file]...",
+	Short: "Format schema and query files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		check, err := cmd.Flags().GetBool("check")
+		if err != nil {
+			return err
+		}
 		dir, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-			os.Exit(1)
+			exit(1)
 		}
-		if _, err := Generate(dir, stderr); err != nil {
-			os.Exit(1)
+		if err := Format(dir, args, check, cmd.InOrStdin(), cmd.OutOrStdout(), stderr); err != nil {
+			exit(1)
 		}
 		return nil
 	},
 }
+
+var vetCmd = &cobra.Command{
+	Use:   "vet",
+	Short: "Check queries against lint rules",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			exit(1)
+		}
+		fix, err := cmd.Flags().GetBool("fix")
+		if err != nil {
+			return err
+		}
+		if err := Vet(dir, fix, cmd.OutOrStdout(), stderr); err != nil {
+			exit(exitCode(err))
+		}
+		return nil
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that a PostgreSQL database matches the schema files",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		url, err := cmd.Flags().GetString("database-url")
+		if err != nil {
+			return err
+		}
+		if url == "" {
+			url = os.Getenv("DATABASE_URL")
+		}
+		if url == "" {
+			return fmt.Errorf("no database: set --database-url or DATABASE_URL")
+		}
+		dir, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
+			exit(1)
+		}
+		if err := Verify(dir, url, cmd.OutOrStdout(), stderr); err != nil {
+			exit(exitCode(err))
+		}
+		return nil
+	},
+}
+
+var introspectCmd = &cobra.Command{
+	Use:   "introspect database_url",
+	Short: "Print the schema of a live PostgreSQL, MySQL or SQLite database as SQL",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schemas, err := cmd.Flags().GetStringSlice("schema")
+		if err != nil {
+			return err
+		}
+		if err := Introspect(args[0], schemas, cmd.OutOrStdout(), cmd.ErrOrStderr()); err != nil {
+			exit(exitCode(err))
+		}
+		return nil
+	},
+}
+
+var migrateDiffCmd = &cobra.Command{
+	Use:   "migrate-diff old_schema new_schema",
+	Short: "Print the statements which migrate one PostgreSQL schema to another",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stderr := cmd.ErrOrStderr()
+		var catalogs []pg.Catalog
+		for _, schema := range args {
+			catalogs = append(catalogs, parseSchema(stderr, schema))
+		}
+		for _, stmt := range pg.Diff(catalogs[0], catalogs[1]) {
+			if strings.HasPrefix(stmt, "--") {
+				fmt.Fprintln(cmd.OutOrStdout(), stmt)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s;\n", stmt)
+			}
+		}
+		return nil
+	},
+}
+
+var diagramCmd = &cobra.Command{
+	Use:   "diagram schema",
+	Short: "Render the tables of a PostgreSQL schema as a diagram",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		var render func(pg.Catalog) string
+		switch format {
+		case "mermaid":
+			render = pg.Mermaid
+		case "dot":
+			render = pg.Dot
+		default:
+			return fmt.Errorf("invalid format %q: must be one of mermaid or dot", format)
+		}
+		c := parseSchema(cmd.ErrOrStderr(), args[0])
+		fmt.Fprint(cmd.OutOrStdout(), render(c))
+		return nil
+	},
+}
+
+func init() {
+	genCmd.Flags().Bool("watch", false, "regenerate whenever the configuration, schema or queries change")
+	genCmd.Flags().Duration("watch-interval", 500*time.Millisecond, "how often --watch checks for changes")
+	genCmd.Flags().Bool("dry-run", false, "print a diff of the changes to the generated files instead of writing them")
+	genCmd.Flags().String("format", "text", "output format: text, or json for a summary of the files generated and timings")
+	for _, c := range []*cobra.Command{genCmd, checkCmd} {
+		c.Flags().StringSlice("package", nil, "only work on the packages with these names")
+		c.Flags().StringSlice("query", nil, "only compile the queries with these names, without writing any code")
+	}
+	checkCmd.Flags().String("engine", "", "database engine of --schema and --queries, either postgresql or mysql")
+	checkCmd.Flags().String("schema", "", "compile this schema, or - for stdin, instead of a configuration file")
+	checkCmd.Flags().String("queries", "", "compile these queries, or - for stdin, instead of a configuration file")
+	vetCmd.Flags().Bool("fix", false, "expand SELECT * in the query files before checking them")
+	fmtCmd.Flags().Bool("check", false, "list the files which aren't formatted, without changing them")
+	initCmd.Flags().String("engine", string(config.EnginePostgreSQL), "database engine, either postgresql or mysql")
+	introspectCmd.Flags().StringSlice("schema", []string{"public"}, "the PostgreSQL schemas to print")
+	verifyCmd.Flags().String("database-url", "", "connection string of the database, defaults to $DATABASE_URL")
+	diagramCmd.Flags().String("format", "mermaid", "output format, either mermaid or dot")
+}
+
+// parseSchema parses the PostgreSQL schema at path, exiting if it contains
+// errors.
+func parseSchema(stderr io.Writer, path string) pg.Catalog {
+	c, err := dinosql.ParseCatalog(path)
+	if err != nil {
+		if parserErr, ok := err.(*dinosql.ParserErr); ok {
+			for _, fileErr := range parserErr.Errs {
+				printFileErr(stderr, "", fileErr)
+			}
+		} else {
+			fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
+		}
+		exit(1)
+	}
+	return c
+}
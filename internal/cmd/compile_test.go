@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/cache"
+)
+
+const compileConfig = `version: "1"
+packages:
+  - path: "one"
+    schema: "schema.sql"
+    queries: "one.sql"
+  - path: "two"
+    schema: "schema.sql"
+    queries: "two.sql"
+`
+
+func TestCompileReportsEveryPackage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-compile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  compileConfig,
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+		"one.sql":    "-- name: ListBooks :many\nSELECT id FROM books;\n",
+		"two.sql":    "-- name: GetAuthor :one\nSELECT missing FROM authors;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	if err := Compile(dir, &stderr); err == nil {
+		t.Fatal("expected compile errors")
+	}
+	for _, msg := range []string{`relation "books" does not exist`, `column "missing" does not exist`} {
+		if !strings.Contains(stderr.String(), msg) {
+			t.Errorf("expected %q in:\n%s", msg, stderr.String())
+		}
+	}
+	for _, out := range []string{"one", "two"} {
+		if _, err := os.Stat(filepath.Join(dir, out)); !os.IsNotExist(err) {
+			t.Errorf("compile created %s", out)
+		}
+	}
+}
+
+func TestGenerateReportsPackagesInOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-parallel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := "version: \"1\"\npackages:\n"
+	files := map[string]string{
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+	}
+	var want []string
+	for i := 0; i < 12; i++ {
+		name := fmt.Sprintf("pkg%02d", i)
+		conf += fmt.Sprintf("  - path: %q\n    schema: \"schema.sql\"\n    queries: \"%s.sql\"\n", name, name)
+		files[name+".sql"] = fmt.Sprintf("-- name: Get%d :one\nSELECT missing%d FROM authors;\n", i, i)
+		want = append(want, "# package "+name)
+	}
+	files["sqlc.yaml"] = conf
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	if _, err := Generate(dir, &stderr); err == nil {
+		t.Fatal("expected generate errors")
+	}
+	var got []string
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if strings.HasPrefix(line, "# package") {
+			got = append(got, line)
+		}
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("packages reported out of order:\n%s", stderr.String())
+	}
+}
+
+func TestGenerateKeepsUnchangedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, contents string) {
+		t.Helper()
+		os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755)
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	schema := "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL);\nCREATE TABLE books (id BIGSERIAL PRIMARY KEY, title text NOT NULL);\n"
+	write("sqlc.yaml", "version: \"1\"\npackages:\n  - path: \"db\"\n    schema: \"schema.sql\"\n    queries: \"queries\"\n")
+	write("schema.sql", schema)
+	write("queries/authors.sql", "-- name: GetAuthor :one\nSELECT * FROM authors WHERE id = $1;\n")
+	write("queries/books.sql", "-- name: ListBooks :many\nSELECT * FROM books;\n")
+
+	generate := func() map[string]string {
+		t.Helper()
+		var stderr bytes.Buffer
+		output, err := Generate(dir, &stderr)
+		if err != nil {
+			t.Fatalf("generate failed: %s", stderr.String())
+		}
+		for filename, source := range output {
+			if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return output
+	}
+	os.MkdirAll(filepath.Join(dir, "db"), 0755)
+	generate()
+
+	// Mark the generated file, as if it had been written by an earlier
+	// version, and record the marked file as the output
+	out := filepath.Join(dir, "db")
+	authors := filepath.Join(out, "authors.sql.go")
+	blob, err := ioutil.ReadFile(authors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	marked := string(blob) + "// kept\n"
+	write("db/authors.sql.go", marked)
+	record := outputCache().Get(out)
+	entry := record["authors.sql.go"]
+	entry.Hash = cache.Key(marked)
+	record["authors.sql.go"] = entry
+	outputCache().Put(out, record)
+
+	// Changing a table the file doesn't use keeps it
+	write("schema.sql", schema+"ALTER TABLE books ADD COLUMN isbn text;\n")
+	output := generate()
+	if output[authors] != marked {
+		t.Errorf("authors.sql.go was generated again after books changed")
+	}
+	if !strings.Contains(output[filepath.Join(out, "books.sql.go")], "isbn") {
+		t.Errorf("books.sql.go wasn't generated again after books changed")
+	}
+
+	// Changing a table it uses doesn't
+	write("schema.sql", schema+"ALTER TABLE books ADD COLUMN isbn text;\nALTER TABLE authors ADD COLUMN bio text;\n")
+	output = generate()
+	if strings.Contains(output[authors], "// kept") || !strings.Contains(output[authors], "bio") {
+		t.Errorf("authors.sql.go wasn't generated again after authors changed:\n%s", output[authors])
+	}
+}
+
+func TestGenerateUncached(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-uncached")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  "version: \"1\"\npackages:\n  - path: \"db\"\n    schema: \"schema.sql\"\n    queries: \"query.sql\"\n",
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+		"query.sql":  "-- name: ListAuthors :many\nSELECT id FROM authors;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var stderr bytes.Buffer
+	output, err := GenerateUncached(dir, &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	if _, ok := output[filepath.Join(dir, "db", "query.sql.go")]; !ok {
+		t.Errorf("query.sql.go wasn't generated")
+	}
+	if record := outputCache().Get(filepath.Join(dir, "db")); len(record) != 0 {
+		t.Errorf("generated files were recorded in the cache: %v", record)
+	}
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// printDiff prints a unified diff of the changes writing output would make,
+// with file names relative to dir
+func printDiff(output map[string]string, dir string, stdout io.Writer) error {
+	var filenames []string
+	for filename := range output {
+		filenames = append(filenames, filename)
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		name := filename
+		if rel, err := filepath.Rel(dir, filename); err == nil && !strings.HasPrefix(rel, "..") {
+			name = filepath.ToSlash(rel)
+		}
+		old, err := ioutil.ReadFile(filename)
+		oldName := "a/" + name
+		if os.IsNotExist(err) {
+			oldName = "/dev/null"
+		} else if err != nil {
+			return err
+		}
+		fmt.Fprint(stdout, unifiedDiff(oldName, "b/"+name, string(old), output[filename]))
+	}
+	return nil
+}
+
+const diffContext = 3
+
+type diffEdit struct {
+	op   byte // ' ', '-' or '+'
+	line string
+}
+
+// unifiedDiff returns the unified diff between a and b, or an empty string if
+// they're the same
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	edits := diffLines(splitLines(a), splitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", aName, bName)
+	for i := 0; i < len(edits); {
+		if edits[i].op == ' ' {
+			i++
+			continue
+		}
+		// A hunk runs from the context before a change to the context after
+		// the last change within twice the context of the one before
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+		end := i
+		for j := i; j < len(edits); j++ {
+			if edits[j].op != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContext {
+				break
+			}
+		}
+		next := end
+		end += diffContext
+		if end > len(edits) {
+			end = len(edits)
+		}
+
+		aLine, bLine := 1, 1
+		for _, e := range edits[:start] {
+			if e.op != '+' {
+				aLine++
+			}
+			if e.op != '-' {
+				bLine++
+			}
+		}
+		var aCount, bCount int
+		for _, e := range edits[start:end] {
+			if e.op != '+' {
+				aCount++
+			}
+			if e.op != '-' {
+				bCount++
+			}
+		}
+		if aCount == 0 {
+			aLine--
+		}
+		if bCount == 0 {
+			bLine--
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aLine, aCount, bLine, bCount)
+		for _, e := range edits[start:end] {
+			fmt.Fprintf(&out, "%c%s\n", e.op, e.line)
+		}
+		i = next
+	}
+	return out.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the shortest edit script turning a into b, found with
+// Myers' algorithm
+func diffLines(a, b []string) []diffEdit {
+	// Lines shared at the start and end are left out of the search
+	var prefix, suffix []diffEdit
+	for len(a) > 0 && len(b) > 0 && a[0] == b[0] {
+		prefix = append(prefix, diffEdit{' ', a[0]})
+		a, b = a[1:], b[1:]
+	}
+	for len(a) > 0 && len(b) > 0 && a[len(a)-1] == b[len(b)-1] {
+		suffix = append([]diffEdit{{' ', a[len(a)-1]}}, suffix...)
+		a, b = a[:len(a)-1], b[:len(b)-1]
+	}
+
+	n, m := len(a), len(b)
+	max := n + m
+	v := make([]int, 2*max+2)
+	// trace holds the furthest x reached on each diagonal k after every
+	// step d, at index k+d
+	var trace [][]int
+search:
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x, y = x+1, y+1
+			}
+			v[max+k] = x
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[max-d:max+d+1]...))
+				break search
+			}
+		}
+		trace = append(trace, append([]int(nil), v[max-d:max+d+1]...))
+	}
+
+	var edits []diffEdit
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			edits = append(edits, diffEdit{' ', a[x-1]})
+			x, y = x-1, y-1
+		}
+		if prevK == k+1 {
+			edits = append(edits, diffEdit{'+', b[y-1]})
+			y--
+		} else {
+			edits = append(edits, diffEdit{'-', a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		edits = append(edits, diffEdit{' ', a[x-1]})
+		x, y = x-1, y-1
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return append(append(prefix, edits...), suffix...)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		a = append(a, fmt.Sprintf("line %d", i))
+	}
+	b = append(b, a[:4]...)
+	b = append(b, "inserted")
+	b = append(b, a[4:6]...)
+	b = append(b, a[7:]...)
+	b[len(b)-1] = "changed"
+
+	want := `--- a/query.sql.go
++++ b/query.sql.go
+@@ -2,9 +2,9 @@
+ line 2
+ line 3
+ line 4
++inserted
+ line 5
+ line 6
+-line 7
+ line 8
+ line 9
+ line 10
+@@ -17,4 +17,4 @@
+ line 17
+ line 18
+ line 19
+-line 20
++changed
+`
+	got := unifiedDiff("a/query.sql.go", "b/query.sql.go", strings.Join(a, "\n")+"\n", strings.Join(b, "\n")+"\n")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+	if got := unifiedDiff("a", "b", "same\n", "same\n"); got != "" {
+		t.Errorf("expected no diff for equal files, got:\n%s", got)
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-diff")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "models.go"), []byte("package db\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout bytes.Buffer
+	err = printDiff(map[string]string{
+		filepath.Join(dir, "models.go"): "package db\n",
+		filepath.Join(dir, "db.go"):     "package db\n\ntype DBTX interface{}\n",
+	}, dir, &stdout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `--- /dev/null
++++ b/db.go
+@@ -0,0 +1,3 @@
++package db
++
++type DBTX interface{}
+`
+	if diff := cmp.Diff(want, stdout.String()); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "db.go")); !os.IsNotExist(err) {
+		t.Error("printDiff wrote db.go")
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// Commands exit with a code for the class of failure, so scripts can tell
+// them apart without parsing the errors
+const (
+	ExitFailure = 1 // any other failure
+	ExitConfig  = 2 // the configuration is missing or invalid
+	ExitSchema  = 3 // a schema failed to parse
+	ExitQueries = 4 // a query failed to parse or type check
+	ExitIO      = 5 // a file couldn't be read, fetched or written
+)
+
+// An ExitError is returned once a command's errors have been printed, with
+// the code to exit with
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return "errored"
+}
+
+// failure returns an ExitError for the first of codes which isn't zero, as
+// that's the first failure printed, or nil if they're all zero
+func failure(codes ...int) error {
+	for _, code := range codes {
+		if code != 0 {
+			return &ExitError{Code: code}
+		}
+	}
+	return nil
+}
+
+// exitCode returns the code to exit with after err
+func exitCode(err error) int {
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
+
+// failureCode returns ExitIO if err is, or holds, an error reading a file,
+// and code otherwise
+func failureCode(err error, code int) int {
+	var pathErr *os.PathError
+	if errors.As(err, &pathErr) {
+		return ExitIO
+	}
+	var parserErr *dinosql.ParserErr
+	if errors.As(err, &parserErr) {
+		for _, fileErr := range parserErr.Errs {
+			if errors.As(fileErr.Err, &pathErr) {
+				return ExitIO
+			}
+		}
+	}
+	return code
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExitCodes(t *testing.T) {
+	const schema = "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n"
+	const query = "-- name: ListAuthors :many\nSELECT id FROM authors;\n"
+	for _, tc := range []struct {
+		name  string
+		files map[string]string
+		code  int
+	}{
+		{
+			name:  "missing config",
+			files: map[string]string{"schema.sql": schema},
+			code:  ExitConfig,
+		},
+		{
+			name:  "invalid config",
+			files: map[string]string{"sqlc.yaml": "packages: []\n"},
+			code:  ExitConfig,
+		},
+		{
+			name:  "schema",
+			files: map[string]string{"sqlc.yaml": compileConfig, "schema.sql": "CREATE TABLE (;\n", "one.sql": query, "two.sql": query},
+			code:  ExitSchema,
+		},
+		{
+			name:  "queries",
+			files: map[string]string{"sqlc.yaml": compileConfig, "schema.sql": schema, "one.sql": query, "two.sql": "-- name: GetAuthor :one\nSELECT missing FROM authors;\n"},
+			code:  ExitQueries,
+		},
+		{
+			name:  "missing queries",
+			files: map[string]string{"sqlc.yaml": compileConfig, "schema.sql": schema, "one.sql": query},
+			code:  ExitIO,
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "sqlc-exit")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			for name, contents := range tc.files {
+				if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			_, err = Generate(dir, ioutil.Discard)
+			if code := exitCode(err); code != tc.code {
+				t.Errorf("generate exited with %d, expected %d (%v)", code, tc.code, err)
+			}
+			if code := exitCode(Compile(dir, ioutil.Discard)); code != tc.code {
+				t.Errorf("compile exited with %d, expected %d", code, tc.code)
+			}
+		})
+	}
+}
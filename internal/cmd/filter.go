@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/dinosql/kotlin"
+)
+
+// A Filter limits the packages and queries sqlc works on. A nil Filter, or
+// an empty list, matches everything.
+type Filter struct {
+	Packages []string
+
+	// Queries limits the queries which are compiled. Generating code for
+	// some of a package's queries would leave out the rest, so generate
+	// ignores it.
+	Queries []string
+
+	mu    sync.Mutex
+	found map[string]bool
+}
+
+func (f *Filter) matchPackage(name string) bool {
+	if f == nil || len(f.Packages) == 0 {
+		return true
+	}
+	for _, pkg := range f.Packages {
+		if pkg == name {
+			f.mark("package " + name)
+			return true
+		}
+	}
+	return false
+}
+
+// only returns the names of the queries to parse, or nil for all of them
+func (f *Filter) only() map[string]bool {
+	if f == nil || len(f.Queries) == 0 {
+		return nil
+	}
+	only := map[string]bool{}
+	for _, q := range f.Queries {
+		only[q] = true
+	}
+	return only
+}
+
+// parsed records the queries found in a package's result
+func (f *Filter) parsed(result dinosql.Generateable) {
+	if f == nil || len(f.Queries) == 0 {
+		return
+	}
+	if res, ok := result.(*kotlin.Result); ok {
+		for _, q := range res.Queries {
+			f.mark("query " + q.Name)
+		}
+	}
+}
+
+func (f *Filter) mark(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.found == nil {
+		f.found = map[string]bool{}
+	}
+	f.found[name] = true
+}
+
+// missing returns an error naming the packages and queries which weren't
+// found
+func (f *Filter) missing() error {
+	if f == nil {
+		return nil
+	}
+	var names []string
+	for _, pkg := range f.Packages {
+		if !f.found["package "+pkg] {
+			names = append(names, "package "+pkg)
+		}
+	}
+	for _, q := range f.Queries {
+		if !f.found["query "+q] {
+			names = append(names, "query "+q)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s not found", strings.Join(names, ", "))
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-filter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  compileConfig,
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+		"one.sql":    "-- name: ListAuthors :many\nSELECT id FROM authors;\n\n-- name: ListBooks :many\nSELECT id FROM books;\n",
+		"two.sql":    "-- name: GetAuthor :one\nSELECT missing FROM authors;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	if err := CompileWorkspace([]string{dir}, &Filter{Queries: []string{"ListAuthors"}}, &stderr); err != nil {
+		t.Fatalf("expected ListAuthors to compile: %s", stderr.String())
+	}
+
+	stderr.Reset()
+	if err := CompileWorkspace([]string{dir}, &Filter{Queries: []string{"ListBooks"}}, &stderr); err == nil {
+		t.Fatal("expected ListBooks to fail")
+	}
+	if !strings.Contains(stderr.String(), `relation "books" does not exist`) || strings.Contains(stderr.String(), "missing") {
+		t.Errorf("expected only the ListBooks error in:\n%s", stderr.String())
+	}
+
+	stderr.Reset()
+	if err := CompileWorkspace([]string{dir}, &Filter{Packages: []string{"two"}, Queries: []string{"ListAuthors"}}, &stderr); err == nil {
+		t.Fatal("expected ListAuthors not to be found in package two")
+	}
+	if !strings.Contains(stderr.String(), "query ListAuthors not found") {
+		t.Errorf("unexpected errors:\n%s", stderr.String())
+	}
+
+	// The files of other packages are left alone
+	if err := ioutil.WriteFile(filepath.Join(dir, "two.sql"), []byte("-- name: GetAuthor :one\nSELECT id FROM authors WHERE id = $1;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stderr.Reset()
+	output, err := GenerateWorkspace([]string{dir}, &Filter{Packages: []string{"two"}}, &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	for filename := range output {
+		if !strings.HasPrefix(filename, filepath.Join(dir, "two")+string(filepath.Separator)) {
+			t.Errorf("generated %s outside package two", filename)
+		}
+	}
+	if len(output) == 0 {
+		t.Error("expected package two to be generated")
+	}
+}
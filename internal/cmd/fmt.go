@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/remote"
+)
+
+// Format rewrites the given SQL files in the standard layout, or the schema
+// and query files of each PostgreSQL package configured in dir if none are
+// given. A file named dinosql.Stdin is read from stdin and written to stdout.
+// With check set no files are changed; the names of those which aren't
+// formatted are printed to stdout instead, and an error is returned if there
+// are any.
+func Format(dir string, files []string, check bool, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(files) == 0 {
+		var err error
+		files, err = formatFiles(dir, stderr)
+		if err != nil {
+			return err
+		}
+	}
+
+	var errored, unformatted bool
+	for _, filename := range files {
+		name := strings.TrimPrefix(displayPath(filename), dir+"/")
+		var blob []byte
+		var err error
+		if filename == dinosql.Stdin {
+			blob, err = ioutil.ReadAll(stdin)
+		} else {
+			blob, err = ioutil.ReadFile(filename)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", name, err)
+			errored = true
+			continue
+		}
+		out, err := dinosql.Format(string(blob))
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", name, err)
+			errored = true
+			continue
+		}
+		if filename == dinosql.Stdin && !check {
+			io.WriteString(stdout, out)
+			continue
+		}
+		if out == string(blob) {
+			continue
+		}
+		if check {
+			fmt.Fprintln(stdout, name)
+			unformatted = true
+			continue
+		}
+		if err := ioutil.WriteFile(filename, []byte(out), 0644); err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", name, err)
+			errored = true
+		}
+	}
+	if errored {
+		return fmt.Errorf("errored")
+	}
+	if unformatted {
+		return fmt.Errorf("files aren't formatted")
+	}
+	return nil
+}
+
+// formatFiles returns the SQL schema and query files of the PostgreSQL
+// packages in dir. Remote schemas, and schemas in other formats, are left
+// out.
+func formatFiles(dir string, stderr io.Writer) ([]string, error) {
+	conf, err := readConfig(dir, stderr)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	var files []string
+	add := func(list func(string) ([]string, error), path string) error {
+		found, err := list(filepath.Join(dir, path))
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %s\n", path, err)
+			return err
+		}
+		for _, f := range found {
+			if filepath.Ext(f) == ".sql" && !seen[f] {
+				seen[f] = true
+				files = append(files, f)
+			}
+		}
+		return nil
+	}
+	for _, sql := range conf.SQL {
+		if sql.Engine != config.EnginePostgreSQL {
+			continue
+		}
+		if !remote.IsURL(sql.Schema) {
+			if err := add(dinosql.ReadSchemaFiles, sql.Schema); err != nil {
+				return nil, err
+			}
+		}
+		if err := add(dinosql.ReadSQLFiles, sql.Queries); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-fmt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	unformatted := "-- name: GetAuthor :one\nselect * from authors where id = $1;\n"
+	formatted := "-- name: GetAuthor :one\nSELECT *\nFROM authors\nWHERE id = $1;\n"
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  compileConfig,
+		"schema.sql": "CREATE TABLE authors (\n  id BIGSERIAL PRIMARY KEY\n);\n",
+		"one.sql":    unformatted,
+		"two.sql":    formatted,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Format(dir, nil, true, nil, &stdout, &stderr); err == nil {
+		t.Fatal("expected --check to fail")
+	}
+	if stdout.String() != "one.sql\n" {
+		t.Errorf("expected only one.sql to be listed, got %q", stdout.String())
+	}
+	blob, _ := ioutil.ReadFile(filepath.Join(dir, "one.sql"))
+	if string(blob) != unformatted {
+		t.Errorf("--check changed one.sql")
+	}
+
+	if err := Format(dir, nil, false, nil, &stdout, &stderr); err != nil {
+		t.Fatalf("format: %s", stderr.String())
+	}
+	blob, _ = ioutil.ReadFile(filepath.Join(dir, "one.sql"))
+	if string(blob) != formatted {
+		t.Errorf("unexpected formatting:\n%s", blob)
+	}
+	stdout.Reset()
+	if err := Format(dir, nil, true, nil, &stdout, &stderr); err != nil {
+		t.Errorf("expected formatted files to pass --check, got %q", stdout.String())
+	}
+}
@@ -2,19 +2,24 @@ package cmd
 
 import (
 	"bytes"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/kyleconroy/sqlc/internal/cache"
 	"github.com/kyleconroy/sqlc/internal/compiler"
 	"github.com/kyleconroy/sqlc/internal/config"
 	"github.com/kyleconroy/sqlc/internal/dinosql"
 	"github.com/kyleconroy/sqlc/internal/dinosql/kotlin"
 	"github.com/kyleconroy/sqlc/internal/mysql"
+	"github.com/kyleconroy/sqlc/internal/pg"
 )
 
 const errMessageNoVersion = `The configuration file must have a version number.
@@ -27,13 +32,13 @@ Set the version to 1 at the top of sqlc.json:
 `
 
 const errMessageUnknownVersion = `The configuration file has an invalid version number.
-The only supported version is "1".
+The supported versions are "1" and "2".
 `
 
 const errMessageNoPackages = `No packages are configured`
 
 func printFileErr(stderr io.Writer, dir string, fileErr dinosql.FileErr) {
-	filename := strings.TrimPrefix(fileErr.Filename, dir+"/")
+	filename := strings.TrimPrefix(displayPath(fileErr.Filename), dir+"/")
 	fmt.Fprintf(stderr, "%s:%d:%d: %s\n", filename, fileErr.Line, fileErr.Column, fileErr.Err)
 }
 
@@ -42,7 +47,9 @@ type outPair struct {
 	config.SQL
 }
 
-func Generate(dir string, stderr io.Writer) (map[string]string, error) {
+// readConfig reads the sqlc.yaml or sqlc.json file in dir, printing any
+// errors to stderr
+func readConfig(dir string, stderr io.Writer) (config.Config, error) {
 	var yamlMissing, jsonMissing bool
 	yamlPath := filepath.Join(dir, "sqlc.yaml")
 	jsonPath := filepath.Join(dir, "sqlc.json")
@@ -56,12 +63,12 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 
 	if yamlMissing && jsonMissing {
 		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, errors.New("config file missing")
+		return config.Config{}, &ExitError{Code: ExitConfig}
 	}
 
 	if !yamlMissing && !jsonMissing {
 		fmt.Fprintln(stderr, "error parsing sqlc.json: both files present")
-		return nil, errors.New("sqlc.json and sqlc.yaml present")
+		return config.Config{}, &ExitError{Code: ExitConfig}
 	}
 
 	configPath := yamlPath
@@ -72,7 +79,7 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 	blob, err := ioutil.ReadFile(configPath)
 	if err != nil {
 		fmt.Fprintln(stderr, "error parsing sqlc.json: file does not exist")
-		return nil, err
+		return config.Config{}, &ExitError{Code: ExitIO}
 	}
 
 	conf, err := config.ParseConfig(bytes.NewReader(blob))
@@ -86,11 +93,29 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 			fmt.Fprintf(stderr, errMessageNoPackages)
 		}
 		fmt.Fprintf(stderr, "error parsing sqlc.json: %s\n", err)
+		return conf, &ExitError{Code: ExitConfig}
+	}
+	return conf, nil
+}
+
+func Generate(dir string, stderr io.Writer) (map[string]string, error) {
+	return generate(dir, nil, nil, nil, true, stderr)
+}
+
+// GenerateUncached generates the files like Generate, but neither reads nor
+// writes the on-disk caches, whatever $SQLC_CACHE is set to. Tests use it so
+// that every file is generated rather than read back.
+func GenerateUncached(dir string, stderr io.Writer) (map[string]string, error) {
+	return generate(dir, nil, nil, nil, false, stderr)
+}
+
+func generate(dir string, schemas *schemaCache, filter *Filter, report *Report, cached bool, stderr io.Writer) (map[string]string, error) {
+	conf, err := readConfig(dir, stderr)
+	if err != nil {
 		return nil, err
 	}
 
 	output := map[string]string{}
-	errored := false
 
 	var pairs []outPair
 	for _, sql := range conf.SQL {
@@ -108,81 +133,416 @@ func Generate(dir string, stderr io.Writer) (map[string]string, error) {
 		}
 	}
 
-	for _, sql := range pairs {
-		combo := config.Combine(conf, sql.SQL)
-		var result dinosql.Generateable
+	// Packages are generated concurrently, sharing the catalogs of their
+	// schemas
+	if schemas == nil {
+		schemas = &schemaCache{}
+	}
+	files := make([]map[string]string, len(pairs))
+	failed := make([]int, len(pairs))
+	parallel(len(pairs), stderr, func(i int, stderr io.Writer) {
+		files[i], failed[i] = generatePackage(dir, conf, pairs[i], schemas, filter, report, cached, stderr)
+	})
+	for i := range pairs {
+		for filename, source := range files[i] {
+			output[filename] = source
+		}
+	}
+
+	if err := failure(failed...); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
 
-		// TODO: This feels like a hack that will bite us later
-		sql.Schema = filepath.Join(dir, sql.Schema)
-		sql.Queries = filepath.Join(dir, sql.Queries)
+// generatePackage generates the code for a package, returning the files keyed
+// by path, or the exit code for its failure. The files and timings are added
+// to report. The on-disk caches are only used if cached is set.
+func generatePackage(dir string, conf config.Config, sql outPair, schemas *schemaCache, filter *Filter, report *Report, cached bool, stderr io.Writer) (map[string]string, int) {
+	combo := config.Combine(conf, sql.SQL)
 
-		var name string
-		parseOpts := dinosql.ParserOpts{}
-		if sql.Gen.Go != nil {
-			name = combo.Go.Package
-		} else if sql.Gen.Kotlin != nil {
-			parseOpts.UsePositionalParameters = true
-			name = combo.Kotlin.Package
-		}
+	var name string
+	parseOpts := dinosql.ParserOpts{}
+	if sql.Gen.Go != nil {
+		name = combo.Go.Package
+	} else if sql.Gen.Kotlin != nil {
+		parseOpts.UsePositionalParameters = true
+		name = combo.Kotlin.Package
+	}
+	if !filter.matchPackage(name) {
+		return nil, 0
+	}
+	// Queries read from the cache aren't analyzed, so there'd be nothing to
+	// trace
+	if cached && !dinosql.Tracing(dinosql.TraceInference) {
+		parseOpts.Cache = queryCache()
+	}
 
-		result, errored = parse(name, dir, sql.SQL, combo, parseOpts, stderr)
-		if errored {
-			break
+	pkg := &PackageReport{Name: name, Dir: dir}
+	for _, o := range combo.Overrides {
+		if o.Deprecated_PostgresType != "" {
+			report.warn(fmt.Sprintf("package %s: \"postgres_type\" is deprecated, use \"db_type\" to override %s", name, o.Deprecated_PostgresType))
 		}
+	}
 
-		var files map[string]string
-		var out string
-		if sql.Gen.Go != nil {
-			out = combo.Go.Out
-			files, err = dinosql.Generate(result, combo)
-		} else if sql.Gen.Kotlin != nil {
-			out = combo.Kotlin.Out
-			ktRes, ok := result.(kotlin.KtGenerateable)
-			if !ok {
-				err = fmt.Errorf("kotlin not supported for engine %s", combo.Package.Engine)
-				break
+	// TODO: This feels like a hack that will bite us later
+	schema, err := schemas.path(dir, sql.Schema)
+	if err != nil {
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error fetching schema: %s\n", err)
+		return nil, ExitIO
+	}
+	sql.Schema = schema
+	sql.Queries = filepath.Join(dir, sql.Queries)
+
+	result, failed := parse(name, dir, sql.SQL, combo, &parseOpts, schemas, &pkg.Timings, stderr)
+	if failed != 0 {
+		return nil, failed
+	}
+
+	start := time.Now()
+	var files map[string]string
+	var out string
+	if sql.Gen.Go != nil {
+		out = combo.Go.Out
+		if combo.Go.ImportPath == "" {
+			combo.Go.ImportPath, err = goImportPath(filepath.Join(dir, out))
+		}
+		if err == nil && combo.Go.OutputModelsPackage != "" {
+			var name string
+			combo.Go.OutputModelsPackage, name, err = modelsImportPath(dir, conf, combo.Go.OutputModelsPackage)
+			if combo.Go.OutputModelsPackageName == "" {
+				combo.Go.OutputModelsPackageName = name
+			}
+		}
+		if err == nil {
+			var outputs *cache.Outputs
+			if cached {
+				outputs = outputCache()
 			}
+			files, err = generateGo(result, combo, filepath.Join(dir, out), outputs)
+		}
+		if err == nil && combo.Go.EmitDocs != "" {
+			err = addDocs(files, result, combo)
+		}
+		if err == nil && combo.Go.EmitTestContainer {
+			err = addTestContainer(files, dir, sql.Schema, result, combo)
+		}
+	} else if sql.Gen.Kotlin != nil {
+		out = combo.Kotlin.Out
+		ktRes, ok := result.(kotlin.KtGenerateable)
+		if ok {
 			files, err = kotlin.KtGenerate(ktRes, combo)
+		} else {
+			err = fmt.Errorf("kotlin not supported for engine %s", combo.Package.Engine)
 		}
-		if err != nil {
-			fmt.Fprintf(stderr, "# package %s\n", name)
-			fmt.Fprintf(stderr, "error generating code: %s\n", err)
-			errored = true
-			continue
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error generating code: %s\n", err)
+		return nil, failureCode(err, ExitFailure)
+	}
+
+	output := map[string]string{}
+	for n, source := range files {
+		output[filepath.Join(dir, out, n)] = source
+	}
+	for filename, source := range parseOpts.Generated {
+		output[filename] = source
+	}
+	pkg.Timings.Generate.since(start)
+
+	if report != nil {
+		pkg.Files = packageFiles(dir, output, result, combo, sql.Gen.Kotlin != nil)
+		if len(result.GoQueries(combo)) == 0 {
+			report.warn(fmt.Sprintf("package %s: no queries found in %s", name, strings.TrimPrefix(sql.Queries, dir+"/")))
 		}
+		report.addPackage(pkg)
+	}
+	return output, 0
+}
 
-		for n, source := range files {
-			filename := filepath.Join(dir, out, n)
-			output[filename] = source
+// parallel calls fn for each of n packages, running at most GOMAXPROCS at a
+// time. The errors each package prints are written to stderr in order, once
+// they have all finished.
+func parallel(n int, stderr io.Writer, fn func(i int, stderr io.Writer)) {
+	bufs := make([]bytes.Buffer, n)
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i, &bufs[i])
+		}(i)
+	}
+	wg.Wait()
+	for i := range bufs {
+		stderr.Write(bufs[i].Bytes())
+	}
+}
+
+// Compile parses the schema and queries of every package configured in dir
+// without generating code. All errors are printed to stderr, rather than
+// stopping at the first package which fails.
+func Compile(dir string, stderr io.Writer) error {
+	return compile(dir, nil, nil, stderr)
+}
+
+func compile(dir string, schemas *schemaCache, filter *Filter, stderr io.Writer) error {
+	conf, err := readConfig(dir, stderr)
+	if err != nil {
+		return err
+	}
+	if schemas == nil {
+		schemas = &schemaCache{}
+	}
+	failed := make([]int, len(conf.SQL))
+	parallel(len(conf.SQL), stderr, func(i int, stderr io.Writer) {
+		_, _, failed[i] = parsePackage(dir, conf, conf.SQL[i], schemas, filter, stderr)
+	})
+	return failure(failed...)
+}
+
+// parsePackage parses a configured package, returning the result and the
+// package name used in errors, or the exit code for its failure
+func parsePackage(dir string, conf config.Config, sql config.SQL, schemas *schemaCache, filter *Filter, stderr io.Writer) (dinosql.Generateable, string, int) {
+	combo := config.Combine(conf, sql)
+	name := sql.Queries
+	parseOpts := dinosql.ParserOpts{}
+	if sql.Gen.Go != nil {
+		name = combo.Go.Package
+	} else if sql.Gen.Kotlin != nil {
+		parseOpts.UsePositionalParameters = true
+		name = combo.Kotlin.Package
+	}
+	if !filter.matchPackage(name) {
+		return nil, name, 0
+	}
+	parseOpts.Only = filter.only()
+	schema, err := schemas.path(dir, sql.Schema)
+	if err != nil {
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error fetching schema: %s\n", err)
+		return nil, name, ExitIO
+	}
+	sql.Schema = schema
+	sql.Queries = filepath.Join(dir, sql.Queries)
+	result, failed := parse(name, dir, sql, combo, &parseOpts, schemas, nil, stderr)
+	filter.parsed(result)
+	return result, name, failed
+}
+
+// addDocs adds the schema documentation for `emit_docs` to files
+func addDocs(files map[string]string, result dinosql.Generateable, combo config.CombinedSettings) error {
+	res, ok := result.(*kotlin.Result)
+	if !ok {
+		return fmt.Errorf("emit_docs is only supported by the %s engine", config.EnginePostgreSQL)
+	}
+	name, source, err := dinosql.Docs(res.Result, combo.Go.EmitDocs)
+	if err != nil {
+		return err
+	}
+	files[name] = source
+	return nil
+}
+
+// addTestContainer adds the test database helpers for `emit_test_container`
+// to files
+func addTestContainer(files map[string]string, dir, schema string, result dinosql.Generateable, combo config.CombinedSettings) error {
+	if _, ok := result.(*kotlin.Result); !ok {
+		return fmt.Errorf("emit_test_container is only supported by the %s engine", config.EnginePostgreSQL)
+	}
+	source, err := dinosql.TestContainer(schema, dir, combo)
+	if err != nil {
+		return err
+	}
+	files[dinosql.TestContainerFilename] = source
+	return nil
+}
+
+var (
+	queryCacheOnce sync.Once
+	queryCacheDisk *cache.Queries
+)
+
+// queryCache returns the on-disk cache of parsed query files, or nil if it's
+// disabled or there's nowhere to put it
+func queryCache() dinosql.QueryCache {
+	queryCacheOnce.Do(func() {
+		if cache.Enabled() {
+			queryCacheDisk, _ = cache.NewQueries()
 		}
+	})
+	if queryCacheDisk == nil {
+		return nil
 	}
+	return queryCacheDisk
+}
+
+var (
+	outputCacheOnce sync.Once
+	outputCacheDisk *cache.Outputs
+)
 
-	if errored {
-		return nil, fmt.Errorf("errored")
+// outputCache returns the on-disk record of generated files, or nil if the
+// cache is disabled or there's nowhere to put it
+func outputCache() *cache.Outputs {
+	outputCacheOnce.Do(func() {
+		if cache.Enabled() {
+			outputCacheDisk, _ = cache.NewOutputs()
+		}
+	})
+	return outputCacheDisk
+}
+
+// generateGo generates the Go code for a package written to out. A query
+// file's code is only generated again if its queries, the tables they use or
+// the settings have changed since it was written, or the file was edited;
+// otherwise the file is read back from out. Every file is generated if
+// outputs is nil.
+func generateGo(result dinosql.Generateable, combo config.CombinedSettings, out string, outputs *cache.Outputs) (map[string]string, error) {
+	keys, ok := dinosql.QueryFileKeys(result, combo)
+	if outputs == nil || !ok || currentBuildID() == "" || dinosql.Tracing(dinosql.TraceInference) {
+		return dinosql.Generate(result, combo)
 	}
-	return output, nil
+
+	prev := outputs.Get(out)
+	inputs := map[string]string{}
+	for source, key := range keys {
+		inputs[dinosql.QueryFilename(source, combo)] = cache.Key(currentBuildID(), key)
+	}
+	kept := map[string]string{}
+	unchanged := func(source string) bool {
+		name := dinosql.QueryFilename(source, combo)
+		entry, ok := prev[name]
+		if !ok || entry.Input != inputs[name] {
+			return false
+		}
+		blob, err := ioutil.ReadFile(filepath.Join(out, name))
+		if err != nil || cache.Key(string(blob)) != entry.Hash {
+			return false
+		}
+		kept[name] = string(blob)
+		return true
+	}
+	files, err := dinosql.GenerateChanged(result, combo, unchanged)
+	if err != nil {
+		return nil, err
+	}
+
+	next := map[string]cache.Output{}
+	for name, input := range inputs {
+		if source, ok := kept[name]; ok {
+			files[name] = source
+		}
+		if source, ok := files[name]; ok {
+			next[name] = cache.Output{Input: input, Hash: cache.Key(source)}
+		}
+	}
+	outputs.Put(out, next)
+	return files, nil
+}
+
+// unchangedOnDisk reports whether filename already holds source, so writing
+// it again can be skipped
+func unchangedOnDisk(filename, source string) bool {
+	info, err := os.Stat(filename)
+	if err != nil || info.Size() != int64(len(source)) {
+		return false
+	}
+	blob, err := ioutil.ReadFile(filename)
+	return err == nil && string(blob) == source
+}
+
+var (
+	buildIDOnce sync.Once
+	buildID     string
+)
+
+// currentBuildID identifies the running sqlc, or is empty if it can't be
+// identified. Development builds have no version, so are identified by a
+// hash of the executable.
+func currentBuildID() string {
+	buildIDOnce.Do(func() {
+		if version != "" {
+			buildID = version
+			return
+		}
+		exe, err := os.Executable()
+		if err != nil {
+			return
+		}
+		blob, err := ioutil.ReadFile(exe)
+		if err != nil {
+			return
+		}
+		buildID = cache.Key(string(blob))
+	})
+	return buildID
 }
 
-func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts dinosql.ParserOpts, stderr io.Writer) (dinosql.Generateable, bool) {
+// cacheKey returns the key which query files parsed with combo against a
+// catalog are cached under, or an empty string if they shouldn't be cached.
+func cacheKey(combo config.CombinedSettings, catalog string) string {
+	settings, err := json.Marshal(combo)
+	if err != nil || currentBuildID() == "" || catalog == "" {
+		return ""
+	}
+	return cache.Key(currentBuildID(), string(settings), catalog)
+}
+
+// parse parses the schema and queries of a package, recording how long each
+// took in timings if it's not nil. If it fails, the exit code for the failure
+// is returned.
+func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, parserOpts *dinosql.ParserOpts, schemas *schemaCache, timings *Timings, stderr io.Writer) (dinosql.Generateable, int) {
+	if timings == nil {
+		timings = &Timings{}
+	}
+	parserOpts.VetRules = customVetRules(sql.Vet)
+	if len(combo.Go.CRUDTables) > 0 && sql.Engine != config.EnginePostgreSQL {
+		fmt.Fprintf(stderr, "# package %s\n", name)
+		fmt.Fprintf(stderr, "error parsing queries: crud_tables is only supported by the %s engine\n", config.EnginePostgreSQL)
+		return nil, ExitConfig
+	}
 	switch sql.Engine {
 	case config.EngineMySQL:
+		if parserOpts.Only != nil {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error parsing queries: --query is only supported by the %s engine\n", config.EnginePostgreSQL)
+			return nil, ExitFailure
+		}
 		// Experimental MySQL support
-		q, err := mysql.GeneratePkg(name, sql.Schema, sql.Queries, combo)
+		start := time.Now()
+		q, err := mysql.GeneratePkgSources(name, sql.Schema, sql.Queries, combo, parserOpts.Sources)
+		timings.Queries.since(start)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
 			if parserErr, ok := err.(*dinosql.ParserErr); ok {
 				for _, fileErr := range parserErr.Errs {
 					printFileErr(stderr, dir, fileErr)
 				}
-			} else {
-				fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
+				return nil, failureCode(err, ExitQueries)
 			}
-			return nil, true
+			fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
+			return nil, failureCode(err, ExitSchema)
 		}
-		return q, false
+		return q, 0
 
 	case config.EnginePostgreSQL:
-		c, err := dinosql.ParseCatalog(sql.Schema)
+		start := time.Now()
+		var c pg.Catalog
+		var err error
+		if parserOpts.Sources != nil {
+			c, err = dinosql.ParseCatalogSources(sql.Schema, parserOpts.Sources)
+		} else {
+			c, err = schemas.catalog(sql.Schema)
+		}
+		timings.Schema.since(start)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
 			if parserErr, ok := err.(*dinosql.ParserErr); ok {
@@ -192,10 +552,25 @@ func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, pars
 			} else {
 				fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
 			}
-			return nil, true
+			return nil, failureCode(err, ExitSchema)
+		}
+
+		if len(combo.Go.CRUDTables) > 0 {
+			source, err := dinosql.CRUDQueries(c, combo.Go.CRUDTables, combo)
+			if err != nil {
+				fmt.Fprintf(stderr, "# package %s\n", name)
+				fmt.Fprintf(stderr, "error parsing queries: %s\n", err)
+				return nil, ExitQueries
+			}
+			parserOpts.Generated = map[string]string{filepath.Join(dir, combo.Go.Out, dinosql.CRUDFilename): source}
+		}
+		if parserOpts.Cache != nil {
+			parserOpts.CacheKey = cacheKey(combo, schemas.catalogHash(sql.Schema))
 		}
 
-		q, err := dinosql.ParseQueries(c, sql.Queries, parserOpts)
+		start = time.Now()
+		q, err := dinosql.ParseQueries(c, sql.Queries, *parserOpts)
+		timings.Queries.since(start)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
 			if parserErr, ok := err.(*dinosql.ParserErr); ok {
@@ -205,18 +580,20 @@ func parse(name, dir string, sql config.SQL, combo config.CombinedSettings, pars
 			} else {
 				fmt.Fprintf(stderr, "error parsing queries: %s\n", err)
 			}
-			return nil, true
+			return nil, failureCode(err, ExitQueries)
 		}
-		return &kotlin.Result{Result: q}, false
+		return &kotlin.Result{Result: q}, 0
 
 	case config.EngineXLemon, config.EngineXDolphin, config.EngineXElephant:
+		start := time.Now()
 		r, err := compiler.Run(sql, combo)
+		timings.Queries.since(start)
 		if err != nil {
 			fmt.Fprintf(stderr, "# package %s\n", name)
 			fmt.Fprintf(stderr, "error: %s\n", err)
-			return nil, true
+			return nil, failureCode(err, ExitQueries)
 		}
-		return r, false
+		return r, 0
 
 	default:
 		panic("invalid engine")
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// goImportPath returns the import path of the package in dir, computed from
+// the nearest go.mod file in dir or one of its parents. It returns an empty
+// string if dir isn't inside a module.
+func goImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := dir; ; {
+		module, err := readModulePath(filepath.Join(root, "go.mod"))
+		if err != nil {
+			return "", err
+		}
+		if module != "" {
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return module, nil
+			}
+			return module + "/" + filepath.ToSlash(rel), nil
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return "", nil
+		}
+		root = parent
+	}
+}
+
+// modelsImportPath resolves the `output_models_package` setting, returning
+// the import path and name of the models package. If it names the output
+// directory of another package in the configuration, the import path and
+// name of that package are returned. Otherwise it is used as an import path,
+// and the name is left for `output_models_package_name` to set.
+func modelsImportPath(dir string, conf config.Config, pkg string) (string, string, error) {
+	for _, sql := range conf.SQL {
+		if sql.Gen.Go == nil || filepath.Clean(sql.Gen.Go.Out) != filepath.Clean(pkg) {
+			continue
+		}
+		if sql.Gen.Go.ImportPath != "" {
+			return sql.Gen.Go.ImportPath, sql.Gen.Go.Package, nil
+		}
+		path, err := goImportPath(filepath.Join(dir, sql.Gen.Go.Out))
+		if err != nil {
+			return "", "", err
+		}
+		if path == "" {
+			return "", "", fmt.Errorf("output_models_package %q: can't find go.mod", pkg)
+		}
+		return path, sql.Gen.Go.Package, nil
+	}
+	return pkg, "", nil
+}
+
+// readModulePath returns the module path declared in the go.mod file at
+// path, or an empty string if the file doesn't exist.
+func readModulePath(path string) (string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+		module := fields[1]
+		if unquoted, err := strconv.Unquote(module); err == nil {
+			module = unquoted
+		}
+		return module, nil
+	}
+	return "", s.Err()
+}
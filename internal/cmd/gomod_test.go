@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoImportPath(t *testing.T) {
+	root, err := ioutil.TempDir("", "sqlc-gomod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	gomod := "// comment\nmodule \"example.com/app\" // trailing\n\ngo 1.13\n"
+	if err := ioutil.WriteFile(filepath.Join(root, "go.mod"), []byte(gomod), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		dir  string
+		path string
+	}{
+		{root, "example.com/app"},
+		{filepath.Join(root, "internal", "db"), "example.com/app/internal/db"},
+	} {
+		path, err := goImportPath(test.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path != test.path {
+			t.Errorf("import path of %s: want %q, got %q", test.dir, test.path, path)
+		}
+	}
+}
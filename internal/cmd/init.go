@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+const initConfig = `version: "1"
+packages:
+  - name: "db"
+    path: "db"
+    schema: "schema"
+    queries: "query"
+    engine: "%s"
+`
+
+var initSchemas = map[config.Engine]string{
+	config.EnginePostgreSQL: `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);
+`,
+	config.EngineMySQL: `CREATE TABLE authors (
+  id   BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name text   NOT NULL,
+  bio  text
+);
+`,
+}
+
+var initQueries = map[config.Engine]string{
+	config.EnginePostgreSQL: `-- name: GetAuthor :one
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+ORDER BY name;
+
+-- name: CreateAuthor :one
+INSERT INTO authors (
+  name, bio
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
+`,
+	config.EngineMySQL: `/* name: GetAuthor :one */
+SELECT * FROM authors
+WHERE id = ? LIMIT 1;
+
+/* name: ListAuthors :many */
+SELECT * FROM authors
+ORDER BY name;
+
+/* name: CreateAuthor :exec */
+INSERT INTO authors (
+  name, bio
+) VALUES (
+  ?, ?
+);
+
+/* name: DeleteAuthor :exec */
+DELETE FROM authors
+WHERE id = ?;
+`,
+}
+
+// initProject writes a starter sqlc.yaml, along with a schema and queries for
+// engine, to dir. Existing files are left alone. It returns the files it
+// created.
+func initProject(dir string, engine config.Engine) ([]string, error) {
+	schema, ok := initSchemas[engine]
+	if !ok {
+		return nil, fmt.Errorf("invalid engine %q: must be one of %s or %s", engine, config.EnginePostgreSQL, config.EngineMySQL)
+	}
+	for _, name := range []string{"sqlc.yaml", "sqlc.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return nil, fmt.Errorf("%s already exists", name)
+		}
+	}
+	files := []struct {
+		name   string
+		source string
+	}{
+		{"sqlc.yaml", fmt.Sprintf(initConfig, engine)},
+		{filepath.Join("schema", "schema.sql"), schema},
+		{filepath.Join("query", "query.sql"), initQueries[engine]},
+	}
+	var created []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return created, err
+		}
+		if err := ioutil.WriteFile(path, []byte(f.source), 0644); err != nil {
+			return created, err
+		}
+		created = append(created, f.name)
+	}
+	return created, nil
+}
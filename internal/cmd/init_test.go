@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+func TestInitProject(t *testing.T) {
+	for _, engine := range []config.Engine{config.EnginePostgreSQL, config.EngineMySQL} {
+		engine := engine
+		t.Run(string(engine), func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "sqlc-init")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+
+			if _, err := initProject(dir, engine); err != nil {
+				t.Fatal(err)
+			}
+			var stderr bytes.Buffer
+			output, err := Generate(dir, &stderr)
+			if err != nil {
+				t.Fatalf("generate: %s", stderr.String())
+			}
+			if len(output) == 0 {
+				t.Error("generate: no files")
+			}
+			if _, err := initProject(dir, engine); err == nil {
+				t.Error("expected an error when sqlc.yaml exists")
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// TestMain keeps the query cache out of the user's cache directory
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("SQLC_CACHE_DIR", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
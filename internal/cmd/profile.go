@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+var profiling struct {
+	sync.Mutex
+	stops []func() error
+}
+
+// startProfiling starts the profiles requested by the --cpuprofile,
+// --memprofile and --trace flags. They are written when the command exits,
+// through stopProfiling.
+func startProfiling(cmd *cobra.Command) error {
+	cpu, err := cmd.Flags().GetString("cpuprofile")
+	if err != nil {
+		return err
+	}
+	mem, err := cmd.Flags().GetString("memprofile")
+	if err != nil {
+		return err
+	}
+	tr, err := cmd.Flags().GetString("trace")
+	if err != nil {
+		return err
+	}
+
+	if cpu != "" {
+		f, err := os.Create(cpu)
+		if err != nil {
+			return err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return err
+		}
+		onStop(func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+	if tr != "" {
+		f, err := os.Create(tr)
+		if err != nil {
+			return err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return err
+		}
+		onStop(func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+	if mem != "" {
+		onStop(func() error {
+			f, err := os.Create(mem)
+			if err != nil {
+				return err
+			}
+			// Collect garbage first, so the profile shows live memory
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				f.Close()
+				return err
+			}
+			return f.Close()
+		})
+	}
+	return nil
+}
+
+func onStop(stop func() error) {
+	profiling.Lock()
+	defer profiling.Unlock()
+	profiling.stops = append(profiling.stops, stop)
+}
+
+// stopProfiling writes the profiles started by startProfiling, printing any
+// errors to stderr
+func stopProfiling(stderr io.Writer) {
+	profiling.Lock()
+	defer profiling.Unlock()
+	for _, stop := range profiling.stops {
+		if err := stop(); err != nil {
+			fmt.Fprintf(stderr, "error writing profile: %s\n", err)
+		}
+	}
+	profiling.stops = nil
+}
+
+// exit writes any profiles and exits with code. Commands call it in place of
+// os.Exit.
+func exit(code int) {
+	stopProfiling(os.Stderr)
+	os.Exit(code)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiling(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  compileConfig,
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+		"one.sql":    "-- name: ListAuthors :many\nSELECT id FROM authors;\n",
+		"two.sql":    "-- name: GetAuthor :one\nSELECT id FROM authors WHERE id = $1;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	profiles := map[string]string{
+		"--cpuprofile": filepath.Join(dir, "cpu.out"),
+		"--memprofile": filepath.Join(dir, "mem.out"),
+		"--trace":      filepath.Join(dir, "trace.out"),
+	}
+	args := []string{"compile", dir}
+	for flag, path := range profiles {
+		args = append(args, flag, path)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := Do(args, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("compile exited with %d: %s", code, stderr.String())
+	}
+	for flag, path := range profiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("%s: %s", flag, err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s wrote an empty profile", flag)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// A Report summarizes a run of sqlc generate, for `--format json`. A nil
+// Report records nothing.
+type Report struct {
+	Packages []*PackageReport `json:"packages"`
+	Warnings []string         `json:"warnings"`
+	Timings  struct {
+		Write Duration `json:"write_ms"`
+		Total Duration `json:"total_ms"`
+	} `json:"timings"`
+
+	mu sync.Mutex
+}
+
+// A PackageReport describes the files generated for a package, and how long
+// each phase of generating them took
+type PackageReport struct {
+	Name    string       `json:"name"`
+	Dir     string       `json:"dir"`
+	Files   []FileReport `json:"files"`
+	Timings Timings      `json:"timings"`
+}
+
+// A FileReport names a generated file, relative to its project directory, and
+// how many queries it holds
+type FileReport struct {
+	Path    string `json:"path"`
+	Queries int    `json:"queries"`
+}
+
+// Timings are the time spent parsing the schema and queries of a package and
+// generating its code. Packages sharing a schema only parse it once, so the
+// others count the time spent waiting for it.
+type Timings struct {
+	Schema   Duration `json:"schema_ms"`
+	Queries  Duration `json:"queries_ms"`
+	Generate Duration `json:"generate_ms"`
+}
+
+// A Duration is encoded in JSON as a number of milliseconds
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(time.Duration(d).Microseconds()) / 1000)
+}
+
+// since sets d to the time elapsed since start
+func (d *Duration) since(start time.Time) {
+	*d = Duration(time.Since(start))
+}
+
+func (r *Report) addPackage(pkg *PackageReport) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Packages = append(r.Packages, pkg)
+}
+
+func (r *Report) warn(msg string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, msg)
+}
+
+// Write writes the report as indented JSON, with the packages in a stable
+// order
+func (r *Report) Write(w io.Writer) error {
+	sort.SliceStable(r.Packages, func(i, j int) bool {
+		if r.Packages[i].Dir != r.Packages[j].Dir {
+			return r.Packages[i].Dir < r.Packages[j].Dir
+		}
+		return r.Packages[i].Name < r.Packages[j].Name
+	})
+	if r.Warnings == nil {
+		r.Warnings = []string{}
+	}
+	blob, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(blob, '\n'))
+	return err
+}
+
+// packageFiles lists the files generated for a package with the number of
+// queries in each. Go queries are written to a file named after their query
+// file, while Kotlin queries are all written to Queries.kt and
+// QueriesImpl.kt.
+func packageFiles(dir string, output map[string]string, result dinosql.Generateable, combo config.CombinedSettings, kotlin bool) []FileReport {
+	counts := map[string]int{}
+	for _, q := range result.GoQueries(combo) {
+		if kotlin {
+			counts["Queries.kt"]++
+			counts["QueriesImpl.kt"]++
+			continue
+		}
+		counts[dinosql.QueryFilename(q.SourceName, combo)]++
+	}
+	var files []FileReport
+	for filename := range output {
+		path := filename
+		if rel, err := filepath.Rel(dir, filename); err == nil {
+			path = filepath.ToSlash(rel)
+		}
+		files = append(files, FileReport{Path: path, Queries: counts[filepath.Base(filename)]})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
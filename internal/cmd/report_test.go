@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  compileConfig,
+		"schema.sql": "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n",
+		"one.sql":    "-- name: ListAuthors :many\nSELECT id FROM authors;\n\n-- name: GetAuthor :one\nSELECT id FROM authors WHERE id = $1;\n",
+		"two.sql":    "SELECT 1;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stderr bytes.Buffer
+	report := &Report{}
+	if _, err := generateWorkspace([]string{dir}, nil, report, &stderr); err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	var out bytes.Buffer
+	if err := report.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Packages []struct {
+			Name    string
+			Files   []FileReport
+			Timings map[string]float64
+		}
+		Warnings []string
+		Timings  map[string]float64
+	}
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("invalid report: %s\n%s", err, out.String())
+	}
+	if len(got.Packages) != 2 {
+		t.Fatalf("expected two packages:\n%s", out.String())
+	}
+	want := []FileReport{
+		{Path: "one/db.go"},
+		{Path: "one/models.go"},
+		{Path: "one/one.sql.go", Queries: 2},
+	}
+	if diff := cmp.Diff(want, got.Packages[0].Files); diff != "" {
+		t.Errorf("unexpected files (-want +got):\n%s", diff)
+	}
+	for _, phase := range []string{"schema_ms", "queries_ms", "generate_ms"} {
+		if _, ok := got.Packages[0].Timings[phase]; !ok {
+			t.Errorf("missing %s timing:\n%s", phase, out.String())
+		}
+	}
+	if diff := cmp.Diff([]string{"package two: no queries found in two.sql"}, got.Warnings); diff != "" {
+		t.Errorf("unexpected warnings (-want +got):\n%s", diff)
+	}
+}
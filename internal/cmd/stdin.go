@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+// CompileFiles parses a schema and queries without a configuration file, for
+// one-off checks. Either path, but not both, may be dinosql.Stdin to read it
+// from stdin.
+func CompileFiles(engine config.Engine, schema, queries string, filter *Filter, stdin io.Reader, stderr io.Writer) error {
+	switch engine {
+	case config.EnginePostgreSQL, config.EngineMySQL:
+	default:
+		fmt.Fprintf(stderr, "unknown engine %q: must be %s or %s\n", engine, config.EnginePostgreSQL, config.EngineMySQL)
+		return &ExitError{Code: ExitConfig}
+	}
+	if schema == dinosql.Stdin && queries == dinosql.Stdin {
+		fmt.Fprintln(stderr, "the schema and queries can't both be read from stdin")
+		return &ExitError{Code: ExitFailure}
+	}
+
+	parseOpts := dinosql.ParserOpts{Only: filter.only()}
+	if schema == dinosql.Stdin || queries == dinosql.Stdin {
+		blob, err := ioutil.ReadAll(stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "error reading stdin: %s\n", err)
+			return &ExitError{Code: ExitIO}
+		}
+		parseOpts.Sources = map[string]string{dinosql.Stdin: string(blob)}
+	}
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	sql := config.SQL{Engine: engine, Schema: schema, Queries: queries}
+	result, failed := parse(displayPath(queries), dir, sql, config.Combine(config.Config{}, sql), &parseOpts, nil, nil, stderr)
+	if failed != 0 {
+		return &ExitError{Code: failed}
+	}
+	filter.parsed(result)
+	if err := filter.missing(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return err
+	}
+	return nil
+}
+
+// displayPath returns the name a path is shown with in errors
+func displayPath(path string) string {
+	if path == dinosql.Stdin {
+		return "<stdin>"
+	}
+	return path
+}
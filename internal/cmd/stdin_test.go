@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+func TestCompileStdin(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	const schema = "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY);\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "schema.sql"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "query.sql"), []byte("-- name: ListAuthors :many\nSELECT id FROM authors;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	err = CompileFiles(config.EnginePostgreSQL, dinosql.Stdin, filepath.Join(dir, "query.sql"), nil, strings.NewReader(schema), &stderr)
+	if err != nil {
+		t.Fatalf("expected the schema on stdin to compile: %s", stderr.String())
+	}
+
+	stderr.Reset()
+	stdin := strings.NewReader("-- name: GetAuthor :one\nSELECT missing FROM authors;\n")
+	err = CompileFiles(config.EnginePostgreSQL, filepath.Join(dir, "schema.sql"), dinosql.Stdin, nil, stdin, &stderr)
+	if code := exitCode(err); code != ExitQueries {
+		t.Fatalf("expected a query error, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), `<stdin>:2:8: column "missing" does not exist`) {
+		t.Errorf("unexpected errors:\n%s", stderr.String())
+	}
+
+	const mysqlSchema = "CREATE TABLE authors (id BIGINT NOT NULL, name TEXT NOT NULL);\n"
+	for name, contents := range map[string]string{
+		"mysql_schema.sql": mysqlSchema,
+		"mysql_query.sql":  "/* name: ListAuthors :many */\nSELECT id, name FROM authors;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stderr.Reset()
+	err = CompileFiles(config.EngineMySQL, dinosql.Stdin, filepath.Join(dir, "mysql_query.sql"), nil, strings.NewReader(mysqlSchema), &stderr)
+	if err != nil {
+		t.Fatalf("expected the MySQL schema on stdin to compile: %s", stderr.String())
+	}
+
+	stderr.Reset()
+	stdin = strings.NewReader("/* name: GetAuthor :one */\nSELECT missing FROM authors;\n")
+	err = CompileFiles(config.EngineMySQL, filepath.Join(dir, "mysql_schema.sql"), dinosql.Stdin, nil, stdin, &stderr)
+	if code := exitCode(err); code != ExitQueries {
+		t.Fatalf("expected a MySQL query error, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "<stdin>:") {
+		t.Errorf("expected errors in <stdin>:\n%s", stderr.String())
+	}
+}
+
+func TestFormatStdin(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	stdin := strings.NewReader("select id from authors where id = $1;\n")
+	if err := Format("", []string{dinosql.Stdin}, false, stdin, &stdout, &stderr); err != nil {
+		t.Fatalf("format failed: %s", stderr.String())
+	}
+	if want := "SELECT id\nFROM authors\nWHERE id = $1;\n"; stdout.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, stdout.String())
+	}
+}
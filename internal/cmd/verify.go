@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+
+	_ "github.com/lib/pq"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/introspect"
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// Verify compares the schema of each PostgreSQL package configured in dir
+// with the database at url. Discrepancies are printed to stdout, and an
+// error is returned if there are any.
+func Verify(dir, url string, stdout, stderr io.Writer) error {
+	conf, err := readConfig(dir, stderr)
+	if err != nil {
+		return err
+	}
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		fmt.Fprintf(stderr, "error connecting to database: %s\n", err)
+		return err
+	}
+	defer db.Close()
+
+	var found int
+	schemas := &schemaCache{}
+	for _, sql := range conf.SQL {
+		if sql.Engine != config.EnginePostgreSQL {
+			continue
+		}
+		schema, err := schemas.path(dir, sql.Schema)
+		if err != nil {
+			fmt.Fprintf(stderr, "# schema %s\n", sql.Schema)
+			fmt.Fprintf(stderr, "error fetching schema: %s\n", err)
+			return &ExitError{Code: ExitIO}
+		}
+		c, err := dinosql.ParseCatalog(schema)
+		if err != nil {
+			fmt.Fprintf(stderr, "# schema %s\n", sql.Schema)
+			if parserErr, ok := err.(*dinosql.ParserErr); ok {
+				for _, fileErr := range parserErr.Errs {
+					printFileErr(stderr, dir, fileErr)
+				}
+			} else {
+				fmt.Fprintf(stderr, "error parsing schema: %s\n", err)
+			}
+			return &ExitError{Code: failureCode(err, ExitSchema)}
+		}
+		var schemas []string
+		for name := range c.Schemas {
+			switch name {
+			case "pg_catalog", "pg_temp", "sqlc":
+				continue
+			}
+			schemas = append(schemas, name)
+		}
+		live, err := introspect.PostgreSQL(db, schemas)
+		if err != nil {
+			fmt.Fprintf(stderr, "error reading database schema: %s\n", err)
+			return err
+		}
+		errs := pg.Verify(c, live)
+		if len(errs) > 0 {
+			fmt.Fprintf(stdout, "# schema %s\n", sql.Schema)
+		}
+		for _, e := range errs {
+			fmt.Fprintf(stdout, "%s: %s\n", e.Code, e.Message)
+		}
+		found += len(errs)
+	}
+	if found > 0 {
+		return fmt.Errorf("%d discrepancies", found)
+	}
+	return nil
+}
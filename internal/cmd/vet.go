@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/dinosql/kotlin"
+)
+
+// Vet compiles the queries of each package configured in dir and runs the
+// `sqlc vet` rules over them. Violations are printed to stdout, and an error
+// is returned if there are any. With fix set, the violations which can be
+// fixed are first fixed in the query files.
+func Vet(dir string, fix bool, stdout, stderr io.Writer) error {
+	conf, err := readConfig(dir, stderr)
+	if err != nil {
+		return err
+	}
+	if fix {
+		if err := vetFix(dir, conf, stdout, stderr); err != nil {
+			return err
+		}
+	}
+
+	var found int
+	for _, sql := range conf.SQL {
+		result, name, failed := parsePackage(dir, conf, sql, nil, nil, stderr)
+		if failed != 0 {
+			return &ExitError{Code: failed}
+		}
+		res, ok := result.(*kotlin.Result)
+		if !ok {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error vetting queries: vet is only supported by the %s engine\n", config.EnginePostgreSQL)
+			return &ExitError{Code: ExitConfig}
+		}
+		errs := dinosql.Vet(res.Result, sql.Vet)
+		if sql.Vet.Explain != nil {
+			explained, err := vetExplain(res.Result, sql.Vet)
+			if err != nil {
+				fmt.Fprintf(stderr, "# package %s\n", name)
+				fmt.Fprintf(stderr, "error explaining queries: %s\n", err)
+				return &ExitError{Code: ExitFailure}
+			}
+			errs = append(errs, explained...)
+		}
+		plugged, err := dinosql.VetPlugins(dir, res.Result, sql.Vet, stderr)
+		if err != nil {
+			fmt.Fprintf(stderr, "# package %s\n", name)
+			fmt.Fprintf(stderr, "error running vet plugins: %s\n", err)
+			return &ExitError{Code: ExitFailure}
+		}
+		errs = append(errs, plugged...)
+		for _, verr := range errs {
+			fmt.Fprintln(stdout, verr.Error())
+			found++
+		}
+	}
+	if found > 0 {
+		return fmt.Errorf("%d vet errors", found)
+	}
+	return nil
+}
+
+// vetExplain runs the rules which check query plans against the database
+// configured in settings
+func vetExplain(r *dinosql.Result, settings config.Vet) ([]dinosql.VetErr, error) {
+	db, err := sql.Open("postgres", settings.Explain.Database)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	return dinosql.VetExplain(db, r, settings)
+}
+
+// vetFix expands the `*` in the SELECTs of each package's query files, for
+// the packages which run the no-select-star rule. The files changed are
+// printed to stdout.
+func vetFix(dir string, conf config.Config, stdout, stderr io.Writer) error {
+	for _, sql := range conf.SQL {
+		if sql.Engine != config.EnginePostgreSQL || contains(sql.Vet.Disable, "no-select-star") {
+			continue
+		}
+		result, _, failed := parsePackage(dir, conf, sql, nil, nil, stderr)
+		if failed != 0 {
+			return &ExitError{Code: failed}
+		}
+		files, err := dinosql.ReadSQLFiles(filepath.Join(dir, sql.Queries))
+		if err != nil {
+			return err
+		}
+		for _, filename := range files {
+			name := strings.TrimPrefix(filename, dir+"/")
+			blob, err := ioutil.ReadFile(filename)
+			if err != nil {
+				fmt.Fprintf(stderr, "%s: %s\n", name, err)
+				return &ExitError{Code: ExitIO}
+			}
+			fixed, err := dinosql.FixSelectStar(result.(*kotlin.Result).Catalog, string(blob), customVetRules(sql.Vet))
+			if err != nil {
+				fmt.Fprintf(stderr, "%s: error fixing queries: %s\n", name, err)
+				return err
+			}
+			if fixed == string(blob) {
+				continue
+			}
+			if err := ioutil.WriteFile(filename, []byte(fixed), 0644); err != nil {
+				fmt.Fprintf(stderr, "%s: %s\n", name, err)
+				return &ExitError{Code: ExitIO}
+			}
+			fmt.Fprintf(stdout, "%s: expanded SELECT *\n", name)
+		}
+	}
+	return nil
+}
+
+// customVetRules returns the names of the custom rules and plugins in
+// settings
+func customVetRules(settings config.Vet) []string {
+	var names []string
+	for _, rule := range settings.Rules {
+		names = append(names, rule.Name)
+	}
+	for _, plugin := range settings.Plugins {
+		names = append(names, plugin.Name)
+	}
+	return names
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
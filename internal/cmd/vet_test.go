@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const vetConfig = `version: "1"
+packages:
+  - path: "db"
+    schema: "schema.sql"
+    queries: "query.sql"
+    vet:
+      max_joins: 1
+`
+
+const vetSchema = `CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL);
+CREATE TABLE books (id BIGSERIAL PRIMARY KEY, author_id bigint NOT NULL, title text NOT NULL);
+CREATE TABLE reviews (id BIGSERIAL PRIMARY KEY, book_id bigint NOT NULL, body text NOT NULL);
+CREATE INDEX books_author_id_title_idx ON books (author_id, title);
+`
+
+const vetQueries = `-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1;
+
+-- name: ListReviews :many
+SELECT reviews.body
+FROM reviews
+JOIN books ON books.id = reviews.book_id
+JOIN authors ON authors.id = books.author_id;
+
+-- name: RenameAll :exec
+UPDATE authors SET name = $1;
+
+-- name: DeleteAll :exec
+DELETE FROM books;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors WHERE id = $1;
+
+-- name: DeleteReviews :exec
+-- Deletes every review.
+-- vet-disable: where-required
+DELETE FROM reviews;
+
+-- name: ListBooksByAuthor :many
+SELECT b.title FROM books b WHERE b.author_id = $1 AND b.title <> '';
+
+-- name: ListBooksByTitle :many
+SELECT id FROM books WHERE title = $1;
+
+-- name: ListReviewsOfBook :many
+SELECT r.body FROM reviews r JOIN books b ON b.id = r.book_id WHERE b.title = $1 AND r.body = 'ok';
+`
+
+func TestVet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  vetConfig,
+		"schema.sql": vetSchema,
+		"query.sql":  vetQueries,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Vet(dir, false, &stdout, &stderr); err == nil {
+		t.Fatal("expected vet errors")
+	}
+	expected := `query.sql: ListAuthors: SELECT * returns every column; list the columns instead (no-select-star)
+query.sql: ListReviews: query has 2 joins, more than the limit of 1 (max-joins)
+query.sql: RenameAll: UPDATE without a WHERE clause updates every row (where-required)
+query.sql: DeleteAll: DELETE without a WHERE clause deletes every row (where-required)
+query.sql: ListBooksByTitle: no index covers the WHERE conditions on books (title), so the query may scan the whole table (unindexed-where)
+query.sql: ListReviewsOfBook: no index covers the WHERE conditions on reviews (body), books (title), so the query may scan the whole table (unindexed-where)
+`
+	if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+		t.Errorf("vet output differed (-want +got):\n%s\n%s", diff, stderr.String())
+	}
+
+	// vet-disable comments are left out of the generated doc comments
+	output, err := Generate(dir, &stderr)
+	if err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	source := output[filepath.Join(dir, "db", "query.sql.go")]
+	if !strings.Contains(source, "// Deletes every review.") || strings.Contains(source, "vet-disable") {
+		t.Errorf("unexpected doc comments in:\n%s", source)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "query.sql"), []byte("-- name: DeleteAll :exec\n-- vet-disable: where-requred\nDELETE FROM books;\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stderr.Reset()
+	if err := Vet(dir, false, &stdout, &stderr); err == nil || !strings.Contains(stderr.String(), `invalid vet rule "where-requred"`) {
+		t.Errorf("expected an unknown rule to be rejected: %s", stderr.String())
+	}
+}
+
+func TestVetFix(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	queries := `-- name: ListAuthors :many
+SELECT * FROM authors;
+
+-- name: ListTitles :many
+SELECT a.name, b.* FROM authors a JOIN books b ON b.author_id = a.id;
+
+-- name: CountAuthors :one
+-- vet-disable: no-select-star
+SELECT count(*) FROM (SELECT * FROM authors) a;
+`
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  vetConfig,
+		"schema.sql": vetSchema,
+		"query.sql":  queries,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Vet(dir, true, &stdout, &stderr); err != nil {
+		t.Fatalf("expected vet to pass after fixing: %s%s", stdout.String(), stderr.String())
+	}
+	if got := stdout.String(); got != "query.sql: expanded SELECT *\n" {
+		t.Errorf("unexpected output: %s", got)
+	}
+	blob, err := ioutil.ReadFile(filepath.Join(dir, "query.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `-- name: ListAuthors :many
+SELECT id, name FROM authors;
+
+-- name: ListTitles :many
+SELECT a.name, b.id, b.author_id, b.title FROM authors a JOIN books b ON b.author_id = a.id;
+
+-- name: CountAuthors :one
+-- vet-disable: no-select-star
+SELECT count(*) FROM (SELECT * FROM authors) a;
+`
+	if diff := cmp.Diff(expected, string(blob)); diff != "" {
+		t.Errorf("fixed queries differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestVetCustomRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := `version: "1"
+packages:
+  - path: "db"
+    schema: "schema.sql"
+    queries: "query.sql"
+    vet:
+      disable: ["unindexed-where"]
+      rules:
+        - name: delete-by-id
+          rule: "query.sql.startsWith('DELETE') && !query.sql.contains('WHERE id = ')"
+          message: "deletes must be by id"
+        - name: get-prefix
+          rule: "query.cmd == ':one' && !query.name.startsWith('Get')"
+        - name: no-reviews
+          rule: "query.tables.exists(t, t == 'reviews') && query.params > 0"
+`
+	queries := `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1;
+
+-- name: FindBook :one
+SELECT id, title FROM books WHERE title = $1;
+
+-- name: DeleteBooksByAuthor :exec
+DELETE FROM books WHERE author_id = $1;
+
+-- name: DeleteReview :exec
+-- vet-disable: no-reviews
+DELETE FROM reviews WHERE id = $1;
+
+-- name: ListReviews :many
+-- vet-disable: unindexed-where
+SELECT body FROM reviews WHERE book_id = $1;
+`
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  conf,
+		"schema.sql": vetSchema,
+		"query.sql":  queries,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Vet(dir, false, &stdout, &stderr); err == nil {
+		t.Fatalf("expected vet errors: %s", stderr.String())
+	}
+	expected := `query.sql: FindBook: query matches query.cmd == ':one' && !query.name.startsWith('Get') (get-prefix)
+query.sql: DeleteBooksByAuthor: deletes must be by id (delete-by-id)
+query.sql: ListReviews: query matches query.tables.exists(t, t == 'reviews') && query.params > 0 (no-reviews)
+`
+	if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+		t.Errorf("vet output differed (-want +got):\n%s\n%s", diff, stderr.String())
+	}
+}
+
+func TestVetPlugins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-vet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	conf := `version: "1"
+packages:
+  - path: "db"
+    schema: "schema.sql"
+    queries: "query.sql"
+    vet:
+      disable: ["where-required"]
+      plugins:
+        - name: tenancy
+          cmd: ./tenancy.sh
+          args: ["authors"]
+`
+	// The plugin reports each query it's sent which names the table in its
+	// argument, checking the catalog holds the table
+	plugin := `#!/bin/sh
+input=$(cat)
+case "$input" in
+  *'"tables":[{"oid":'*'"name":"'$1'"'*) ;;
+  *) echo "no $1 table" >&2; exit 1 ;;
+esac
+case "$input" in
+  *'"name":"RenameAll","cmd":":exec"'*) ;;
+  *) echo "no RenameAll query" >&2; exit 1 ;;
+esac
+echo '{"errors": ['
+echo '{"filename": "query.sql", "query": "RenameAll", "rule": "tenant-id", "message": "queries must filter by tenant"},'
+echo '{"filename": "query.sql", "query": "DeleteAuthor", "rule": "tenant-id", "message": "queries must filter by tenant"},'
+echo '{"filename": "query.sql", "query": "GetAuthor", "message": "authors are private"}'
+echo ']}'
+`
+	queries := `-- name: GetAuthor :one
+SELECT id, name FROM authors WHERE id = $1;
+
+-- name: RenameAll :exec
+UPDATE authors SET name = $1;
+
+-- name: DeleteAuthor :exec
+-- vet-disable: tenancy/tenant-id
+DELETE FROM authors WHERE id = $1;
+`
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  conf,
+		"schema.sql": vetSchema,
+		"query.sql":  queries,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "tenancy.sh"), []byte(plugin), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if err := Vet(dir, false, &stdout, &stderr); err == nil {
+		t.Fatalf("expected vet errors: %s", stderr.String())
+	}
+	expected := `query.sql: RenameAll: queries must filter by tenant (tenancy/tenant-id)
+query.sql: GetAuthor: authors are private (tenancy)
+`
+	if diff := cmp.Diff(expected, stdout.String()); diff != "" {
+		t.Errorf("vet output differed (-want +got):\n%s\n%s", diff, stderr.String())
+	}
+
+	// A failing plugin fails vet, with its errors passed through
+	conf = strings.Replace(conf, `["authors"]`, `["publishers"]`, 1)
+	if err := ioutil.WriteFile(filepath.Join(dir, "sqlc.yaml"), []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	stderr.Reset()
+	if err := Vet(dir, false, &stdout, &stderr); exitCode(err) != ExitFailure {
+		t.Errorf("expected exit code %d, got %v", ExitFailure, err)
+	}
+	if !strings.Contains(stderr.String(), "no publishers table") || !strings.Contains(stderr.String(), "vet plugin tenancy failed") {
+		t.Errorf("unexpected errors: %s", stderr.String())
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// A watcher regenerates code when the configuration, schema or queries of a
+// project change. Only output files whose contents changed are rewritten.
+type watcher struct {
+	dir    string
+	stdout io.Writer
+	stderr io.Writer
+
+	stamps  map[string]fileStamp
+	written map[string]string
+}
+
+// Watch generates the code for the project in dir, then polls its files
+// every interval and regenerates whenever one of them changes. Errors are
+// printed, rather than stopping the loop, until stop is closed.
+func Watch(dir string, interval time.Duration, stdout, stderr io.Writer, stop <-chan struct{}) {
+	w := &watcher{dir: dir, stdout: stdout, stderr: stderr}
+	w.poll()
+	w.generate()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if w.poll() {
+				w.generate()
+			}
+		}
+	}
+}
+
+// files returns the configuration file and the SQL files it refers to. Paths
+// which can't be read are left out; generate reports them.
+func (w *watcher) files() []string {
+	var files []string
+	for _, name := range []string{"sqlc.yaml", "sqlc.json"} {
+		if _, err := os.Stat(filepath.Join(w.dir, name)); err == nil {
+			files = append(files, filepath.Join(w.dir, name))
+		}
+	}
+	conf, err := readConfig(w.dir, ioutil.Discard)
+	if err != nil {
+		return files
+	}
+	for _, sql := range conf.SQL {
+		if schema, err := dinosql.ReadSchemaFiles(filepath.Join(w.dir, sql.Schema)); err == nil {
+			files = append(files, schema...)
+		}
+		if queries, err := dinosql.ReadSQLFiles(filepath.Join(w.dir, sql.Queries)); err == nil {
+			files = append(files, queries...)
+		}
+	}
+	return files
+}
+
+// poll records the modification time and size of each watched file, and
+// reports whether any were added, changed or removed since the last poll
+func (w *watcher) poll() bool {
+	stamps := map[string]fileStamp{}
+	changed := false
+	for _, filename := range w.files() {
+		info, err := os.Stat(filename)
+		if err != nil {
+			continue
+		}
+		stamp := fileStamp{modTime: info.ModTime(), size: info.Size()}
+		if prev, ok := w.stamps[filename]; !ok || prev != stamp {
+			changed = true
+		}
+		stamps[filename] = stamp
+	}
+	if len(stamps) != len(w.stamps) {
+		changed = true
+	}
+	w.stamps = stamps
+	return changed
+}
+
+func (w *watcher) generate() {
+	output, err := Generate(w.dir, w.stderr)
+	if err != nil {
+		fmt.Fprintf(w.stderr, "%s: generate failed, waiting for changes\n", time.Now().Format("15:04:05"))
+		return
+	}
+	if w.written == nil {
+		w.written = map[string]string{}
+	}
+	var updated int
+	for filename, source := range output {
+		if prev, ok := w.written[filename]; ok && prev == source {
+			continue
+		}
+		os.MkdirAll(filepath.Dir(filename), 0755)
+		if err := ioutil.WriteFile(filename, []byte(source), 0644); err != nil {
+			fmt.Fprintf(w.stderr, "%s: %s\n", filename, err)
+			continue
+		}
+		w.written[filename] = source
+		updated++
+	}
+	fmt.Fprintf(w.stdout, "%s: generated %d files, %d updated\n", time.Now().Format("15:04:05"), len(output), updated)
+}
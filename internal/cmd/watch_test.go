@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-watch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if _, err := initProject(dir, config.EnginePostgreSQL); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	w := &watcher{dir: dir, stdout: &stdout, stderr: &stderr}
+	if !w.poll() {
+		t.Fatal("expected the first poll to report changes")
+	}
+	w.generate()
+	if stderr.Len() > 0 {
+		t.Fatalf("generate: %s", stderr.String())
+	}
+	if w.poll() {
+		t.Error("expected no changes after generating")
+	}
+
+	query := filepath.Join(dir, "query", "query.sql")
+	blob, err := ioutil.ReadFile(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob = append(blob, "\n-- name: CountAuthors :one\nSELECT count(*) FROM authors;\n"...)
+	if err := ioutil.WriteFile(query, blob, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if !w.poll() {
+		t.Fatal("expected the edited query file to be reported")
+	}
+	stdout.Reset()
+	w.generate()
+	if !strings.HasSuffix(stdout.String(), "1 updated\n") {
+		t.Errorf("expected only the query file to be rewritten: %s", stdout.String())
+	}
+	generated, err := ioutil.ReadFile(filepath.Join(dir, "db", "query.sql.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(generated, []byte("func (q *Queries) CountAuthors(")) {
+		t.Error("expected CountAuthors to be generated")
+	}
+}
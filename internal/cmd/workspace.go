@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/kyleconroy/sqlc/internal/cache"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/remote"
+)
+
+// A schemaCache holds the PostgreSQL catalogs parsed for each set of schema
+// files, so configurations which use the same files only parse them once,
+// however their schema paths are written. Each package gets its own copy of
+// the catalog, so it's free to change it, including while other packages are
+// generated concurrently. Remote schemas are likewise fetched once for each
+// URL. A nil cache parses and fetches the schema every time.
+type schemaCache struct {
+	mu       sync.Mutex
+	catalogs map[string]*parsedSchema
+	remotes  map[string]*fetchedSchema
+}
+
+type fetchedSchema struct {
+	once sync.Once
+	path string
+	err  error
+}
+
+type parsedSchema struct {
+	once    sync.Once
+	catalog pg.Catalog
+	err     error
+
+	hashOnce sync.Once
+	hash     string
+}
+
+// path returns the path of a package's schema, fetching it first if it is a
+// URL. Packages sharing a URL share a single fetch, so one package can't
+// replace the copy another is reading.
+func (sc *schemaCache) path(dir, schema string) (string, error) {
+	if !remote.IsURL(schema) {
+		return filepath.Join(dir, schema), nil
+	}
+	if sc == nil {
+		return remote.Fetch(schema)
+	}
+	sc.mu.Lock()
+	if sc.remotes == nil {
+		sc.remotes = map[string]*fetchedSchema{}
+	}
+	fetched, ok := sc.remotes[schema]
+	if !ok {
+		fetched = &fetchedSchema{}
+		sc.remotes[schema] = fetched
+	}
+	sc.mu.Unlock()
+
+	fetched.once.Do(func() {
+		fetched.path, fetched.err = remote.Fetch(schema)
+	})
+	return fetched.path, fetched.err
+}
+
+func (sc *schemaCache) catalog(schema string) (pg.Catalog, error) {
+	if sc == nil {
+		return dinosql.ParseCatalog(schema)
+	}
+	key, err := schemaKey(schema)
+	if err != nil {
+		return pg.Catalog{}, err
+	}
+	parsed := sc.lookup(key)
+
+	// Packages waiting on the same schema share a single parse
+	parsed.once.Do(func() {
+		parsed.catalog, parsed.err = dinosql.ParseCatalog(schema)
+	})
+	if parsed.err != nil {
+		return pg.Catalog{}, parsed.err
+	}
+	return parsed.catalog.Clone(), nil
+}
+
+// schemaKey identifies the schema files read for schema, so that paths such
+// as "schema" and "./schema/" share a catalog
+func schemaKey(schema string) (string, error) {
+	files, err := dinosql.ReadSchemaFiles(schema)
+	if err != nil {
+		return "", err
+	}
+	for i, filename := range files {
+		if filename == dinosql.Stdin {
+			continue
+		}
+		if files[i], err = filepath.Abs(filename); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(files, "\x00"), nil
+}
+
+func (sc *schemaCache) lookup(key string) *parsedSchema {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if sc.catalogs == nil {
+		sc.catalogs = map[string]*parsedSchema{}
+	}
+	parsed, ok := sc.catalogs[key]
+	if !ok {
+		parsed = &parsedSchema{}
+		sc.catalogs[key] = parsed
+	}
+	return parsed
+}
+
+// catalogHash returns a hash of the catalog parsed from schema, which must
+// already have been parsed, or an empty string if it can't be hashed
+func (sc *schemaCache) catalogHash(schema string) string {
+	key, err := schemaKey(schema)
+	if err != nil {
+		return ""
+	}
+	parsed := sc.lookup(key)
+	parsed.hashOnce.Do(func() {
+		blob, err := json.Marshal(parsed.catalog)
+		if err == nil {
+			parsed.hash = cache.Key(string(blob))
+		}
+	})
+	return parsed.hash
+}
+
+// workspaceDirs returns the project directories named by args. An argument
+// ending in "/..." names every directory beneath it which contains a sqlc.yaml
+// or sqlc.json file. No arguments name the current directory.
+func workspaceDirs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	var dirs []string
+	for _, arg := range args {
+		if arg != "..." && !strings.HasSuffix(arg, "/...") {
+			dir, err := filepath.Abs(arg)
+			if err != nil {
+				return nil, err
+			}
+			dirs = append(dirs, dir)
+			continue
+		}
+		root, err := filepath.Abs(strings.TrimSuffix(strings.TrimSuffix(arg, "..."), "/"))
+		if err != nil {
+			return nil, err
+		}
+		found, err := findConfigs(root)
+		if err != nil {
+			return nil, err
+		}
+		if len(found) == 0 {
+			return nil, fmt.Errorf("%s: no sqlc.yaml or sqlc.json files found", arg)
+		}
+		dirs = append(dirs, found...)
+	}
+	return dirs, nil
+}
+
+// findConfigs returns the directories beneath root which contain a sqlc
+// configuration file. Hidden, vendor and testdata directories are skipped.
+func findConfigs(root string) ([]string, error) {
+	var dirs []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata" || name == "node_modules") {
+			return filepath.SkipDir
+		}
+		for _, config := range []string{"sqlc.yaml", "sqlc.json"} {
+			if _, err := os.Stat(filepath.Join(path, config)); err == nil {
+				dirs = append(dirs, path)
+				break
+			}
+		}
+		return nil
+	})
+	return dirs, err
+}
+
+// GenerateWorkspace generates the code for each project in dirs, sharing
+// parsed schemas between them. Every project is generated, even if an earlier
+// one fails. When there's more than one project, the errors for each are
+// printed under its directory.
+func GenerateWorkspace(dirs []string, filter *Filter, stderr io.Writer) (map[string]string, error) {
+	return generateWorkspace(dirs, filter, nil, stderr)
+}
+
+func generateWorkspace(dirs []string, filter *Filter, report *Report, stderr io.Writer) (map[string]string, error) {
+	schemas := &schemaCache{}
+	output := map[string]string{}
+	var failed error
+	for _, dir := range dirs {
+		files, err := generate(dir, schemas, filter, report, true, workspaceStderr(dirs, dir, stderr))
+		if err != nil {
+			if failed == nil {
+				failed = err
+			}
+			continue
+		}
+		for filename, source := range files {
+			output[filename] = source
+		}
+	}
+	if failed != nil {
+		return nil, failed
+	}
+	if err := filter.missing(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return nil, err
+	}
+	return output, nil
+}
+
+// CompileWorkspace checks each project in dirs, sharing parsed schemas
+// between them
+func CompileWorkspace(dirs []string, filter *Filter, stderr io.Writer) error {
+	schemas := &schemaCache{}
+	var failed error
+	for _, dir := range dirs {
+		if err := compile(dir, schemas, filter, workspaceStderr(dirs, dir, stderr)); err != nil && failed == nil {
+			failed = err
+		}
+	}
+	if failed != nil {
+		return failed
+	}
+	if err := filter.missing(); err != nil {
+		fmt.Fprintln(stderr, err)
+		return err
+	}
+	return nil
+}
+
+// workspaceStderr returns a writer which prefixes the first error printed for
+// a project with its directory, if there's more than one project
+func workspaceStderr(dirs []string, dir string, stderr io.Writer) io.Writer {
+	if len(dirs) == 1 {
+		return stderr
+	}
+	return &headerWriter{header: "## " + dir + "\n", w: stderr}
+}
+
+type headerWriter struct {
+	header  string
+	w       io.Writer
+	written bool
+}
+
+func (h *headerWriter) Write(p []byte) (int, error) {
+	if !h.written && len(bytes.TrimSpace(p)) > 0 {
+		h.written = true
+		if _, err := io.WriteString(h.w, h.header); err != nil {
+			return 0, err
+		}
+	}
+	return h.w.Write(p)
+}
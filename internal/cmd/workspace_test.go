@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+const sharedSchemaConfig = `version: "1"
+packages:
+  - path: "db"
+    schema: "../a/schema"
+    queries: "../a/query"
+`
+
+func TestWorkspace(t *testing.T) {
+	root, err := ioutil.TempDir("", "sqlc-workspace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	for _, dir := range []string{"a", "b", ".hidden", "vendor/c"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := initProject(filepath.Join(root, "a"), config.EnginePostgreSQL); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{"b", ".hidden", "vendor/c"} {
+		if err := ioutil.WriteFile(filepath.Join(root, dir, "sqlc.yaml"), []byte(sharedSchemaConfig), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dirs, err := workspaceDirs([]string{root + "/..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{filepath.Join(root, "a"), filepath.Join(root, "b")}
+	if diff := cmp.Diff(expected, dirs); diff != "" {
+		t.Errorf("workspace directories differed (-want +got):\n%s", diff)
+	}
+
+	var stderr bytes.Buffer
+	output, err := GenerateWorkspace(dirs, nil, &stderr)
+	if err != nil {
+		t.Fatalf("generate: %s", stderr.String())
+	}
+	for _, dir := range expected {
+		if _, ok := output[filepath.Join(dir, "db", "models.go")]; !ok {
+			t.Errorf("no models.go generated for %s", dir)
+		}
+	}
+
+	schemas := &schemaCache{}
+	for _, dir := range dirs {
+		if err := compile(dir, schemas, nil, &stderr); err != nil {
+			t.Fatalf("compile: %s", stderr.String())
+		}
+	}
+	if len(schemas.catalogs) != 1 {
+		t.Errorf("expected the shared schema to be parsed once; got %d catalogs", len(schemas.catalogs))
+	}
+}
+
+func TestSchemaCacheClones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-schema-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "schema"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ddl := "CREATE TABLE authors (id SERIAL PRIMARY KEY, name TEXT NOT NULL);"
+	if err := ioutil.WriteFile(filepath.Join(dir, "schema", "001_authors.sql"), []byte(ddl), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	schemas := &schemaCache{}
+	first, err := schemas.catalog(filepath.Join(dir, "schema"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	delete(first.Schemas["public"].Tables, "authors")
+	first.Schemas["pg_catalog"].Funcs["count"][0].Name = "changed"
+
+	second, err := schemas.catalog(filepath.Join(dir, ".", "schema") + string(filepath.Separator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schemas.catalogs) != 1 {
+		t.Errorf("expected both paths to share a catalog; got %d catalogs", len(schemas.catalogs))
+	}
+	if _, ok := second.Schemas["public"].Tables["authors"]; !ok {
+		t.Errorf("changes to one package's catalog were seen by another")
+	}
+	if name := second.Schemas["pg_catalog"].Funcs["count"][0].Name; name != "count" {
+		t.Errorf("changes to one package's functions were seen by another: %s", name)
+	}
+}
+
+const sharedRemoteConfig = `version: "1"
+packages:
+  - name: "one"
+    path: "one"
+    schema: "%[1]s/schema.sql"
+    queries: "query.sql"
+  - name: "two"
+    path: "two"
+    schema: "%[1]s/schema.sql"
+    queries: "query.sql"
+`
+
+func TestSharedRemoteSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-remote-schema")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("CREATE TABLE authors (id SERIAL PRIMARY KEY, name TEXT NOT NULL);\n"))
+	}))
+	defer srv.Close()
+
+	conf := fmt.Sprintf(sharedRemoteConfig, srv.URL)
+	if err := ioutil.WriteFile(filepath.Join(dir, "sqlc.yaml"), []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	query := "-- name: ListAuthors :many\nSELECT * FROM authors;\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, "query.sql"), []byte(query), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	output, err := Generate(dir, &stderr)
+	if err != nil {
+		t.Fatalf("generate: %s", stderr.String())
+	}
+	for _, pkg := range []string{"one", "two"} {
+		if _, ok := output[filepath.Join(dir, pkg, "models.go")]; !ok {
+			t.Errorf("no models.go generated for %s", pkg)
+		}
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected the shared schema to be fetched once; got %d requests", n)
+	}
+}
@@ -15,9 +15,9 @@ import (
 	"github.com/kyleconroy/sqlc/internal/dolphin"
 	"github.com/kyleconroy/sqlc/internal/pg"
 	"github.com/kyleconroy/sqlc/internal/postgresql"
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
-	"github.com/kyleconroy/sqlc/internal/sql/catalog"
 	"github.com/kyleconroy/sqlc/internal/sqlite"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+	"github.com/kyleconroy/sqlc/pkg/sql/catalog"
 )
 
 type Parser interface {
@@ -95,7 +95,7 @@ func Run(conf config.SQL, combo config.CombinedSettings) (*Result, error) {
 				s.Fields = append(s.Fields, dinosql.GoField{
 					Name:    structName(col.Name),
 					Type:    "string",
-					Tags:    map[string]string{"json:": col.Name},
+					Tags:    dinosql.FieldTags(col.Name, nil, combo),
 					Comment: col.Comment,
 				})
 			}
@@ -128,10 +128,10 @@ func Run(conf config.SQL, combo config.CombinedSettings) (*Result, error) {
 	}
 
 	if len(structs) > 0 {
-		sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+		sort.SliceStable(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
 	}
 	if len(enums) > 0 {
-		sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+		sort.SliceStable(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	}
 	return &Result{structs: structs, enums: enums}, nil
 }
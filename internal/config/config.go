@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 
+	"github.com/kyleconroy/sqlc/internal/expr"
 	"github.com/kyleconroy/sqlc/internal/pg"
 
 	yaml "gopkg.in/yaml.v3"
@@ -24,7 +29,7 @@ Set the version to 1 at the top of sqlc.json:
 `
 
 const errMessageUnknownVersion = `The configuration file has an invalid version number.
-The only supported version is "1".
+The supported versions are "1" and "2".
 `
 
 const errMessageNoPackages = `No packages are configured`
@@ -70,21 +75,412 @@ type SQL struct {
 	Schema  string `json:"schema" yaml:"schema"`
 	Queries string `json:"queries" yaml:"queries"`
 	Gen     SQLGen `json:"gen" yaml:"gen"`
+	Vet     Vet    `json:"vet,omitempty" yaml:"vet"`
 }
 
+// Vet configures the rules run by `sqlc vet`
+type Vet struct {
+	// Names of built-in rules which are not run
+	Disable []string `json:"disable,omitempty" yaml:"disable"`
+
+	// The number of joins a query may have before the max-joins rule
+	// reports it. Defaults to DefaultVetMaxJoins.
+	MaxJoins int `json:"max_joins,omitempty" yaml:"max_joins"`
+
+	// If set, the plans of the queries are checked against a live database
+	Explain *VetExplain `json:"explain,omitempty" yaml:"explain"`
+
+	// Rules written by the user, which run alongside the built-in ones
+	Rules []VetRule `json:"rules,omitempty" yaml:"rules"`
+
+	// Programs which are sent the compiled queries and catalog, and reply
+	// with the rules the queries fail
+	Plugins []VetPlugin `json:"plugins,omitempty" yaml:"plugins"`
+}
+
+// A VetPlugin is a program run by `sqlc vet`. Cmd is looked up on $PATH
+// unless it contains a slash, in which case it's relative to the
+// configuration file.
+type VetPlugin struct {
+	Name string   `json:"name" yaml:"name"`
+	Cmd  string   `json:"cmd" yaml:"cmd"`
+	Args []string `json:"args,omitempty" yaml:"args"`
+}
+
+// A VetRule is a custom `sqlc vet` rule. Rule is an expression over the
+// variables in VetRuleVars, and a query fails the rule when it's true.
+type VetRule struct {
+	Name    string `json:"name" yaml:"name"`
+	Rule    string `json:"rule" yaml:"rule"`
+	Message string `json:"message,omitempty" yaml:"message"`
+}
+
+// VetRuleVars are the variables custom vet rules can use. explain is only
+// set when `explain` is configured.
+var VetRuleVars = []string{"query", "explain"}
+
+// DefaultVetMaxJoins is the max-joins limit used when `max_joins` isn't set
+const DefaultVetMaxJoins = 5
+
+// VetExplain configures the rules which check the plan PostgreSQL chooses for
+// each query, as shown by EXPLAIN
+type VetExplain struct {
+	// The URL of the database, after expanding environment variables
+	Database string `json:"database" yaml:"database"`
+
+	// The highest total cost a plan may have. Zero means no limit.
+	MaxCost float64 `json:"max_cost,omitempty" yaml:"max_cost"`
+
+	// The most rows a plan may estimate it returns. Zero means no limit.
+	MaxRows float64 `json:"max_rows,omitempty" yaml:"max_rows"`
+
+	// The number of rows a table needs before a sequential scan of it is
+	// reported. Defaults to DefaultVetSeqScanRows.
+	SeqScanRows float64 `json:"seq_scan_rows,omitempty" yaml:"seq_scan_rows"`
+}
+
+// DefaultVetSeqScanRows is the table size used when `seq_scan_rows` isn't set
+const DefaultVetSeqScanRows = 10000
+
 type SQLGen struct {
 	Go     *SQLGo     `json:"go,omitempty" yaml:"go"`
 	Kotlin *SQLKotlin `json:"kotlin,omitempty" yaml:"kotlin"`
 }
 
 type SQLGo struct {
-	EmitInterface       bool              `json:"emit_interface" yaml:"emit_interface"`
-	EmitJSONTags        bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
-	EmitPreparedQueries bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries":`
-	Package             string            `json:"package" yaml:"package"`
-	Out                 string            `json:"out" yaml:"out"`
-	Overrides           []Override        `json:"overrides,omitempty" yaml:"overrides"`
-	Rename              map[string]string `json:"rename,omitempty" yaml:"rename"`
+	EmitInterface            bool              `json:"emit_interface" yaml:"emit_interface"`
+	EmitFileInterfaces       bool              `json:"emit_file_interfaces,omitempty" yaml:"emit_file_interfaces"`
+	EmitQuerierFake          bool              `json:"emit_querier_fake,omitempty" yaml:"emit_querier_fake"`
+	EmitTestFactories        bool              `json:"emit_test_factories,omitempty" yaml:"emit_test_factories"`
+	EmitTestContainer        bool              `json:"emit_test_container,omitempty" yaml:"emit_test_container"`
+	TestContainerImage       string            `json:"test_container_image,omitempty" yaml:"test_container_image"`
+	EmitProto                bool              `json:"emit_proto,omitempty" yaml:"emit_proto"`
+	ProtoPackage             string            `json:"proto_package,omitempty" yaml:"proto_package"`
+	ProtoGoPackage           string            `json:"proto_go_package,omitempty" yaml:"proto_go_package"`
+	EmitOpenAPI              bool              `json:"emit_openapi,omitempty" yaml:"emit_openapi"`
+	EmitGraphQL              bool              `json:"emit_graphql,omitempty" yaml:"emit_graphql"`
+	EmitJSONTags             bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries      bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	EmitStatementCache       bool              `json:"emit_statement_cache,omitempty" yaml:"emit_statement_cache"`
+	DBTXInterfaceName        string            `json:"dbtx_interface_name,omitempty" yaml:"dbtx_interface_name"`
+	DBTXMethods              []string          `json:"dbtx_methods,omitempty" yaml:"dbtx_methods"`
+	EmitExactTableNames      bool              `json:"emit_exact_table_names" yaml:"emit_exact_table_names"`
+	EmitEmptySlices          bool              `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
+	EmitHooks                bool              `json:"emit_hooks,omitempty" yaml:"emit_hooks"`
+	EmitInterceptors         bool              `json:"emit_interceptors,omitempty" yaml:"emit_interceptors"`
+	EmitMetrics              bool              `json:"emit_metrics,omitempty" yaml:"emit_metrics"`
+	EmitGenericHelpers       bool              `json:"emit_generic_helpers,omitempty" yaml:"emit_generic_helpers"`
+	EmitScanByName           bool              `json:"emit_scan_by_name,omitempty" yaml:"emit_scan_by_name"`
+	ForEachQueries           []string          `json:"for_each_queries,omitempty" yaml:"for_each_queries"`
+	CRUDTables               []string          `json:"crud_tables,omitempty" yaml:"crud_tables"`
+	EmitDocs                 string            `json:"emit_docs,omitempty" yaml:"emit_docs"`
+	EmitPointersForNullTypes bool              `json:"emit_pointers_for_null_types,omitempty" yaml:"emit_pointers_for_null_types"`
+	OmitUnusedStructs        bool              `json:"omit_unused_structs,omitempty" yaml:"omit_unused_structs"`
+	ReuseRowStructs          bool              `json:"reuse_row_structs,omitempty" yaml:"reuse_row_structs"`
+	StructTags               []string          `json:"struct_tags,omitempty" yaml:"struct_tags"`
+	JSONTagsCaseStyle        string            `json:"json_tags_case_style,omitempty" yaml:"json_tags_case_style"`
+	JSONTagsIDUppercase      bool              `json:"json_tags_id_uppercase,omitempty" yaml:"json_tags_id_uppercase"`
+	Initialisms              []string          `json:"initialisms,omitempty" yaml:"initialisms"`
+	EnumValueStyle           string            `json:"enum_value_style,omitempty" yaml:"enum_value_style"`
+	EnumValueRename          map[string]string `json:"enum_value_rename,omitempty" yaml:"enum_value_rename"`
+	UUIDType                 string            `json:"uuid_type,omitempty" yaml:"uuid_type"`
+	DecimalType              string            `json:"decimal_type,omitempty" yaml:"decimal_type"`
+	TimestampType            string            `json:"timestamp_type,omitempty" yaml:"timestamp_type"`
+	DateType                 string            `json:"date_type,omitempty" yaml:"date_type"`
+	TimeType                 string            `json:"time_type,omitempty" yaml:"time_type"`
+	IntervalType             string            `json:"interval_type,omitempty" yaml:"interval_type"`
+	XMLType                  string            `json:"xml_type,omitempty" yaml:"xml_type"`
+	QueryParameterLimit      *int              `json:"query_parameter_limit,omitempty" yaml:"query_parameter_limit"`
+	ParamsStructSuffix       string            `json:"params_struct_suffix,omitempty" yaml:"params_struct_suffix"`
+	OutputFilesSuffix        string            `json:"output_files_suffix,omitempty" yaml:"output_files_suffix"`
+	OutputModelsPackage      string            `json:"output_models_package,omitempty" yaml:"output_models_package"`
+	OutputModelsPackageName  string            `json:"output_models_package_name,omitempty" yaml:"output_models_package_name"`
+	EmitModelsOnly           bool              `json:"emit_models_only,omitempty" yaml:"emit_models_only"`
+	BuildTags                string            `json:"build_tags,omitempty" yaml:"build_tags"`
+	FileHeader               string            `json:"file_header,omitempty" yaml:"file_header"`
+	Package                  string            `json:"package" yaml:"package"`
+	Out                      string            `json:"out" yaml:"out"`
+	ImportPath               string            `json:"import_path,omitempty" yaml:"import_path"`
+	Overrides                []Override        `json:"overrides,omitempty" yaml:"overrides"`
+	Rename                   map[string]string `json:"rename,omitempty" yaml:"rename"`
+}
+
+// Supported values for the `json_tags_case_style` setting
+const (
+	JSONTagsCaseStyleNone   = "none"
+	JSONTagsCaseStyleCamel  = "camel"
+	JSONTagsCaseStylePascal = "pascal"
+	JSONTagsCaseStyleSnake  = "snake"
+)
+
+func validateJSONTagsCaseStyle(style string) error {
+	switch style {
+	case "", JSONTagsCaseStyleNone, JSONTagsCaseStyleCamel, JSONTagsCaseStylePascal, JSONTagsCaseStyleSnake:
+		return nil
+	default:
+		return fmt.Errorf("invalid json_tags_case_style %q: must be one of none, camel, pascal or snake", style)
+	}
+}
+
+// Supported values for the `enum_value_style` setting
+const (
+	EnumValueStyleDefault = "default"
+	EnumValueStylePascal  = "pascal"
+)
+
+func validateEnumValueStyle(style string) error {
+	switch style {
+	case "", EnumValueStyleDefault, EnumValueStylePascal:
+		return nil
+	default:
+		return fmt.Errorf("invalid enum_value_style %q: must be one of default or pascal", style)
+	}
+}
+
+// validateParams checks the `query_parameter_limit` and `params_struct_suffix`
+// settings.
+func validateParams(g SQLGo) error {
+	if g.QueryParameterLimit != nil && *g.QueryParameterLimit < 0 {
+		return fmt.Errorf("invalid query_parameter_limit %d: must not be negative", *g.QueryParameterLimit)
+	}
+	if g.ParamsStructSuffix != "" && !token.IsIdentifier("X"+g.ParamsStructSuffix) {
+		return fmt.Errorf("invalid params_struct_suffix %q: must be letters, digits or underscores", g.ParamsStructSuffix)
+	}
+	return nil
+}
+
+// validateScanByName checks `emit_scan_by_name` isn't combined with the
+// generic helpers, which scan their rows by position.
+func validateScanByName(g SQLGo) error {
+	if g.EmitScanByName && g.EmitGenericHelpers {
+		return errors.New("emit_scan_by_name can't be used with emit_generic_helpers")
+	}
+	return nil
+}
+
+// validateDBTX checks the `dbtx_interface_name` and `dbtx_methods` settings.
+// Each method must be a single interface method, e.g. "Begin() (*sql.Tx, error)".
+func validateDBTX(g SQLGo) error {
+	if g.DBTXInterfaceName != "" && !token.IsIdentifier(g.DBTXInterfaceName) {
+		return fmt.Errorf("invalid dbtx_interface_name %q: must be a Go identifier", g.DBTXInterfaceName)
+	}
+	if len(g.DBTXMethods) > 0 && g.EmitPreparedQueries {
+		return errors.New("dbtx_methods can't be used with emit_prepared_queries")
+	}
+	if len(g.DBTXMethods) > 0 && g.EmitStatementCache {
+		return errors.New("dbtx_methods can't be used with emit_statement_cache")
+	}
+	for _, m := range g.DBTXMethods {
+		expr, err := parser.ParseExpr("interface{" + m + "}")
+		if err != nil {
+			return fmt.Errorf("invalid dbtx_methods entry %q: %s", m, err)
+		}
+		iface, ok := expr.(*ast.InterfaceType)
+		if !ok || len(iface.Methods.List) != 1 || len(iface.Methods.List[0].Names) != 1 {
+			return fmt.Errorf("invalid dbtx_methods entry %q: must be a single method", m)
+		}
+	}
+	return nil
+}
+
+// VetRules holds the names of the built-in `sqlc vet` rules. The last three
+// only run when `explain` is configured.
+var VetRules = []string{"no-select-star", "where-required", "max-joins", "unindexed-where", "max-cost", "max-rows", "seq-scan"}
+
+func validateVet(v Vet) error {
+	if v.MaxJoins < 0 {
+		return fmt.Errorf("invalid vet max_joins %d: must not be negative", v.MaxJoins)
+	}
+	if e := v.Explain; e != nil {
+		if e.Database == "" {
+			return fmt.Errorf("invalid vet explain: database is required")
+		}
+		if e.MaxCost < 0 || e.MaxRows < 0 || e.SeqScanRows < 0 {
+			return fmt.Errorf("invalid vet explain: max_cost, max_rows and seq_scan_rows must not be negative")
+		}
+	}
+	names := append([]string{}, VetRules...)
+	for _, rule := range v.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("invalid vet rule: name is required")
+		}
+		for _, name := range names {
+			if name == rule.Name {
+				return fmt.Errorf("invalid vet rule %q: the name is already used", rule.Name)
+			}
+		}
+		names = append(names, rule.Name)
+		prog, err := expr.Compile(rule.Rule, VetRuleVars)
+		if err != nil {
+			return fmt.Errorf("invalid vet rule %q: %w", rule.Name, err)
+		}
+		if prog.Uses("explain") && v.Explain == nil {
+			return fmt.Errorf("invalid vet rule %q: explain needs the explain setting", rule.Name)
+		}
+	}
+	for _, plugin := range v.Plugins {
+		if plugin.Name == "" || strings.Contains(plugin.Name, "/") {
+			return fmt.Errorf("invalid vet plugin %q: the name must be set and can't contain /", plugin.Name)
+		}
+		for _, name := range names {
+			if name == plugin.Name {
+				return fmt.Errorf("invalid vet plugin %q: the name is already used", plugin.Name)
+			}
+		}
+		if plugin.Cmd == "" {
+			return fmt.Errorf("invalid vet plugin %q: cmd is required", plugin.Name)
+		}
+		names = append(names, plugin.Name)
+	}
+	for _, name := range v.Disable {
+		known := false
+		for _, rule := range names {
+			known = known || rule == name
+		}
+		if !known {
+			return fmt.Errorf("invalid vet rule %q: must be one of %s", name, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+func validateModelsPackage(g SQLGo) error {
+	if g.OutputModelsPackage != "" && g.EmitModelsOnly {
+		return errors.New("output_models_package can't be used with emit_models_only")
+	}
+	return nil
+}
+
+func validateProto(g SQLGo) error {
+	if g.EmitProto && g.ProtoGoPackage == "" {
+		return errors.New("emit_proto requires proto_go_package")
+	}
+	return nil
+}
+
+// Supported values for the `emit_docs` setting
+const (
+	DocsFormatMarkdown = "markdown"
+	DocsFormatHTML     = "html"
+)
+
+func validateDocsFormat(format string) error {
+	switch format {
+	case "", DocsFormatMarkdown, DocsFormatHTML:
+		return nil
+	default:
+		return fmt.Errorf("invalid emit_docs %q: must be one of markdown or html", format)
+	}
+}
+
+// Supported values for the `xml_type` setting
+const (
+	XMLTypeString = "string"
+	XMLTypeBytes  = "[]byte"
+)
+
+func validateXMLType(typ string) error {
+	switch typ {
+	case "", XMLTypeString, XMLTypeBytes:
+		return nil
+	default:
+		return fmt.Errorf("invalid xml_type %q: must be one of string or []byte", typ)
+	}
+}
+
+// nullTypes maps the types supported by settings such as `uuid_type` to the
+// type used for nullable columns. Other types use a pointer instead.
+var nullTypes = map[string]string{
+	"github.com/google/uuid.UUID":           "github.com/google/uuid.NullUUID",
+	"github.com/jackc/pgtype.Date":          "github.com/jackc/pgtype.Date",
+	"github.com/jackc/pgtype.Interval":      "github.com/jackc/pgtype.Interval",
+	"github.com/jackc/pgtype.Numeric":       "github.com/jackc/pgtype.Numeric",
+	"github.com/jackc/pgtype.Time":          "github.com/jackc/pgtype.Time",
+	"github.com/jackc/pgtype.Timestamp":     "github.com/jackc/pgtype.Timestamp",
+	"github.com/jackc/pgtype.Timestamptz":   "github.com/jackc/pgtype.Timestamptz",
+	"github.com/jackc/pgtype.UUID":          "github.com/jackc/pgtype.UUID",
+	"github.com/shopspring/decimal.Decimal": "github.com/shopspring/decimal.NullDecimal",
+}
+
+// zonedTypes maps the types supported by `timestamp_type` which hold a
+// timestamp without a time zone to the type used for timestamptz columns
+var zonedTypes = map[string]string{
+	"github.com/jackc/pgtype.Timestamp": "github.com/jackc/pgtype.Timestamptz",
+}
+
+// typeOverrides returns the type overrides for dbTypes implied by a setting
+// such as `uuid_type`.
+func typeOverrides(setting, goType string, dbTypes ...string) ([]Override, error) {
+	if goType == "" {
+		return nil, nil
+	}
+	var overrides []Override
+	for _, dbType := range dbTypes {
+		null := Override{DBType: dbType, GoType: goType, Null: true, Pointer: true}
+		if nullType, ok := nullTypes[goType]; ok {
+			null = Override{DBType: dbType, GoType: nullType, Null: true}
+		}
+		overrides = append(overrides, Override{DBType: dbType, GoType: goType}, null)
+	}
+	for i := range overrides {
+		if err := overrides[i].Parse(); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %s", setting, goType, err)
+		}
+	}
+	return overrides, nil
+}
+
+// IntervalDuration is the `interval_type` which maps intervals to a Duration
+// type generated with the models, as time.Duration can't be scanned from the
+// text drivers return
+const IntervalDuration = "time.Duration"
+
+// TypeOverrides returns the type overrides implied by the `uuid_type`,
+// `decimal_type`, `timestamp_type`, `date_type`, `time_type` and
+// `interval_type` settings.
+func (g SQLGo) TypeOverrides() ([]Override, error) {
+	uuid, err := typeOverrides("uuid_type", g.UUIDType, "uuid")
+	if err != nil {
+		return nil, err
+	}
+	decimal, err := typeOverrides("decimal_type", g.DecimalType, "pg_catalog.numeric", "decimal")
+	if err != nil {
+		return nil, err
+	}
+	timestamp, err := typeOverrides("timestamp_type", g.TimestampType,
+		"pg_catalog.timestamp", "timestamp", "datetime")
+	if err != nil {
+		return nil, err
+	}
+	zoned := g.TimestampType
+	if tz, ok := zonedTypes[zoned]; ok {
+		zoned = tz
+	}
+	timestamptz, err := typeOverrides("timestamp_type", zoned, "pg_catalog.timestamptz", "timestamptz")
+	if err != nil {
+		return nil, err
+	}
+	date, err := typeOverrides("date_type", g.DateType, "date")
+	if err != nil {
+		return nil, err
+	}
+	tod, err := typeOverrides("time_type", g.TimeType, "pg_catalog.time", "pg_catalog.timetz", "time")
+	if err != nil {
+		return nil, err
+	}
+	var interval []Override
+	if g.IntervalType != IntervalDuration {
+		interval, err = typeOverrides("interval_type", g.IntervalType, "pg_catalog.interval", "interval")
+		if err != nil {
+			return nil, err
+		}
+	}
+	overrides := append(uuid, decimal...)
+	overrides = append(overrides, timestamp...)
+	overrides = append(overrides, timestamptz...)
+	overrides = append(overrides, date...)
+	overrides = append(overrides, tod...)
+	return append(overrides, interval...), nil
 }
 
 type SQLKotlin struct {
@@ -106,14 +502,42 @@ type Override struct {
 	// True if the GoType should override if the maching postgres type is nullable
 	Null bool `json:"null" yaml:"null"`
 
+	// True if the GoType should be referenced through a pointer, e.g. `*string`
+	Pointer bool `json:"pointer" yaml:"pointer"`
+
 	// fully qualified name of the column, e.g. `accounts.id`
 	Column string `json:"column" yaml:"column"`
 
-	ColumnName  string
-	Table       pg.FQN
-	GoTypeName  string
-	GoPackage   string
-	GoBasicType bool
+	// additional struct tags for the column, e.g. `validate:"required" bun:"name"`
+	GoStructTag string `json:"go_struct_tag" yaml:"go_struct_tag"`
+
+	// True if the json or jsonb column is unmarshaled into GoType
+	JSON bool `json:"json" yaml:"json"`
+
+	ColumnName   string
+	Table        pg.FQN
+	GoTypeName   string
+	GoPackage    string
+	GoBasicType  bool
+	GoStructTags map[string]string
+}
+
+var structTagPattern = regexp.MustCompile(`^([^\s:"]+):"((?:[^"\\]|\\.)*)"`)
+
+// parseStructTag splits a struct tag in the conventional `key:"value"` format
+// into its key value pairs.
+func parseStructTag(tag string) (map[string]string, error) {
+	tags := map[string]string{}
+	rest := strings.TrimSpace(tag)
+	for rest != "" {
+		m := structTagPattern.FindStringSubmatch(rest)
+		if m == nil {
+			return nil, fmt.Errorf("Override `go_struct_tag` specifier %q is not the proper format, expected 'key:\"value\"', e.g. 'validate:\"required\"'", tag)
+		}
+		tags[m[1]] = m[2]
+		rest = strings.TrimSpace(rest[len(m[0]):])
+	}
+	return tags, nil
 }
 
 func (o *Override) Parse() error {
@@ -153,10 +577,41 @@ func (o *Override) Parse() error {
 		}
 	}
 
+	// validate JSON
+	if o.JSON {
+		if o.Column == "" {
+			return fmt.Errorf("Override specifying `json` must also specify `column`")
+		}
+		if o.GoType == "" {
+			return fmt.Errorf("Override specifying `json` must also specify `go_type`")
+		}
+	}
+
+	// validate GoStructTag
+	if o.GoStructTag != "" {
+		if o.Column == "" {
+			return fmt.Errorf("Override specifying `go_struct_tag` (%q) must also specify `column`", o.GoStructTag)
+		}
+		tags, err := parseStructTag(o.GoStructTag)
+		if err != nil {
+			return err
+		}
+		o.GoStructTags = tags
+		// A struct tag override may leave the column's type unchanged
+		if o.GoType == "" {
+			return nil
+		}
+	}
+
 	// validate GoType
-	lastDot := strings.LastIndex(o.GoType, ".")
-	lastSlash := strings.LastIndex(o.GoType, "/")
-	typename := o.GoType
+	isPointer := o.Pointer || strings.HasPrefix(o.GoType, "*")
+	goType := strings.TrimPrefix(o.GoType, "*")
+	if goType == "" {
+		return fmt.Errorf("Package override `go_type` specifier %q is empty", o.GoType)
+	}
+	lastDot := strings.LastIndex(goType, ".")
+	lastSlash := strings.LastIndex(goType, "/")
+	typename := goType
 	if lastDot == -1 && lastSlash == -1 {
 		// if the type name has no slash and no dot, validate that the type is a basic Go type
 		var found bool
@@ -184,7 +639,7 @@ func (o *Override) Parse() error {
 		if lastSlash == -1 {
 			return fmt.Errorf("Package override `go_type` specifier %q is not the proper format, expected 'package.type', e.g. 'github.com/segmentio/ksuid.KSUID'", o.GoType)
 		}
-		typename = o.GoType[lastSlash+1:]
+		typename = goType[lastSlash+1:]
 		if strings.HasPrefix(typename, "go-") {
 			// a package name beginning with "go-" will give syntax errors in
 			// generated code. We should do the right thing and get the actual
@@ -195,12 +650,10 @@ func (o *Override) Parse() error {
 		if strings.HasSuffix(typename, "-go") {
 			typename = typename[:len(typename)-len("-go")]
 		}
-		o.GoPackage = o.GoType[:lastDot]
+		o.GoPackage = goType[:lastDot]
 	}
 	o.GoTypeName = typename
-	isPointer := o.GoType[0] == '*'
 	if isPointer {
-		o.GoPackage = o.GoPackage[1:]
 		o.GoTypeName = "*" + o.GoTypeName
 	}
 
@@ -263,6 +716,21 @@ func Combine(conf Config, pkg SQL) CombinedSettings {
 	if pkg.Gen.Go != nil {
 		cs.Go = *pkg.Gen.Go
 		cs.Overrides = append(cs.Overrides, pkg.Gen.Go.Overrides...)
+		// Explicit overrides take precedence over type settings such as
+		// uuid_type
+		builtin, _ := pkg.Gen.Go.TypeOverrides()
+		cs.Overrides = append(cs.Overrides, builtin...)
+		// Package level renames take precedence over global renames
+		if len(pkg.Gen.Go.Rename) > 0 {
+			rename := make(map[string]string, len(cs.Rename)+len(pkg.Gen.Go.Rename))
+			for k, v := range cs.Rename {
+				rename[k] = v
+			}
+			for k, v := range pkg.Gen.Go.Rename {
+				rename[k] = v
+			}
+			cs.Rename = rename
+		}
 	}
 	if pkg.Gen.Kotlin != nil {
 		cs.Kotlin = *pkg.Gen.Kotlin
@@ -1,6 +1,9 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -23,6 +26,137 @@ const unknownFields = `{
   "foo": "bar"
 }`
 
+const invalidJSONTagsCaseStyle = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "json_tags_case_style": "kebab"
+    }
+  ]
+}`
+
+const invalidEnumValueStyle = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "enum_value_style": "snake"
+    }
+  ]
+}`
+
+const invalidUUIDType = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "uuid_type": "UUID"
+    }
+  ]
+}`
+
+const invalidIntervalType = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "interval_type": "Interval"
+    }
+  ]
+}`
+
+const invalidXMLType = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "xml_type": "xml.Node"
+    }
+  ]
+}`
+
+const invalidQueryParameterLimit = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "query_parameter_limit": -1
+    }
+  ]
+}`
+
+const invalidParamsStructSuffix = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "params_struct_suffix": "Params-V2"
+    }
+  ]
+}`
+
+const conflictingScanByName = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "emit_scan_by_name": true,
+      "emit_generic_helpers": true
+    }
+  ]
+}`
+
+const invalidDBTXMethods = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "dbtx_methods": ["Begin() (*sql.Tx, error); Close() error"]
+    }
+  ]
+}`
+
+const missingProtoGoPackage = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "emit_proto": true
+    }
+  ]
+}`
+
+const unknownVetRule = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "vet": {"disable": ["no-select"]}
+    }
+  ]
+}`
+
+const invalidVetRuleExpr = `
+version: "1"
+packages:
+  - path: "db"
+    vet:
+      rules:
+        - name: no-deletes
+          rule: "query.sql.contains('DELETE'"
+`
+
+const missingVetDatabase = `{
+  "version": "1",
+  "packages": [
+    {
+      "path": "db",
+      "vet": {"explain": {"max_cost": 100}}
+    }
+  ]
+}`
+
 func TestBadConfigs(t *testing.T) {
 	for _, test := range []struct {
 		name string
@@ -50,6 +184,71 @@ func TestBadConfigs(t *testing.T) {
   line 3: field foo not found in type config.V1GenerateSettings`,
 			unknownFields,
 		},
+		{
+			"invalid json tags case style",
+			`invalid json_tags_case_style "kebab": must be one of none, camel, pascal or snake`,
+			invalidJSONTagsCaseStyle,
+		},
+		{
+			"invalid enum value style",
+			`invalid enum_value_style "snake": must be one of default or pascal`,
+			invalidEnumValueStyle,
+		},
+		{
+			"invalid uuid type",
+			`invalid uuid_type "UUID": Package override ` + "`go_type`" + ` specifier "UUID" is not a Go basic type e.g. 'string'`,
+			invalidUUIDType,
+		},
+		{
+			"invalid interval type",
+			`invalid interval_type "Interval": Package override ` + "`go_type`" + ` specifier "Interval" is not a Go basic type e.g. 'string'`,
+			invalidIntervalType,
+		},
+		{
+			"invalid xml type",
+			`invalid xml_type "xml.Node": must be one of string or []byte`,
+			invalidXMLType,
+		},
+		{
+			"invalid query parameter limit",
+			`invalid query_parameter_limit -1: must not be negative`,
+			invalidQueryParameterLimit,
+		},
+		{
+			"invalid params struct suffix",
+			`invalid params_struct_suffix "Params-V2": must be letters, digits or underscores`,
+			invalidParamsStructSuffix,
+		},
+		{
+			"conflicting scan by name",
+			"emit_scan_by_name can't be used with emit_generic_helpers",
+			conflictingScanByName,
+		},
+		{
+			"invalid dbtx methods",
+			`invalid dbtx_methods entry "Begin() (*sql.Tx, error); Close() error": must be a single method`,
+			invalidDBTXMethods,
+		},
+		{
+			"missing proto_go_package",
+			"emit_proto requires proto_go_package",
+			missingProtoGoPackage,
+		},
+		{
+			"unknown vet rule",
+			`invalid vet rule "no-select": must be one of no-select-star, where-required, max-joins, unindexed-where, max-cost, max-rows, seq-scan`,
+			unknownVetRule,
+		},
+		{
+			"missing vet explain database",
+			"invalid vet explain: database is required",
+			missingVetDatabase,
+		},
+		{
+			"invalid vet rule expression",
+			`invalid vet rule "no-deletes": unexpected end of expression`,
+			invalidVetRuleExpr,
+		},
 	} {
 		tt := test
 		t.Run(tt.name, func(t *testing.T) {
@@ -64,6 +263,26 @@ func TestBadConfigs(t *testing.T) {
 	}
 }
 
+func TestStructTagOverrides(t *testing.T) {
+	o := Override{
+		Column:      "users.email",
+		GoStructTag: `validate:"required,email" db:"email_address"`,
+	}
+	if err := o.Parse(); err != nil {
+		t.Fatalf("override parsing failed; %s", err)
+	}
+	expected := map[string]string{
+		"validate": "required,email",
+		"db":       "email_address",
+	}
+	if diff := cmp.Diff(expected, o.GoStructTags); diff != "" {
+		t.Errorf("struct tags mismatch;\n%s", diff)
+	}
+	if o.GoTypeName != "" {
+		t.Errorf("expected empty type name; got %s", o.GoTypeName)
+	}
+}
+
 func TestTypeOverrides(t *testing.T) {
 	for _, test := range []struct {
 		override Override
@@ -80,17 +299,34 @@ func TestTypeOverrides(t *testing.T) {
 			"ksuid.KSUID",
 			false,
 		},
-		// TODO: Add test for struct pointers
-		//
-		// {
-		// 	Override{
-		// 		DBType: "uuid",
-		// 		GoType:       "github.com/segmentio/*ksuid.KSUID",
-		// 	},
-		// 	"github.com/segmentio/ksuid",
-		// 	"*ksuid.KSUID",
-		// 	false,
-		// },
+		{
+			Override{
+				DBType: "uuid",
+				GoType: "*github.com/segmentio/ksuid.KSUID",
+			},
+			"github.com/segmentio/ksuid",
+			"*ksuid.KSUID",
+			false,
+		},
+		{
+			Override{
+				DBType:  "uuid",
+				GoType:  "github.com/segmentio/ksuid.KSUID",
+				Pointer: true,
+			},
+			"github.com/segmentio/ksuid",
+			"*ksuid.KSUID",
+			false,
+		},
+		{
+			Override{
+				DBType: "text",
+				GoType: "*string",
+			},
+			"",
+			"*string",
+			true,
+		},
 		{
 			Override{
 				DBType: "citext",
@@ -135,6 +371,35 @@ func TestTypeOverrides(t *testing.T) {
 			},
 			"Package override `go_type` specifier \"untyped rune\" is not a Go basic type e.g. 'string'",
 		},
+		{
+			Override{
+				DBType:      "text",
+				GoStructTag: `validate:"required"`,
+			},
+			"Override specifying `go_struct_tag` (\"validate:\\\"required\\\"\") must also specify `column`",
+		},
+		{
+			Override{
+				Column:      "users.email",
+				GoStructTag: `validate:required`,
+			},
+			"Override `go_struct_tag` specifier \"validate:required\" is not the proper format, expected 'key:\"value\"', e.g. 'validate:\"required\"'",
+		},
+		{
+			Override{
+				DBType: "jsonb",
+				GoType: "example.com/pkg.Settings",
+				JSON:   true,
+			},
+			"Override specifying `json` must also specify `column`",
+		},
+		{
+			Override{
+				Column: "users.settings",
+				JSON:   true,
+			},
+			"Override specifying `json` must also specify `go_type`",
+		},
 	} {
 		tt := test
 		t.Run(tt.override.GoType, func(t *testing.T) {
@@ -148,3 +413,272 @@ func TestTypeOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestCombineRename(t *testing.T) {
+	conf := Config{
+		Gen: Gen{
+			Go: &GenGo{
+				Rename: map[string]string{
+					"users": "Member",
+					"id":    "Identifier",
+				},
+			},
+		},
+	}
+	pkg := SQL{
+		Gen: SQLGen{
+			Go: &SQLGo{
+				Rename: map[string]string{
+					"users": "Account",
+				},
+			},
+		},
+	}
+	combo := Combine(conf, pkg)
+	expected := map[string]string{
+		"users": "Account",
+		"id":    "Identifier",
+	}
+	if diff := cmp.Diff(expected, combo.Rename); diff != "" {
+		t.Errorf("rename mismatch;\n%s", diff)
+	}
+}
+
+func TestCombineUUIDType(t *testing.T) {
+	for _, test := range []struct {
+		goType   string
+		typeName string
+		nullName string
+	}{
+		{"github.com/google/uuid.UUID", "uuid.UUID", "uuid.NullUUID"},
+		{"github.com/gofrs/uuid.UUID", "uuid.UUID", "*uuid.UUID"},
+		{"string", "string", "*string"},
+		{"github.com/jackc/pgtype.UUID", "pgtype.UUID", "pgtype.UUID"},
+	} {
+		tt := test
+		t.Run(tt.goType, func(t *testing.T) {
+			combo := Combine(Config{}, SQL{
+				Gen: SQLGen{
+					Go: &SQLGo{UUIDType: tt.goType},
+				},
+			})
+			if len(combo.Overrides) != 2 {
+				t.Fatalf("expected two overrides; got %d", len(combo.Overrides))
+			}
+			if diff := cmp.Diff(tt.typeName, combo.Overrides[0].GoTypeName); diff != "" {
+				t.Errorf("type name mismatch;\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.nullName, combo.Overrides[1].GoTypeName); diff != "" {
+				t.Errorf("null type name mismatch;\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCombineDecimalType(t *testing.T) {
+	combo := Combine(Config{}, SQL{
+		Gen: SQLGen{
+			Go: &SQLGo{DecimalType: "github.com/shopspring/decimal.Decimal"},
+		},
+	})
+	var names []string
+	for _, o := range combo.Overrides {
+		names = append(names, o.DBType+" "+o.GoTypeName)
+	}
+	expected := []string{
+		"pg_catalog.numeric decimal.Decimal",
+		"pg_catalog.numeric decimal.NullDecimal",
+		"decimal decimal.Decimal",
+		"decimal decimal.NullDecimal",
+	}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Errorf("overrides mismatch;\n%s", diff)
+	}
+}
+
+func TestCombineTimestampType(t *testing.T) {
+	for _, test := range []struct {
+		goType   string
+		expected []string
+	}{
+		{
+			"github.com/jackc/pgtype.Timestamp",
+			[]string{
+				"pg_catalog.timestamp pgtype.Timestamp",
+				"timestamp pgtype.Timestamp",
+				"datetime pgtype.Timestamp",
+				"pg_catalog.timestamptz pgtype.Timestamptz",
+				"timestamptz pgtype.Timestamptz",
+			},
+		},
+		{
+			"github.com/jackc/pgtype.Timestamptz",
+			[]string{
+				"pg_catalog.timestamp pgtype.Timestamptz",
+				"timestamp pgtype.Timestamptz",
+				"datetime pgtype.Timestamptz",
+				"pg_catalog.timestamptz pgtype.Timestamptz",
+				"timestamptz pgtype.Timestamptz",
+			},
+		},
+		{
+			"cloud.google.com/go/civil.DateTime",
+			[]string{
+				"pg_catalog.timestamp civil.DateTime",
+				"timestamp civil.DateTime",
+				"datetime civil.DateTime",
+				"pg_catalog.timestamptz civil.DateTime",
+				"timestamptz civil.DateTime",
+			},
+		},
+	} {
+		tt := test
+		t.Run(tt.goType, func(t *testing.T) {
+			combo := Combine(Config{}, SQL{
+				Gen: SQLGen{
+					Go: &SQLGo{TimestampType: tt.goType},
+				},
+			})
+			var names []string
+			for _, o := range combo.Overrides {
+				if !o.Null {
+					names = append(names, o.DBType+" "+o.GoTypeName)
+				}
+			}
+			if diff := cmp.Diff(tt.expected, names); diff != "" {
+				t.Errorf("overrides mismatch;\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestV2PathErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  string
+		yaml string
+	}{
+		{
+			"unknown field",
+			`line 8: sql[0].gen.go.emit_interfaces: unknown field, did you mean "emit_interface"?`,
+			`version: "2"
+sql:
+- engine: postgresql
+  schema: schema.sql
+  queries: query.sql
+  gen:
+    go:
+      emit_interfaces: true
+      out: db
+`,
+		},
+		{
+			"wrong type",
+			`line 9: sql[0].gen.go.emit_json_tags: expected true or false, got "sometimes"`,
+			`version: "2"
+sql:
+- engine: postgresql
+  schema: schema.sql
+  queries: query.sql
+  gen:
+    go:
+      out: db
+      emit_json_tags: sometimes
+`,
+		},
+		{
+			"list instead of string",
+			`line 4: sql[0].schema: expected a string, got a list`,
+			`version: "2"
+sql:
+- engine: postgresql
+  schema: [schema.sql]
+  queries: query.sql
+`,
+		},
+		{
+			"invalid engine",
+			`line 3: sql[0].engine: invalid engine "sqlite": must be one of mysql or postgresql`,
+			`version: "2"
+sql:
+- engine: sqlite
+  schema: schema.sql
+  queries: query.sql
+`,
+		},
+		{
+			"conflicting options",
+			`line 7: sql[0].gen.go: dbtx_methods can't be used with emit_prepared_queries`,
+			`version: "2"
+sql:
+- engine: postgresql
+  schema: schema.sql
+  queries: query.sql
+  gen:
+    go:
+      out: db
+      emit_prepared_queries: true
+      dbtx_methods: ["Begin() (*sql.Tx, error)"]
+`,
+		},
+	} {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig(strings.NewReader(tt.yaml))
+			if err == nil {
+				t.Fatalf("expected err; got nil")
+			}
+			if diff := cmp.Diff(tt.err, err.Error()); diff != "" {
+				t.Errorf("differed (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnvExpansion(t *testing.T) {
+	os.Setenv("SQLC_TEST_OUT", "internal/db")
+	os.Unsetenv("SQLC_TEST_UNSET")
+	defer os.Unsetenv("SQLC_TEST_OUT")
+
+	conf, err := ParseConfig(strings.NewReader(`version: "2"
+sql:
+- engine: postgresql
+  schema: ${SQLC_TEST_UNSET:-schema}.sql
+  queries: query.sql
+  gen:
+    go:
+      package: db
+      out: ${SQLC_TEST_OUT}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("internal/db", conf.SQL[0].Gen.Go.Out); diff != "" {
+		t.Errorf("out differed (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("schema.sql", conf.SQL[0].Schema); diff != "" {
+		t.Errorf("schema differed (-want +got):\n%s", diff)
+	}
+
+	_, err = ParseConfig(strings.NewReader(`{"version": "1", "packages": [{"path": "${SQLC_TEST_UNSET}", "schema": "schema.sql", "queries": "query.sql"}]}`))
+	if err == nil {
+		t.Fatalf("expected err; got nil")
+	}
+	if diff := cmp.Diff("packages[0].path: environment variable SQLC_TEST_UNSET is not set", err.Error()); diff != "" {
+		t.Errorf("differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	blob, err := JSONSchema()
+	if err != nil {
+		t.Fatal(err)
+	}
+	published, err := ioutil.ReadFile(filepath.Join("..", "..", "docs", "config.v2.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(published), string(blob)); diff != "" {
+		t.Errorf("docs/config.v2.schema.json is out of date, run `make jsonschema`:\n%s", diff)
+	}
+}
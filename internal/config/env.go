@@ -0,0 +1,78 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces ${NAME} and ${NAME:-default} in the string settings held
+// by v with the value of the environment variable NAME. As in the shell, the
+// default is used when NAME is unset or empty. A variable which isn't set, and
+// has no default, is an error.
+func expandEnv(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			return expandEnv(v.Elem(), path)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			if err := expandEnv(v.Field(i), joinPath(path, name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandEnv(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for _, key := range v.MapKeys() {
+			expanded, err := expandString(v.MapIndex(key).String(), joinPath(path, key.String()))
+			if err != nil {
+				return err
+			}
+			v.SetMapIndex(key, reflect.ValueOf(expanded).Convert(v.Type().Elem()))
+		}
+	case reflect.String:
+		expanded, err := expandString(v.String(), path)
+		if err != nil {
+			return err
+		}
+		v.SetString(expanded)
+	}
+	return nil
+}
+
+func expandString(s, path string) (string, error) {
+	var err error
+	expanded := envPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envPattern.FindStringSubmatch(ref)
+		val, ok := os.LookupEnv(m[1])
+		if m[2] != "" && val == "" {
+			return m[3]
+		}
+		if ok {
+			return val
+		}
+		if err == nil {
+			err = &PathError{Path: path, Err: fmt.Sprintf("environment variable %s is not set", m[1])}
+		}
+		return ""
+	})
+	return expanded, err
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Values of the settings which only accept a few strings
+var schemaEnums = map[string][]string{
+	"engine":               {string(EnginePostgreSQL), string(EngineMySQL)},
+	"emit_docs":            {DocsFormatMarkdown, DocsFormatHTML},
+	"enum_value_style":     {EnumValueStyleDefault, EnumValueStylePascal},
+	"json_tags_case_style": {JSONTagsCaseStyleNone, JSONTagsCaseStyleCamel, JSONTagsCaseStylePascal, JSONTagsCaseStyleSnake},
+}
+
+// Settings which v2ParseConfig requires, by the type which holds them
+var schemaRequired = map[reflect.Type][]string{
+	reflect.TypeOf(Config{}):    {"version", "sql"},
+	reflect.TypeOf(SQL{}):       {"engine", "schema", "queries"},
+	reflect.TypeOf(SQLGo{}):     {"out"},
+	reflect.TypeOf(SQLKotlin{}): {"package", "out"},
+}
+
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Const                string                 `json:"const,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"`
+}
+
+// JSONSchema returns a JSON Schema for version 2 configuration files, built
+// from the yaml tags of Config so that it can't drift from the parser
+func JSONSchema() ([]byte, error) {
+	s := schemaFor(reflect.TypeOf(Config{}))
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.Title = "sqlc configuration, version 2"
+	s.Properties["version"] = &jsonSchema{Type: "string", Const: "2"}
+	blob, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(blob, '\n'), nil
+}
+
+func schemaFor(t reflect.Type) *jsonSchema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		s := &jsonSchema{
+			Type:                 "object",
+			Properties:           map[string]*jsonSchema{},
+			Required:             schemaRequired[t],
+			AdditionalProperties: false,
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			prop := schemaFor(f.Type)
+			if vals, ok := schemaEnums[name]; ok {
+				prop.Enum = vals
+			}
+			s.Properties[name] = prop
+		}
+		return s
+	case reflect.Slice:
+		return &jsonSchema{Type: "array", Items: schemaFor(t.Elem())}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: schemaFor(t.Elem())}
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	}
+	return &jsonSchema{Type: "string"}
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// A PathError is a problem with the value at a path in the configuration
+// file, such as sql[0].gen.go.emit_interface
+type PathError struct {
+	Line int
+	Path string
+	Err  string
+}
+
+func (e *PathError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.Path, e.Err)
+	}
+	return fmt.Sprintf("line %d: %s: %s", e.Line, e.Path, e.Err)
+}
+
+// checkNode reports the first key in node which isn't a field of t, or value
+// which can't be decoded into its field, along with its path
+func checkNode(node *yaml.Node, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return pathErr(node, path, "expected a mapping")
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				msg := "unknown field"
+				if name := closest(key.Value, fields); name != "" {
+					msg += fmt.Sprintf(", did you mean %q?", name)
+				}
+				return pathErr(key, joinPath(path, key.Value), msg)
+			}
+			if err := checkNode(value, field.Type, joinPath(path, key.Value)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return pathErr(node, path, "expected a list")
+		}
+		for i, item := range node.Content {
+			if err := checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return pathErr(node, path, "expected a mapping")
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if err := checkNode(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value)); err != nil {
+				return err
+			}
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return pathErr(node, path, "expected true or false, got "+describe(node))
+		}
+	case reflect.Int:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			return pathErr(node, path, "expected an integer, got "+describe(node))
+		}
+	case reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			return pathErr(node, path, "expected a number, got "+describe(node))
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			return pathErr(node, path, "expected a string, got "+describe(node))
+		}
+	}
+	return nil
+}
+
+// yamlFields returns the fields of a struct by their yaml names. Fields
+// without a yaml tag are computed from the others and can't be set.
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = f
+		}
+	}
+	return fields
+}
+
+func describe(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	return fmt.Sprintf("%q", node.Value)
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func pathErr(node *yaml.Node, path, msg string) error {
+	return &PathError{Line: node.Line, Path: path, Err: msg}
+}
+
+// closest returns the field name nearest to key, if it's within a couple of
+// edits
+func closest(key string, fields map[string]reflect.StructField) string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	best, bestDist := "", 3
+	for _, name := range names {
+		if d := editDistance(key, name); d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// lookup returns the line of the value at a path of mapping keys and
+// sequence indexes beneath node. Mapping values are found at the line of
+// their key. It returns zero if there isn't a value at the path.
+func lookup(node *yaml.Node, path ...interface{}) int {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	var line int
+	for _, step := range path {
+		if node == nil {
+			return 0
+		}
+		var next *yaml.Node
+		switch step := step.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == step {
+						next, line = node.Content[i+1], node.Content[i].Line
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && step < len(node.Content) {
+				next = node.Content[step]
+				line = next.Line
+			}
+		}
+		node = next
+	}
+	if node == nil {
+		return 0
+	}
+	return line
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"reflect"
 
 	yaml "gopkg.in/yaml.v3"
 )
@@ -16,15 +17,65 @@ type V1GenerateSettings struct {
 }
 
 type v1PackageSettings struct {
-	Name                string     `json:"name" yaml:"name"`
-	Engine              Engine     `json:"engine,omitempty" yaml:"engine"`
-	Path                string     `json:"path" yaml:"path"`
-	Schema              string     `json:"schema" yaml:"schema"`
-	Queries             string     `json:"queries" yaml:"queries"`
-	EmitInterface       bool       `json:"emit_interface" yaml:"emit_interface"`
-	EmitJSONTags        bool       `json:"emit_json_tags" yaml:"emit_json_tags"`
-	EmitPreparedQueries bool       `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
-	Overrides           []Override `json:"overrides" yaml:"overrides"`
+	Name                     string            `json:"name" yaml:"name"`
+	Engine                   Engine            `json:"engine,omitempty" yaml:"engine"`
+	Path                     string            `json:"path" yaml:"path"`
+	ImportPath               string            `json:"import_path,omitempty" yaml:"import_path"`
+	Schema                   string            `json:"schema" yaml:"schema"`
+	Queries                  string            `json:"queries" yaml:"queries"`
+	EmitInterface            bool              `json:"emit_interface" yaml:"emit_interface"`
+	EmitFileInterfaces       bool              `json:"emit_file_interfaces,omitempty" yaml:"emit_file_interfaces"`
+	EmitQuerierFake          bool              `json:"emit_querier_fake,omitempty" yaml:"emit_querier_fake"`
+	EmitTestFactories        bool              `json:"emit_test_factories,omitempty" yaml:"emit_test_factories"`
+	EmitTestContainer        bool              `json:"emit_test_container,omitempty" yaml:"emit_test_container"`
+	TestContainerImage       string            `json:"test_container_image,omitempty" yaml:"test_container_image"`
+	EmitProto                bool              `json:"emit_proto,omitempty" yaml:"emit_proto"`
+	ProtoPackage             string            `json:"proto_package,omitempty" yaml:"proto_package"`
+	ProtoGoPackage           string            `json:"proto_go_package,omitempty" yaml:"proto_go_package"`
+	EmitOpenAPI              bool              `json:"emit_openapi,omitempty" yaml:"emit_openapi"`
+	EmitGraphQL              bool              `json:"emit_graphql,omitempty" yaml:"emit_graphql"`
+	EmitJSONTags             bool              `json:"emit_json_tags" yaml:"emit_json_tags"`
+	EmitPreparedQueries      bool              `json:"emit_prepared_queries" yaml:"emit_prepared_queries"`
+	EmitStatementCache       bool              `json:"emit_statement_cache,omitempty" yaml:"emit_statement_cache"`
+	DBTXInterfaceName        string            `json:"dbtx_interface_name,omitempty" yaml:"dbtx_interface_name"`
+	DBTXMethods              []string          `json:"dbtx_methods,omitempty" yaml:"dbtx_methods"`
+	EmitExactTableNames      bool              `json:"emit_exact_table_names" yaml:"emit_exact_table_names"`
+	EmitEmptySlices          bool              `json:"emit_empty_slices,omitempty" yaml:"emit_empty_slices"`
+	EmitHooks                bool              `json:"emit_hooks,omitempty" yaml:"emit_hooks"`
+	EmitInterceptors         bool              `json:"emit_interceptors,omitempty" yaml:"emit_interceptors"`
+	EmitMetrics              bool              `json:"emit_metrics,omitempty" yaml:"emit_metrics"`
+	EmitGenericHelpers       bool              `json:"emit_generic_helpers,omitempty" yaml:"emit_generic_helpers"`
+	EmitScanByName           bool              `json:"emit_scan_by_name,omitempty" yaml:"emit_scan_by_name"`
+	ForEachQueries           []string          `json:"for_each_queries,omitempty" yaml:"for_each_queries"`
+	CRUDTables               []string          `json:"crud_tables,omitempty" yaml:"crud_tables"`
+	EmitDocs                 string            `json:"emit_docs,omitempty" yaml:"emit_docs"`
+	EmitPointersForNullTypes bool              `json:"emit_pointers_for_null_types,omitempty" yaml:"emit_pointers_for_null_types"`
+	OmitUnusedStructs        bool              `json:"omit_unused_structs,omitempty" yaml:"omit_unused_structs"`
+	ReuseRowStructs          bool              `json:"reuse_row_structs,omitempty" yaml:"reuse_row_structs"`
+	StructTags               []string          `json:"struct_tags,omitempty" yaml:"struct_tags"`
+	JSONTagsCaseStyle        string            `json:"json_tags_case_style,omitempty" yaml:"json_tags_case_style"`
+	JSONTagsIDUppercase      bool              `json:"json_tags_id_uppercase,omitempty" yaml:"json_tags_id_uppercase"`
+	Initialisms              []string          `json:"initialisms,omitempty" yaml:"initialisms"`
+	EnumValueStyle           string            `json:"enum_value_style,omitempty" yaml:"enum_value_style"`
+	EnumValueRename          map[string]string `json:"enum_value_rename,omitempty" yaml:"enum_value_rename"`
+	UUIDType                 string            `json:"uuid_type,omitempty" yaml:"uuid_type"`
+	DecimalType              string            `json:"decimal_type,omitempty" yaml:"decimal_type"`
+	TimestampType            string            `json:"timestamp_type,omitempty" yaml:"timestamp_type"`
+	DateType                 string            `json:"date_type,omitempty" yaml:"date_type"`
+	TimeType                 string            `json:"time_type,omitempty" yaml:"time_type"`
+	IntervalType             string            `json:"interval_type,omitempty" yaml:"interval_type"`
+	XMLType                  string            `json:"xml_type,omitempty" yaml:"xml_type"`
+	QueryParameterLimit      *int              `json:"query_parameter_limit,omitempty" yaml:"query_parameter_limit"`
+	ParamsStructSuffix       string            `json:"params_struct_suffix,omitempty" yaml:"params_struct_suffix"`
+	OutputFilesSuffix        string            `json:"output_files_suffix,omitempty" yaml:"output_files_suffix"`
+	OutputModelsPackage      string            `json:"output_models_package,omitempty" yaml:"output_models_package"`
+	OutputModelsPackageName  string            `json:"output_models_package_name,omitempty" yaml:"output_models_package_name"`
+	EmitModelsOnly           bool              `json:"emit_models_only,omitempty" yaml:"emit_models_only"`
+	BuildTags                string            `json:"build_tags,omitempty" yaml:"build_tags"`
+	FileHeader               string            `json:"file_header,omitempty" yaml:"file_header"`
+	Overrides                []Override        `json:"overrides" yaml:"overrides"`
+	Rename                   map[string]string `json:"rename,omitempty" yaml:"rename"`
+	Vet                      Vet               `json:"vet,omitempty" yaml:"vet"`
 }
 
 func v1ParseConfig(rd io.Reader) (Config, error) {
@@ -35,6 +86,9 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 	if err := dec.Decode(&settings); err != nil {
 		return config, err
 	}
+	if err := expandEnv(reflect.ValueOf(&settings), ""); err != nil {
+		return config, err
+	}
 	if settings.Version == "" {
 		return config, ErrMissingVersion
 	}
@@ -56,6 +110,21 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 		if settings.Packages[j].Path == "" {
 			return config, ErrNoPackagePath
 		}
+		if err := validateJSONTagsCaseStyle(settings.Packages[j].JSONTagsCaseStyle); err != nil {
+			return config, err
+		}
+		if err := validateEnumValueStyle(settings.Packages[j].EnumValueStyle); err != nil {
+			return config, err
+		}
+		if err := validateDocsFormat(settings.Packages[j].EmitDocs); err != nil {
+			return config, err
+		}
+		if err := validateXMLType(settings.Packages[j].XMLType); err != nil {
+			return config, err
+		}
+		if err := validateVet(settings.Packages[j].Vet); err != nil {
+			return config, err
+		}
 		for i := range settings.Packages[j].Overrides {
 			if err := settings.Packages[j].Overrides[i].Parse(); err != nil {
 				return config, err
@@ -68,7 +137,28 @@ func v1ParseConfig(rd io.Reader) (Config, error) {
 			settings.Packages[j].Engine = EnginePostgreSQL
 		}
 	}
-	return settings.Translate(), nil
+	config = settings.Translate()
+	for _, pkg := range config.SQL {
+		if _, err := pkg.Gen.Go.TypeOverrides(); err != nil {
+			return config, err
+		}
+		if err := validateDBTX(*pkg.Gen.Go); err != nil {
+			return config, err
+		}
+		if err := validateParams(*pkg.Gen.Go); err != nil {
+			return config, err
+		}
+		if err := validateScanByName(*pkg.Gen.Go); err != nil {
+			return config, err
+		}
+		if err := validateModelsPackage(*pkg.Gen.Go); err != nil {
+			return config, err
+		}
+		if err := validateProto(*pkg.Gen.Go); err != nil {
+			return config, err
+		}
+	}
+	return config, nil
 }
 
 func (c *V1GenerateSettings) ValidateGlobalOverrides() error {
@@ -98,14 +188,64 @@ func (c *V1GenerateSettings) Translate() Config {
 			Engine:  pkg.Engine,
 			Schema:  pkg.Schema,
 			Queries: pkg.Queries,
+			Vet:     pkg.Vet,
 			Gen: SQLGen{
 				Go: &SQLGo{
-					EmitInterface:       pkg.EmitInterface,
-					EmitJSONTags:        pkg.EmitJSONTags,
-					EmitPreparedQueries: pkg.EmitPreparedQueries,
-					Package:             pkg.Name,
-					Out:                 pkg.Path,
-					Overrides:           pkg.Overrides,
+					EmitInterface:            pkg.EmitInterface,
+					EmitFileInterfaces:       pkg.EmitFileInterfaces,
+					EmitQuerierFake:          pkg.EmitQuerierFake,
+					EmitTestFactories:        pkg.EmitTestFactories,
+					EmitTestContainer:        pkg.EmitTestContainer,
+					TestContainerImage:       pkg.TestContainerImage,
+					EmitProto:                pkg.EmitProto,
+					ProtoPackage:             pkg.ProtoPackage,
+					ProtoGoPackage:           pkg.ProtoGoPackage,
+					EmitOpenAPI:              pkg.EmitOpenAPI,
+					EmitGraphQL:              pkg.EmitGraphQL,
+					EmitJSONTags:             pkg.EmitJSONTags,
+					EmitPreparedQueries:      pkg.EmitPreparedQueries,
+					EmitStatementCache:       pkg.EmitStatementCache,
+					DBTXInterfaceName:        pkg.DBTXInterfaceName,
+					DBTXMethods:              pkg.DBTXMethods,
+					EmitExactTableNames:      pkg.EmitExactTableNames,
+					EmitEmptySlices:          pkg.EmitEmptySlices,
+					EmitHooks:                pkg.EmitHooks,
+					EmitInterceptors:         pkg.EmitInterceptors,
+					EmitMetrics:              pkg.EmitMetrics,
+					EmitGenericHelpers:       pkg.EmitGenericHelpers,
+					EmitScanByName:           pkg.EmitScanByName,
+					ForEachQueries:           pkg.ForEachQueries,
+					CRUDTables:               pkg.CRUDTables,
+					EmitDocs:                 pkg.EmitDocs,
+					EmitPointersForNullTypes: pkg.EmitPointersForNullTypes,
+					OmitUnusedStructs:        pkg.OmitUnusedStructs,
+					ReuseRowStructs:          pkg.ReuseRowStructs,
+					StructTags:               pkg.StructTags,
+					JSONTagsCaseStyle:        pkg.JSONTagsCaseStyle,
+					JSONTagsIDUppercase:      pkg.JSONTagsIDUppercase,
+					Initialisms:              pkg.Initialisms,
+					EnumValueStyle:           pkg.EnumValueStyle,
+					EnumValueRename:          pkg.EnumValueRename,
+					UUIDType:                 pkg.UUIDType,
+					DecimalType:              pkg.DecimalType,
+					TimestampType:            pkg.TimestampType,
+					DateType:                 pkg.DateType,
+					TimeType:                 pkg.TimeType,
+					IntervalType:             pkg.IntervalType,
+					XMLType:                  pkg.XMLType,
+					QueryParameterLimit:      pkg.QueryParameterLimit,
+					ParamsStructSuffix:       pkg.ParamsStructSuffix,
+					OutputFilesSuffix:        pkg.OutputFilesSuffix,
+					OutputModelsPackage:      pkg.OutputModelsPackage,
+					OutputModelsPackageName:  pkg.OutputModelsPackageName,
+					EmitModelsOnly:           pkg.EmitModelsOnly,
+					BuildTags:                pkg.BuildTags,
+					FileHeader:               pkg.FileHeader,
+					Package:                  pkg.Name,
+					Out:                      pkg.Path,
+					ImportPath:               pkg.ImportPath,
+					Overrides:                pkg.Overrides,
+					Rename:                   pkg.Rename,
 				},
 			},
 		})
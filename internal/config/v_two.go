@@ -4,15 +4,27 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"reflect"
 
 	yaml "gopkg.in/yaml.v3"
 )
 
 func v2ParseConfig(rd io.Reader) (Config, error) {
 	dec := yaml.NewDecoder(rd)
-	dec.KnownFields(true)
 	var conf Config
-	if err := dec.Decode(&conf); err != nil {
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		return conf, err
+	}
+	if len(doc.Content) > 0 {
+		if err := checkNode(doc.Content[0], reflect.TypeOf(conf), ""); err != nil {
+			return conf, err
+		}
+	}
+	if err := doc.Decode(&conf); err != nil {
+		return conf, err
+	}
+	if err := expandEnv(reflect.ValueOf(&conf), ""); err != nil {
 		return conf, err
 	}
 	if conf.Version == "" {
@@ -30,7 +42,7 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 	if conf.Gen.Go != nil {
 		for i := range conf.Gen.Go.Overrides {
 			if err := conf.Gen.Go.Overrides[i].Parse(); err != nil {
-				return conf, err
+				return conf, atPath(&doc, err, "overrides", "go", "overrides", i)
 			}
 		}
 	}
@@ -38,6 +50,12 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 		if conf.SQL[j].Engine == "" {
 			return conf, ErrMissingEngine
 		}
+		if err := validateEngine(conf.SQL[j].Engine); err != nil {
+			return conf, atPath(&doc, err, "sql", j, "engine")
+		}
+		if err := validateVet(conf.SQL[j].Vet); err != nil {
+			return conf, atPath(&doc, err, "sql", j, "vet")
+		}
 		if conf.SQL[j].Gen.Go != nil {
 			if conf.SQL[j].Gen.Go.Out == "" {
 				return conf, ErrNoPackagePath
@@ -45,9 +63,25 @@ func v2ParseConfig(rd io.Reader) (Config, error) {
 			if conf.SQL[j].Gen.Go.Package == "" {
 				conf.SQL[j].Gen.Go.Package = filepath.Base(conf.SQL[j].Gen.Go.Out)
 			}
+			for _, validate := range []func(SQLGo) error{
+				func(g SQLGo) error { return validateJSONTagsCaseStyle(g.JSONTagsCaseStyle) },
+				func(g SQLGo) error { return validateEnumValueStyle(g.EnumValueStyle) },
+				func(g SQLGo) error { return validateDocsFormat(g.EmitDocs) },
+				func(g SQLGo) error { return validateXMLType(g.XMLType) },
+				validateDBTX,
+				validateParams,
+				validateScanByName,
+				validateModelsPackage,
+				validateProto,
+				func(g SQLGo) error { _, err := g.TypeOverrides(); return err },
+			} {
+				if err := validate(*conf.SQL[j].Gen.Go); err != nil {
+					return conf, atPath(&doc, err, "sql", j, "gen", "go")
+				}
+			}
 			for i := range conf.SQL[j].Gen.Go.Overrides {
 				if err := conf.SQL[j].Gen.Go.Overrides[i].Parse(); err != nil {
-					return conf, err
+					return conf, atPath(&doc, err, "sql", j, "gen", "go", "overrides", i)
 				}
 			}
 		}
@@ -81,3 +115,27 @@ func (c *Config) validateGlobalOverrides() error {
 	}
 	return nil
 }
+
+func validateEngine(engine Engine) error {
+	switch engine {
+	case EngineMySQL, EnginePostgreSQL, EngineXLemon, EngineXDolphin, EngineXElephant:
+		return nil
+	default:
+		return fmt.Errorf("%s %q: must be one of %s or %s", ErrUnknownEngine, engine, EngineMySQL, EnginePostgreSQL)
+	}
+}
+
+// atPath attaches the path, and the line of the value at that path, to an
+// error found while validating the configuration
+func atPath(doc *yaml.Node, err error, path ...interface{}) error {
+	var p string
+	for _, step := range path {
+		switch step := step.(type) {
+		case string:
+			p = joinPath(p, step)
+		case int:
+			p += fmt.Sprintf("[%d]", step)
+		}
+	}
+	return &PathError{Line: lookup(doc, path...), Path: p, Err: err.Error()}
+}
@@ -0,0 +1,255 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/hcl"
+)
+
+// Atlas blocks which don't affect the catalog
+var atlasIgnored = map[string]bool{
+	"extension":  true,
+	"function":   true,
+	"locals":     true,
+	"permission": true,
+	"policy":     true,
+	"sequence":   true,
+	"trigger":    true,
+	"variable":   true,
+}
+
+// Type names Atlas spells with underscores
+var atlasTypes = map[string]string{
+	"bit_varying":                 "bit varying",
+	"character_varying":           "character varying",
+	"double_precision":            "double precision",
+	"time_with_time_zone":         "time with time zone",
+	"time_without_time_zone":      "time without time zone",
+	"timestamp_with_time_zone":    "timestamp with time zone",
+	"timestamp_without_time_zone": "timestamp without time zone",
+}
+
+type atlasGen struct {
+	enums  map[string]string
+	tables map[string]string
+	sql    []string
+	later  []string
+}
+
+// AtlasSQL returns the DDL for an Atlas HCL schema file. Schemas, enums,
+// tables and views are converted; functions, triggers and the like are left
+// out.
+func AtlasSQL(src string) (string, error) {
+	body, err := hcl.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	g := &atlasGen{enums: map[string]string{}, tables: map[string]string{}}
+	for _, block := range body.Blocks {
+		if !atlasIgnored[block.Type] && !atlasKnown(block.Type) {
+			return "", &hcl.Error{Line: block.Line, Err: fmt.Sprintf("unsupported Atlas block %q", block.Type)}
+		}
+		if len(block.Labels) == 0 && !atlasIgnored[block.Type] {
+			return "", &hcl.Error{Line: block.Line, Err: fmt.Sprintf("%s block has no name", block.Type)}
+		}
+	}
+	for _, block := range body.BlocksOf("schema") {
+		if name := block.Labels[0]; name != "public" {
+			g.sql = append(g.sql, "CREATE SCHEMA "+name)
+		}
+		g.comment(block, "SCHEMA "+block.Labels[0])
+	}
+	for _, block := range body.BlocksOf("enum") {
+		name := g.qualify(block)
+		g.enums["enum."+block.Labels[0]] = name
+		values, _ := block.Body.Attr("values")
+		var vals []string
+		for _, v := range values.Items {
+			vals = append(vals, quoteLiteral(v.Str))
+		}
+		g.sql = append(g.sql, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", name, strings.Join(vals, ", ")))
+		g.comment(block, "TYPE "+name)
+	}
+	for _, block := range body.BlocksOf("table") {
+		g.tables[block.Labels[0]] = g.qualify(block)
+	}
+	for _, block := range body.BlocksOf("table") {
+		if err := g.table(block); err != nil {
+			return "", err
+		}
+	}
+	for _, block := range body.BlocksOf("view") {
+		as, ok := block.Body.Attr("as")
+		if !ok {
+			return "", &hcl.Error{Line: block.Line, Err: fmt.Sprintf("view %q has no as attribute", block.Labels[0])}
+		}
+		g.later = append(g.later, fmt.Sprintf("CREATE VIEW %s AS %s", g.qualify(block), strings.TrimSuffix(strings.TrimSpace(as.Str), ";")))
+	}
+	return strings.Join(append(g.sql, g.later...), ";\n") + ";\n", nil
+}
+
+func atlasKnown(typ string) bool {
+	switch typ {
+	case "schema", "enum", "table", "view":
+		return true
+	}
+	return false
+}
+
+// qualify returns the name of a block, qualified by its schema attribute
+func (g *atlasGen) qualify(block hcl.Block) string {
+	if schema, ok := block.Body.Attr("schema"); ok {
+		if name := strings.TrimPrefix(schema.Str, "schema."); name != "public" {
+			return name + "." + block.Labels[0]
+		}
+	}
+	return block.Labels[0]
+}
+
+func (g *atlasGen) comment(block hcl.Block, target string) {
+	if c, ok := block.Body.Attr("comment"); ok {
+		g.later = append(g.later, fmt.Sprintf("COMMENT ON %s IS %s", target, quoteLiteral(c.Str)))
+	}
+}
+
+func (g *atlasGen) table(block hcl.Block) error {
+	name := g.qualify(block)
+	var defs []string
+	for _, col := range block.Body.BlocksOf("column") {
+		if len(col.Labels) == 0 {
+			return &hcl.Error{Line: col.Line, Err: "column block has no name"}
+		}
+		typ, ok := col.Body.Attr("type")
+		if !ok {
+			return &hcl.Error{Line: col.Line, Err: fmt.Sprintf("column %q has no type", col.Labels[0])}
+		}
+		def := col.Labels[0] + " " + g.columnType(typ)
+		if null, ok := col.Body.Attr("null"); !ok || null.Str != "true" {
+			def += " NOT NULL"
+		}
+		if d, ok := col.Body.Attr("default"); ok {
+			def += " DEFAULT " + atlasValue(d)
+		}
+		if len(col.Body.BlocksOf("identity")) > 0 {
+			def += " GENERATED BY DEFAULT AS IDENTITY"
+		}
+		defs = append(defs, def)
+		g.comment(col, "COLUMN "+name+"."+col.Labels[0])
+	}
+	for _, pk := range block.Body.BlocksOf("primary_key") {
+		cols, _ := pk.Body.Attr("columns")
+		defs = append(defs, "PRIMARY KEY ("+atlasColumns(cols)+")")
+	}
+	for _, check := range block.Body.BlocksOf("check") {
+		if expr, ok := check.Body.Attr("expr"); ok {
+			defs = append(defs, "CHECK ("+expr.Str+")")
+		}
+	}
+	g.sql = append(g.sql, fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", name, strings.Join(defs, ",\n  ")))
+	g.comment(block, "TABLE "+name)
+
+	for _, index := range block.Body.BlocksOf("index") {
+		stmt := "CREATE INDEX "
+		if unique, ok := index.Body.Attr("unique"); ok && unique.Str == "true" {
+			stmt = "CREATE UNIQUE INDEX "
+		}
+		if len(index.Labels) > 0 {
+			stmt += index.Labels[0] + " "
+		}
+		var cols []string
+		if c, ok := index.Body.Attr("columns"); ok {
+			cols = append(cols, atlasColumns(c))
+		}
+		for _, on := range index.Body.BlocksOf("on") {
+			if c, ok := on.Body.Attr("column"); ok {
+				cols = append(cols, atlasColumn(c))
+			} else if e, ok := on.Body.Attr("expr"); ok {
+				cols = append(cols, "("+e.Str+")")
+			}
+		}
+		g.sql = append(g.sql, stmt+"ON "+name+" ("+strings.Join(cols, ", ")+")")
+	}
+	for _, fk := range block.Body.BlocksOf("foreign_key") {
+		cols, _ := fk.Body.Attr("columns")
+		refs, _ := fk.Body.Attr("ref_columns")
+		var table string
+		if len(refs.Items) > 0 {
+			parts := strings.Split(refs.Items[0].Str, ".")
+			for i := range parts {
+				if parts[i] == "table" && i+1 < len(parts) {
+					table = g.tables[parts[i+1]]
+					if table == "" {
+						table = parts[i+1]
+					}
+				}
+			}
+		}
+		if table == "" {
+			return &hcl.Error{Line: fk.Line, Err: "foreign key has no ref_columns"}
+		}
+		stmt := "ALTER TABLE " + name + " ADD "
+		if len(fk.Labels) > 0 {
+			stmt += "CONSTRAINT " + fk.Labels[0] + " "
+		}
+		stmt += "FOREIGN KEY (" + atlasColumns(cols) + ") REFERENCES " + table + " (" + atlasColumns(refs) + ")"
+		if action, ok := fk.Body.Attr("on_delete"); ok {
+			stmt += " ON DELETE " + strings.Replace(action.Str, "_", " ", -1)
+		}
+		if action, ok := fk.Body.Attr("on_update"); ok {
+			stmt += " ON UPDATE " + strings.Replace(action.Str, "_", " ", -1)
+		}
+		g.later = append(g.later, stmt)
+	}
+	return nil
+}
+
+func (g *atlasGen) columnType(e hcl.Expr) string {
+	if e.Kind == hcl.Call && e.Str == "sql" && len(e.Items) == 1 {
+		return e.Items[0].Str
+	}
+	if e.Kind == hcl.Ref {
+		if enum, ok := g.enums[e.Str]; ok {
+			return enum
+		}
+		if t, ok := atlasTypes[e.Str]; ok {
+			return t
+		}
+	}
+	if e.Kind == hcl.Call {
+		if t, ok := atlasTypes[e.Str]; ok {
+			return t + e.Raw[len(e.Str):]
+		}
+	}
+	return e.Raw
+}
+
+// atlasValue returns the SQL for a default value
+func atlasValue(e hcl.Expr) string {
+	switch {
+	case e.Kind == hcl.String:
+		return quoteLiteral(e.Str)
+	case e.Kind == hcl.Call && e.Str == "sql" && len(e.Items) == 1:
+		return e.Items[0].Str
+	}
+	return e.Raw
+}
+
+// atlasColumn returns the name of a column reference such as column.id or
+// table.users.column.id
+func atlasColumn(e hcl.Expr) string {
+	parts := strings.Split(e.Str, ".")
+	return parts[len(parts)-1]
+}
+
+func atlasColumns(e hcl.Expr) string {
+	var cols []string
+	for _, item := range e.Items {
+		cols = append(cols, atlasColumn(item))
+	}
+	return strings.Join(cols, ", ")
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,133 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// CRUDFilename is the name given to the queries generated for `crud_tables`.
+const CRUDFilename = "crud.sql"
+
+// CRUDQueries returns the source of Get, List, Create, Update and Delete
+// queries for each table. Tables are named either "table" or "schema.table".
+// Queries which select a single row use the table's primary key, and are left
+// out for tables without one. Create leaves out serial columns and those with
+// a default.
+func CRUDQueries(c core.Catalog, tables []string, settings config.CombinedSettings) (string, error) {
+	var b strings.Builder
+	for _, name := range tables {
+		fqn := core.FQN{Schema: "public", Rel: name}
+		if i := strings.Index(name, "."); i >= 0 {
+			fqn = core.FQN{Schema: name[:i], Rel: name[i+1:]}
+		}
+		schema, ok := c.Schemas[fqn.Schema]
+		if !ok {
+			return "", fmt.Errorf("crud_tables: schema %q does not exist", fqn.Schema)
+		}
+		table, ok := schema.Tables[fqn.Rel]
+		if !ok {
+			return "", fmt.Errorf("crud_tables: table %q does not exist", name)
+		}
+		ref, modelName := core.QuoteIdent(fqn.Rel), fqn.Rel
+		if fqn.Schema != "public" {
+			ref = core.QuoteIdent(fqn.Schema) + "." + ref
+			modelName = fqn.Schema + "_" + fqn.Rel
+		}
+		writeCRUD(&b, ref, ModelName(modelName, settings), table)
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+func writeCRUD(b *strings.Builder, ref, model string, table core.Table) {
+	var keys []string
+	for _, index := range table.Indexes {
+		if index.Primary {
+			keys = index.Columns
+		}
+	}
+	for _, key := range keys {
+		// Keys on expressions can't be matched by a parameter
+		if key == "" {
+			keys = nil
+		}
+	}
+	isKey := map[string]bool{}
+	for _, key := range keys {
+		isKey[key] = true
+	}
+
+	var inserted, updated []string
+	for _, col := range table.Columns {
+		if !isSerial(col.DataType) && !col.HasDefault {
+			inserted = append(inserted, core.QuoteIdent(col.Name))
+		}
+		if !isKey[col.Name] {
+			updated = append(updated, core.QuoteIdent(col.Name))
+		}
+	}
+	where := make([]string, len(keys))
+	for i, key := range keys {
+		where[i] = fmt.Sprintf("%s = $%d", core.QuoteIdent(key), i+1)
+	}
+	match := strings.Join(where, " AND ")
+
+	if len(keys) > 0 {
+		fmt.Fprintf(b, "-- name: Get%s :one\nSELECT * FROM %s\nWHERE %s;\n\n", model, ref, match)
+	}
+
+	fmt.Fprintf(b, "-- name: List%s :many\nSELECT * FROM %s", inflection.Plural(model), ref)
+	if len(keys) > 0 {
+		order := make([]string, len(keys))
+		for i, key := range keys {
+			order[i] = core.QuoteIdent(key)
+		}
+		fmt.Fprintf(b, "\nORDER BY %s", strings.Join(order, ", "))
+	}
+	b.WriteString(";\n\n")
+
+	fmt.Fprintf(b, "-- name: Create%s :one\nINSERT INTO %s ", model, ref)
+	if len(inserted) == 0 {
+		b.WriteString("DEFAULT VALUES")
+	} else {
+		fmt.Fprintf(b, "(%s)\nVALUES (%s)", strings.Join(inserted, ", "), placeholders(1, len(inserted)))
+	}
+	b.WriteString("\nRETURNING *;\n\n")
+
+	if len(keys) == 0 {
+		return
+	}
+
+	if len(updated) > 0 {
+		fmt.Fprintf(b, "-- name: Update%s :one\nUPDATE %s\nSET ", model, ref)
+		for i, col := range updated {
+			if i > 0 {
+				b.WriteString(",\n    ")
+			}
+			fmt.Fprintf(b, "%s = $%d", col, len(keys)+i+1)
+		}
+		fmt.Fprintf(b, "\nWHERE %s\nRETURNING *;\n\n", match)
+	}
+
+	fmt.Fprintf(b, "-- name: Delete%s :exec\nDELETE FROM %s\nWHERE %s;\n\n", model, ref, match)
+}
+
+func isSerial(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "serial", "serial2", "serial4", "serial8", "smallserial", "bigserial":
+		return true
+	}
+	return false
+}
+
+func placeholders(start, n int) string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = fmt.Sprintf("$%d", start+i)
+	}
+	return strings.Join(p, ", ")
+}
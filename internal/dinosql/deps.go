@@ -0,0 +1,126 @@
+package dinosql
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// queryResult is implemented by the results of the PostgreSQL engine, which
+// know the queries of each file
+type queryResult interface {
+	catalogResult
+	queries() []*Query
+}
+
+func (r Result) queries() []*Query {
+	return r.Queries
+}
+
+// Dependencies returns the tables the queries of each query file read or
+// write, keyed by file name
+func (r Result) Dependencies() map[string][]core.FQN {
+	deps := map[string][]core.FQN{}
+	seen := map[string]map[core.FQN]bool{}
+	for _, q := range r.Queries {
+		if seen[q.Filename] == nil {
+			seen[q.Filename] = map[core.FQN]bool{}
+		}
+		add := func(fqn core.FQN) {
+			if fqn.Rel == "" || seen[q.Filename][fqn] {
+				return
+			}
+			seen[q.Filename][fqn] = true
+			deps[q.Filename] = append(deps[q.Filename], fqn)
+		}
+		for _, fqn := range q.Tables {
+			add(fqn)
+		}
+		// Output columns may come from tables used in a subquery
+		for _, col := range q.Columns {
+			add(col.Table)
+		}
+	}
+	for _, tables := range deps {
+		sort.Slice(tables, func(i, j int) bool { return tables[i].String() < tables[j].String() })
+	}
+	return deps
+}
+
+// QueryFileKeys returns a key for each query file of r which changes whenever
+// the Go code generated for the file might: when its queries, the tables they
+// depend on, the names of the schema's types or the settings change. The
+// result is false if r doesn't track its dependencies, or if files share row
+// structs, so one file's code depends on the others.
+func QueryFileKeys(r Generateable, settings config.CombinedSettings) (map[string]string, bool) {
+	qr, ok := r.(queryResult)
+	if !ok || settings.Go.ReuseRowStructs {
+		return nil, false
+	}
+	c := qr.catalog()
+	blob, err := json.Marshal(settings)
+	if err != nil {
+		return nil, false
+	}
+	shared := string(blob)
+
+	// Enums and composite types are told apart from unknown types by name,
+	// so every file depends on the names of all of them
+	var types []string
+	for name, schema := range c.Schemas {
+		if name == "pg_catalog" {
+			continue
+		}
+		for typeName, typ := range schema.Types {
+			switch typ := typ.(type) {
+			case core.Enum:
+				types = append(types, name+"."+typeName+" enum")
+			case core.CompositeType:
+				types = append(types, name+"."+typeName+" composite")
+			case core.BaseType:
+				types = append(types, name+"."+typeName+" base")
+			case core.Domain:
+				types = append(types, name+"."+typeName+" domain "+typ.BaseType)
+			}
+		}
+	}
+	sort.Strings(types)
+
+	files := map[string][]*Query{}
+	for _, q := range qr.queries() {
+		files[q.Filename] = append(files[q.Filename], q)
+	}
+	deps := Result{Queries: qr.queries()}.Dependencies()
+
+	keys := map[string]string{}
+	for filename, queries := range files {
+		var tables []core.Table
+		for _, fqn := range deps[filename] {
+			schema := fqn.Schema
+			if schema == "" {
+				schema = "public"
+			}
+			if table, ok := c.Schemas[schema].Tables[fqn.Rel]; ok {
+				tables = append(tables, table)
+			}
+		}
+		blob, err := json.Marshal(struct {
+			Queries []*Query
+			Tables  []core.Table
+			Types   []string
+		}{queries, tables, types})
+		if err != nil {
+			return nil, false
+		}
+		h := sha256.New()
+		h.Write([]byte(shared))
+		h.Write([]byte{0})
+		h.Write(blob)
+		keys[filename] = hex.EncodeToString(h.Sum(nil))
+	}
+	return keys, true
+}
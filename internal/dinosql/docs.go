@@ -0,0 +1,196 @@
+package dinosql
+
+import (
+	"bytes"
+	htemplate "html/template"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+type docSchema struct {
+	Name    string
+	Comment string
+	Tables  []docTable
+	Enums   []core.Enum
+}
+
+type docTable struct {
+	Name    string
+	Comment string
+	Columns []docColumn
+	Queries []*Query
+}
+
+type docColumn struct {
+	Name    string
+	Type    string
+	NotNull bool
+	Comment string
+}
+
+// Docs returns the name and contents of a file documenting the tables, enums
+// and comments in the catalog of r, along with the queries which refer to
+// each table. The format is one of the `emit_docs` values.
+func Docs(r *Result, format string) (string, string, error) {
+	schemas := docSchemas(r)
+	var b bytes.Buffer
+	switch format {
+	case config.DocsFormatHTML:
+		tmpl := htemplate.Must(htemplate.New("docs").Parse(htmlDocsTmpl))
+		if err := tmpl.Execute(&b, schemas); err != nil {
+			return "", "", err
+		}
+		return "schema.html", b.String(), nil
+	default:
+		funcs := template.FuncMap{"cell": markdownCell}
+		tmpl := template.Must(template.New("docs").Funcs(funcs).Parse(markdownDocsTmpl))
+		if err := tmpl.Execute(&b, schemas); err != nil {
+			return "", "", err
+		}
+		return "schema.md", b.String(), nil
+	}
+}
+
+func docSchemas(r *Result) []docSchema {
+	var names []string
+	for name := range r.Catalog.Schemas {
+		switch name {
+		case "pg_catalog", "pg_temp", "sqlc":
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var schemas []docSchema
+	for _, name := range names {
+		schema := r.Catalog.Schemas[name]
+		ds := docSchema{Name: name, Comment: schema.Comment, Enums: schema.Enums()}
+		for _, table := range schema.Tables {
+			dt := docTable{Name: table.Name, Comment: table.Comment}
+			for _, col := range table.Columns {
+				typ := strings.TrimPrefix(col.DataType, "pg_catalog.")
+				if col.IsArray {
+					typ += "[]"
+				}
+				dt.Columns = append(dt.Columns, docColumn{
+					Name:    col.Name,
+					Type:    typ,
+					NotNull: col.NotNull,
+					Comment: col.Comment,
+				})
+			}
+			fqn := core.FQN{Schema: name, Rel: table.Name}
+			for _, q := range r.Queries {
+				for _, t := range q.Tables {
+					if t == fqn {
+						dt.Queries = append(dt.Queries, q)
+						break
+					}
+				}
+			}
+			ds.Tables = append(ds.Tables, dt)
+		}
+		sort.Slice(ds.Tables, func(i, j int) bool { return ds.Tables[i].Name < ds.Tables[j].Name })
+		if len(ds.Tables) == 0 && len(ds.Enums) == 0 {
+			continue
+		}
+		schemas = append(schemas, ds)
+	}
+	return schemas
+}
+
+// markdownCell escapes s for use in a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.Replace(s, "|", `\|`, -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
+
+const markdownDocsTmpl = `# Schema
+{{range .}}
+## {{.Name}}
+{{if .Comment}}
+{{.Comment}}
+{{end}}
+{{- range .Tables}}
+### {{.Name}}
+{{if .Comment}}
+{{.Comment}}
+{{end}}
+| Column | Type | Not null | Comment |
+| --- | --- | --- | --- |
+{{- range .Columns}}
+| {{cell .Name}} | {{cell .Type}} | {{if .NotNull}}yes{{else}}no{{end}} | {{cell .Comment}} |
+{{- end}}
+{{if .Queries}}
+Queries:
+{{range .Queries}}
+- ` + "`{{.Name}}`" + ` ({{.Filename}})
+{{- end}}
+{{end}}
+{{- end}}
+{{- range .Enums}}
+### {{.Name}} (enum)
+{{if .Comment}}
+{{.Comment}}
+{{end}}
+{{- range .Vals}}
+- ` + "`{{.}}`" + `
+{{- end}}
+{{end}}
+{{- end -}}
+`
+
+const htmlDocsTmpl = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Schema</title>
+</head>
+<body>
+<h1>Schema</h1>
+{{- range .}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+{{- $schema := .Name}}
+{{- range .Tables}}
+<h3 id="{{$schema}}.{{.Name}}">{{.Name}}</h3>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+<table>
+<tr><th>Column</th><th>Type</th><th>Not null</th><th>Comment</th></tr>
+{{- range .Columns}}
+<tr><td>{{.Name}}</td><td>{{.Type}}</td><td>{{if .NotNull}}yes{{else}}no{{end}}</td><td>{{.Comment}}</td></tr>
+{{- end}}
+</table>
+{{- if .Queries}}
+<p>Queries:</p>
+<ul>
+{{- range .Queries}}
+<li><code>{{.Name}}</code> ({{.Filename}})</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+{{- range .Enums}}
+<h3 id="{{$schema}}.{{.Name}}">{{.Name}} (enum)</h3>
+{{- if .Comment}}
+<p>{{.Comment}}</p>
+{{- end}}
+<ul>
+{{- range .Vals}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
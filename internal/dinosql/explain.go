@@ -0,0 +1,226 @@
+package dinosql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// explainRules are the `sqlc vet` rules checked against the plans of a live
+// database. They must be kept in sync with config.VetRules.
+var explainRules = []string{"max-cost", "max-rows", "seq-scan"}
+
+// A planNode is a node of the plan printed by EXPLAIN (FORMAT JSON)
+type planNode struct {
+	NodeType  string     `json:"Node Type"`
+	Relation  string     `json:"Relation Name"`
+	Schema    string     `json:"Schema"`
+	TotalCost float64    `json:"Total Cost"`
+	PlanRows  float64    `json:"Plan Rows"`
+	Plans     []planNode `json:"Plans"`
+}
+
+// VetExplain runs the rules of settings.Explain, and the custom rules using
+// explain, which aren't disabled over the plans db chooses for the queries in
+// r. Each query is explained with a generic plan, as its parameters aren't
+// known, so db must be PostgreSQL 12 or later. The queries themselves are
+// never run.
+func VetExplain(db *sql.DB, r *Result, settings config.Vet) ([]VetErr, error) {
+	sizes := map[string]float64{}
+	tableRows := func(schema, rel string) (float64, error) {
+		key := schema + "." + rel
+		if n, ok := sizes[key]; ok {
+			return n, nil
+		}
+		var n float64
+		row := db.QueryRow(`
+			SELECT c.reltuples
+			FROM pg_catalog.pg_class c
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = $1 AND c.relname = $2`, schema, rel)
+		if err := row.Scan(&n); err != nil && err != sql.ErrNoRows {
+			return 0, err
+		}
+		sizes[key] = n
+		return n, nil
+	}
+
+	enabled := func(q *Query, name string) bool {
+		return !contains(settings.Disable, name) && !contains(q.VetDisable, name)
+	}
+	custom := compileRules(settings, true)
+	var errs []VetErr
+	for i, q := range r.Queries {
+		if q.Stmt == nil {
+			continue
+		}
+		var rules []string
+		for _, name := range explainRules {
+			if enabled(q, name) {
+				rules = append(rules, name)
+			}
+		}
+		var checks []customRule
+		for _, rule := range custom {
+			if enabled(q, rule.Name) {
+				checks = append(checks, rule)
+			}
+		}
+		if len(rules) == 0 && len(checks) == 0 {
+			continue
+		}
+		plan, err := explain(db, q, i)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", q.Filename, q.Name, err)
+		}
+		found, err := vetPlan(q, plan, rules, tableRows, *settings.Explain)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, found...)
+		for _, rule := range checks {
+			if msg := rule.check(q, &plan); msg != "" {
+				errs = append(errs, VetErr{
+					Filename: q.Filename,
+					Query:    q.Name,
+					Rule:     rule.Name,
+					Message:  msg,
+				})
+			}
+		}
+	}
+	return errs, nil
+}
+
+// planEnv returns the `explain` variable of custom rules: the total cost and
+// estimated rows of the plan, the types of its nodes and the tables it scans
+// sequentially
+func planEnv(plan planNode) map[string]interface{} {
+	nodes, scans := []interface{}{}, []interface{}{}
+	var walk func(n planNode)
+	walk = func(n planNode) {
+		nodes = append(nodes, n.NodeType)
+		if n.NodeType == "Seq Scan" {
+			scans = append(scans, n.Relation)
+		}
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	walk(plan)
+	return map[string]interface{}{
+		"cost":      plan.TotalCost,
+		"rows":      plan.PlanRows,
+		"nodes":     nodes,
+		"seq_scans": scans,
+	}
+}
+
+// explain returns the generic plan for q. Each query is prepared under its
+// own name, as a statement prepared in a failed transaction stays prepared.
+func explain(db *sql.DB, q *Query, id int) (planNode, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return planNode{}, err
+	}
+	defer tx.Rollback()
+
+	name := fmt.Sprintf("sqlc_vet_%d", id)
+	if _, err := tx.Exec("SET LOCAL plan_cache_mode = force_generic_plan"); err != nil {
+		return planNode{}, err
+	}
+	if _, err := tx.Exec(fmt.Sprintf("PREPARE %s AS %s", name, q.SQL)); err != nil {
+		return planNode{}, err
+	}
+	var params int
+	for _, p := range q.Params {
+		if p.Number > params {
+			params = p.Number
+		}
+	}
+	args := strings.TrimSuffix(strings.Repeat("NULL, ", params), ", ")
+	execute := name
+	if params > 0 {
+		execute += "(" + args + ")"
+	}
+
+	var blob []byte
+	if err := tx.QueryRow("EXPLAIN (FORMAT JSON, VERBOSE) EXECUTE " + execute).Scan(&blob); err != nil {
+		return planNode{}, err
+	}
+	if _, err := tx.Exec("DEALLOCATE " + name); err != nil {
+		return planNode{}, err
+	}
+	return parsePlan(blob)
+}
+
+func parsePlan(blob []byte) (planNode, error) {
+	var plans []struct {
+		Plan planNode `json:"Plan"`
+	}
+	if err := json.Unmarshal(blob, &plans); err != nil {
+		return planNode{}, fmt.Errorf("invalid EXPLAIN output: %w", err)
+	}
+	if len(plans) != 1 {
+		return planNode{}, fmt.Errorf("invalid EXPLAIN output: expected one plan, found %d", len(plans))
+	}
+	return plans[0].Plan, nil
+}
+
+// vetPlan checks the plan of q against the named rules. tableRows returns the
+// estimated number of rows in a table.
+func vetPlan(q *Query, plan planNode, rules []string, tableRows func(schema, rel string) (float64, error), settings config.VetExplain) ([]VetErr, error) {
+	var errs []VetErr
+	report := func(rule, msg string) {
+		errs = append(errs, VetErr{
+			Filename: q.Filename,
+			Query:    q.Name,
+			Rule:     rule,
+			Message:  msg,
+		})
+	}
+	if contains(rules, "max-cost") && settings.MaxCost > 0 && plan.TotalCost > settings.MaxCost {
+		report("max-cost", fmt.Sprintf("plan costs %.2f, more than the limit of %g", plan.TotalCost, settings.MaxCost))
+	}
+	if contains(rules, "max-rows") && settings.MaxRows > 0 && plan.PlanRows > settings.MaxRows {
+		report("max-rows", fmt.Sprintf("plan estimates %.0f rows, more than the limit of %g", plan.PlanRows, settings.MaxRows))
+	}
+	if !contains(rules, "seq-scan") {
+		return errs, nil
+	}
+
+	min := settings.SeqScanRows
+	if min == 0 {
+		min = config.DefaultVetSeqScanRows
+	}
+	var scans []string
+	var walk func(n planNode) error
+	walk = func(n planNode) error {
+		if n.NodeType == "Seq Scan" {
+			rows, err := tableRows(n.Schema, n.Relation)
+			if err != nil {
+				return err
+			}
+			scan := fmt.Sprintf("%s (about %.0f rows)", n.Relation, rows)
+			if rows >= min && !contains(scans, scan) {
+				scans = append(scans, scan)
+			}
+		}
+		for _, child := range n.Plans {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(plan); err != nil {
+		return nil, err
+	}
+	if len(scans) > 0 {
+		report("seq-scan", fmt.Sprintf("plan scans every row of %s", strings.Join(scans, ", ")))
+	}
+	return errs, nil
+}
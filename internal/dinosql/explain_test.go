@@ -0,0 +1,94 @@
+package dinosql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+const explainOutput = `[
+  {
+    "Plan": {
+      "Node Type": "Hash Join",
+      "Total Cost": 2150.5,
+      "Plan Rows": 48000,
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Relation Name": "books",
+          "Schema": "public",
+          "Total Cost": 1800.0,
+          "Plan Rows": 48000
+        },
+        {
+          "Node Type": "Hash",
+          "Total Cost": 40.0,
+          "Plan Rows": 1200,
+          "Plans": [
+            {
+              "Node Type": "Seq Scan",
+              "Relation Name": "authors",
+              "Schema": "public",
+              "Total Cost": 40.0,
+              "Plan Rows": 1200
+            }
+          ]
+        }
+      ]
+    }
+  }
+]`
+
+func TestVetPlan(t *testing.T) {
+	plan, err := parsePlan([]byte(explainOutput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := map[string]float64{"public.books": 48000, "public.authors": 1200}
+	tableRows := func(schema, rel string) (float64, error) {
+		return rows[schema+"."+rel], nil
+	}
+	q := &Query{Name: "ListBooks", Filename: "query.sql"}
+	settings := config.VetExplain{Database: "postgres://", MaxCost: 1000, MaxRows: 50000}
+
+	errs, err := vetPlan(q, plan, explainRules, tableRows, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	expected := []string{
+		"query.sql: ListBooks: plan costs 2150.50, more than the limit of 1000 (max-cost)",
+		"query.sql: ListBooks: plan scans every row of books (about 48000 rows) (seq-scan)",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("vet errors differed (-want +got):\n%s", diff)
+	}
+
+	// Disabled rules and smaller tables aren't reported
+	settings.SeqScanRows = 1000
+	errs, err = vetPlan(q, plan, []string{"max-rows", "seq-scan"}, tableRows, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = nil
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	expected = []string{
+		"query.sql: ListBooks: plan scans every row of books (about 48000 rows), authors (about 1200 rows) (seq-scan)",
+	}
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("vet errors differed (-want +got):\n%s", diff)
+	}
+}
+
+func TestParsePlanInvalid(t *testing.T) {
+	if _, err := parsePlan([]byte(`[]`)); err == nil {
+		t.Error("expected an error for output without a plan")
+	}
+}
@@ -0,0 +1,122 @@
+package dinosql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// GoFactory holds the test helpers generated for a table by
+// `emit_test_factories`
+type GoFactory struct {
+	Name      string
+	Struct    GoStruct
+	Values    []GoFactoryValue
+	Table     string
+	Columns   []string
+	Returning []string
+	Arg       GoQueryValue
+	Ret       GoQueryValue
+}
+
+// A GoFactoryValue is the value a factory gives a struct field
+type GoFactoryValue struct {
+	Field string
+	Value string
+}
+
+func (f GoFactory) ConstantName() string {
+	return "insert" + f.Name + "ForTest"
+}
+
+// Insert returns the INSERT statement run by the factory. The table and
+// column names are quoted where PostgreSQL would otherwise misread them.
+func (f GoFactory) Insert() string {
+	sql := "INSERT INTO " + f.Table
+	if len(f.Columns) == 0 {
+		sql += " DEFAULT VALUES"
+	} else {
+		sql += " (" + quoteIdents(f.Columns) + ") VALUES (" + placeholders(1, len(f.Columns)) + ")"
+	}
+	return sql + " RETURNING " + quoteIdents(f.Returning)
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = core.QuoteIdent(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// factories returns a factory for each struct which models a table in c.
+// Factories fill in NOT NULL columns with a value the database accepts, where
+// the Go type allows it, and insert every column without a default.
+func factories(c core.Catalog, structs []GoStruct, enums []GoEnum, settings config.CombinedSettings) []GoFactory {
+	qualifier := ModelsQualifier(settings)
+	firstValues := map[string]string{}
+	for _, enum := range enums {
+		if len(enum.Constants) > 0 {
+			firstValues[qualifier+enum.Name] = qualifier + enum.Constants[0].Name
+		}
+	}
+
+	var out []GoFactory
+	for i := range structs {
+		s := structs[i]
+		table, ok := c.Schemas[s.Table.Schema].Tables[s.Table.Rel]
+		if !ok || len(table.Columns) != len(s.Fields) {
+			continue
+		}
+		f := GoFactory{
+			Name:   strings.TrimPrefix(s.Name, qualifier),
+			Struct: s,
+			Table:  core.QuoteIdent(s.Table.Rel),
+			Ret:    GoQueryValue{Name: "i", Struct: &s},
+		}
+		if s.Table.Schema != "public" {
+			f.Table = core.QuoteIdent(s.Table.Schema) + "." + f.Table
+		}
+		inserted := GoStruct{Name: s.Name}
+		for i, col := range table.Columns {
+			field := s.Fields[i]
+			f.Returning = append(f.Returning, col.Name)
+			if col.HasDefault || isSerial(col.DataType) {
+				continue
+			}
+			f.Columns = append(f.Columns, col.Name)
+			inserted.Fields = append(inserted.Fields, field)
+			if !col.NotNull {
+				continue
+			}
+			if v := factoryValue(col, field.Type, firstValues); v != "" {
+				f.Values = append(f.Values, GoFactoryValue{Field: field.Name, Value: v})
+			}
+		}
+		f.Arg = GoQueryValue{Name: "arg", Struct: &inserted}
+		out = append(out, f)
+	}
+	return out
+}
+
+// factoryValue returns a value for a NOT NULL column, or an empty string if
+// the zero value of the Go type will do
+func factoryValue(col core.Column, typ string, firstValues map[string]string) string {
+	if v, ok := firstValues[typ]; ok {
+		return v
+	}
+	switch {
+	case typ == "string":
+		return strconv.Quote(col.Name)
+	case typ == "json.RawMessage":
+		return `[]byte("{}")`
+	case typ == "[]byte":
+		return "[]byte{}"
+	case strings.HasPrefix(typ, "[]") && !strings.Contains(typ, "."):
+		// Nil slices are NULL, so use an empty array instead
+		return typ + "{}"
+	}
+	return ""
+}
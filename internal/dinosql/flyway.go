@@ -0,0 +1,99 @@
+package dinosql
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Flyway migrations are named V<version>__<description>.sql, with undo
+// migrations starting with U, baselines with B and repeatable migrations
+// named R__<description>.sql
+var flywayName = regexp.MustCompile(`^(?:([VUB])(\d+(?:[._]\d+)*)|R)__.+\.sql$`)
+
+type flywayFile struct {
+	name    string
+	prefix  string
+	version []string
+}
+
+// compareVersions compares two Flyway versions part by part, numerically
+func compareVersions(a, b []string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y string
+		if i < len(a) {
+			x = strings.TrimLeft(a[i], "0")
+		}
+		if i < len(b) {
+			y = strings.TrimLeft(b[i], "0")
+		}
+		if len(x) != len(y) {
+			if len(x) < len(y) {
+				return -1
+			}
+			return 1
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// flywayOrder returns files in the order Flyway applies them, if they are all
+// Flyway migrations. Versioned migrations run in version order, starting from
+// the latest baseline, followed by repeatable migrations in order of their
+// description. Undo migrations are left out. Other files are returned as is.
+func flywayOrder(files []string) []string {
+	var migrations []flywayFile
+	for _, name := range files {
+		m := flywayName.FindStringSubmatch(filepath.Base(name))
+		if m == nil {
+			return files
+		}
+		f := flywayFile{name: name, prefix: m[1]}
+		if f.prefix == "" {
+			f.prefix = "R"
+		} else {
+			f.version = strings.FieldsFunc(m[2], func(r rune) bool { return r == '.' || r == '_' })
+		}
+		migrations = append(migrations, f)
+	}
+
+	var baseline []string
+	for _, f := range migrations {
+		if f.prefix == "B" && compareVersions(f.version, baseline) > 0 {
+			baseline = f.version
+		}
+	}
+	var versioned, repeatable []flywayFile
+	for _, f := range migrations {
+		switch {
+		case f.prefix == "R":
+			repeatable = append(repeatable, f)
+		case f.prefix == "U":
+		case baseline == nil && f.prefix == "V":
+			versioned = append(versioned, f)
+		case baseline != nil && f.prefix == "B" && compareVersions(f.version, baseline) == 0:
+			versioned = append(versioned, f)
+		case baseline != nil && f.prefix == "V" && compareVersions(f.version, baseline) > 0:
+			versioned = append(versioned, f)
+		}
+	}
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return compareVersions(versioned[i].version, versioned[j].version) < 0
+	})
+	sort.SliceStable(repeatable, func(i, j int) bool {
+		return filepath.Base(repeatable[i].name) < filepath.Base(repeatable[j].name)
+	})
+
+	var ordered []string
+	for _, f := range append(versioned, repeatable...) {
+		ordered = append(ordered, f.name)
+	}
+	return ordered
+}
@@ -0,0 +1,671 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// Keywords which are always upper cased. Words which are often used as
+// names, such as name and type, aren't included, and a few more are only
+// keywords in context; see keyword.
+var fmtKeywords = map[string]bool{}
+
+func init() {
+	for _, kw := range strings.Fields(`
+		ADD ALL ALTER ALWAYS AND ANY ARRAY AS ASC BETWEEN BY CASCADE CASE CAST
+		CHECK COALESCE COLLATE COLUMN CONCURRENTLY CONFLICT CONSTRAINT CREATE
+		CROSS CURRENT_DATE CURRENT_TIME CURRENT_TIMESTAMP CURRENT_USER DEFAULT
+		DEFERRABLE DEFERRED DELETE DESC DISTINCT DO DROP ELSE END ENUM EXCEPT
+		EXISTS EXTENSION EXTRACT FALSE FETCH FIRST FOR FOREIGN FROM FULL
+		FUNCTION GENERATED GRANT GREATEST GROUP HAVING IDENTITY IF ILIKE
+		IMMEDIATE IN INDEX INITIALLY INNER INSERT INTERSECT INTO IS ISNULL JOIN
+		LAST LATERAL LEAST LIKE LIMIT MATERIALIZED NATURAL NOT NOTHING NOTNULL
+		NULL NULLIF NULLS OFFSET ON ONLY OR ORDER OUTER OVER PARTITION PRIMARY
+		RECURSIVE REFERENCES RENAME REPLACE RESTRICT RETURNING RETURNS SCHEMA
+		SELECT SEQUENCE SET SETOF SIMILAR SOME STORED TABLE TEMP TEMPORARY THEN
+		TO TRIGGER TRUE UNION UNIQUE UPDATE USING VALUES VIEW WHEN WHERE WINDOW
+		WITH
+	`) {
+		fmtKeywords[kw] = true
+	}
+}
+
+// Keywords written without a space before an opening parenthesis, like
+// function calls
+var fmtCallKeywords = map[string]bool{
+	"ANY": true, "ARRAY": true, "CAST": true, "COALESCE": true, "EXTRACT": true,
+	"GREATEST": true, "LEAST": true, "NULLIF": true, "SOME": true,
+}
+
+// Keywords after which a name is followed by a space and a parenthesized
+// list of columns, rather than being a function call. EXISTS, ON and USING
+// only count in CREATE statements.
+var fmtTableKeywords = map[string]bool{
+	"EXISTS": true, "INTO": true, "ON": true, "REFERENCES": true, "TABLE": true,
+	"USING": true, "VIEW": true,
+}
+
+// Keywords which are values rather than operators when followed by a + or -
+var fmtValueKeywords = map[string]bool{
+	"CURRENT_DATE": true, "CURRENT_TIME": true, "CURRENT_TIMESTAMP": true,
+	"CURRENT_USER": true, "END": true, "FALSE": true, "NULL": true, "TRUE": true,
+}
+
+// Words which start a statement and are upper cased there, such as BEGIN
+var fmtStatementKeywords = map[string]bool{
+	"BEGIN": true, "COMMENT": true, "COMMIT": true, "REVOKE": true,
+	"ROLLBACK": true, "TRUNCATE": true,
+}
+
+const (
+	sqlWord = iota
+	sqlQuoted
+	sqlString
+	sqlNumber
+	sqlParam
+	sqlOp
+	sqlPunct
+	sqlComment
+	sqlBlockComment
+)
+
+type sqlToken struct {
+	kind int
+	text string
+	// newlines counts the line breaks between the previous token and this one
+	newlines int
+	// keyword is set on written words which were upper cased
+	keyword bool
+}
+
+func (t sqlToken) is(text string) bool {
+	return (t.kind == sqlWord && strings.EqualFold(t.text, text)) || ((t.kind == sqlPunct || t.kind == sqlOp) && t.text == text)
+}
+
+func (t sqlToken) isComment() bool {
+	return t.kind == sqlComment || t.kind == sqlBlockComment
+}
+
+func isOpChar(c byte) bool {
+	return strings.IndexByte("+-*/<>=~!@#%^&|`?", c) >= 0
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentChar(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// lexSQL splits PostgreSQL source into tokens, keeping comments and the
+// exact text of literals and quoted identifiers
+func lexSQL(src string) ([]sqlToken, error) {
+	var toks []sqlToken
+	newlines := 0
+	add := func(kind int, text string) {
+		toks = append(toks, sqlToken{kind: kind, text: text, newlines: newlines})
+		newlines = 0
+	}
+	// quoted returns the end of the string or identifier starting at i
+	quoted := func(i int, quote byte, backslash bool) (int, error) {
+		for j := i + 1; j < len(src); j++ {
+			switch {
+			case backslash && src[j] == '\\':
+				j++
+			case src[j] == quote && j+1 < len(src) && src[j+1] == quote:
+				j++
+			case src[j] == quote:
+				return j + 1, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated %c", quote)
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		r := rune(c)
+		if c >= 0x80 {
+			r = []rune(src[i:])[0]
+		}
+		switch {
+		case c == '\n':
+			newlines++
+			i++
+		case unicode.IsSpace(r):
+			i += len(string(r))
+		case strings.HasPrefix(src[i:], "--"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			add(sqlComment, strings.TrimRightFunc(src[i:i+end], unicode.IsSpace))
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			depth, j := 0, i
+			for ; j < len(src); j++ {
+				if strings.HasPrefix(src[j:], "/*") {
+					depth++
+					j++
+				} else if strings.HasPrefix(src[j:], "*/") {
+					depth--
+					j++
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if depth != 0 {
+				return nil, errors.New("unterminated comment")
+			}
+			add(sqlBlockComment, src[i:j+1])
+			i = j + 1
+		case c == '\'':
+			end, err := quoted(i, '\'', false)
+			if err != nil {
+				return nil, err
+			}
+			add(sqlString, src[i:end])
+			i = end
+		case (c == 'e' || c == 'E') && i+1 < len(src) && src[i+1] == '\'':
+			end, err := quoted(i+1, '\'', true)
+			if err != nil {
+				return nil, err
+			}
+			add(sqlString, src[i:end])
+			i = end
+		case (c == 'b' || c == 'B' || c == 'x' || c == 'X') && i+1 < len(src) && src[i+1] == '\'':
+			end, err := quoted(i+1, '\'', false)
+			if err != nil {
+				return nil, err
+			}
+			add(sqlString, src[i:end])
+			i = end
+		case c == '"':
+			end, err := quoted(i, '"', false)
+			if err != nil {
+				return nil, err
+			}
+			add(sqlQuoted, src[i:end])
+			i = end
+		case c == '$' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1])):
+			j := i + 1
+			for j < len(src) && unicode.IsDigit(rune(src[j])) {
+				j++
+			}
+			add(sqlParam, src[i:j])
+			i = j
+		case c == '$':
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			if j >= len(src) || src[j] != '$' {
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+			tag := src[i : j+1]
+			end := strings.Index(src[j+1:], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated %s string", tag)
+			}
+			end += j + 1 + len(tag)
+			add(sqlString, src[i:end])
+			i = end
+		case unicode.IsDigit(r) || (c == '.' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			j := i
+			for j < len(src) && (unicode.IsDigit(rune(src[j])) || src[j] == '.') {
+				if src[j] == '.' && j+1 < len(src) && src[j+1] == '.' {
+					break
+				}
+				j++
+			}
+			if j < len(src) && (src[j] == 'e' || src[j] == 'E') {
+				k := j + 1
+				if k < len(src) && (src[k] == '+' || src[k] == '-') {
+					k++
+				}
+				if k < len(src) && unicode.IsDigit(rune(src[k])) {
+					for j = k; j < len(src) && unicode.IsDigit(rune(src[j])); j++ {
+					}
+				}
+			}
+			add(sqlNumber, src[i:j])
+			i = j
+		case isIdentStart(r):
+			j := i
+			for j < len(src) {
+				r, size := rune(src[j]), 1
+				if src[j] >= 0x80 {
+					r = []rune(src[j:])[0]
+					size = len(string(r))
+				}
+				if !isIdentChar(r) {
+					break
+				}
+				j += size
+			}
+			add(sqlWord, src[i:j])
+			i = j
+		case strings.HasPrefix(src[i:], "::"):
+			add(sqlPunct, "::")
+			i += 2
+		case strings.IndexByte("(),;[].:", c) >= 0:
+			add(sqlPunct, string(c))
+			i++
+		case isOpChar(c):
+			j := i
+			for j < len(src) && isOpChar(src[j]) && !strings.HasPrefix(src[j:], "--") && !strings.HasPrefix(src[j:], "/*") {
+				j++
+			}
+			// As in PostgreSQL, an operator only ends in + or - if it
+			// contains one of the characters below, so =-1 is = and -1
+			op := src[i:j]
+			if !strings.ContainsAny(op, "~!@#%^&|`?") {
+				for len(op) > 1 && (op[len(op)-1] == '+' || op[len(op)-1] == '-') {
+					op = op[:len(op)-1]
+				}
+			}
+			add(sqlOp, op)
+			i += len(op)
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return toks, nil
+}
+
+// Layouts of statements
+const (
+	// Everything on one line
+	layoutLine = iota
+	// Each clause of a query on its own line, with subqueries indented
+	layoutQuery
+	// Each column and constraint of a CREATE TABLE on its own line
+	layoutTable
+)
+
+func statementLayout(stmt nodes.Node) int {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return layoutLine
+	}
+	switch raw.Stmt.(type) {
+	case nodes.SelectStmt, nodes.InsertStmt, nodes.UpdateStmt, nodes.DeleteStmt, nodes.ViewStmt:
+		return layoutQuery
+	case nodes.CreateStmt:
+		return layoutTable
+	}
+	return layoutLine
+}
+
+// Format returns a file of PostgreSQL statements in sqlc's standard layout.
+// Keywords are upper cased and other words are left as written. Queries put
+// each clause on its own line, with subqueries indented by two spaces, and
+// CREATE TABLE statements put each column and constraint on its own line.
+// Comments and up to one blank line between statements are kept.
+//
+// The layout of each statement depends on its type in the parse tree, and the
+// formatted file must parse to the same tree as src, so formatting never
+// changes the meaning of a file.
+func Format(src string) (string, error) {
+	tree, err := pg.Parse(src)
+	if err != nil {
+		return "", err
+	}
+	toks, err := lexSQL(src)
+	if err != nil {
+		return "", err
+	}
+
+	f := &formatter{}
+	stmts := splitStatements(toks)
+	n := 0
+	for _, stmt := range stmts {
+		if stmt.empty() {
+			continue
+		}
+		n++
+	}
+	mapped := n == len(tree.Statements)
+	for i, stmt := range stmts {
+		layout := layoutLine
+		if !stmt.empty() && mapped {
+			layout = statementLayout(tree.Statements[0])
+			tree.Statements = tree.Statements[1:]
+		}
+		if i > 0 {
+			f.b.WriteString("\n")
+			if stmt.blankBefore() {
+				f.b.WriteString("\n")
+			}
+		}
+		f.statement(stmt, layout)
+	}
+	out := f.b.String()
+	if out != "" {
+		out += "\n"
+	}
+
+	same, err := sameParseTree(src, out)
+	if err != nil {
+		return "", err
+	}
+	if !same {
+		return "", errors.New("formatting would change the meaning of the file; please report this as a bug")
+	}
+	return out, nil
+}
+
+type sqlStatement []sqlToken
+
+// empty reports whether a statement only holds comments
+func (s sqlStatement) empty() bool {
+	for _, t := range s {
+		if !t.isComment() && !t.is(";") {
+			return false
+		}
+	}
+	return true
+}
+
+func (s sqlStatement) blankBefore() bool {
+	return len(s) > 0 && s[0].newlines > 1
+}
+
+// splitStatements splits tokens after each semicolon. A comment on the same
+// line as the semicolon stays with the statement it ends.
+func splitStatements(toks []sqlToken) []sqlStatement {
+	var stmts []sqlStatement
+	var cur sqlStatement
+	for i := 0; i < len(toks); i++ {
+		cur = append(cur, toks[i])
+		if !toks[i].is(";") {
+			continue
+		}
+		for i+1 < len(toks) && toks[i+1].isComment() && toks[i+1].newlines == 0 {
+			i++
+			cur = append(cur, toks[i])
+		}
+		stmts = append(stmts, cur)
+		cur = nil
+	}
+	if len(cur) > 0 {
+		stmts = append(stmts, cur)
+	}
+	return stmts
+}
+
+// A formatContext is the statement or parenthesized expression being
+// formatted
+type formatContext struct {
+	layout int
+	indent int
+	// broken is true if the closing parenthesis goes on its own line
+	broken bool
+}
+
+type formatter struct {
+	b strings.Builder
+	// prev holds the tokens written in the current statement, without
+	// comments
+	prev      []sqlToken
+	lineStart bool
+	newline   bool
+	stack     []formatContext
+}
+
+func (f *formatter) last(n int) sqlToken {
+	if len(f.prev) < n {
+		return sqlToken{kind: -1}
+	}
+	return f.prev[len(f.prev)-n]
+}
+
+func (f *formatter) breakLine(indent int) {
+	if !f.lineStart {
+		f.b.WriteString("\n")
+	}
+	f.b.WriteString(strings.Repeat(" ", indent))
+	f.lineStart = true
+	f.newline = false
+}
+
+func (f *formatter) statement(stmt sqlStatement, layout int) {
+	f.prev = nil
+	f.stack = []formatContext{{layout: layout}}
+	f.lineStart = true
+	f.newline = false
+	for i, t := range stmt {
+		top := f.stack[len(f.stack)-1]
+		if t.isComment() {
+			switch {
+			case f.lineStart:
+			case t.newlines > 0:
+				// Blank lines between leading comments are kept
+				if len(f.prev) == 0 && t.newlines > 1 {
+					f.b.WriteString("\n")
+				}
+				f.breakLine(top.indent)
+			default:
+				f.b.WriteString(" ")
+			}
+			f.b.WriteString(t.text)
+			f.lineStart = false
+			if t.kind == sqlComment {
+				f.newline = true
+			}
+			continue
+		}
+		// Leading comments are separated from the statement by their blank
+		// lines
+		if len(f.prev) == 0 && i > 0 && t.newlines > 1 {
+			f.b.WriteString("\n")
+		}
+
+		var next sqlToken
+		for _, n := range stmt[i+1:] {
+			if !n.isComment() {
+				next = n
+				break
+			}
+		}
+
+		if t.kind == sqlWord && f.keyword(t, next) {
+			t.text = strings.ToUpper(t.text)
+			t.keyword = true
+		}
+		// A block comment on its own line stays there
+		if i > 0 && stmt[i-1].kind == sqlBlockComment && t.newlines > 0 {
+			f.newline = true
+		}
+
+		switch {
+		case t.is(")") && len(f.stack) > 1:
+			f.stack = f.stack[:len(f.stack)-1]
+			if top.broken {
+				f.breakLine(f.stack[len(f.stack)-1].indent)
+			} else if f.newline {
+				f.breakLine(top.indent)
+			}
+		case f.newline:
+			f.breakLine(top.indent)
+		case len(f.prev) > 0 && top.layout == layoutQuery && f.clause(t, next):
+			f.breakLine(top.indent)
+		case !f.lineStart && f.space(t):
+			f.b.WriteString(" ")
+		}
+		f.b.WriteString(t.text)
+		f.lineStart = false
+		f.prev = append(f.prev, t)
+
+		switch {
+		case t.is("("):
+			ctx := formatContext{layout: layoutLine, indent: top.indent}
+			switch {
+			case top.layout != layoutLine && (next.is("select") || next.is("with")):
+				ctx = formatContext{layout: layoutQuery, indent: top.indent + 2, broken: true}
+				f.newline = true
+			case top.layout == layoutTable && len(f.stack) == 1 && !next.is(")"):
+				ctx = formatContext{layout: layoutTable, indent: 2, broken: true}
+				f.newline = true
+			}
+			f.stack = append(f.stack, ctx)
+		case t.is(",") && top.layout == layoutTable && len(f.stack) == 2:
+			f.newline = true
+		}
+	}
+}
+
+// keyword reports whether a word is upper cased
+func (f *formatter) keyword(t, next sqlToken) bool {
+	upper := strings.ToUpper(t.text)
+	prev := f.last(1)
+	if prev.is(".") || next.is(".") {
+		// A qualified name, such as t.order
+		return false
+	}
+	switch upper {
+	case "LEFT", "RIGHT":
+		return next.is("join") || next.is("outer")
+	case "TYPE":
+		return prev.is("create") || prev.is("alter") || prev.is("drop") || f.last(2).is("column")
+	case "KEY":
+		return prev.is("primary") || prev.is("foreign")
+	case "ACTION":
+		return prev.is("no")
+	case "NO":
+		return next.is("action")
+	case "FILTER":
+		return prev.is(")") && next.is("(")
+	case "WITH", "WITHOUT":
+		// timestamp with time zone is left as written
+		if next.is("time") {
+			return false
+		}
+	}
+	if len(f.prev) == 0 && fmtStatementKeywords[upper] {
+		return true
+	}
+	return fmtKeywords[upper]
+}
+
+// clause reports whether t starts a clause of a query, which goes on a new
+// line
+func (f *formatter) clause(t, next sqlToken) bool {
+	if t.kind != sqlWord {
+		return false
+	}
+	prev := f.last(1)
+	switch strings.ToUpper(t.text) {
+	case "SELECT", "WHERE", "HAVING", "LIMIT", "OFFSET", "RETURNING", "WINDOW", "UNION", "INTERSECT", "EXCEPT", "SET", "INNER", "CROSS", "NATURAL":
+		return true
+	case "FROM":
+		return !prev.is("distinct") && !prev.is("delete")
+	case "GROUP", "ORDER":
+		return next.is("by")
+	case "VALUES":
+		return !prev.is("default")
+	case "JOIN":
+		for _, kw := range []string{"left", "right", "inner", "full", "cross", "natural", "outer"} {
+			if prev.is(kw) {
+				return false
+			}
+		}
+		return true
+	case "LEFT", "RIGHT", "FULL":
+		return next.is("join") || next.is("outer")
+	case "ON":
+		return next.is("conflict")
+	case "FOR":
+		return next.is("update") || next.is("share") || next.is("no")
+	}
+	return false
+}
+
+// space reports whether a space goes between the previous token and t
+func (f *formatter) space(t sqlToken) bool {
+	prev := f.last(1)
+	switch {
+	case prev.is("(") || prev.is("[") || prev.is(".") || prev.is("::"):
+		return false
+	case t.is(")") || t.is("]") || t.is(",") || t.is(";") || t.is(".") || t.is("::") || t.is(":") || prev.is(":"):
+		return false
+	case t.is("["):
+		return prev.kind != sqlWord && prev.kind != sqlQuoted && !prev.is(")") && !prev.is("]") && prev.kind != sqlParam
+	case t.is("("):
+		switch prev.kind {
+		case sqlWord:
+			if prev.keyword {
+				return !fmtCallKeywords[prev.text]
+			}
+			// A name followed by a column list, rather than a function
+			j := len(f.prev) - 1
+			for j >= 2 && f.prev[j-1].is(".") {
+				j -= 2
+			}
+			if j > 0 && f.prev[j-1].kind == sqlWord {
+				kw := strings.ToUpper(f.prev[j-1].text)
+				if (kw == "EXISTS" || kw == "ON" || kw == "USING") && !f.prev[0].is("create") {
+					return false
+				}
+				return fmtTableKeywords[kw]
+			}
+			return false
+		case sqlQuoted:
+			return true
+		}
+		return true
+	}
+	// Unary operators are written against their operand
+	if prev.kind == sqlOp && (prev.text == "-" || prev.text == "+" || prev.text == "~" || prev.text == "@") {
+		before := f.last(2)
+		switch {
+		case before.kind == -1, before.is("("), before.is("["), before.is(","), before.kind == sqlOp:
+			return false
+		case before.keyword && !fmtValueKeywords[before.text]:
+			return false
+		}
+	}
+	return true
+}
+
+// sameParseTree reports whether a and b parse to the same statements,
+// ignoring the locations of their nodes
+func sameParseTree(a, b string) (bool, error) {
+	var trees [2]interface{}
+	for i, src := range []string{a, b} {
+		blob, err := pg.ParseToJSON(src)
+		if err != nil {
+			return false, err
+		}
+		if err := json.Unmarshal([]byte(blob), &trees[i]); err != nil {
+			return false, err
+		}
+		trees[i] = withoutLocations(trees[i])
+	}
+	return reflect.DeepEqual(trees[0], trees[1]), nil
+}
+
+func withoutLocations(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if k == "location" || k == "stmt_location" || k == "stmt_len" {
+				delete(v, k)
+				continue
+			}
+			v[k] = withoutLocations(val)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = withoutLocations(v[i])
+		}
+	}
+	return v
+}
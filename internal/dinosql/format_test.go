@@ -0,0 +1,88 @@
+package dinosql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFormat(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			"query",
+			"-- name: GetAuthor :one\nselect * from authors\n  where id = $1 limit 1;\n",
+			"-- name: GetAuthor :one\nSELECT *\nFROM authors\nWHERE id = $1\nLIMIT 1;\n",
+		},
+		{
+			"blank lines between statements",
+			"-- name: A :exec\ndelete from a;\n\n\n-- name: B :exec\ndelete from b; -- all of them\n/* last */ delete from c;\n",
+			"-- name: A :exec\nDELETE FROM a;\n\n-- name: B :exec\nDELETE FROM b; -- all of them\n/* last */ DELETE FROM c;\n",
+		},
+		{
+			"joins and subqueries",
+			"SELECT a.id, count(*) filter (where b.id > -1) FROM authors a left join books b on b.author_id = a.id WHERE a.id in (select author_id from books where title ilike $1) group by a.id;",
+			`SELECT a.id, count(*) FILTER (WHERE b.id > -1)
+FROM authors a
+LEFT JOIN books b ON b.author_id = a.id
+WHERE a.id IN (
+  SELECT author_id
+  FROM books
+  WHERE title ILIKE $1
+)
+GROUP BY a.id;
+`,
+		},
+		{
+			"insert",
+			"insert into authors(name, bio) values ($1, $2) on conflict (name) do nothing returning *",
+			"INSERT INTO authors (name, bio)\nVALUES ($1, $2)\nON CONFLICT (name) DO NOTHING\nRETURNING *\n",
+		},
+		{
+			"named parameters and casts",
+			"UPDATE authors SET name = @name::text, tags = '{}'::text[] WHERE id = sqlc.arg(id);",
+			"UPDATE authors\nSET name = @name::text, tags = '{}'::text[]\nWHERE id = sqlc.arg(id);\n",
+		},
+		{
+			"create table",
+			"create table authors (id bigserial primary key, name text not null, created_at timestamp with time zone default now(), check (length(name) > 0));\ncreate index authors_name on authors using btree (lower(name));",
+			`CREATE TABLE authors (
+  id bigserial PRIMARY KEY,
+  name text NOT NULL,
+  created_at timestamp with time zone DEFAULT now(),
+  CHECK (length(name) > 0)
+);
+CREATE INDEX authors_name ON authors USING btree (lower(name));
+`,
+		},
+		{
+			"function bodies",
+			"CREATE FUNCTION f() returns integer AS $$\n  select 1\n$$ language sql;",
+			"CREATE FUNCTION f() RETURNS integer AS $$\n  select 1\n$$ language sql;\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			out, err := Format(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.out, out); diff != "" {
+				t.Errorf("formatted differently (-want +got):\n%s", diff)
+			}
+			again, err := Format(out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if again != out {
+				t.Errorf("formatting isn't stable:\n%s", again)
+			}
+		})
+	}
+
+	if _, err := Format("SELECT FROM WHERE;"); err == nil {
+		t.Error("expected an error for invalid SQL")
+	}
+}
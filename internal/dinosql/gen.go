@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"go/format"
 	"log"
+	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	"text/template"
+	"time"
 	"unicode"
 
 	"github.com/kyleconroy/sqlc/internal/catalog"
@@ -38,6 +41,12 @@ type GoField struct {
 	Type    string
 	Tags    map[string]string
 	Comment string
+
+	// JSON is true if the field is sent and scanned as JSON
+	JSON bool
+
+	// Column is the name of the column the field is scanned from
+	Column string
 }
 
 func (gf GoField) Tag() string {
@@ -49,7 +58,25 @@ func (gf GoField) Tag() string {
 		return ""
 	}
 	sort.Strings(tags)
-	return strings.Join(tags, ",")
+	return strings.Join(tags, " ")
+}
+
+// FieldTags returns the struct tags for a field generated from the column
+// `name`. JSON tags are only included if `emit_json_tags` is set. Tags listed
+// in `struct_tags` use the column name as their value, while extra contains
+// per-column tags from overrides.
+func FieldTags(name string, extra map[string]string, settings config.CombinedSettings) map[string]string {
+	tags := map[string]string{}
+	if settings.Go.EmitJSONTags {
+		tags["json:"] = JSONTagName(name, settings)
+	}
+	for _, key := range settings.Go.StructTags {
+		tags[key+":"] = name
+	}
+	for key, val := range extra {
+		tags[key+":"] = val
+	}
+	return tags
 }
 
 type GoStruct struct {
@@ -64,6 +91,15 @@ type GoQueryValue struct {
 	Name   string
 	Struct *GoStruct
 	Typ    string
+	JSON   bool
+
+	// Names holds the name of each argument when the fields of Struct are
+	// passed as separate arguments, instead of as a struct
+	Names []string
+}
+
+func (v GoQueryValue) isPositional() bool {
+	return len(v.Names) > 0
 }
 
 func (v GoQueryValue) EmitStruct() bool {
@@ -82,9 +118,45 @@ func (v GoQueryValue) Pair() string {
 	if v.isEmpty() {
 		return ""
 	}
+	if v.isPositional() {
+		var pairs []string
+		for i, f := range v.Struct.Fields {
+			pairs = append(pairs, v.Names[i]+" "+f.Type)
+		}
+		return strings.Join(pairs, ", ")
+	}
 	return v.Name + " " + v.Type()
 }
 
+// Args returns the arguments which pass the value on to another call
+func (v GoQueryValue) Args() string {
+	if v.isPositional() {
+		return strings.Join(v.Names, ", ")
+	}
+	return v.Name
+}
+
+// CallType returns the type which records a call with the value. Positional
+// arguments are recorded as an anonymous struct.
+func (v GoQueryValue) CallType() string {
+	if v.isPositional() {
+		var fields []string
+		for _, f := range v.Struct.Fields {
+			fields = append(fields, f.Name+" "+f.Type)
+		}
+		return "struct{ " + strings.Join(fields, "; ") + " }"
+	}
+	return v.Type()
+}
+
+// Call returns the value of CallType for the arguments
+func (v GoQueryValue) Call() string {
+	if v.isPositional() {
+		return v.CallType() + "{" + v.Args() + "}"
+	}
+	return v.Name
+}
+
 func (v GoQueryValue) Type() string {
 	if v.Typ != "" {
 		return v.Typ
@@ -101,17 +173,25 @@ func (v GoQueryValue) Params() string {
 	}
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
+		if v.JSON {
+			out = append(out, "jsonColumn{"+v.Name+"}")
+		} else if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
 			out = append(out, "pq.Array("+v.Name+")")
 		} else {
 			out = append(out, v.Name)
 		}
 	} else {
-		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
-				out = append(out, "pq.Array("+v.Name+"."+f.Name+")")
+		for i, f := range v.Struct.Fields {
+			name := v.Name + "." + f.Name
+			if v.isPositional() {
+				name = v.Names[i]
+			}
+			if f.JSON {
+				out = append(out, "jsonColumn{"+name+"}")
+			} else if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+				out = append(out, "pq.Array("+name+")")
 			} else {
-				out = append(out, v.Name+"."+f.Name)
+				out = append(out, name)
 			}
 		}
 	}
@@ -123,27 +203,62 @@ func (v GoQueryValue) Params() string {
 }
 
 func (v GoQueryValue) Scan() string {
+	out := v.scanTargets()
+	if len(out) <= 3 {
+		return strings.Join(out, ",")
+	}
+	out = append(out, "")
+	return "\n" + strings.Join(out, ",\n")
+}
+
+// ScanMap returns a map from the name of each column of the struct to the
+// pointer it's scanned into
+func (v GoQueryValue) ScanMap() string {
+	out := []string{"map[string]interface{}{"}
+	for i, target := range v.scanTargets() {
+		out = append(out, fmt.Sprintf("%q: %s,", v.Struct.Fields[i].Column, target))
+	}
+	return strings.Join(out, "\n") + "\n}"
+}
+
+// scansByName reports whether the columns of the struct can be scanned by
+// name, which needs each to have a distinct name
+func (v GoQueryValue) scansByName() bool {
+	if v.Struct == nil {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, f := range v.Struct.Fields {
+		if f.Column == "" || seen[f.Column] {
+			return false
+		}
+		seen[f.Column] = true
+	}
+	return true
+}
+
+func (v GoQueryValue) scanTargets() []string {
 	var out []string
 	if v.Struct == nil {
-		if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
+		if v.JSON {
+			out = append(out, "jsonColumn{&"+v.Name+"}")
+		} else if strings.HasPrefix(v.Typ, "[]") && v.Typ != "[]byte" {
 			out = append(out, "pq.Array(&"+v.Name+")")
 		} else {
 			out = append(out, "&"+v.Name)
 		}
 	} else {
 		for _, f := range v.Struct.Fields {
-			if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+			if f.JSON {
+				out = append(out, "jsonColumn{&"+v.Name+"."+f.Name+"}")
+			} else if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
 				out = append(out, "pq.Array(&"+v.Name+"."+f.Name+")")
 			} else {
 				out = append(out, "&"+v.Name+"."+f.Name)
 			}
 		}
 	}
-	if len(out) <= 3 {
-		return strings.Join(out, ",")
-	}
-	out = append(out, "")
-	return "\n" + strings.Join(out, ",\n")
+	return out
 }
 
 // A struct used to generate methods and fields on the Queries struct
@@ -157,6 +272,47 @@ type GoQuery struct {
 	SourceName   string
 	Ret          GoQueryValue
 	Arg          GoQueryValue
+
+	// ForEach is true if a callback based ForEach method is generated in
+	// addition to the :many method
+	ForEach bool
+
+	// Retries is the number of times the method runs the query again after
+	// it fails, set by the query's retries option
+	Retries int
+
+	// Timeout bounds each run of the query, set by the query's timeout
+	// option
+	Timeout time.Duration
+
+	// ScanByName is true if the columns of each row are matched to the
+	// fields of Ret by name, set by emit_scan_by_name
+	ScanByName bool
+}
+
+// TimeoutExpr returns the Go expression for the query's timeout, such as
+// 5 * time.Second
+func (q GoQuery) TimeoutExpr() string {
+	units := []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, unit := range units {
+		if q.Timeout%unit.d != 0 {
+			continue
+		}
+		if n := q.Timeout / unit.d; n != 1 {
+			return fmt.Sprintf("%d * %s", n, unit.name)
+		}
+		return unit.name
+	}
+	return fmt.Sprintf("%d", q.Timeout)
 }
 
 type Generateable interface {
@@ -165,10 +321,90 @@ type Generateable interface {
 	Enums(settings config.CombinedSettings) []GoEnum
 }
 
+// unusedFilter hides the model structs and enums which are not referenced by
+// any of the generated queries.
+type unusedFilter struct {
+	Generateable
+	structs []GoStruct
+	enums   []GoEnum
+}
+
+func (u *unusedFilter) Structs(settings config.CombinedSettings) []GoStruct {
+	return u.structs
+}
+
+func (u *unusedFilter) Enums(settings config.CombinedSettings) []GoEnum {
+	return u.enums
+}
+
+func omitUnusedStructs(r Generateable, settings config.CombinedSettings) Generateable {
+	queries := r.GoQueries(settings)
+
+	usedStructs := map[string]struct{}{}
+	usedTypes := map[string]struct{}{}
+	useValue := func(v GoQueryValue) {
+		if v.isEmpty() {
+			return
+		}
+		usedTypes[strings.TrimLeft(v.Type(), "[]*")] = struct{}{}
+		if v.Struct == nil {
+			return
+		}
+		if !v.Emit {
+			usedStructs[v.Struct.Name] = struct{}{}
+		}
+		for _, f := range v.Struct.Fields {
+			usedTypes[strings.TrimLeft(f.Type, "[]*")] = struct{}{}
+		}
+	}
+	for _, q := range queries {
+		useValue(q.Arg)
+		useValue(q.Ret)
+	}
+
+	f := &unusedFilter{Generateable: r}
+	for _, s := range r.Structs(settings) {
+		if _, ok := usedStructs[s.Name]; ok {
+			f.structs = append(f.structs, s)
+		}
+	}
+	for _, e := range r.Enums(settings) {
+		_, used := usedTypes[e.Name]
+		_, usedNull := usedTypes["Null"+e.Name]
+		if used || usedNull {
+			f.enums = append(f.enums, e)
+		}
+	}
+	return f
+}
+
+// IsNullType reports whether typ is one of the wrapper types, such as
+// sql.NullString, that are replaced by pointers when
+// emit_pointers_for_null_types is set.
+func IsNullType(typ string) bool {
+	return strings.HasPrefix(typ, "sql.Null") || typ == "pq.NullTime" || strings.HasPrefix(typ, "Null")
+}
+
+// usesCmd reports whether any of the queries use the given command.
+func usesCmd(queries []GoQuery, cmd string) bool {
+	for _, q := range queries {
+		if q.Cmd == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// baseType strips the slice and pointer prefixes from a Go type, so that
+// "[]*time.Time" matches the "time.Time" import check.
+func baseType(typ string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(typ, "[]"), "*")
+}
+
 func UsesType(r Generateable, typ string, settings config.CombinedSettings) bool {
 	for _, strct := range r.Structs(settings) {
 		for _, f := range strct.Fields {
-			fType := strings.TrimPrefix(f.Type, "[]")
+			fType := baseType(f.Type)
 			if strings.HasPrefix(fType, typ) {
 				return true
 			}
@@ -177,6 +413,67 @@ func UsesType(r Generateable, typ string, settings config.CombinedSettings) bool
 	return false
 }
 
+// usesModelsType reports whether a model, parameter or result uses name, one
+// of the types declared with the models such as Inet
+func usesModelsType(r Generateable, name string, settings config.CombinedSettings) bool {
+	typ := ModelsQualifier(settings) + name
+	uses := func(s *GoStruct) bool {
+		for _, f := range s.Fields {
+			if baseType(f.Type) == typ {
+				return true
+			}
+		}
+		return false
+	}
+	for _, s := range r.Structs(settings) {
+		if uses(&s) {
+			return true
+		}
+	}
+	for _, q := range r.GoQueries(settings) {
+		for _, v := range []GoQueryValue{q.Arg, q.Ret} {
+			if v.isEmpty() {
+				continue
+			}
+			if baseType(v.Type()) == typ || (v.Struct != nil && uses(v.Struct)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// modelsTypeColumns names the columns each of the types declared with the
+// models is generated for
+var modelsTypeColumns = map[string]string{
+	"Inet":     "inet and cidr",
+	"MacAddr":  "macaddr",
+	"Duration": "interval",
+}
+
+// checkModelsTypes returns an error if a model or enum has the name of one of
+// the types declared with the models which r uses, as the name would then be
+// declared twice
+func checkModelsTypes(r Generateable, settings config.CombinedSettings) error {
+	for _, name := range []string{"Inet", "MacAddr", "Duration"} {
+		if !usesModelsType(r, name, settings) {
+			continue
+		}
+		typ := ModelsQualifier(settings) + name
+		for _, s := range r.Structs(settings) {
+			if s.Name == typ {
+				return fmt.Errorf("the struct for table %s is named %s, which is the type generated for %s columns; rename the struct", s.Table.String(), name, modelsTypeColumns[name])
+			}
+		}
+		for _, e := range r.Enums(settings) {
+			if e.Name == typ {
+				return fmt.Errorf("enum %s has the name of the type generated for %s columns; rename the enum", name, modelsTypeColumns[name])
+			}
+		}
+	}
+	return nil
+}
+
 func UsesArrays(r Generateable, settings config.CombinedSettings) bool {
 	for _, strct := range r.Structs(settings) {
 		for _, f := range strct.Fields {
@@ -234,29 +531,79 @@ func Imports(r Generateable, settings config.CombinedSettings) func(string) [][]
 			return mergeImports(interfaceImports(r, settings))
 		}
 
+		if filename == "proto.go" {
+			return mergeImports(protoImports(r, settings))
+		}
+
+		if filename == "dataloaders.go" {
+			imps := fileImports{Std: []string{"context", "database/sql", "sync"}}
+			if settings.Go.OutputModelsPackage != "" {
+				imps.Dep = []string{settings.Go.OutputModelsPackage}
+			}
+			return mergeImports(imps)
+		}
+
+		if filename == "factories.go" {
+			return mergeImports(factoryImports(r, settings))
+		}
+
+		if filename == "querier_fake.go" {
+			imps := interfaceImports(r, settings)
+			imps.Std = append(imps.Std, "sync")
+			sort.Strings(imps.Std)
+			return mergeImports(imps)
+		}
+
 		return mergeImports(queryImports(r, settings, filename))
 	}
 }
 
 func dbImports(r Generateable, settings config.CombinedSettings) fileImports {
 	std := []string{"context", "database/sql"}
-	if settings.Go.EmitPreparedQueries {
+	queries := r.GoQueries(settings)
+	usesJSON := usesJSONColumns(queries)
+	if usesJSON {
+		std = append(std, "database/sql/driver", "encoding/json")
+	}
+	if settings.Go.EmitPreparedQueries || usesJSON || usesScanByName(queries) {
 		std = append(std, "fmt")
 	}
+	if settings.Go.EmitStatementCache {
+		std = append(std, "sync")
+	}
+	if settings.Go.EmitHooks || settings.Go.EmitMetrics || usesRetries(r.GoQueries(settings)) {
+		std = append(std, "time")
+	}
 	return fileImports{Std: std}
 }
 
+func usesRetries(queries []GoQuery) bool {
+	for _, q := range queries {
+		if q.Retries > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func interfaceImports(r Generateable, settings config.CombinedSettings) fileImports {
 	gq := r.GoQueries(settings)
 	uses := func(name string) bool {
 		for _, q := range gq {
 			if !q.Ret.isEmpty() {
-				if strings.HasPrefix(q.Ret.Type(), name) {
+				if strings.HasPrefix(baseType(q.Ret.Type()), name) {
 					return true
 				}
 			}
 			if !q.Arg.isEmpty() {
-				if strings.HasPrefix(q.Arg.Type(), name) {
+				if q.Arg.isPositional() {
+					for _, f := range q.Arg.Struct.Fields {
+						if strings.HasPrefix(baseType(f.Type), name) {
+							return true
+						}
+					}
+				}
+				if strings.HasPrefix(baseType(q.Arg.Type()), name) {
 					return true
 				}
 			}
@@ -267,6 +614,9 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	std := map[string]struct{}{
 		"context": struct{}{},
 	}
+	if usesCmd(gq, ":iter") {
+		std["iter"] = struct{}{}
+	}
 	if uses("sql.Null") {
 		std["database/sql"] = struct{}{}
 	}
@@ -276,20 +626,14 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	if uses("time.Time") {
 		std["time"] = struct{}{}
 	}
-	if uses("net.IP") {
-		std["net"] = struct{}{}
-	}
-	if uses("net.HardwareAddr") {
-		std["net"] = struct{}{}
-	}
 
 	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
-		overrideTypes[o.GoTypeName] = o.GoPackage
+		overrideTypes[baseType(o.GoTypeName)] = o.GoPackage
 	}
 
 	_, overrideNullTime := overrideTypes["pq.NullTime"]
@@ -300,6 +644,10 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	if uses("uuid.UUID") && !overrideUUID {
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
+	_, overrideHstore := overrideTypes["hstore.Hstore"]
+	if uses("hstore.Hstore") && !overrideHstore {
+		pkg["github.com/lib/pq/hstore"] = struct{}{}
+	}
 
 	// Custom imports
 	for goType, importPath := range overrideTypes {
@@ -308,6 +656,10 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 		}
 	}
 
+	if q := ModelsQualifier(settings); q != "" && uses(q) {
+		pkg[settings.Go.OutputModelsPackage] = struct{}{}
+	}
+
 	pkgs := make([]string, 0, len(pkg))
 	for p, _ := range pkg {
 		pkgs = append(pkgs, p)
@@ -323,6 +675,65 @@ func interfaceImports(r Generateable, settings config.CombinedSettings) fileImpo
 	return fileImports{stds, pkgs}
 }
 
+func factoryImports(r Generateable, settings config.CombinedSettings) fileImports {
+	imps := fileImports{Std: []string{"context"}}
+	for _, s := range r.Structs(settings) {
+		if usesSlice(s) {
+			imps.Dep = append(imps.Dep, "github.com/lib/pq")
+			break
+		}
+	}
+	if settings.Go.OutputModelsPackage != "" && len(r.Structs(settings)) > 0 {
+		imps.Dep = append(imps.Dep, settings.Go.OutputModelsPackage)
+	}
+	sort.Strings(imps.Dep)
+	return imps
+}
+
+func protoImports(r Generateable, settings config.CombinedSettings) fileImports {
+	structs := r.Structs(settings)
+	_, helpers, timestamp := protoMessages(structs, r.GoQueries(settings), r.Enums(settings), settings)
+	std := map[string]struct{}{}
+	pkg := map[string]struct{}{settings.Go.ProtoGoPackage: struct{}{}}
+	for _, h := range helpers {
+		switch {
+		case strings.HasPrefix(h.GoType, "sql."):
+			std["database/sql"] = struct{}{}
+		case h.GoType == "*time.Time":
+			std["time"] = struct{}{}
+		}
+	}
+	if timestamp {
+		pkg["google.golang.org/protobuf/types/known/timestamppb"] = struct{}{}
+	}
+	if usesWrappers(helpers) {
+		pkg["google.golang.org/protobuf/types/known/wrapperspb"] = struct{}{}
+	}
+	if settings.Go.OutputModelsPackage != "" && len(structs) > 0 {
+		pkg[settings.Go.OutputModelsPackage] = struct{}{}
+	}
+
+	var imps fileImports
+	for s := range std {
+		imps.Std = append(imps.Std, s)
+	}
+	for p := range pkg {
+		imps.Dep = append(imps.Dep, p)
+	}
+	sort.Strings(imps.Std)
+	sort.Strings(imps.Dep)
+	return imps
+}
+
+func usesSlice(s GoStruct) bool {
+	for _, f := range s.Fields {
+		if strings.HasPrefix(f.Type, "[]") && f.Type != "[]byte" {
+			return true
+		}
+	}
+	return false
+}
+
 func modelImports(r Generateable, settings config.CombinedSettings) fileImports {
 	std := make(map[string]struct{})
 	if UsesType(r, "sql.Null", settings) {
@@ -334,24 +745,36 @@ func modelImports(r Generateable, settings config.CombinedSettings) fileImports
 	if UsesType(r, "time.Time", settings) {
 		std["time"] = struct{}{}
 	}
-	if UsesType(r, "net.IP", settings) {
-		std["net"] = struct{}{}
+	if len(r.Enums(settings)) > 0 {
+		std["fmt"] = struct{}{}
+		std["database/sql/driver"] = struct{}{}
+	}
+	for _, name := range []string{"Inet", "MacAddr"} {
+		if usesModelsType(r, name, settings) {
+			std["fmt"] = struct{}{}
+			std["database/sql/driver"] = struct{}{}
+			std["net"] = struct{}{}
+		}
 	}
-	if UsesType(r, "net.HardwareAddr", settings) {
-		std["net"] = struct{}{}
+	if usesModelsType(r, "Inet", settings) {
+		std["strings"] = struct{}{}
 	}
-	if len(r.Enums(settings)) > 0 {
+	if usesModelsType(r, "Duration", settings) {
 		std["fmt"] = struct{}{}
+		std["database/sql/driver"] = struct{}{}
+		std["strconv"] = struct{}{}
+		std["strings"] = struct{}{}
+		std["time"] = struct{}{}
 	}
 
 	// Custom imports
 	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
-		overrideTypes[o.GoTypeName] = o.GoPackage
+		overrideTypes[baseType(o.GoTypeName)] = o.GoPackage
 	}
 
 	_, overrideNullTime := overrideTypes["pq.NullTime"]
@@ -364,6 +787,11 @@ func modelImports(r Generateable, settings config.CombinedSettings) fileImports
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
 
+	_, overrideHstore := overrideTypes["hstore.Hstore"]
+	if UsesType(r, "hstore.Hstore", settings) && !overrideHstore {
+		pkg["github.com/lib/pq/hstore"] = struct{}{}
+	}
+
 	for goType, importPath := range overrideTypes {
 		if _, ok := std[importPath]; !ok && UsesType(r, goType, settings) {
 			pkg[importPath] = struct{}{}
@@ -405,26 +833,26 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 			if !q.Ret.isEmpty() {
 				if q.Ret.EmitStruct() {
 					for _, f := range q.Ret.Struct.Fields {
-						fType := strings.TrimPrefix(f.Type, "[]")
+						fType := baseType(f.Type)
 						if strings.HasPrefix(fType, name) {
 							return true
 						}
 					}
 				}
-				if strings.HasPrefix(q.Ret.Type(), name) {
+				if strings.HasPrefix(baseType(q.Ret.Type()), name) {
 					return true
 				}
 			}
 			if !q.Arg.isEmpty() {
-				if q.Arg.EmitStruct() {
+				if q.Arg.IsStruct() {
 					for _, f := range q.Arg.Struct.Fields {
-						fType := strings.TrimPrefix(f.Type, "[]")
+						fType := baseType(f.Type)
 						if strings.HasPrefix(fType, name) {
 							return true
 						}
 					}
 				}
-				if strings.HasPrefix(q.Arg.Type(), name) {
+				if strings.HasPrefix(baseType(q.Arg.Type()), name) {
 					return true
 				}
 			}
@@ -467,6 +895,14 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 	std := map[string]struct{}{
 		"context": struct{}{},
 	}
+	if usesCmd(gq, ":iter") {
+		std["iter"] = struct{}{}
+	}
+	for _, q := range gq {
+		if q.Timeout > 0 {
+			std["time"] = struct{}{}
+		}
+	}
 	if uses("sql.Null") {
 		std["database/sql"] = struct{}{}
 	}
@@ -476,17 +912,14 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 	if uses("time.Time") {
 		std["time"] = struct{}{}
 	}
-	if uses("net.IP") {
-		std["net"] = struct{}{}
-	}
 
 	pkg := make(map[string]struct{})
 	overrideTypes := map[string]string{}
 	for _, o := range settings.Overrides {
-		if o.GoBasicType {
+		if o.GoBasicType || o.GoTypeName == "" {
 			continue
 		}
-		overrideTypes[o.GoTypeName] = o.GoPackage
+		overrideTypes[baseType(o.GoTypeName)] = o.GoPackage
 	}
 
 	if sliceScan() {
@@ -500,6 +933,10 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 	if uses("uuid.UUID") && !overrideUUID {
 		pkg["github.com/google/uuid"] = struct{}{}
 	}
+	_, overrideHstore := overrideTypes["hstore.Hstore"]
+	if uses("hstore.Hstore") && !overrideHstore {
+		pkg["github.com/lib/pq/hstore"] = struct{}{}
+	}
 
 	// Custom imports
 	for goType, importPath := range overrideTypes {
@@ -508,6 +945,10 @@ func queryImports(r Generateable, settings config.CombinedSettings, filename str
 		}
 	}
 
+	if q := ModelsQualifier(settings); q != "" && uses(q) {
+		pkg[settings.Go.OutputModelsPackage] = struct{}{}
+	}
+
 	pkgs := make([]string, 0, len(pkg))
 	for p, _ := range pkg {
 		pkgs = append(pkgs, p)
@@ -535,18 +976,60 @@ func enumValueName(value string) string {
 	return name
 }
 
+// EnumValueRename returns the entry in enum_value_rename for value, a member
+// of the enum enumName, keyed by "enum.value" or just "value"
+func EnumValueRename(enumName, value string, settings config.CombinedSettings) (string, bool) {
+	if rename, ok := settings.Go.EnumValueRename[enumName+"."+value]; ok {
+		return rename, true
+	}
+	rename, ok := settings.Go.EnumValueRename[value]
+	return rename, ok
+}
+
+// EnumConstantName returns the name of the constant generated for value,
+// a member of the SQL enum enumName whose Go type is typeName. Entries in
+// enum_value_rename are used as-is.
+func EnumConstantName(typeName, enumName, value string, settings config.CombinedSettings) string {
+	if rename, ok := EnumValueRename(enumName, value, settings); ok {
+		return rename
+	}
+	if settings.Go.EnumValueStyle != config.EnumValueStylePascal {
+		return typeName + enumValueName(value)
+	}
+	name := ""
+	parts := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, part := range parts {
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+		name += strings.Title(part)
+	}
+	if name == "" {
+		name = "Empty"
+	}
+	return typeName + name
+}
+
+// Enums returns an enum for each enum type in the catalog, sorted by name.
+// Enums with the same name keep the order of their schemas' names, and the
+// constants keep the order of the enum's values.
 func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 	var enums []GoEnum
-	for name, schema := range r.Catalog.Schemas {
+	for _, name := range r.Catalog.SchemaNames() {
+		schema := r.Catalog.Schemas[name]
 		if name == "pg_catalog" {
 			continue
 		}
 		for _, enum := range schema.Enums() {
-			var enumName string
+			var enumName, sqlName string
 			if name == "public" {
 				enumName = enum.Name
+				sqlName = enum.Name
 			} else {
 				enumName = name + "_" + enum.Name
+				sqlName = name + "." + enum.Name
 			}
 			e := GoEnum{
 				Name:    StructName(enumName, settings),
@@ -554,7 +1037,7 @@ func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 			}
 			for _, v := range enum.Vals {
 				e.Constants = append(e.Constants, GoConstant{
-					Name:  e.Name + enumValueName(v),
+					Name:  EnumConstantName(e.Name, sqlName, v, settings),
 					Value: v,
 					Type:  e.Name,
 				})
@@ -563,7 +1046,7 @@ func (r Result) Enums(settings config.CombinedSettings) []GoEnum {
 		}
 	}
 	if len(enums) > 0 {
-		sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+		sort.SliceStable(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	}
 	return enums
 }
@@ -572,10 +1055,14 @@ func StructName(name string, settings config.CombinedSettings) string {
 	if rename := settings.Rename[name]; rename != "" {
 		return rename
 	}
+	initialisms := settings.Go.Initialisms
+	if initialisms == nil {
+		initialisms = []string{"id"}
+	}
 	out := ""
 	for _, p := range strings.Split(name, "_") {
-		if p == "id" {
-			out += "ID"
+		if isInitialism(p, initialisms) {
+			out += strings.ToUpper(p)
 		} else {
 			out += strings.Title(p)
 		}
@@ -583,13 +1070,111 @@ func StructName(name string, settings config.CombinedSettings) string {
 	return out
 }
 
+// FieldName returns the name of the struct field generated for a column of
+// table in schema. Renames keyed by "table.column" or "schema.table.column"
+// take precedence over renames of the column name alone.
+func FieldName(schema, table, column string, settings config.CombinedSettings) string {
+	if table != "" {
+		if schema != "" && schema != "public" {
+			if rename := settings.Rename[schema+"."+table+"."+column]; rename != "" {
+				return rename
+			}
+		}
+		if rename := settings.Rename[table+"."+column]; rename != "" {
+			return rename
+		}
+	}
+	return StructName(column, settings)
+}
+
+func isInitialism(part string, initialisms []string) bool {
+	for _, initialism := range initialisms {
+		if strings.ToLower(initialism) == part {
+			return true
+		}
+	}
+	return false
+}
+
+// ModelsQualifier returns the prefix used to refer to the generated models,
+// e.g. "models." when `output_models_package` is set, and an empty string
+// otherwise. The package is named by `output_models_package_name`, or else
+// the last element of its import path.
+func ModelsQualifier(settings config.CombinedSettings) string {
+	if settings.Go.OutputModelsPackage == "" {
+		return ""
+	}
+	if name := settings.Go.OutputModelsPackageName; name != "" {
+		return name + "."
+	}
+	return path.Base(settings.Go.OutputModelsPackage) + "."
+}
+
+// ModelName returns the name of the struct generated for a table. Entries in
+// the `rename` map are used verbatim. Otherwise the table name is converted to
+// a struct name and singularized, unless `emit_exact_table_names` is set.
+func ModelName(tableName string, settings config.CombinedSettings) string {
+	if rename := settings.Rename[tableName]; rename != "" {
+		return rename
+	}
+	name := StructName(tableName, settings)
+	if settings.Go.EmitExactTableNames {
+		return name
+	}
+	return inflection.Singular(name)
+}
+
+// JSONTagName returns the JSON struct tag name for a column, converted to the
+// configured `json_tags_case_style`. By default the column name is used as-is.
+func JSONTagName(name string, settings config.CombinedSettings) string {
+	style := settings.Go.JSONTagsCaseStyle
+	if style == "" || style == config.JSONTagsCaseStyleNone {
+		return name
+	}
+	if style == config.JSONTagsCaseStyleSnake {
+		return toSnakeCase(name)
+	}
+	out := ""
+	for i, p := range strings.Split(toSnakeCase(name), "_") {
+		switch {
+		case p == "id" && settings.Go.JSONTagsIDUppercase && (i > 0 || style == config.JSONTagsCaseStylePascal):
+			out += "ID"
+		case i == 0 && style == config.JSONTagsCaseStyleCamel:
+			out += p
+		default:
+			out += strings.Title(p)
+		}
+	}
+	return out
+}
+
+func toSnakeCase(name string) string {
+	var out []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && !unicode.IsUpper(runes[i-1]) {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// Structs returns a struct for each table in the catalog, sorted by name.
+// Structs with the same name keep the order of their schema and table names,
+// and the fields keep the order of the table's columns.
 func (r Result) Structs(settings config.CombinedSettings) []GoStruct {
 	var structs []GoStruct
-	for name, schema := range r.Catalog.Schemas {
+	for _, name := range r.Catalog.SchemaNames() {
+		schema := r.Catalog.Schemas[name]
 		if name == "pg_catalog" {
 			continue
 		}
-		for _, table := range schema.Tables {
+		for _, rel := range schema.TableNames() {
+			table := schema.Tables[rel]
 			var tableName string
 			if name == "public" {
 				tableName = table.Name
@@ -598,55 +1183,122 @@ func (r Result) Structs(settings config.CombinedSettings) []GoStruct {
 			}
 			s := GoStruct{
 				Table:   core.FQN{Schema: name, Rel: table.Name},
-				Name:    inflection.Singular(StructName(tableName, settings)),
+				Name:    ModelsQualifier(settings) + ModelName(tableName, settings),
 				Comment: table.Comment,
 			}
 			for _, column := range table.Columns {
 				s.Fields = append(s.Fields, GoField{
-					Name:    StructName(column.Name, settings),
+					Name:    FieldName(name, table.Name, column.Name, settings),
 					Type:    r.goType(column, settings),
-					Tags:    map[string]string{"json:": column.Name},
+					Tags:    FieldTags(column.Name, columnTags(column, settings), settings),
 					Comment: column.Comment,
+					JSON:    jsonColumn(column, settings),
+					Column:  column.Name,
 				})
 			}
 			structs = append(structs, s)
 		}
 	}
 	if len(structs) > 0 {
-		sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+		sort.SliceStable(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
 	}
 	return structs
 }
 
-func (r Result) goType(col core.Column, settings config.CombinedSettings) string {
-	// package overrides have a higher precedence
+// jsonColumn reports whether an override with json set maps col to a Go
+// type, which is unmarshaled from the column's JSON
+func jsonColumn(col core.Column, settings config.CombinedSettings) bool {
 	for _, oride := range settings.Overrides {
-		if oride.Column != "" && oride.ColumnName == col.Name && oride.Table == col.Table {
-			return oride.GoTypeName
+		if oride.JSON && oride.ColumnName == col.Name && oride.Table == col.Table {
+			return true
 		}
 	}
-	typ := r.goInnerType(col, settings)
-	if col.IsArray {
-		return "[]" + typ
-	}
-	return typ
+	return false
 }
 
-func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) string {
-	columnType := col.DataType
-	notNull := col.NotNull || col.IsArray
-
-	// package overrides have a higher precedence
-	for _, oride := range settings.Overrides {
-		if oride.DBType != "" && oride.DBType == columnType && oride.Null != notNull {
-			return oride.GoTypeName
+// usesScanByName reports whether any query scans its rows by column name
+func usesScanByName(queries []GoQuery) bool {
+	for _, q := range queries {
+		if q.ScanByName {
+			return true
 		}
 	}
+	return false
+}
 
-	switch columnType {
-	case "serial", "pg_catalog.serial4":
-		if notNull {
-			return "int32"
+// usesJSONColumns reports whether any query sends or scans a column as JSON
+func usesJSONColumns(queries []GoQuery) bool {
+	for _, q := range queries {
+		for _, v := range []GoQueryValue{q.Arg, q.Ret} {
+			if v.JSON {
+				return true
+			}
+			if v.Struct == nil {
+				continue
+			}
+			for _, f := range v.Struct.Fields {
+				if f.JSON {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// columnTags returns the struct tags configured for a specific column
+func columnTags(col core.Column, settings config.CombinedSettings) map[string]string {
+	tags := map[string]string{}
+	for _, oride := range settings.Overrides {
+		if oride.Column != "" && oride.ColumnName == col.Name && oride.Table == col.Table {
+			for key, val := range oride.GoStructTags {
+				tags[key] = val
+			}
+		}
+	}
+	return tags
+}
+
+func (r Result) goType(col core.Column, settings config.CombinedSettings) string {
+	// package overrides have a higher precedence
+	for _, oride := range settings.Overrides {
+		if oride.GoTypeName == "" {
+			continue
+		}
+		if oride.Column != "" && oride.ColumnName == col.Name && oride.Table == col.Table {
+			return oride.GoTypeName
+		}
+	}
+	typ := r.goInnerType(col, settings)
+	if settings.Go.EmitPointersForNullTypes && !col.NotNull && !col.IsArray && IsNullType(strings.TrimPrefix(typ, ModelsQualifier(settings))) {
+		nonNull := col
+		nonNull.NotNull = true
+		typ = "*" + r.goInnerType(nonNull, settings)
+	}
+	if col.IsArray {
+		return "[]" + typ
+	}
+	return typ
+}
+
+func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) string {
+	columnType := col.DataType
+	notNull := col.NotNull || col.IsArray
+
+	// package overrides have a higher precedence
+	for _, oride := range settings.Overrides {
+		if oride.GoTypeName == "" {
+			continue
+		}
+		if oride.DBType != "" && oride.DBType == columnType && oride.Null != notNull {
+			return oride.GoTypeName
+		}
+	}
+
+	switch columnType {
+	case "serial", "pg_catalog.serial4":
+		if notNull {
+			return "int32"
 		}
 		return "sql.NullInt32"
 
@@ -708,38 +1360,116 @@ func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) s
 	case "bytea", "blob", "pg_catalog.bytea":
 		return "[]byte"
 
+	case "pg_catalog.bit", "pg_catalog.varbit", "bit", "varbit":
+		// Bit strings are returned as text of 0s and 1s, such as 0101
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
 	case "date":
 		if notNull {
 			return "time.Time"
 		}
 		return "sql.NullTime"
 
-	case "pg_catalog.time", "pg_catalog.timetz":
+	case "pg_catalog.time", "pg_catalog.timetz", "time":
 		if notNull {
 			return "time.Time"
 		}
 		return "sql.NullTime"
 
-	case "pg_catalog.timestamp", "pg_catalog.timestamptz", "timestamptz":
+	case "pg_catalog.timestamp", "pg_catalog.timestamptz", "timestamp", "timestamptz":
 		if notNull {
 			return "time.Time"
 		}
 		return "sql.NullTime"
 
+	case "pg_catalog.interval", "interval":
+		if settings.Go.IntervalType == config.IntervalDuration {
+			if notNull {
+				return ModelsQualifier(settings) + "Duration"
+			}
+			return "*" + ModelsQualifier(settings) + "Duration"
+		}
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
 	case "text", "pg_catalog.varchar", "pg_catalog.bpchar", "string":
 		if notNull {
 			return "string"
 		}
 		return "sql.NullString"
 
+	case "tsvector", "tsquery", "regconfig":
+		// Text search values are sent and returned as text, such as
+		// 'fat' & 'rat'
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "xml":
+		// XML documents are returned as text. Arrays, such as the results of
+		// xpath, are always scanned as strings, as pq.Array would take
+		// [][]byte to be an array of bytea.
+		if settings.Go.XMLType == config.XMLTypeBytes && !col.IsArray {
+			return "[]byte"
+		}
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange",
+		"int4multirange", "int8multirange", "nummultirange", "tsmultirange", "tstzmultirange", "datemultirange":
+		// Ranges are sent and returned as text, such as [1,10)
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "money":
+		// Money is returned as text in the format of the database's locale,
+		// such as $1,000.00
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "name", "char":
+		// The types of system catalog columns, such as pg_class.relname
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "oid":
+		if notNull {
+			return "uint32"
+		}
+		return "sql.NullInt64"
+
+	case "regclass", "regtype", "regproc", "regprocedure", "regnamespace", "regrole":
+		// OID aliases are returned as the name of the object, such as
+		// public.users for a regclass
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
 	case "uuid":
 		return "uuid.UUID"
 
-	case "inet":
-		return "net.IP"
+	case "inet", "cidr":
+		// lib/pq returns network addresses as text, so they're parsed by
+		// types declared with the models
+		return ModelsQualifier(settings) + "Inet"
 
 	case "macaddr", "macaddr8":
-		return "net.HardwareAddr"
+		return ModelsQualifier(settings) + "MacAddr"
 
 	case "ltree", "lquery", "ltxtquery":
 		// This module implements a data type ltree for representing labels
@@ -752,6 +1482,34 @@ func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) s
 		}
 		return "sql.NullString"
 
+	case "citext":
+		// A case-insensitive string, which compares equal whatever the case
+		// of its letters
+		//
+		// https://www.postgresql.org/docs/current/citext.html
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
+	case "hstore":
+		// Values are sql.NullStrings, as hstore values may be NULL. A NULL
+		// hstore scans as a nil map, so there's no separate null type.
+		//
+		// https://www.postgresql.org/docs/current/hstore.html
+		return "hstore.Hstore"
+
+	case "geometry", "geography":
+		// PostGIS returns spatial values as hex-encoded EWKB, and accepts
+		// them as WKT or EWKB. Packages such as github.com/twpayne/go-geom
+		// provide types which can be used as overrides.
+		//
+		// https://postgis.net/docs/using_postgis_dbmanagement.html
+		if notNull {
+			return "string"
+		}
+		return "sql.NullString"
+
 	case "void":
 		// A void value always returns NULL. Since there is no built-in NULL
 		// value into the SQL package, we'll use sql.NullBool
@@ -775,16 +1533,37 @@ func (r Result) goInnerType(col core.Column, settings config.CombinedSettings) s
 				switch t := typ.(type) {
 				case core.Enum:
 					if fqn.Rel == t.Name && fqn.Schema == name {
-						if name == "public" {
-							return StructName(t.Name, settings)
+						enumName := t.Name
+						if name != "public" {
+							enumName = name + "_" + t.Name
 						}
-						return StructName(name+"_"+t.Name, settings)
+						if notNull {
+							return ModelsQualifier(settings) + StructName(enumName, settings)
+						}
+						return ModelsQualifier(settings) + "Null" + StructName(enumName, settings)
 					}
 				case core.CompositeType:
-					if notNull {
-						return "string"
+					if fqn.Rel == t.Name && fqn.Schema == name {
+						if notNull {
+							return "string"
+						}
+						return "sql.NullString"
+					}
+				case core.Domain:
+					// Domains use the Go type of their base type
+					if fqn.Rel == t.Name && fqn.Schema == name {
+						base := core.Column{DataType: t.BaseType, NotNull: col.NotNull, IsArray: col.IsArray}
+						if t.IsArray && !col.IsArray {
+							base.IsArray = true
+							return "[]" + r.goInnerType(base, settings)
+						}
+						return r.goInnerType(base, settings)
+					}
+				case core.BaseType:
+					// Extension types installed outside public
+					if fqn.Rel == t.Name && fqn.Schema == name {
+						return r.goInnerType(core.Column{DataType: t.Name, NotNull: col.NotNull, IsArray: col.IsArray}, settings)
 					}
-					return "sql.NullString"
 				}
 			}
 		}
@@ -799,6 +1578,28 @@ type goColumn struct {
 	core.Column
 }
 
+// FindStruct returns the first struct in structs with the same fields as s, or
+// nil if there is none.
+func FindStruct(structs []*GoStruct, s *GoStruct) *GoStruct {
+	for _, other := range structs {
+		if len(other.Fields) != len(s.Fields) {
+			continue
+		}
+		same := true
+		for i, f := range other.Fields {
+			g := s.Fields[i]
+			if f.Name != g.Name || f.Type != g.Type || f.Tag() != g.Tag() {
+				same = false
+				break
+			}
+		}
+		if same {
+			return other
+		}
+	}
+	return nil
+}
+
 // It's possible that this method will generate duplicate JSON tag values
 //
 //   Columns: count, count,   count_2
@@ -814,7 +1615,7 @@ func (r Result) columnsToStruct(name string, columns []goColumn, settings config
 	suffixes := map[int]int{}
 	for i, c := range columns {
 		tagName := c.Name
-		fieldName := StructName(columnName(c.Column, i), settings)
+		fieldName := FieldName(c.Table.Schema, c.Table.Rel, columnName(c.Column, i), settings)
 		// Track suffixes by the ID of the column, so that columns referring to the same numbered parameter can be
 		// reused.
 		suffix := 0
@@ -829,9 +1630,11 @@ func (r Result) columnsToStruct(name string, columns []goColumn, settings config
 			fieldName = fmt.Sprintf("%s_%d", fieldName, suffix)
 		}
 		gs.Fields = append(gs.Fields, GoField{
-			Name: fieldName,
-			Type: r.goType(c.Column, settings),
-			Tags: map[string]string{"json:": tagName},
+			Name:   fieldName,
+			Type:   r.goType(c.Column, settings),
+			Tags:   FieldTags(tagName, columnTags(c.Column, settings), settings),
+			JSON:   jsonColumn(c.Column, settings),
+			Column: c.Name,
 		})
 		seen[c.Name]++
 	}
@@ -852,6 +1655,37 @@ func argName(name string) string {
 	return out
 }
 
+// QueryParameterLimit returns the number of parameters a query method takes
+// as separate arguments. Queries with more take a params struct.
+func QueryParameterLimit(settings config.CombinedSettings) int {
+	if settings.Go.QueryParameterLimit != nil {
+		return *settings.Go.QueryParameterLimit
+	}
+	return 1
+}
+
+// ParamsStructName returns the name of the params struct of a query method
+func ParamsStructName(method string, settings config.CombinedSettings) string {
+	if settings.Go.ParamsStructSuffix != "" {
+		return method + settings.Go.ParamsStructSuffix
+	}
+	return method + "Params"
+}
+
+// PositionalArgs returns a value passing the fields of s as separate
+// arguments with the given names. Repeated names are numbered, as in id and
+// id_2.
+func PositionalArgs(names []string, s *GoStruct) GoQueryValue {
+	seen := map[string]int{}
+	for i, name := range names {
+		seen[name]++
+		if seen[name] > 1 {
+			names[i] = fmt.Sprintf("%s_%d", name, seen[name])
+		}
+	}
+	return GoQueryValue{Struct: s, Names: names}
+}
+
 func paramName(p Parameter) string {
 	if p.Column.Name != "" {
 		return argName(p.Column.Name)
@@ -878,6 +1712,7 @@ func compareFQN(a *core.FQN, b *core.FQN) bool {
 
 func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 	structs := r.Structs(settings)
+	var rows []*GoStruct
 
 	qs := make([]GoQuery, 0, len(r.Queries))
 	for _, query := range r.Queries {
@@ -896,15 +1731,30 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			SourceName:   query.Filename,
 			SQL:          query.SQL,
 			Comments:     query.Comments,
+			Retries:      query.Options.Retries,
+			Timeout:      query.Options.Timeout,
 		}
 
-		if len(query.Params) == 1 {
+		limit := QueryParameterLimit(settings)
+		if len(query.Params) == 1 && limit > 0 {
 			p := query.Params[0]
 			gq.Arg = GoQueryValue{
 				Name: paramName(p),
 				Typ:  r.goType(p.Column, settings),
+				JSON: jsonColumn(p.Column, settings),
+			}
+		} else if len(query.Params) > 1 && len(query.Params) <= limit {
+			var names []string
+			var cols []goColumn
+			for _, p := range query.Params {
+				names = append(names, paramName(p))
+				cols = append(cols, goColumn{
+					id:     p.Number,
+					Column: p.Column,
+				})
 			}
-		} else if len(query.Params) > 1 {
+			gq.Arg = PositionalArgs(names, r.columnsToStruct("", cols, settings))
+		} else if len(query.Params) > 0 {
 			var cols []goColumn
 			for _, p := range query.Params {
 				cols = append(cols, goColumn{
@@ -915,7 +1765,7 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			gq.Arg = GoQueryValue{
 				Emit:   true,
 				Name:   "arg",
-				Struct: r.columnsToStruct(gq.MethodName+"Params", cols, settings),
+				Struct: r.columnsToStruct(ParamsStructName(gq.MethodName, settings), cols, settings),
 			}
 		}
 
@@ -924,6 +1774,7 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			gq.Ret = GoQueryValue{
 				Name: columnName(c, 0),
 				Typ:  r.goType(c, settings),
+				JSON: jsonColumn(c, settings),
 			}
 		} else if len(query.Columns) > 1 {
 			var gs *GoStruct
@@ -936,7 +1787,7 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 				same := true
 				for i, f := range s.Fields {
 					c := query.Columns[i]
-					sameName := f.Name == StructName(columnName(c, i), settings)
+					sameName := f.Name == FieldName(c.Table.Schema, c.Table.Rel, columnName(c, i), settings)
 					sameType := f.Type == r.goType(c, settings)
 					sameTable := s.Table.Catalog == c.Table.Catalog && s.Table.Schema == c.Table.Schema && s.Table.Rel == c.Table.Rel
 
@@ -960,6 +1811,13 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 				}
 				gs = r.columnsToStruct(gq.MethodName+"Row", columns, settings)
 				emit = true
+				if settings.Go.ReuseRowStructs {
+					if row := FindStruct(rows, gs); row != nil {
+						gs, emit = row, false
+					} else {
+						rows = append(rows, gs)
+					}
+				}
 			}
 			gq.Ret = GoQueryValue{
 				Emit:   emit,
@@ -968,15 +1826,25 @@ func (r Result) GoQueries(settings config.CombinedSettings) []GoQuery {
 			}
 		}
 
+		gq.ScanByName = settings.Go.EmitScanByName && gq.Ret.scansByName()
 		qs = append(qs, gq)
 	}
-	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
+	// Queries come in the order of their files and their place in them, so
+	// a stable sort settles ties the same way every time
+	sort.SliceStable(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
 	return qs
 }
 
 var templateSet = `
-{{define "dbFile"}}// Code generated by sqlc. DO NOT EDIT.
+{{define "header"}}
+{{- if .BuildTags}}//go:build {{.BuildTags}}
 
+{{end -}}
+// Code generated by sqlc. DO NOT EDIT.
+{{range .Header}}//{{if .}} {{.}}{{end}}
+{{end -}}
+{{end}}
+{{define "dbFile"}}{{template "header" .}}
 package {{.Package}}
 
 import (
@@ -990,19 +1858,207 @@ import (
 {{end}}
 
 {{define "dbCode"}}
-type DBTX interface {
+type {{.DBTX}} interface {
 	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
 	PrepareContext(context.Context, string) (*sql.Stmt, error)
 	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
 	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	{{- range .DBTXMethods}}
+	{{.}}
+	{{- end}}
 }
 
-func New(db DBTX) *Queries {
+func New(db {{.DBTX}}) *Queries {
 	return &Queries{db: db}
 }
 
+{{if .EmitStatementCache}}
+// StmtCache is a {{.DBTX}} which prepares each query the first time it is run and
+// reuses the prepared statement for later calls. The database/sql package
+// re-prepares statements on each connection as needed.
+type StmtCache struct {
+	db    {{.DBTX}}
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+func NewStmtCache(db {{.DBTX}}) *StmtCache {
+	return &StmtCache{db: db, stmts: map[string]*sql.Stmt{}}
+}
+
+func (c *StmtCache) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if stmt, ok := c.stmts[query]; ok {
+		return stmt, nil
+	}
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.stmts[query] = stmt
+	return stmt, nil
+}
+
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (c *StmtCache) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(ctx, query)
+}
+
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	stmt, err := c.stmt(ctx, query)
+	if err != nil {
+		// *sql.Row can't carry an error, so let the driver report it
+		return c.db.QueryRowContext(ctx, query, args...)
+	}
+	return stmt.QueryRowContext(ctx, args...)
+}
+
+// Tx returns a {{.DBTX}} which runs the cached statements inside tx.
+func (c *StmtCache) Tx(tx *sql.Tx) {{.DBTX}} {
+	return &txStmtCache{cache: c, tx: tx}
+}
+
+// Close closes every cached statement.
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	for query, stmt := range c.stmts {
+		if cerr := stmt.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		delete(c.stmts, query)
+	}
+	return err
+}
+
+type txStmtCache struct {
+	cache *StmtCache
+	tx    *sql.Tx
+}
+
+func (t *txStmtCache) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := t.cache.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return t.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+}
+
+func (t *txStmtCache) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *txStmtCache) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := t.cache.stmt(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return t.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+}
+
+func (t *txStmtCache) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	stmt, err := t.cache.stmt(ctx, query)
+	if err != nil {
+		return t.tx.QueryRowContext(ctx, query, args...)
+	}
+	return t.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+}
+{{end}}
+
+{{if .UsesScanByName}}
+// scanByName scans the current row of rows, matching each column to the
+// pointer of the same name in dest, which it empties. Columns without a
+// pointer are skipped, so a query may return new columns, but it's an error
+// for a pointer's column to be missing.
+func scanByName(rows *sql.Rows, dest map[string]interface{}) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		target, ok := dest[col]
+		if !ok {
+			target = new(interface{})
+		}
+		targets[i] = target
+		delete(dest, col)
+	}
+	for col := range dest {
+		return fmt.Errorf("column %q isn't returned by the query", col)
+	}
+	return rows.Scan(targets...)
+}
+
+// scanRowByName scans the first row of rows like scanByName, returning
+// sql.ErrNoRows if there isn't one, and closes rows.
+func scanRowByName(rows *sql.Rows, err error, dest map[string]interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := scanByName(rows, dest); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+{{end}}
+
+{{if .UsesJSONColumns}}
+// jsonColumn sends the value v points to as JSON, and unmarshals JSON into
+// it. It's used for the columns of overrides with json set.
+type jsonColumn struct {
+	v interface{}
+}
+
+// Scan implements the Scanner interface.
+func (c jsonColumn) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		return json.Unmarshal([]byte("null"), c.v)
+	case []byte:
+		return json.Unmarshal(s, c.v)
+	case string:
+		return json.Unmarshal([]byte(s), c.v)
+	}
+	return fmt.Errorf("unsupported scan type for JSON: %T", src)
+}
+
+// Value implements the driver Valuer interface. A nil value is sent as NULL.
+func (c jsonColumn) Value() (driver.Value, error) {
+	b, err := json.Marshal(c.v)
+	if err != nil || string(b) == "null" {
+		return nil, err
+	}
+	return string(b), nil
+}
+{{end}}
+
 {{if .EmitPreparedQueries}}
-func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+func Prepare(ctx context.Context, db {{.DBTX}}) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
 	{{- if eq (len .GoQueries) 0 }}
@@ -1063,7 +2119,19 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 {{end}}
 
 type Queries struct {
-	db DBTX
+	db {{.DBTX}}
+
+	{{- if .EmitHooks}}
+	hooks QueryHooks
+	{{- end}}
+
+	{{- if .EmitMetrics}}
+	metrics QueryMetrics
+	{{- end}}
+
+	{{- if .EmitInterceptors}}
+	interceptors []Interceptor
+	{{- end}}
 
     {{- if .EmitPreparedQueries}}
 	tx         *sql.Tx
@@ -1073,9 +2141,18 @@ type Queries struct {
 	{{- end}}
 }
 
-func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+func (q *Queries) WithTx(tx {{if .DBTXMethods}}{{.DBTX}}{{else}}*sql.Tx{{end}}) *Queries {
 	return &Queries{
 		db: tx,
+		{{- if .EmitHooks}}
+		hooks: q.hooks,
+		{{- end}}
+		{{- if .EmitMetrics}}
+		metrics: q.metrics,
+		{{- end}}
+		{{- if .EmitInterceptors}}
+		interceptors: q.interceptors,
+		{{- end}}
      	{{- if .EmitPreparedQueries}}
 		tx: tx,
 		{{- range .GoQueries}}
@@ -1084,30 +2161,340 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		{{- end}}
 	}
 }
-{{end}}
 
-{{define "interfaceFile"}}// Code generated by sqlc. DO NOT EDIT.
-
-package {{.Package}}
+{{if .EmitGenericHelpers}}
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
 
-import (
-	{{range imports .SourceName}}
-	{{range .}}"{{.}}"
-	{{end}}
-	{{end}}
+// queryMany scans every row returned by a query using scan and closes rows.
+func queryMany[T any](rows *sql.Rows, err error, scan func(scanner) (T, error)) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	{{- if .EmitEmptySlices}}
+	items := []T{}
+	{{- else}}
+	var items []T
+	{{- end}}
+	for rows.Next() {
+		i, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+{{end}}
+
+{{if .EmitRetries}}
+// retry calls fn until it succeeds or has been retried the given number of
+// times, waiting a little longer after each failure. sql.ErrNoRows isn't
+// retried, and nor is anything once ctx is done.
+func retry(ctx context.Context, retries int, fn func() error) error {
+	wait := 50 * time.Millisecond
+	for i := 0; ; i++ {
+		err := fn()
+		if err == nil || err == sql.ErrNoRows || i == retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+{{end}}
+
+{{if .EmitInterceptors}}
+// QueryInfo describes the query run by an Interceptor.
+type QueryInfo struct {
+	Name  string
+	Cmd   string
+	Query string
+}
+
+// Interceptor wraps the execution of a query. It runs the query by calling
+// next, and may call it more than once, e.g. to retry a serialization
+// failure, or with a different db to route the query to a replica.
+type Interceptor func(ctx context.Context, info QueryInfo, db {{.DBTX}}, next func(context.Context, {{.DBTX}}) error) error
+
+// WithInterceptors returns a copy of q which runs every query through
+// interceptors. The first interceptor is the outermost.
+func (q *Queries) WithInterceptors(interceptors ...Interceptor) *Queries {
+	intercepted := *q
+	intercepted.interceptors = append(append([]Interceptor{}, q.interceptors...), interceptors...)
+	return &intercepted
+}
+
+func (q *Queries) intercept(ctx context.Context, info QueryInfo, run func(context.Context, *Queries) error) error {
+	next := func(ctx context.Context, db {{.DBTX}}) error {
+		c := *q
+		{{- if .EmitPreparedQueries}}
+		if db != q.db {
+			// The statements of q were prepared on q.db, so run the query
+			// on db without them
+			c = Queries{
+				{{- if .EmitHooks}}
+				hooks: q.hooks,
+				{{- end}}
+				{{- if .EmitMetrics}}
+				metrics: q.metrics,
+				{{- end}}
+				interceptors: q.interceptors,
+			}
+		}
+		{{- end}}
+		c.db = db
+		return run(ctx, &c)
+	}
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := q.interceptors[i], next
+		next = func(ctx context.Context, db {{.DBTX}}) error {
+			return interceptor(ctx, info, db, inner)
+		}
+	}
+	return next(ctx, q.db)
+}
+{{end}}
+
+{{if .EmitHooks}}
+// QueryHooks is implemented by types that observe the queries executed by
+// Queries, e.g. to record tracing spans or log slow queries.
+type QueryHooks interface {
+	// BeforeQuery is called before a query is executed. The returned context
+	// is used to execute the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name, query string, args []interface{}) context.Context
+	// AfterQuery is called once a query has completed or failed.
+	AfterQuery(ctx context.Context, name, query string, args []interface{}, duration time.Duration, err error)
+}
+
+// WithHooks returns a copy of q which calls hooks around every query.
+func (q *Queries) WithHooks(hooks QueryHooks) *Queries {
+	hooked := *q
+	hooked.hooks = hooks
+	return &hooked
+}
+{{end}}
+
+{{if .EmitMetrics}}
+// QueryMetrics records the outcome of every query, e.g. as a counter and a
+// latency histogram labelled with the query name.
+type QueryMetrics interface {
+	ObserveQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// WithMetrics returns a copy of q which reports every query to metrics.
+func (q *Queries) WithMetrics(metrics QueryMetrics) *Queries {
+	measured := *q
+	measured.metrics = metrics
+	return &measured
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	{{- if .EmitHooks}}
+	if q.hooks == nil && q.metrics == nil {
+		return ctx, func(err error) error { return err }
+	}
+	if q.hooks != nil {
+		ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	}
+	{{- else}}
+	if q.metrics == nil {
+		return ctx, func(err error) error { return err }
+	}
+	{{- end}}
+	start := time.Now()
+	return ctx, func(err error) error {
+		duration := time.Since(start)
+		{{- if .EmitHooks}}
+		if q.hooks != nil {
+			q.hooks.AfterQuery(ctx, name, query, args, duration, err)
+		}
+		if q.metrics != nil {
+			q.metrics.ObserveQuery(ctx, name, duration, err)
+		}
+		{{- else}}
+		q.metrics.ObserveQuery(ctx, name, duration, err)
+		{{- end}}
+		return err
+	}
+}
+{{else if .EmitHooks}}
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.hooks == nil {
+		return ctx, func(err error) error { return err }
+	}
+	ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	start := time.Now()
+	return ctx, func(err error) error {
+		q.hooks.AfterQuery(ctx, name, query, args, time.Since(start), err)
+		return err
+	}
+}
+{{end}}
+{{end}}
+
+{{define "interceptMethod"}}func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) {{template "interceptResult" .}} {
+	{{- if eq .Cmd ":exec"}}
+	return q.intercept(ctx, QueryInfo{Name: "{{.MethodName}}", Cmd: "{{.Cmd}}", Query: {{.ConstantName}}}, func(ctx context.Context, q *Queries) error {
+		return q.{{lowerTitle .MethodName}}(ctx, {{.Arg.Args}})
+	})
+	{{- else}}
+	var result {{template "interceptResultType" .}}
+	err := q.intercept(ctx, QueryInfo{Name: "{{.MethodName}}", Cmd: "{{.Cmd}}", Query: {{.ConstantName}}}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.{{lowerTitle .MethodName}}(ctx, {{.Arg.Args}})
+		return err
+	})
+	return result, err
+	{{- end}}
+}
+
+{{end}}
+
+{{define "interceptForEach"}}func (q *Queries) ForEach{{.MethodName}}(ctx context.Context, {{if .Arg.Pair}}{{.Arg.Pair}}, {{end}}fn func({{.Ret.Type}}) error) error {
+	return q.intercept(ctx, QueryInfo{Name: "ForEach{{.MethodName}}", Cmd: "{{.Cmd}}", Query: {{.ConstantName}}}, func(ctx context.Context, q *Queries) error {
+		return q.forEach{{.MethodName}}(ctx, {{if .Arg.Args}}{{.Arg.Args}}, {{end}}fn)
+	})
+}
+
+{{end}}
+
+{{define "interceptIter"}}func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) iter.Seq2[{{.Ret.Type}}, error] {
+	return func(yield func({{.Ret.Type}}, error) bool) {
+		// Once yield returns false it mustn't be called again, even if an
+		// interceptor runs the query again
+		stopped := false
+		err := q.intercept(ctx, QueryInfo{Name: "{{.MethodName}}", Cmd: "{{.Cmd}}", Query: {{.ConstantName}}}, func(ctx context.Context, q *Queries) error {
+			var err error
+			q.{{lowerTitle .MethodName}}(ctx, {{.Arg.Args}})(func(row {{.Ret.Type}}, rowErr error) bool {
+				if rowErr != nil {
+					err = rowErr
+					return false
+				}
+				if stopped || !yield(row, nil) {
+					stopped = true
+					return false
+				}
+				return true
+			})
+			return err
+		})
+		if err != nil && !stopped {
+			var zero {{.Ret.Type}}
+			yield(zero, err)
+		}
+	}
+}
+
+{{end}}
+
+{{define "timeout"}}
+{{- if .Timeout}}
+	ctx, cancel := context.WithTimeout(ctx, {{.TimeoutExpr}})
+	defer cancel()
+{{- end}}
+{{- end}}
+
+{{define "retryMethod"}}func (q *Queries) {{.Name}}(ctx context.Context, {{.Arg.Pair}}) {{template "interceptResult" .GoQuery}} {
+	{{- if eq .Cmd ":exec"}}
+	return retry(ctx, {{.Retries}}, func() error {
+		return q.{{.Next}}(ctx, {{.Arg.Args}})
+	})
+	{{- else}}
+	var result {{template "interceptResultType" .GoQuery}}
+	err := retry(ctx, {{.Retries}}, func() error {
+		var err error
+		result, err = q.{{.Next}}(ctx, {{.Arg.Args}})
+		return err
+	})
+	return result, err
+	{{- end}}
+}
+
+{{end}}
+
+{{define "interceptResult"}}
+{{- if eq .Cmd ":exec"}}error{{else}}({{template "interceptResultType" .}}, error){{end}}
+{{- end}}
+
+{{define "interceptResultType"}}
+{{- if eq .Cmd ":one"}}{{.Ret.Type}}{{end}}
+{{- if eq .Cmd ":many"}}[]{{.Ret.Type}}{{end}}
+{{- if eq .Cmd ":execrows"}}int64{{end}}
+{{- end}}
+
+{{define "scanRows"}}
+{{- if .ScanByName}}scanByName(rows, {{.Ret.ScanMap}}){{else}}rows.Scan({{.Ret.Scan}}){{end}}
+{{- end}}
+
+{{define "scanFunc"}}
+func scan{{.MethodName}}(row scanner) ({{.Ret.Type}}, error) {
+	var {{.Ret.Name}} {{.Ret.Type}}
+	err := row.Scan({{.Ret.Scan}})
+	return {{.Ret.Name}}, err
+}
+{{end}}
+
+{{define "interfaceFile"}}{{template "header" .}}
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
 )
 
 {{template "interfaceCode" . }}
 {{end}}
 
 {{define "interfaceCode"}}
+{{- if .EmitFileInterfaces}}
+{{- range .QuerierGroups}}
+type {{.Name}} interface {
+	{{- template "interfaceMethods" .Queries}}
+}
+{{end}}
 type Querier interface {
-	{{- range .GoQueries}}
+	{{- range .QuerierGroups}}
+	{{.Name}}
+	{{- end}}
+}
+{{- else}}
+type Querier interface {
+	{{- template "interfaceMethods" .GoQueries}}
+}
+{{- end}}
+
+var _ Querier = (*Queries)(nil)
+{{end}}
+
+{{define "interfaceMethods"}}
+	{{- range .}}
+	{{- range .Comments}}
+	//{{.}}
+	{{- end}}
 	{{- if eq .Cmd ":one"}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error)
 	{{- end}}
 	{{- if eq .Cmd ":many"}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error)
+	{{- if .ForEach}}
+	ForEach{{.MethodName}}(ctx context.Context, {{if .Arg.Pair}}{{.Arg.Pair}}, {{end}}fn func({{.Ret.Type}}) error) error
+	{{- end}}
 	{{- end}}
 	{{- if eq .Cmd ":exec"}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error
@@ -1115,14 +2502,313 @@ type Querier interface {
 	{{- if eq .Cmd ":execrows"}}
 	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error)
 	{{- end}}
+	{{- if eq .Cmd ":iter"}}
+	{{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) iter.Seq2[{{.Ret.Type}}, error]
+	{{- end}}
+	{{- end}}
+{{- end}}
+
+{{define "fakeFile"}}{{template "header" .}}
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "fakeCode" . }}
+{{end}}
+
+{{define "fakeCode"}}
+// FakeQuerier is a fake implementation of the generated queries for use in
+// tests. Each method records its arguments and then calls the matching Func
+// field, returning zero values when the field is nil.
+type FakeQuerier struct {
+	mu sync.Mutex
+	{{range .GoQueries}}
+	{{- if eq .Cmd ":one"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error)
+	{{- end}}
+	{{- if eq .Cmd ":many"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error)
+	{{- end}}
+	{{- if eq .Cmd ":exec"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) error
+	{{- end}}
+	{{- if eq .Cmd ":execrows"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) (int64, error)
+	{{- end}}
+	{{- if eq .Cmd ":iter"}}
+	{{.MethodName}}Func func(ctx context.Context, {{.Arg.Pair}}) iter.Seq2[{{.Ret.Type}}, error]
+	{{- end}}
+	{{- if .Arg.Pair}}
+	{{.MethodName}}Calls []{{.Arg.CallType}}
+	{{- else}}
+	{{.MethodName}}Calls int
+	{{- end}}
+	{{end}}
+}
+
+{{if .EmitInterface}}
+var _ Querier = (*FakeQuerier)(nil)
+{{end}}
+
+{{range .GoQueries}}
+{{- if eq .Cmd ":one"}}
+func (f *FakeQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error) {
+	{{- template "fakeRecord" .}}
+	if fn == nil {
+		var zero {{.Ret.Type}}
+		return zero, nil
+	}
+	return fn(ctx, {{.Arg.Args}})
+}
+{{end}}
+{{- if eq .Cmd ":many"}}
+func (f *FakeQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error) {
+	{{- template "fakeRecord" .}}
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx, {{.Arg.Args}})
+}
+{{if .ForEach}}
+func (f *FakeQuerier) ForEach{{.MethodName}}(ctx context.Context, {{if .Arg.Pair}}{{.Arg.Pair}}, {{end}}fn func({{.Ret.Type}}) error) error {
+	items, err := f.{{.MethodName}}(ctx, {{.Arg.Args}})
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+{{end}}
+{{end}}
+{{- if eq .Cmd ":exec"}}
+func (f *FakeQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+	{{- template "fakeRecord" .}}
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, {{.Arg.Args}})
+}
+{{end}}
+{{- if eq .Cmd ":execrows"}}
+func (f *FakeQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	{{- template "fakeRecord" .}}
+	if fn == nil {
+		return 0, nil
+	}
+	return fn(ctx, {{.Arg.Args}})
+}
+{{end}}
+{{- if eq .Cmd ":iter"}}
+func (f *FakeQuerier) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) iter.Seq2[{{.Ret.Type}}, error] {
+	{{- template "fakeRecord" .}}
+	if fn == nil {
+		return func(yield func({{.Ret.Type}}, error) bool) {}
+	}
+	return fn(ctx, {{.Arg.Args}})
+}
+{{end}}
+{{- end}}
+{{end}}
+
+{{define "fakeRecord"}}
+	f.mu.Lock()
+	{{- if .Arg.Pair}}
+	f.{{.MethodName}}Calls = append(f.{{.MethodName}}Calls, {{.Arg.Call}})
+	{{- else}}
+	f.{{.MethodName}}Calls++
+	{{- end}}
+	fn := f.{{.MethodName}}Func
+	f.mu.Unlock()
+{{- end}}
+
+{{define "factoriesFile"}}{{template "header" .}}
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "factoriesCode" . }}
+{{end}}
+
+{{define "factoriesCode"}}
+{{range .Factories}}
+// New{{.Name}}ForTest returns a row of {{.Table}} with a value in each NOT NULL
+// column. If override isn't nil, it's called to change the row first.
+func New{{.Name}}ForTest(override func(*{{.Struct.Name}})) {{.Struct.Name}} {
+	{{- if .Values}}
+	v := {{.Struct.Name}}{
+		{{- range .Values}}
+		{{.Field}}: {{.Value}},
+		{{- end}}
+	}
+	{{- else}}
+	var v {{.Struct.Name}}
 	{{- end}}
+	if override != nil {
+		override(&v)
+	}
+	return v
+}
+
+const {{.ConstantName}} = {{$.Q}}{{.Insert}}{{$.Q}}
+
+// Insert{{.Name}}ForTest inserts arg, leaving out the columns which have a
+// default, and returns the new row.
+func (q *Queries) Insert{{.Name}}ForTest(ctx context.Context, arg {{.Struct.Name}}) ({{.Struct.Name}}, error) {
+	row := q.db.QueryRowContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	var i {{.Struct.Name}}
+	err := row.Scan({{.Ret.Scan}})
+	return i, err
+}
+{{end}}
+{{end}}
+
+{{define "protoFile"}}{{template "header" .}}
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "protoCode" . }}
+{{end}}
+
+{{define "protoCode"}}
+{{range .ProtoMessages}}
+// {{.Name}}ToProto converts v to a {{$.ProtoQualifier}}{{.Name}} message.
+func {{.Name}}ToProto(v {{.Struct.Name}}) *{{$.ProtoQualifier}}{{.Name}} {
+	return &{{$.ProtoQualifier}}{{.Name}}{
+		{{- range .Fields}}
+		{{- if not .Unsupported}}
+		{{.PbName}}: {{.To}},
+		{{- end}}
+		{{- end}}
+	}
+}
+
+// {{.Name}}FromProto converts m to a {{.Struct.Name}}.
+func {{.Name}}FromProto(m *{{$.ProtoQualifier}}{{.Name}}) {{.Struct.Name}} {
+	return {{.Struct.Name}}{
+		{{- range .Fields}}
+		{{- if not .Unsupported}}
+		{{.GoName}}: {{.From}},
+		{{- end}}
+		{{- end}}
+	}
+}
+{{end}}
+{{- range .ProtoHelpers}}
+func {{.Name}}ToProto(v {{.GoType}}) {{.PbType}} {
+	if {{.IsNull}} {
+		return nil
+	}
+	return {{.ToPb}}
+}
+
+func {{.Name}}FromProto(m {{.PbType}}) {{.GoType}} {
+	if m == nil {
+		return {{.Zero}}
+	}
+	{{- if .Pointer}}
+	v := {{.FromPb}}
+	return &v
+	{{- else}}
+	return {{.FromPb}}
+	{{- end}}
+}
+{{end}}
+{{end}}
+
+{{define "loadersFile"}}{{template "header" .}}
+package {{.Package}}
+
+import (
+	{{range imports .SourceName}}
+	{{range .}}"{{.}}"
+	{{end}}
+	{{end}}
+)
+
+{{template "loadersCode" . }}
+{{end}}
+
+{{define "loadersCode"}}
+{{range .Loaders}}
+// {{.Name}} loads and caches {{.Struct}} rows by {{.KeyField}}.
+// Foreign keys: {{join .Uses ", "}}.
+//
+// Fetch is called with the keys which aren't cached yet and returns their
+// rows in any order. Use one loader per request.
+type {{.Name}} struct {
+	Fetch func(ctx context.Context, keys []{{.KeyType}}) ([]{{.Struct}}, error)
+
+	mu    sync.Mutex
+	cache map[{{.KeyType}}]{{.Struct}}
+}
+
+// LoadAll returns the rows for keys. Keys without a row are left out.
+func (l *{{.Name}}) LoadAll(ctx context.Context, keys []{{.KeyType}}) (map[{{.KeyType}}]{{.Struct}}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cache == nil {
+		l.cache = map[{{.KeyType}}]{{.Struct}}{}
+	}
+	var missing []{{.KeyType}}
+	for _, key := range keys {
+		if _, ok := l.cache[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		rows, err := l.Fetch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			l.cache[row.{{.KeyField}}] = row
+		}
+	}
+	out := make(map[{{.KeyType}}]{{.Struct}}, len(keys))
+	for _, key := range keys {
+		if row, ok := l.cache[key]; ok {
+			out[key] = row
+		}
+	}
+	return out, nil
 }
 
-var _ Querier = (*Queries)(nil)
+// Load returns the row for key, or sql.ErrNoRows if there isn't one.
+func (l *{{.Name}}) Load(ctx context.Context, key {{.KeyType}}) ({{.Struct}}, error) {
+	rows, err := l.LoadAll(ctx, []{{.KeyType}}{key})
+	if err != nil {
+		return {{.Struct}}{}, err
+	}
+	row, ok := rows[key]
+	if !ok {
+		return {{.Struct}}{}, sql.ErrNoRows
+	}
+	return row, nil
+}
+{{end}}
 {{end}}
 
-{{define "modelsFile"}}// Code generated by sqlc. DO NOT EDIT.
-
+{{define "modelsFile"}}{{template "header" .}}
 package {{.Package}}
 
 import (
@@ -1139,13 +2825,13 @@ import (
 {{range .Enums}}
 {{if .Comment}}{{comment .Comment}}{{end}}
 type {{.Name}} string
-
+{{if .Constants}}
 const (
 	{{- range .Constants}}
 	{{.Name}} {{.Type}} = "{{.Value}}"
 	{{- end}}
 )
-
+{{end}}
 func (e *{{.Name}}) Scan(src interface{}) error {
 	switch s := src.(type) {
 	case []byte:
@@ -1157,6 +2843,48 @@ func (e *{{.Name}}) Scan(src interface{}) error {
 	}
 	return nil
 }
+
+type Null{{.Name}} struct {
+	{{.Name}} {{.Name}}
+	Valid bool // Valid is true if {{.Name}} is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *Null{{.Name}}) Scan(value interface{}) error {
+	if value == nil {
+		ns.{{.Name}}, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.{{.Name}}.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns Null{{.Name}}) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.{{.Name}}), nil
+}
+
+func (e {{.Name}}) Valid() bool {
+	switch e {
+	{{- if .Constants}}
+	case {{range $i, $c := .Constants}}{{if $i}},
+		{{end}}{{.Name}}{{end}}:
+		return true
+	{{- end}}
+	}
+	return false
+}
+
+func All{{.Name}}Values() []{{.Name}} {
+	return []{{.Name}}{
+		{{- range .Constants}}
+		{{.Name}},
+		{{- end}}
+	}
+}
 {{end}}
 
 {{range .Structs}}
@@ -1165,14 +2893,215 @@ type {{.Name}} struct { {{- range .Fields}}
   {{- if .Comment}}
   {{comment .Comment}}{{else}}
   {{- end}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
+{{- if .UsesInet}}
+
+// Inet holds an inet or cidr value. IP is the whole address, which for an
+// inet may have bits set outside its network. NULL is scanned as a nil IP.
+type Inet struct {
+	net.IPNet
+}
+
+// Scan implements the Scanner interface.
+func (n *Inet) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*n = Inet{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("unsupported scan type for Inet: %T", value)
+	}
+	if !strings.Contains(s, "/") {
+		// Addresses of single hosts are written without their mask
+		if strings.Contains(s, ":") {
+			s += "/128"
+		} else {
+			s += "/32"
+		}
+	}
+	ip, network, err := net.ParseCIDR(s)
+	if err != nil {
+		return err
+	}
+	n.IP, n.Mask = ip, network.Mask
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (n Inet) Value() (driver.Value, error) {
+	if n.IP == nil {
+		return nil, nil
+	}
+	if n.Mask == nil {
+		return n.IP.String(), nil
+	}
+	ones, _ := n.Mask.Size()
+	return fmt.Sprintf("%s/%d", n.IP, ones), nil
+}
+
+// MarshalText encodes the address as it's written by PostgreSQL.
+func (n Inet) MarshalText() ([]byte, error) {
+	v, err := n.Value()
+	if v == nil || err != nil {
+		return nil, err
+	}
+	return []byte(v.(string)), nil
+}
+
+// UnmarshalText decodes an address written by MarshalText.
+func (n *Inet) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = Inet{}
+		return nil
+	}
+	return n.Scan(text)
+}
+{{- end}}
+{{- if .UsesMacAddr}}
+
+// MacAddr holds a macaddr or macaddr8 value. NULL is scanned as a nil
+// HardwareAddr.
+type MacAddr struct {
+	net.HardwareAddr
+}
+
+// Scan implements the Scanner interface.
+func (m *MacAddr) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*m = MacAddr{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("unsupported scan type for MacAddr: %T", value)
+	}
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+	m.HardwareAddr = addr
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (m MacAddr) Value() (driver.Value, error) {
+	if m.HardwareAddr == nil {
+		return nil, nil
+	}
+	return m.HardwareAddr.String(), nil
+}
+
+// MarshalText encodes the address as it's written by PostgreSQL.
+func (m MacAddr) MarshalText() ([]byte, error) {
+	return []byte(m.HardwareAddr.String()), nil
+}
+
+// UnmarshalText decodes an address written by MarshalText.
+func (m *MacAddr) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = MacAddr{}
+		return nil
+	}
+	return m.Scan(text)
+}
+{{- end}}
+{{- if .UsesDuration}}
+
+// Duration holds an interval, with months taken to be 30 days and days 24
+// hours. Intervals are scanned from PostgreSQL's default output style.
+type Duration struct {
+	time.Duration
+}
+
+// Scan implements the Scanner interface.
+func (d *Duration) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("unsupported scan type for Duration: %T", value)
+	}
+	var total time.Duration
+	fields := strings.Fields(s)
+	for i := 0; i < len(fields); i++ {
+		if clock := fields[i]; strings.Contains(clock, ":") {
+			// [-]hh:mm:ss[.ffffff]
+			sign := ""
+			if strings.HasPrefix(clock, "-") || strings.HasPrefix(clock, "+") {
+				sign, clock = clock[:1], clock[1:]
+			}
+			parts := strings.Split(clock, ":")
+			if len(parts) != 3 {
+				return fmt.Errorf("invalid interval %q", s)
+			}
+			t, err := time.ParseDuration(sign + parts[0] + "h" + parts[1] + "m" + parts[2] + "s")
+			if err != nil {
+				return fmt.Errorf("invalid interval %q: %s", s, err)
+			}
+			total += t
+			continue
+		}
+		if i+1 == len(fields) {
+			return fmt.Errorf("invalid interval %q", s)
+		}
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid interval %q: %s", s, err)
+		}
+		i++
+		switch strings.TrimSuffix(fields[i], "s") {
+		case "year":
+			total += time.Duration(n) * 12 * 30 * 24 * time.Hour
+		case "mon":
+			total += time.Duration(n) * 30 * 24 * time.Hour
+		case "day":
+			total += time.Duration(n) * 24 * time.Hour
+		default:
+			return fmt.Errorf("invalid interval %q: unknown unit %q", s, fields[i])
+		}
+	}
+	d.Duration = total
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (d Duration) Value() (driver.Value, error) {
+	return fmt.Sprintf("%d microseconds", d.Microseconds()), nil
+}
+
+// MarshalText encodes the duration as it's formatted by time.Duration.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a duration written by MarshalText.
+func (d *Duration) UnmarshalText(text []byte) error {
+	t, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = t
+	return nil
+}
+{{- end}}
 {{end}}
 
-{{define "queryFile"}}// Code generated by sqlc. DO NOT EDIT.
-// source: {{.SourceName}}
+{{define "queryFile"}}{{template "header" .}}// source: {{.SourceName}}
 
 package {{.Package}}
 
@@ -1195,14 +3124,14 @@ const {{.ConstantName}} = {{$.Q}}-- name: {{.MethodName}} {{.Cmd}}
 
 {{if .Arg.EmitStruct}}
 type {{.Arg.Type}} struct { {{- range .Arg.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
 
 {{if .Ret.EmitStruct}}
 type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
-  {{.Name}} {{.Type}} {{if $.EmitJSONTags}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
+  {{.Name}} {{.Type}} {{if .Tag}}{{$.Q}}{{.Tag}}{{$.Q}}{{end}}
   {{- end}}
 }
 {{end}}
@@ -1210,75 +3139,217 @@ type {{.Ret.Type}} struct { {{- range .Ret.Struct.Fields}}
 {{if eq .Cmd ":one"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error) {
+{{if $.EmitInterceptors}}{{template "interceptMethod" .}}{{end -}}
+{{if .Retries}}{{template "retryMethod" $.Retry .}}{{end -}}
+func (q *Queries) {{$.QueryMethod .}}(ctx context.Context, {{.Arg.Pair}}) ({{.Ret.Type}}, error) {
+	{{- template "timeout" .}}
+	{{- if $.Instrumented}}
+	ctx, endQuery := q.startQuery(ctx, "{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
+	{{- if .ScanByName}}
+	{{- if $.EmitPreparedQueries}}
+	rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+	{{- else}}
+	rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
+	var {{.Ret.Name}} {{.Ret.Type}}
+	err = scanRowByName(rows, err, {{.Ret.ScanMap}})
+	return {{.Ret.Name}}, {{$.End "err"}}
+	{{- else}}
   	{{- if $.EmitPreparedQueries}}
 	row := q.queryRow(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
 	{{- else}}
 	row := q.db.QueryRowContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
 	{{- end}}
+	{{- if $.EmitGenericHelpers}}
+	{{- if $.Instrumented}}
+	{{.Ret.Name}}, err := scan{{.MethodName}}(row)
+	return {{.Ret.Name}}, endQuery(err)
+	{{- else}}
+	return scan{{.MethodName}}(row)
+	{{- end}}
+	{{- else}}
 	var {{.Ret.Name}} {{.Ret.Type}}
 	err := row.Scan({{.Ret.Scan}})
-	return {{.Ret.Name}}, err
+	return {{.Ret.Name}}, {{$.End "err"}}
+	{{- end}}
+	{{- end}}
 }
+{{if $.EmitGenericHelpers}}{{template "scanFunc" .}}{{end}}
 {{end}}
 
 {{if eq .Cmd ":many"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error) {
+{{if $.EmitInterceptors}}{{template "interceptMethod" .}}{{end -}}
+{{if .Retries}}{{template "retryMethod" $.Retry .}}{{end -}}
+func (q *Queries) {{$.QueryMethod .}}(ctx context.Context, {{.Arg.Pair}}) ([]{{.Ret.Type}}, error) {
+	{{- template "timeout" .}}
+	{{- if $.Instrumented}}
+	ctx, endQuery := q.startQuery(ctx, "{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
   	{{- if $.EmitPreparedQueries}}
 	rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
+	{{- if $.EmitGenericHelpers}}
+	{{- if $.Instrumented}}
+	items, err := queryMany(rows, err, scan{{.MethodName}})
+	return items, endQuery(err)
+	{{- else}}
+	return queryMany(rows, err, scan{{.MethodName}})
+	{{- end}}
+	{{- else}}
 	if err != nil {
-		return nil, err
+		return nil, {{$.End "err"}}
 	}
 	defer rows.Close()
+	{{- if $.EmitEmptySlices}}
+	items := []{{.Ret.Type}}{}
+	{{else}}
 	var items []{{.Ret.Type}}
+	{{end -}}
 	for rows.Next() {
 		var {{.Ret.Name}} {{.Ret.Type}}
-		if err := rows.Scan({{.Ret.Scan}}); err != nil {
-			return nil, err
+		if err := {{template "scanRows" .}}; err != nil {
+			return nil, {{$.End "err"}}
 		}
 		items = append(items, {{.Ret.Name}})
 	}
 	if err := rows.Close(); err != nil {
-		return nil, err
+		return nil, {{$.End "err"}}
 	}
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, {{$.End "err"}}
+	}
+	return items, {{$.End "nil"}}
+	{{- end}}
+}
+{{if $.EmitGenericHelpers}}{{template "scanFunc" .}}{{end}}
+{{if .ForEach}}
+// ForEach{{.MethodName}} calls fn for each row returned by {{.MethodName}},
+// without loading every row into memory. Iteration stops at the first error.
+{{if $.EmitInterceptors}}{{template "interceptForEach" .}}{{end -}}
+func (q *Queries) {{$.ForEachMethod .}}(ctx context.Context, {{if .Arg.Pair}}{{.Arg.Pair}}, {{end}}fn func({{.Ret.Type}}) error) error {
+	{{- template "timeout" .}}
+	{{- if $.Instrumented}}
+	ctx, endQuery := q.startQuery(ctx, "ForEach{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
+  	{{- if $.EmitPreparedQueries}}
+	rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- else}}
+	rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+  	{{- end}}
+	if err != nil {
+		return {{$.End "err"}}
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var {{.Ret.Name}} {{.Ret.Type}}
+		if err := {{template "scanRows" .}}; err != nil {
+			return {{$.End "err"}}
+		}
+		if err := fn({{.Ret.Name}}); err != nil {
+			return {{$.End "err"}}
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return {{$.End "err"}}
+	}
+	return {{$.End "rows.Err()"}}
+}
+{{end}}
+{{end}}
+
+{{if eq .Cmd ":iter"}}
+{{range .Comments}}//{{.}}
+{{end -}}
+{{if $.EmitInterceptors}}{{template "interceptIter" .}}{{end -}}
+func (q *Queries) {{$.QueryMethod .}}(ctx context.Context, {{.Arg.Pair}}) iter.Seq2[{{.Ret.Type}}, error] {
+	return func(yield func({{.Ret.Type}}, error) bool) {
+		{{- template "timeout" .}}
+		{{- if $.Instrumented}}
+		ctx, endQuery := q.startQuery(ctx, "{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+		{{- end}}
+		var zero {{.Ret.Type}}
+		{{- if $.EmitPreparedQueries}}
+		rows, err := q.query(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
+		{{- else}}
+		rows, err := q.db.QueryContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
+		{{- end}}
+		if err != nil {
+			yield(zero, {{$.End "err"}})
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var {{.Ret.Name}} {{.Ret.Type}}
+			if err := {{template "scanRows" .}}; err != nil {
+				yield(zero, {{$.End "err"}})
+				return
+			}
+			if !yield({{.Ret.Name}}, nil) {
+				{{- if $.Instrumented}}
+				endQuery(nil)
+				{{- end}}
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, {{$.End "err"}})
+			return
+		}
+		{{- if $.Instrumented}}
+		endQuery(nil)
+		{{- end}}
 	}
-	return items, nil
 }
 {{end}}
 
 {{if eq .Cmd ":exec"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) error {
+{{if $.EmitInterceptors}}{{template "interceptMethod" .}}{{end -}}
+{{if .Retries}}{{template "retryMethod" $.Retry .}}{{end -}}
+func (q *Queries) {{$.QueryMethod .}}(ctx context.Context, {{.Arg.Pair}}) error {
+	{{- template "timeout" .}}
+	{{- if $.Instrumented}}
+	ctx, endQuery := q.startQuery(ctx, "{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
   	{{- if $.EmitPreparedQueries}}
 	_, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	_, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
-	return err
+	return {{$.End "err"}}
 }
 {{end}}
 
 {{if eq .Cmd ":execrows"}}
 {{range .Comments}}//{{.}}
 {{end -}}
-func (q *Queries) {{.MethodName}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+{{if $.EmitInterceptors}}{{template "interceptMethod" .}}{{end -}}
+{{if .Retries}}{{template "retryMethod" $.Retry .}}{{end -}}
+func (q *Queries) {{$.QueryMethod .}}(ctx context.Context, {{.Arg.Pair}}) (int64, error) {
+	{{- template "timeout" .}}
+	{{- if $.Instrumented}}
+	ctx, endQuery := q.startQuery(ctx, "{{.MethodName}}", {{.ConstantName}}, {{.Arg.Params}})
+	{{- end}}
   	{{- if $.EmitPreparedQueries}}
 	result, err := q.exec(ctx, q.{{.FieldName}}, {{.ConstantName}}, {{.Arg.Params}})
   	{{- else}}
 	result, err := q.db.ExecContext(ctx, {{.ConstantName}}, {{.Arg.Params}})
   	{{- end}}
 	if err != nil {
-		return 0, err
+		return 0, {{$.End "err"}}
 	}
+	{{- if $.Instrumented}}
+	affected, err := result.RowsAffected()
+	return affected, endQuery(err)
+	{{- else}}
 	return result.RowsAffected()
+	{{- end}}
 }
 {{end}}
 {{end}}
@@ -1292,14 +3363,127 @@ type tmplCtx struct {
 	Enums     []GoEnum
 	Structs   []GoStruct
 	GoQueries []GoQuery
+	Factories []GoFactory
 	Settings  config.Config
 
+	Loaders []GoLoader
+
+	ProtoMessages  []ProtoMessage
+	ProtoHelpers   []protoHelper
+	ProtoQualifier string
+
 	// TODO: Race conditions
 	SourceName string
 
 	EmitJSONTags        bool
 	EmitPreparedQueries bool
 	EmitInterface       bool
+	EmitFileInterfaces  bool
+	EmitEmptySlices     bool
+	EmitHooks           bool
+	EmitGenericHelpers  bool
+	EmitStatementCache  bool
+	EmitInterceptors    bool
+	EmitMetrics         bool
+	EmitRetries         bool
+	UsesInet            bool
+	UsesMacAddr         bool
+	UsesDuration        bool
+	UsesJSONColumns     bool
+	UsesScanByName      bool
+	DBTX                string
+	DBTXMethods         []string
+	BuildTags           string
+	Header              []string
+
+	combined config.CombinedSettings
+}
+
+type querierGroup struct {
+	Name    string
+	Queries []GoQuery
+}
+
+// QuerierGroups splits the queries by source file, in file name order. Each
+// group is named after its file, so users.sql becomes UsersQuerier.
+func (t *tmplCtx) QuerierGroups() []querierGroup {
+	var groups []querierGroup
+	index := map[string]int{}
+	for _, q := range t.GoQueries {
+		i, ok := index[q.SourceName]
+		if !ok {
+			i = len(groups)
+			index[q.SourceName] = i
+			groups = append(groups, querierGroup{Name: querierName(q.SourceName, t.combined)})
+		}
+		groups[i].Queries = append(groups[i].Queries, q)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+func querierName(sourceName string, settings config.CombinedSettings) string {
+	base := strings.SplitN(filepath.Base(sourceName), ".", 2)[0]
+	base = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, base)
+	return StructName(base, settings) + "Querier"
+}
+
+// Instrumented reports whether query methods call startQuery, which reports
+// to the hooks and metrics.
+func (t *tmplCtx) Instrumented() bool {
+	return t.EmitHooks || t.EmitMetrics
+}
+
+// End wraps the error returned by a query method so that it is reported to
+// the query hooks and metrics.
+func (t *tmplCtx) End(err string) string {
+	if t.Instrumented() {
+		return "endQuery(" + err + ")"
+	}
+	return err
+}
+
+// QueryMethod returns the name of the method which runs q. With interceptors
+// or retries, the exported method wraps an unexported one.
+func (t *tmplCtx) QueryMethod(q GoQuery) string {
+	switch {
+	case q.Retries > 0 && t.EmitInterceptors:
+		return LowerTitle(q.MethodName) + "Once"
+	case q.Retries > 0 || t.EmitInterceptors:
+		return LowerTitle(q.MethodName)
+	}
+	return q.MethodName
+}
+
+// ForEachMethod returns the name of the method which calls a function for
+// each row of q. With interceptors, the exported method wraps an unexported
+// one.
+func (t *tmplCtx) ForEachMethod(q GoQuery) string {
+	if t.EmitInterceptors {
+		return "forEach" + q.MethodName
+	}
+	return "ForEach" + q.MethodName
+}
+
+// A retryWrapper is the method which retries a query, named Name. It's called
+// by the interceptor method, if there is one, and calls Next.
+type retryWrapper struct {
+	GoQuery
+	Name string
+	Next string
+}
+
+func (t *tmplCtx) Retry(q GoQuery) retryWrapper {
+	name := q.MethodName
+	if t.EmitInterceptors {
+		name = LowerTitle(name)
+	}
+	return retryWrapper{GoQuery: q, Name: name, Next: t.QueryMethod(q)}
 }
 
 func (t *tmplCtx) OutputQuery(sourceName string) bool {
@@ -1316,26 +3500,122 @@ func DoubleSlashComment(s string) string {
 	return "// " + strings.ReplaceAll(s, "\n", "\n// ")
 }
 
+// markForEach sets ForEach on the :many queries named in for_each_queries.
+func markForEach(queries []GoQuery, names []string) error {
+	for _, name := range names {
+		found := false
+		for i := range queries {
+			if queries[i].MethodName != name {
+				continue
+			}
+			if queries[i].Cmd != ":many" {
+				return fmt.Errorf("for_each_queries: query %q must be a :many query", name)
+			}
+			queries[i].ForEach = true
+			found = true
+		}
+		if !found {
+			return fmt.Errorf("for_each_queries: query %q not found", name)
+		}
+	}
+	return nil
+}
+
+// QueryFilename returns the name of the Go file generated for a query file
+func QueryFilename(source string, settings config.CombinedSettings) string {
+	if settings.Go.OutputFilesSuffix != "" {
+		return source + settings.Go.OutputFilesSuffix
+	}
+	if !strings.HasSuffix(source, ".go") {
+		return source + ".go"
+	}
+	return source
+}
+
+// catalogResult is implemented by the results of the PostgreSQL engine
+type catalogResult interface {
+	catalog() core.Catalog
+}
+
+func (r Result) catalog() core.Catalog {
+	return r.Catalog
+}
+
 func Generate(r Generateable, settings config.CombinedSettings) (map[string]string, error) {
+	return GenerateChanged(r, settings, nil)
+}
+
+// GenerateChanged is Generate, but leaves out the code for each query file for
+// which unchanged returns true, so unchanged files aren't generated again
+func GenerateChanged(r Generateable, settings config.CombinedSettings, unchanged func(source string) bool) (map[string]string, error) {
+	cr, hasCatalog := r.(catalogResult)
+	if settings.Go.EmitTestFactories && !hasCatalog {
+		return nil, fmt.Errorf("emit_test_factories is only supported by the %s engine", config.EnginePostgreSQL)
+	}
+	if settings.Go.EmitGraphQL && !hasCatalog {
+		return nil, fmt.Errorf("emit_graphql is only supported by the %s engine", config.EnginePostgreSQL)
+	}
+	if settings.Go.OmitUnusedStructs {
+		r = omitUnusedStructs(r, settings)
+	}
+	if err := checkModelsTypes(r, settings); err != nil {
+		return nil, err
+	}
+
 	funcMap := template.FuncMap{
 		"lowerTitle": LowerTitle,
+		"join":       strings.Join,
 		"comment":    DoubleSlashComment,
 		"imports":    Imports(r, settings),
 	}
 
 	tmpl := template.Must(template.New("table").Funcs(funcMap).Parse(templateSet))
 
+	queries := r.GoQueries(settings)
+	if len(settings.Go.ForEachQueries) > 0 {
+		if err := markForEach(queries, settings.Go.ForEachQueries); err != nil {
+			return nil, err
+		}
+	}
+
 	golang := settings.Go
 	tctx := tmplCtx{
 		Settings:            settings.Global,
 		EmitInterface:       golang.EmitInterface,
+		EmitFileInterfaces:  golang.EmitFileInterfaces,
 		EmitJSONTags:        golang.EmitJSONTags,
 		EmitPreparedQueries: golang.EmitPreparedQueries,
+		EmitEmptySlices:     golang.EmitEmptySlices,
+		EmitHooks:           golang.EmitHooks,
+		EmitGenericHelpers:  golang.EmitGenericHelpers,
+		EmitStatementCache:  golang.EmitStatementCache,
+		EmitInterceptors:    golang.EmitInterceptors,
+		EmitMetrics:         golang.EmitMetrics,
+		EmitRetries:         usesRetries(queries),
+		UsesInet:            usesModelsType(r, "Inet", settings),
+		UsesMacAddr:         usesModelsType(r, "MacAddr", settings),
+		UsesDuration:        usesModelsType(r, "Duration", settings),
+		UsesJSONColumns:     usesJSONColumns(queries),
+		UsesScanByName:      usesScanByName(queries),
+		DBTX:                "DBTX",
+		DBTXMethods:         golang.DBTXMethods,
+		BuildTags:           golang.BuildTags,
 		Q:                   "`",
 		Package:             golang.Package,
-		GoQueries:           r.GoQueries(settings),
+		GoQueries:           queries,
 		Enums:               r.Enums(settings),
 		Structs:             r.Structs(settings),
+		combined:            settings,
+	}
+
+	if golang.DBTXInterfaceName != "" {
+		tctx.DBTX = golang.DBTXInterfaceName
+	}
+	if golang.EmitTestFactories {
+		tctx.Factories = factories(cr.catalog(), tctx.Structs, tctx.Enums, settings)
+	}
+	if golang.FileHeader != "" {
+		tctx.Header = strings.Split(strings.TrimRight(golang.FileHeader, "\n"), "\n")
 	}
 
 	output := map[string]string{}
@@ -1354,24 +3634,75 @@ func Generate(r Generateable, settings config.CombinedSettings) (map[string]stri
 			fmt.Println(b.String())
 			return fmt.Errorf("source error: %w", err)
 		}
-		if !strings.HasSuffix(name, ".go") {
+		if templateName == "queryFile" {
+			name = QueryFilename(name, settings)
+		} else if !strings.HasSuffix(name, ".go") {
 			name += ".go"
 		}
 		output[name] = string(code)
 		return nil
 	}
 
+	if golang.EmitModelsOnly {
+		if err := execute("models.go", "modelsFile"); err != nil {
+			return nil, err
+		}
+		return output, nil
+	}
+
 	if err := execute("db.go", "dbFile"); err != nil {
 		return nil, err
 	}
-	if err := execute("models.go", "modelsFile"); err != nil {
-		return nil, err
+	if golang.OutputModelsPackage == "" {
+		if err := execute("models.go", "modelsFile"); err != nil {
+			return nil, err
+		}
 	}
 	if golang.EmitInterface {
 		if err := execute("querier.go", "interfaceFile"); err != nil {
 			return nil, err
 		}
 	}
+	if golang.EmitQuerierFake {
+		if err := execute("querier_fake.go", "fakeFile"); err != nil {
+			return nil, err
+		}
+	}
+	if golang.EmitTestFactories {
+		if err := execute("factories.go", "factoriesFile"); err != nil {
+			return nil, err
+		}
+	}
+	if golang.EmitProto {
+		var timestamp bool
+		tctx.ProtoMessages, tctx.ProtoHelpers, timestamp = protoMessages(tctx.Structs, tctx.GoQueries, tctx.Enums, settings)
+		tctx.ProtoQualifier = ProtoQualifier(settings)
+		schema, err := protoSchema(tctx.ProtoMessages, tctx.ProtoHelpers, timestamp, settings)
+		if err != nil {
+			return nil, err
+		}
+		output[ProtoFilename] = schema
+		if err := execute("proto.go", "protoFile"); err != nil {
+			return nil, err
+		}
+	}
+	if golang.EmitGraphQL {
+		output[GraphQLSchemaFilename] = GraphQLSchema(tctx.Structs, tctx.Enums, settings)
+		output[GQLGenModelsFilename] = GQLGenModels(tctx.Structs, settings)
+		tctx.Loaders = loaders(cr.catalog(), tctx.Structs, settings)
+		if len(tctx.Loaders) > 0 {
+			if err := execute("dataloaders.go", "loadersFile"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if golang.EmitOpenAPI {
+		doc, err := OpenAPI(tctx.Structs, tctx.GoQueries, tctx.Enums, settings)
+		if err != nil {
+			return nil, err
+		}
+		output[OpenAPIFilename] = doc
+	}
 
 	files := map[string]struct{}{}
 	for _, gq := range r.GoQueries(settings) {
@@ -1379,6 +3710,9 @@ func Generate(r Generateable, settings config.CombinedSettings) (map[string]stri
 	}
 
 	for source := range files {
+		if unchanged != nil && unchanged(source) {
+			continue
+		}
 		if err := execute(source, "queryFile"); err != nil {
 			return nil, err
 		}
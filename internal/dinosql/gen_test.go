@@ -0,0 +1,70 @@
+package dinosql
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func TestOutputOrder(t *testing.T) {
+	c := core.NewCatalog()
+	public := c.Schemas["public"]
+	for _, name := range []string{"users", "audit_events", "books", "authors"} {
+		public.Tables[name] = core.Table{Name: name, Columns: []core.Column{{Name: "id", DataType: "text"}}}
+	}
+	public.Types["status"] = core.Enum{Name: "status", Vals: []string{"open", "closed"}}
+	public.Types["audit_kind"] = core.Enum{Name: "audit_kind", Vals: []string{"b", "a"}}
+	audit := core.NewSchema()
+	audit.Tables["events"] = core.Table{Name: "events", Columns: []core.Column{{Name: "at", DataType: "text"}}}
+	audit.Types["kind"] = core.Enum{Name: "kind", Vals: []string{"insert", "delete"}}
+	c.Schemas["audit"] = audit
+
+	r := Result{
+		Catalog: c,
+		Queries: []*Query{
+			{Name: "ListUsers", Cmd: ":many", Filename: "b.sql"},
+			{Name: "GetUser", Cmd: ":one", Filename: "b.sql"},
+			{Name: "CreateUser", Cmd: ":exec", Filename: "a.sql"},
+		},
+	}
+	var settings config.CombinedSettings
+
+	wantStructs := []string{"audit.events", "public.audit_events", "public.authors", "public.books", "public.users"}
+	wantEnums := []string{"AuditKind", "AuditKind", "Status"}
+	wantConstants := []string{"insert", "delete", "b", "a", "open", "closed"}
+	wantQueries := []string{"CreateUser", "GetUser", "ListUsers"}
+
+	// Map iteration differs from run to run, so try a few
+	for i := 0; i < 20; i++ {
+		var structs []string
+		for _, s := range r.Structs(settings) {
+			structs = append(structs, s.Table.Schema+"."+s.Table.Rel)
+		}
+		if diff := cmp.Diff(wantStructs, structs); diff != "" {
+			t.Fatalf("structs differed (-want +got):\n%s", diff)
+		}
+		var enums, constants []string
+		for _, e := range r.Enums(settings) {
+			enums = append(enums, e.Name)
+			for _, c := range e.Constants {
+				constants = append(constants, c.Value)
+			}
+		}
+		if diff := cmp.Diff(wantEnums, enums); diff != "" {
+			t.Fatalf("enums differed (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff(wantConstants, constants); diff != "" {
+			t.Fatalf("enum constants differed (-want +got):\n%s", diff)
+		}
+		var queries []string
+		for _, q := range r.GoQueries(settings) {
+			queries = append(queries, q.MethodName)
+		}
+		if diff := cmp.Diff(wantQueries, queries); diff != "" {
+			t.Fatalf("queries differed (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package dinosql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// isGlob reports whether path contains any of the characters which
+// filepath.Match treats specially
+func isGlob(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// Stdin is the path which names standard input. Its contents are passed to
+// ParseCatalogSources, or to ParseQueries in ParserOpts.Sources, rather than
+// being read from disk.
+const Stdin = "-"
+
+// A missingPathErr reports a configured path which couldn't be found,
+// wrapping the error from os.Stat
+type missingPathErr struct {
+	path string
+	err  error
+}
+
+func (e *missingPathErr) Error() string {
+	return fmt.Sprintf("path %s does not exist", e.path)
+}
+
+func (e *missingPathErr) Unwrap() error {
+	return e.err
+}
+
+// listFiles returns the files named by path, in lexical order. The path may
+// be a file, a directory, whose files are listed, or a glob pattern. Patterns
+// follow filepath.Match, and a `**` element matches any number of
+// directories, so `queries/**/*.sql` matches every .sql file beneath queries.
+func listFiles(path string) ([]string, error) {
+	if path == Stdin {
+		return []string{Stdin}, nil
+	}
+	if isGlob(path) {
+		files, err := glob(path)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("pattern %s matches no files", path)
+		}
+		return files, nil
+	}
+
+	f, err := os.Stat(path)
+	if err != nil {
+		return nil, &missingPathErr{path: path, err: err}
+	}
+	if !f.IsDir() {
+		return []string{path}, nil
+	}
+	listing, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range listing {
+		files = append(files, filepath.Join(path, f.Name()))
+	}
+	return files, nil
+}
+
+func glob(pattern string) ([]string, error) {
+	pattern = filepath.Clean(pattern)
+	elems := strings.Split(filepath.ToSlash(pattern), "/")
+
+	// Walk from the longest prefix without any patterns
+	var i int
+	for i < len(elems)-1 && !isGlob(elems[i]) {
+		i++
+	}
+	root := filepath.FromSlash(strings.Join(elems[:i], "/"))
+	if root == "" {
+		root = "."
+		if strings.HasPrefix(pattern, string(filepath.Separator)) {
+			root = string(filepath.Separator)
+		}
+	}
+	elems = elems[i:]
+	for _, elem := range elems {
+		if _, err := filepath.Match(elem, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %s", pattern, err)
+		}
+	}
+
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if matchElems(elems, strings.Split(filepath.ToSlash(rel), "/")) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchElems reports whether the elements of a path match those of a pattern
+func matchElems(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchElems(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 {
+		return false
+	}
+	if ok, _ := filepath.Match(pattern[0], path[0]); !ok {
+		return false
+	}
+	return matchElems(pattern[1:], path[1:])
+}
@@ -0,0 +1,207 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// The files written by `emit_graphql`
+const (
+	GraphQLSchemaFilename = "schema.graphql"
+	GQLGenModelsFilename  = "gqlgen_models.yml"
+)
+
+// graphQLScalars maps Go types to the GraphQL scalar gqlgen binds them to
+var graphQLScalars = map[string]string{
+	"string":    "String",
+	"bool":      "Boolean",
+	"int16":     "Int",
+	"int32":     "Int",
+	"int64":     "Int",
+	"float32":   "Float",
+	"float64":   "Float",
+	"time.Time": "Time",
+}
+
+// graphQLType returns the GraphQL type for a Go type, or an empty string if
+// gqlgen can't bind it without a custom scalar
+func graphQLType(goType string, enums map[string]bool) string {
+	if t, ok := graphQLScalars[goType]; ok {
+		return t + "!"
+	}
+	if enums[goType] {
+		return "String!"
+	}
+	if strings.HasPrefix(goType, "*") {
+		return strings.TrimSuffix(graphQLType(strings.TrimPrefix(goType, "*"), enums), "!")
+	}
+	if strings.HasPrefix(goType, "[]") && goType != "[]byte" {
+		if elem := graphQLType(strings.TrimPrefix(goType, "[]"), enums); strings.HasSuffix(elem, "!") {
+			return "[" + elem + "]"
+		}
+	}
+	return ""
+}
+
+// GraphQLSchema returns a GraphQL type for each model struct, and declares
+// the Time scalar if a field uses it. Fields which gqlgen can't bind, such as
+// the sql.Null types, are left out.
+func GraphQLSchema(structs []GoStruct, enums []GoEnum, settings config.CombinedSettings) string {
+	qualifier := ModelsQualifier(settings)
+	isEnum := map[string]bool{}
+	for _, enum := range enums {
+		isEnum[qualifier+enum.Name] = true
+	}
+
+	var b strings.Builder
+	usesTime := false
+	for _, s := range structs {
+		b.WriteString("\n")
+		if s.Comment != "" {
+			fmt.Fprintf(&b, "%s\n", graphQLString(s.Comment))
+		}
+		fmt.Fprintf(&b, "type %s {\n", strings.TrimPrefix(s.Name, qualifier))
+		for _, f := range s.Fields {
+			name := graphQLFieldName(f.Name)
+			typ := graphQLType(f.Type, isEnum)
+			if typ == "" {
+				fmt.Fprintf(&b, "  # %s is left out: %s can't be bound\n", name, f.Type)
+				continue
+			}
+			if f.Comment != "" {
+				fmt.Fprintf(&b, "  %s\n", graphQLString(f.Comment))
+			}
+			fmt.Fprintf(&b, "  %s: %s\n", name, typ)
+			if strings.Trim(typ, "[]!") == "Time" {
+				usesTime = true
+			}
+		}
+		b.WriteString("}\n")
+	}
+
+	// Time isn't built into GraphQL, but gqlgen binds it to time.Time once
+	// it's declared
+	header := "# Code generated by sqlc. DO NOT EDIT.\n"
+	if usesTime {
+		header += "\nscalar Time\n"
+	}
+	return header + b.String()
+}
+
+// GQLGenModels returns the `models` section of a gqlgen configuration which
+// binds each GraphQL type to its model struct
+func GQLGenModels(structs []GoStruct, settings config.CombinedSettings) string {
+	qualifier := ModelsQualifier(settings)
+	pkg := settings.Go.ImportPath
+	if qualifier != "" {
+		pkg = settings.Go.OutputModelsPackage
+	}
+	var b strings.Builder
+	b.WriteString("# Code generated by sqlc. DO NOT EDIT.\n")
+	b.WriteString("# Merge into the models section of gqlgen.yml.\n")
+	b.WriteString("models:\n")
+	for _, s := range structs {
+		name := strings.TrimPrefix(s.Name, qualifier)
+		fmt.Fprintf(&b, "  %s:\n    model: %s.%s\n", name, pkg, name)
+	}
+	return b.String()
+}
+
+// graphQLFieldName returns the camel case name gqlgen matches against a
+// struct field, e.g. createdAt for CreatedAt
+func graphQLFieldName(name string) string {
+	parts := strings.Split(toSnakeCase(name), "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.Title(parts[i])
+	}
+	return strings.Join(parts, "")
+}
+
+func graphQLString(s string) string {
+	blob, _ := json.Marshal(s)
+	return string(blob)
+}
+
+// A GoLoader caches the rows of a table by the column which foreign keys
+// refer to
+type GoLoader struct {
+	Name     string
+	Struct   string
+	KeyField string
+	KeyType  string
+	Uses     []string
+}
+
+// loaders returns a loader for each column referred to by a single column
+// foreign key. Foreign keys without referenced columns are assumed to refer
+// to the id column.
+func loaders(c core.Catalog, structs []GoStruct, settings config.CombinedSettings) []GoLoader {
+	qualifier := ModelsQualifier(settings)
+	byTable := map[core.FQN]GoStruct{}
+	for _, s := range structs {
+		byTable[s.Table] = s
+	}
+
+	found := map[string]*GoLoader{}
+	for _, s := range structs {
+		table, ok := c.Schemas[s.Table.Schema].Tables[s.Table.Rel]
+		if !ok || len(table.Columns) != len(s.Fields) {
+			continue
+		}
+		for _, fk := range table.ForeignKeys {
+			if len(fk.Columns) != 1 || len(fk.RefColumns) > 1 {
+				continue
+			}
+			ref, ok := byTable[fk.RefTable]
+			refTable, exists := c.Schemas[fk.RefTable.Schema].Tables[fk.RefTable.Rel]
+			if !ok || !exists || len(refTable.Columns) != len(ref.Fields) {
+				continue
+			}
+			refColumn := "id"
+			if len(fk.RefColumns) == 1 {
+				refColumn = fk.RefColumns[0]
+			}
+			var key *GoField
+			for i, col := range refTable.Columns {
+				if col.Name == refColumn {
+					key = &ref.Fields[i]
+				}
+			}
+			// Keys must be comparable, and types from other packages would
+			// need imports
+			if key == nil || strings.HasPrefix(key.Type, "[]") || strings.Contains(key.Type, ".") {
+				continue
+			}
+			use := strings.TrimPrefix(s.Name, qualifier) + "."
+			for i, col := range table.Columns {
+				if col.Name == fk.Columns[0] {
+					use += s.Fields[i].Name
+				}
+			}
+			name := strings.TrimPrefix(ref.Name, qualifier) + "By" + key.Name + "Loader"
+			if l, ok := found[name]; ok {
+				l.Uses = append(l.Uses, use)
+				continue
+			}
+			found[name] = &GoLoader{
+				Name:     name,
+				Struct:   ref.Name,
+				KeyField: key.Name,
+				KeyType:  key.Type,
+				Uses:     []string{use},
+			}
+		}
+	}
+
+	var out []GoLoader
+	for _, l := range found {
+		out = append(out, *l)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
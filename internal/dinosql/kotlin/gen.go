@@ -387,7 +387,8 @@ type Result struct {
 
 func (r Result) KtEnums(settings config.CombinedSettings) []KtEnum {
 	var enums []KtEnum
-	for name, schema := range r.Catalog.Schemas {
+	for _, name := range r.Catalog.SchemaNames() {
+		schema := r.Catalog.Schemas[name]
 		if name == "pg_catalog" {
 			continue
 		}
@@ -413,7 +414,7 @@ func (r Result) KtEnums(settings config.CombinedSettings) []KtEnum {
 		}
 	}
 	if len(enums) > 0 {
-		sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
+		sort.SliceStable(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	}
 	return enums
 }
@@ -435,11 +436,13 @@ func KtMemberName(name string, settings config.CombinedSettings) string {
 
 func (r Result) KtDataClasses(settings config.CombinedSettings) []KtStruct {
 	var structs []KtStruct
-	for name, schema := range r.Catalog.Schemas {
+	for _, name := range r.Catalog.SchemaNames() {
+		schema := r.Catalog.Schemas[name]
 		if name == "pg_catalog" {
 			continue
 		}
-		for _, table := range schema.Tables {
+		for _, rel := range schema.TableNames() {
+			table := schema.Tables[rel]
 			var tableName string
 			if name == "public" {
 				tableName = table.Name
@@ -462,7 +465,7 @@ func (r Result) KtDataClasses(settings config.CombinedSettings) []KtStruct {
 		}
 	}
 	if len(structs) > 0 {
-		sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+		sort.SliceStable(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
 	}
 	return structs
 }
@@ -569,7 +572,7 @@ func (r Result) ktInnerType(col core.Column, settings config.CombinedSettings) (
 		// TODO
 		return "OffsetDateTime", false
 
-	case "text", "pg_catalog.varchar", "pg_catalog.bpchar", "string":
+	case "text", "pg_catalog.varchar", "pg_catalog.bpchar", "string", "citext":
 		return "String", false
 
 	case "uuid":
@@ -604,6 +607,12 @@ func (r Result) ktInnerType(col core.Column, settings config.CombinedSettings) (
 					return KtDataClassName(name+"_"+enum.Name, settings), true
 				}
 			}
+			for _, typ := range schema.Types {
+				// Domains use the type of their base type
+				if domain, ok := typ.(core.Domain); ok && columnType == domain.Name {
+					return r.ktInnerType(core.Column{DataType: domain.BaseType, NotNull: col.NotNull}, settings)
+				}
+			}
 		}
 		log.Printf("unknown PostgreSQL type: %s\n", columnType)
 		return "interface{}", false
@@ -763,7 +772,7 @@ func (r Result) KtQueries(settings config.CombinedSettings) []KtQuery {
 
 		qs = append(qs, gq)
 	}
-	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
+	sort.SliceStable(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
 	return qs
 }
 
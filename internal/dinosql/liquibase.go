@@ -0,0 +1,382 @@
+package dinosql
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Text    string     `xml:",chardata"`
+	Nodes   []xmlNode  `xml:",any"`
+}
+
+func (n xmlNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Liquibase changes which only touch data
+var liquibaseIgnored = map[string]bool{
+	"comment":        true,
+	"delete":         true,
+	"empty":          true,
+	"insert":         true,
+	"loadData":       true,
+	"loadUpdateData": true,
+	"output":         true,
+	"preConditions":  true,
+	"rollback":       true,
+	"stop":           true,
+	"tagDatabase":    true,
+	"update":         true,
+	"validCheckSum":  true,
+}
+
+// Liquibase types which PostgreSQL spells differently
+var liquibaseTypes = map[string]string{
+	"blob":     "bytea",
+	"clob":     "text",
+	"currency": "money",
+	"datetime": "timestamp",
+	"double":   "double precision",
+	"nclob":    "text",
+	"tinyint":  "smallint",
+}
+
+// IsLiquibaseChangelog reports whether an XML file is a Liquibase changelog
+func IsLiquibaseChangelog(filename string) bool {
+	blob, err := ioutil.ReadFile(filename)
+	return err == nil && strings.Contains(string(blob), "<databaseChangeLog")
+}
+
+// LiquibaseSQL returns the DDL for a Liquibase XML changelog, following its
+// include and includeAll elements. Change sets limited to other databases
+// with dbms are skipped, as are changes which only touch data.
+func LiquibaseSQL(filename string) (string, error) {
+	var stmts []string
+	if err := liquibaseFile(filename, &stmts, map[string]bool{}); err != nil {
+		return "", err
+	}
+	return strings.Join(stmts, ";\n") + ";\n", nil
+}
+
+func liquibaseFile(filename string, stmts *[]string, seen map[string]bool) error {
+	if seen[filepath.Clean(filename)] {
+		return nil
+	}
+	seen[filepath.Clean(filename)] = true
+	blob, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	if !strings.HasSuffix(filename, ".xml") {
+		*stmts = append(*stmts, strings.TrimSuffix(strings.TrimSpace(RemoveRollbackStatements(string(blob))), ";"))
+		return nil
+	}
+	var root xmlNode
+	if err := xml.Unmarshal(blob, &root); err != nil {
+		return fmt.Errorf("%s: %s", filename, err)
+	}
+	if root.XMLName.Local != "databaseChangeLog" {
+		return fmt.Errorf("%s: not a Liquibase changelog", filename)
+	}
+	for _, n := range root.Nodes {
+		switch n.XMLName.Local {
+		case "include":
+			if err := liquibaseFile(liquibasePath(filename, n, "file"), stmts, seen); err != nil {
+				return err
+			}
+		case "includeAll":
+			dir := liquibasePath(filename, n, "path")
+			listing, err := ioutil.ReadDir(dir)
+			if err != nil {
+				return err
+			}
+			var names []string
+			for _, f := range listing {
+				if !f.IsDir() && (strings.HasSuffix(f.Name(), ".xml") || strings.HasSuffix(f.Name(), ".sql")) {
+					names = append(names, filepath.Join(dir, f.Name()))
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				if err := liquibaseFile(name, stmts, seen); err != nil {
+					return err
+				}
+			}
+		case "changeSet":
+			if !liquibaseDBMS(n.attr("dbms")) {
+				continue
+			}
+			for _, change := range n.Nodes {
+				sql, err := liquibaseChange(filename, change)
+				if err != nil {
+					return fmt.Errorf("%s: changeSet %s: %s", filename, n.attr("id"), err)
+				}
+				*stmts = append(*stmts, sql...)
+			}
+		}
+	}
+	return nil
+}
+
+// liquibasePath resolves the path in attribute attr of an include
+func liquibasePath(changelog string, n xmlNode, attr string) string {
+	path := filepath.FromSlash(n.attr(attr))
+	if n.attr("relativeToChangelogFile") == "true" {
+		return filepath.Join(filepath.Dir(changelog), path)
+	}
+	return path
+}
+
+// liquibaseDBMS reports whether a dbms attribute includes PostgreSQL
+func liquibaseDBMS(dbms string) bool {
+	if dbms == "" {
+		return true
+	}
+	included := false
+	for _, db := range strings.Split(dbms, ",") {
+		switch strings.TrimSpace(db) {
+		case "postgresql", "all":
+			included = true
+		case "!postgresql", "none":
+			return false
+		default:
+			if strings.HasPrefix(strings.TrimSpace(db), "!") {
+				included = true
+			}
+		}
+	}
+	return included
+}
+
+func liquibaseTable(n xmlNode, attr string) string {
+	if schema := n.attr("schemaName"); schema != "" {
+		return schema + "." + n.attr(attr)
+	}
+	return n.attr(attr)
+}
+
+func liquibaseType(typ string, autoIncrement bool) string {
+	typ = strings.TrimPrefix(typ, "java.sql.Types.")
+	base := strings.ToLower(typ)
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		base = base[:i]
+	}
+	if autoIncrement {
+		switch base {
+		case "bigint", "int8":
+			return "bigserial"
+		case "smallint", "int2":
+			return "smallserial"
+		default:
+			return "serial"
+		}
+	}
+	if t, ok := liquibaseTypes[base]; ok {
+		return t + typ[len(base):]
+	}
+	return typ
+}
+
+// liquibaseDefault returns the SQL for the default value of a column or
+// addDefaultValue element, if it has one
+func liquibaseDefault(n xmlNode) string {
+	switch {
+	case n.attr("defaultValue") != "":
+		return quoteLiteral(n.attr("defaultValue"))
+	case n.attr("defaultValueDate") != "":
+		return quoteLiteral(n.attr("defaultValueDate"))
+	case n.attr("defaultValueComputed") != "":
+		return n.attr("defaultValueComputed")
+	case n.attr("defaultValueNumeric") != "":
+		return n.attr("defaultValueNumeric")
+	}
+	return n.attr("defaultValueBoolean")
+}
+
+// liquibaseColumn returns the definition of a column element
+func liquibaseColumn(col xmlNode) string {
+	def := col.attr("name") + " " + liquibaseType(col.attr("type"), col.attr("autoIncrement") == "true")
+	if value := liquibaseDefault(col); value != "" {
+		def += " DEFAULT " + value
+	}
+	for _, c := range col.Nodes {
+		if c.XMLName.Local != "constraints" {
+			continue
+		}
+		if c.attr("primaryKey") == "true" {
+			def += " PRIMARY KEY"
+		} else if c.attr("nullable") == "false" {
+			def += " NOT NULL"
+		}
+		if c.attr("unique") == "true" {
+			def += " UNIQUE"
+		}
+		if ref := c.attr("references"); ref != "" {
+			def += " REFERENCES " + ref
+		} else if ref := c.attr("referencedTableName"); ref != "" {
+			def += " REFERENCES " + ref
+			if cols := c.attr("referencedColumnNames"); cols != "" {
+				def += " (" + cols + ")"
+			}
+		}
+	}
+	return def
+}
+
+func liquibaseColumns(n xmlNode) []xmlNode {
+	var cols []xmlNode
+	for _, c := range n.Nodes {
+		if c.XMLName.Local == "column" {
+			cols = append(cols, c)
+		}
+	}
+	return cols
+}
+
+func liquibaseComment(target, remarks string) []string {
+	if remarks == "" {
+		return nil
+	}
+	return []string{fmt.Sprintf("COMMENT ON %s IS %s", target, quoteLiteral(remarks))}
+}
+
+// liquibaseChange returns the statements for a change
+func liquibaseChange(changelog string, n xmlNode) ([]string, error) {
+	table := liquibaseTable(n, "tableName")
+	switch n.XMLName.Local {
+	case "sql":
+		return []string{strings.TrimSuffix(strings.TrimSpace(n.Text), ";")}, nil
+	case "sqlFile":
+		blob, err := ioutil.ReadFile(liquibasePath(changelog, n, "path"))
+		if err != nil {
+			return nil, err
+		}
+		return []string{strings.TrimSuffix(strings.TrimSpace(string(blob)), ";")}, nil
+	case "createTable":
+		var defs []string
+		var comments []string
+		for _, col := range liquibaseColumns(n) {
+			defs = append(defs, liquibaseColumn(col))
+			comments = append(comments, liquibaseComment("COLUMN "+table+"."+col.attr("name"), col.attr("remarks"))...)
+		}
+		stmt := fmt.Sprintf("CREATE TABLE %s (\n  %s\n)", table, strings.Join(defs, ",\n  "))
+		return append(append([]string{stmt}, liquibaseComment("TABLE "+table, n.attr("remarks"))...), comments...), nil
+	case "addColumn":
+		var stmts []string
+		for _, col := range liquibaseColumns(n) {
+			stmts = append(stmts, "ALTER TABLE "+table+" ADD COLUMN "+liquibaseColumn(col))
+			stmts = append(stmts, liquibaseComment("COLUMN "+table+"."+col.attr("name"), col.attr("remarks"))...)
+		}
+		return stmts, nil
+	case "dropColumn":
+		var stmts []string
+		if name := n.attr("columnName"); name != "" {
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP COLUMN "+name)
+		}
+		for _, col := range liquibaseColumns(n) {
+			stmts = append(stmts, "ALTER TABLE "+table+" DROP COLUMN "+col.attr("name"))
+		}
+		return stmts, nil
+	case "renameColumn":
+		return []string{fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", table, n.attr("oldColumnName"), n.attr("newColumnName"))}, nil
+	case "renameTable":
+		return []string{fmt.Sprintf("ALTER TABLE %s RENAME TO %s", liquibaseTable(n, "oldTableName"), n.attr("newTableName"))}, nil
+	case "dropTable":
+		stmt := "DROP TABLE " + table
+		if n.attr("cascadeConstraints") == "true" {
+			stmt += " CASCADE"
+		}
+		return []string{stmt}, nil
+	case "modifyDataType":
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", table, n.attr("columnName"), liquibaseType(n.attr("newDataType"), false))}, nil
+	case "addNotNullConstraint":
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", table, n.attr("columnName"))}, nil
+	case "dropNotNullConstraint":
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", table, n.attr("columnName"))}, nil
+	case "addDefaultValue":
+		value := liquibaseDefault(n)
+		if value == "" {
+			return nil, fmt.Errorf("addDefaultValue has no value")
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", table, n.attr("columnName"), value)}, nil
+	case "dropDefaultValue":
+		return []string{fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", table, n.attr("columnName"))}, nil
+	case "addPrimaryKey", "addUniqueConstraint":
+		kind := "PRIMARY KEY"
+		if n.XMLName.Local == "addUniqueConstraint" {
+			kind = "UNIQUE"
+		}
+		stmt := "ALTER TABLE " + table + " ADD "
+		if name := n.attr("constraintName"); name != "" {
+			stmt += "CONSTRAINT " + name + " "
+		}
+		return []string{stmt + kind + " (" + n.attr("columnNames") + ")"}, nil
+	case "addForeignKeyConstraint":
+		stmt := "ALTER TABLE " + liquibaseTable(n, "baseTableName") + " ADD "
+		if name := n.attr("constraintName"); name != "" {
+			stmt += "CONSTRAINT " + name + " "
+		}
+		ref := n.attr("referencedTableName")
+		if schema := n.attr("referencedTableSchemaName"); schema != "" {
+			ref = schema + "." + ref
+		}
+		stmt += fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)", n.attr("baseColumnNames"), ref, n.attr("referencedColumnNames"))
+		if action := n.attr("onDelete"); action != "" {
+			stmt += " ON DELETE " + action
+		}
+		return []string{stmt}, nil
+	case "dropPrimaryKey", "dropUniqueConstraint", "dropForeignKeyConstraint":
+		if n.XMLName.Local == "dropForeignKeyConstraint" {
+			table = liquibaseTable(n, "baseTableName")
+		}
+		name := n.attr("constraintName")
+		if name == "" && n.XMLName.Local == "dropPrimaryKey" {
+			name = n.attr("tableName") + "_pkey"
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", table, name)}, nil
+	case "createIndex":
+		stmt := "CREATE INDEX "
+		if n.attr("unique") == "true" {
+			stmt = "CREATE UNIQUE INDEX "
+		}
+		var cols []string
+		for _, col := range liquibaseColumns(n) {
+			cols = append(cols, col.attr("name"))
+		}
+		return []string{fmt.Sprintf("%s%s ON %s (%s)", stmt, n.attr("indexName"), table, strings.Join(cols, ", "))}, nil
+	case "dropIndex":
+		index := n.attr("indexName")
+		if schema := n.attr("schemaName"); schema != "" {
+			index = schema + "." + index
+		}
+		return []string{"DROP INDEX " + index}, nil
+	case "createView":
+		stmt := "CREATE VIEW "
+		if n.attr("replaceIfExists") == "true" {
+			stmt = "CREATE OR REPLACE VIEW "
+		}
+		return []string{stmt + liquibaseTable(n, "viewName") + " AS " + strings.TrimSuffix(strings.TrimSpace(n.Text), ";")}, nil
+	case "dropView":
+		return []string{"DROP VIEW " + liquibaseTable(n, "viewName")}, nil
+	case "setTableRemarks":
+		return liquibaseComment("TABLE "+table, n.attr("remarks")), nil
+	case "setColumnRemarks":
+		return liquibaseComment("COLUMN "+table+"."+n.attr("columnName"), n.attr("remarks")), nil
+	}
+	if liquibaseIgnored[n.XMLName.Local] {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported change %s", n.XMLName.Local)
+}
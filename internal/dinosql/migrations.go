@@ -5,25 +5,59 @@ import (
 	"strings"
 )
 
-// Remove all lines after a rollback comment.
+// Comments which start the "up" and "down" sections of a migration file
+var (
+	migrationUp = []string{
+		"-- +goose Up",   // goose
+		"-- +migrate Up", // sql-migrate
+		"-- migrate:up",  // dbmate
+	}
+	migrationDown = []string{
+		"-- +goose Down",
+		"-- +migrate Down",
+		"-- migrate:down",
+		"---- create above / drop below ----", // tern
+	}
+)
+
+func hasMarker(line string, markers []string) bool {
+	for _, m := range markers {
+		if len(line) >= len(m) && strings.EqualFold(line[:len(m)], m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove the rollback sections of a migration, so only the "up" direction is
+// applied. A rollback section runs from a rollback comment to the next up
+// comment, or the end of the file.
 //
 // goose:       -- +goose Down
 // sql-migrate: -- +migrate Down
+// dbmate:      -- migrate:down
 // tern: ---- create above / drop below ----
+//
+// Rollback lines are blanked rather than removed, so that errors in later up
+// sections point at the right line, and trailing rollback lines are dropped.
 func RemoveRollbackStatements(contents string) string {
 	s := bufio.NewScanner(strings.NewReader(contents))
 	var lines []string
+	kept := 0
+	down := false
 	for s.Scan() {
-		if strings.HasPrefix(s.Text(), "-- +goose Down") {
-			break
-		}
-		if strings.HasPrefix(s.Text(), "-- +migrate Down") {
-			break
+		switch {
+		case hasMarker(s.Text(), migrationDown):
+			down = true
+		case hasMarker(s.Text(), migrationUp):
+			down = false
 		}
-		if strings.HasPrefix(s.Text(), "---- create above / drop below ----") {
-			break
+		if down {
+			lines = append(lines, "")
+			continue
 		}
 		lines = append(lines, s.Text())
+		kept = len(lines)
 	}
-	return strings.Join(lines, "\n")
+	return strings.Join(lines[:kept], "\n")
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
 )
 
 const inputGoose = `
@@ -47,6 +49,32 @@ const outputTern = `
 -- Write your migrate up statements here
 ALTER TABLE todo RENAME COLUMN done TO is_done;`
 
+const inputDbmate = `-- migrate:up
+CREATE TABLE users (id serial);
+
+-- migrate:down
+DROP TABLE users;
+`
+
+const outputDbmate = `-- migrate:up
+CREATE TABLE users (id serial);
+`
+
+const inputDownFirst = `-- +goose down
+DROP TABLE users;
+-- +goose up
+-- +goose StatementBegin
+CREATE TABLE users (id serial);
+-- +goose StatementEnd
+`
+
+const outputDownFirst = `
+
+-- +goose up
+-- +goose StatementBegin
+CREATE TABLE users (id serial);
+-- +goose StatementEnd`
+
 func TestRemoveRollback(t *testing.T) {
 	if diff := cmp.Diff(outputGoose, RemoveRollbackStatements(inputGoose)); diff != "" {
 		t.Errorf("goose migration mismatch:\n%s", diff)
@@ -57,6 +85,12 @@ func TestRemoveRollback(t *testing.T) {
 	if diff := cmp.Diff(outputTern, RemoveRollbackStatements(inputTern)); diff != "" {
 		t.Errorf("tern migration mismatch:\n%s", diff)
 	}
+	if diff := cmp.Diff(outputDbmate, RemoveRollbackStatements(inputDbmate)); diff != "" {
+		t.Errorf("dbmate migration mismatch:\n%s", diff)
+	}
+	if diff := cmp.Diff(outputDownFirst, RemoveRollbackStatements(inputDownFirst)); diff != "" {
+		t.Errorf("down before up migration mismatch:\n%s", diff)
+	}
 }
 
 func TestRemoveGolangMigrateRollback(t *testing.T) {
@@ -78,3 +112,108 @@ func TestRemoveGolangMigrateRollback(t *testing.T) {
 		t.Errorf("golang-migrate filtering mismatch: \n %s", diff)
 	}
 }
+
+// columns summarizes the columns of each table in the catalog
+func columns(c core.Catalog) map[string][]string {
+	tables := map[string][]string{}
+	for name, schema := range c.Schemas {
+		if name == "pg_catalog" {
+			continue
+		}
+		for _, table := range schema.Tables {
+			var cols []string
+			for _, col := range table.Columns {
+				desc := col.Name + " " + col.DataType
+				if col.IsArray {
+					desc += "[]"
+				}
+				if col.NotNull {
+					desc += " not null"
+				}
+				if col.HasDefault {
+					desc += " default"
+				}
+				cols = append(cols, desc)
+			}
+			tables[name+"."+table.Name] = cols
+		}
+	}
+	return tables
+}
+
+func TestFlywayMigrations(t *testing.T) {
+	files, err := ReadSchemaFiles("./testdata/flyway")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"testdata/flyway/V1__init.sql",
+		"testdata/flyway/V2__name.sql",
+		"testdata/flyway/V10__rename.sql",
+		"testdata/flyway/R__user_names.sql",
+	}
+	if diff := cmp.Diff(want, files); diff != "" {
+		t.Errorf("flyway order mismatch:\n%s", diff)
+	}
+	if _, err := ParseCatalog("./testdata/flyway"); err != nil {
+		t.Fatal(err)
+	}
+
+	baseline := flywayOrder([]string{"B2__base.sql", "V1__init.sql", "V2__name.sql", "V3__more.sql"})
+	if diff := cmp.Diff([]string{"B2__base.sql", "V3__more.sql"}, baseline); diff != "" {
+		t.Errorf("flyway baseline mismatch:\n%s", diff)
+	}
+}
+
+func TestAtlasSchema(t *testing.T) {
+	c, err := ParseCatalog("./testdata/atlas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"public.users": {
+			"id pg_catalog.int8 not null",
+			"email pg_catalog.varchar not null",
+			"bio text",
+			"created_at pg_catalog.timestamptz not null default",
+		},
+		"billing.invoices": {
+			"id pg_catalog.int8 not null",
+			"user_id pg_catalog.int8 not null",
+			"status billing.status not null default",
+			"tags text[]",
+		},
+	}
+	if diff := cmp.Diff(want, columns(c)); diff != "" {
+		t.Errorf("atlas catalog mismatch:\n%s", diff)
+	}
+	if c.Schemas["public"].Tables["users"].Comment != "Everyone who can sign in" {
+		t.Errorf("expected the users table comment to be set")
+	}
+	if len(c.Schemas["public"].Tables["users"].Indexes) != 2 {
+		t.Errorf("expected the users table to have a primary key and unique index")
+	}
+}
+
+func TestLiquibaseChangelog(t *testing.T) {
+	c, err := ParseCatalog("./testdata/liquibase/changelog.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"public.authors": {
+			"id bigserial not null",
+			"name pg_catalog.varchar not null",
+			"born_at pg_catalog.timestamp",
+		},
+		"public.books": {
+			"id bigserial not null",
+			"author_id pg_catalog.int8 not null",
+			"title text not null default",
+			"isbn text",
+		},
+	}
+	if diff := cmp.Diff(want, columns(c)); diff != "" {
+		t.Errorf("liquibase catalog mismatch:\n%s", diff)
+	}
+}
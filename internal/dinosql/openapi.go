@@ -0,0 +1,189 @@
+package dinosql
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// OpenAPIFilename is the name of the document written by `emit_openapi`
+const OpenAPIFilename = "openapi.json"
+
+type openAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty"`
+	AllOf       []*openAPISchema          `json:"allOf,omitempty"`
+	Type        string                    `json:"type,omitempty"`
+	Format      string                    `json:"format,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	Nullable    bool                      `json:"nullable,omitempty"`
+	Enum        []string                  `json:"enum,omitempty"`
+	Items       *openAPISchema            `json:"items,omitempty"`
+	Properties  map[string]*openAPISchema `json:"properties,omitempty"`
+	Required    []string                  `json:"required,omitempty"`
+}
+
+// openAPIScalars maps Go types to the schema of their JSON encoding
+var openAPIScalars = map[string]openAPISchema{
+	"string":          {Type: "string"},
+	"bool":            {Type: "boolean"},
+	"int16":           {Type: "integer", Format: "int32"},
+	"int32":           {Type: "integer", Format: "int32"},
+	"int64":           {Type: "integer", Format: "int64"},
+	"float32":         {Type: "number", Format: "float"},
+	"float64":         {Type: "number", Format: "double"},
+	"time.Time":       {Type: "string", Format: "date-time"},
+	"[]byte":          {Type: "string", Format: "byte", Nullable: true},
+	"uuid.UUID":       {Type: "string", Format: "uuid"},
+	"json.RawMessage": {},
+	"interface{}":     {},
+}
+
+// Nullable types which encode as an object with a Valid field, and the name
+// and type of their value field
+var openAPINullTypes = map[string][2]string{
+	"sql.NullString":  {"String", "string"},
+	"sql.NullBool":    {"Bool", "bool"},
+	"sql.NullInt32":   {"Int32", "int32"},
+	"sql.NullInt64":   {"Int64", "int64"},
+	"sql.NullFloat64": {"Float64", "float64"},
+	"sql.NullTime":    {"Time", "time.Time"},
+	"pq.NullTime":     {"Time", "time.Time"},
+	"uuid.NullUUID":   {"UUID", "uuid.UUID"},
+}
+
+type openAPIGen struct {
+	qualifier string
+	schemas   map[string]*openAPISchema
+	enums     map[string]GoEnum
+	nullEnums map[string]GoEnum
+}
+
+// OpenAPI returns an OpenAPI document whose component schemas describe the
+// JSON encoding of the model structs, the row structs returned by queries and
+// the enums they use
+func OpenAPI(structs []GoStruct, queries []GoQuery, enums []GoEnum, settings config.CombinedSettings) (string, error) {
+	qualifier := ModelsQualifier(settings)
+	g := openAPIGen{
+		qualifier: qualifier,
+		schemas:   map[string]*openAPISchema{},
+		enums:     map[string]GoEnum{},
+		nullEnums: map[string]GoEnum{},
+	}
+	for _, enum := range enums {
+		g.enums[qualifier+enum.Name] = enum
+		g.nullEnums[qualifier+"Null"+enum.Name] = enum
+	}
+	for _, s := range structs {
+		g.schemas[strings.TrimPrefix(s.Name, qualifier)] = g.object(s)
+	}
+	for _, q := range queries {
+		if q.Ret.EmitStruct() {
+			g.schemas[q.Ret.Struct.Name] = g.object(*q.Ret.Struct)
+		}
+	}
+
+	doc := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]string{
+			"title":   settings.Go.Package,
+			"version": "0.0.0",
+		},
+		"paths":      map[string]interface{}{},
+		"components": map[string]interface{}{"schemas": g.schemas},
+	}
+	blob, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(blob) + "\n", nil
+}
+
+func (g *openAPIGen) object(s GoStruct) *openAPISchema {
+	obj := &openAPISchema{
+		Type:        "object",
+		Description: s.Comment,
+		Properties:  map[string]*openAPISchema{},
+	}
+	for _, f := range s.Fields {
+		name, omitempty := f.Name, false
+		if tag, ok := f.Tags["json:"]; ok {
+			parts := strings.Split(tag, ",")
+			if parts[0] == "-" && len(parts) == 1 {
+				continue
+			}
+			if parts[0] != "" {
+				name = parts[0]
+			}
+			for _, opt := range parts[1:] {
+				omitempty = omitempty || opt == "omitempty"
+			}
+		}
+		prop := g.schema(f.Type)
+		if f.Comment != "" {
+			prop.Description = f.Comment
+		}
+		obj.Properties[name] = prop
+		if !omitempty {
+			obj.Required = append(obj.Required, name)
+		}
+	}
+	return obj
+}
+
+// schema returns the schema for a Go type, adding any enums it refers to
+func (g *openAPIGen) schema(goType string) *openAPISchema {
+	if s, ok := openAPIScalars[goType]; ok {
+		return &s
+	}
+	switch goType {
+	case g.qualifier + "Inet", g.qualifier + "MacAddr", g.qualifier + "Duration":
+		// The types declared with the models encode as text
+		return &openAPISchema{Type: "string"}
+	}
+	if null, ok := openAPINullTypes[goType]; ok {
+		return nullObject(null[0], g.schema(null[1]))
+	}
+	if enum, ok := g.enums[goType]; ok {
+		return g.enumRef(enum)
+	}
+	if enum, ok := g.nullEnums[goType]; ok {
+		return nullObject(enum.Name, g.enumRef(enum))
+	}
+	if strings.HasPrefix(goType, "*") {
+		s := g.schema(strings.TrimPrefix(goType, "*"))
+		if s.Ref != "" {
+			s = &openAPISchema{AllOf: []*openAPISchema{s}}
+		}
+		s.Nullable = true
+		return s
+	}
+	if strings.HasPrefix(goType, "[]") {
+		return &openAPISchema{Type: "array", Items: g.schema(strings.TrimPrefix(goType, "[]")), Nullable: true}
+	}
+	return &openAPISchema{Description: "Go type " + goType}
+}
+
+func (g *openAPIGen) enumRef(enum GoEnum) *openAPISchema {
+	if _, ok := g.schemas[enum.Name]; !ok {
+		s := &openAPISchema{Type: "string", Description: enum.Comment}
+		for _, c := range enum.Constants {
+			s.Enum = append(s.Enum, c.Value)
+		}
+		g.schemas[enum.Name] = s
+	}
+	return &openAPISchema{Ref: "#/components/schemas/" + enum.Name}
+}
+
+// nullObject returns the schema of a struct like sql.NullString, which has a
+// value field and a Valid field
+func nullObject(field string, value *openAPISchema) *openAPISchema {
+	return &openAPISchema{
+		Type: "object",
+		Properties: map[string]*openAPISchema{
+			field:   value,
+			"Valid": {Type: "boolean"},
+		},
+		Required: []string{field, "Valid"},
+	}
+}
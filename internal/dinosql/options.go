@@ -0,0 +1,86 @@
+package dinosql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// QueryOptions tune the code generated for a single query. They're set by
+// `-- key: value` comments after the query's name.
+type QueryOptions struct {
+	// Timeout bounds how long the query may run
+	Timeout time.Duration `json:",omitempty"`
+
+	// Retries is the number of times a failed query is run again
+	Retries int `json:",omitempty"`
+
+	// Nullable lists the output columns treated as nullable, whatever the
+	// schema says, such as those of an outer join the compiler can't see
+	// through
+	Nullable []string `json:",omitempty"`
+}
+
+// queryOptionKeys are the keys which mark a comment as a query option. Other
+// comments are left alone, so `-- Note: ...` stays part of the doc comment.
+var queryOptionKeys = []string{"timeout", "retries", "nullable"}
+
+// queryOptions removes the option comments from a query's comments, returning
+// the options they set. Nullable columns are marked in cols.
+func queryOptions(comments []string, cmd string, cols []core.Column) ([]string, QueryOptions, error) {
+	var rest []string
+	var opts QueryOptions
+	seen := map[string]bool{}
+	for _, comment := range comments {
+		parts := strings.SplitN(strings.TrimSpace(comment), ":", 2)
+		key := parts[0]
+		if len(parts) != 2 || !contains(queryOptionKeys, key) {
+			rest = append(rest, comment)
+			continue
+		}
+		if seen[key] {
+			return nil, opts, fmt.Errorf("query option %q given more than once", key)
+		}
+		seen[key] = true
+		val := strings.TrimSpace(parts[1])
+
+		switch key {
+		case "timeout":
+			d, err := time.ParseDuration(val)
+			if err != nil || d <= 0 {
+				return nil, opts, fmt.Errorf("invalid timeout %q: must be a positive duration, such as 5s or 250ms", val)
+			}
+			opts.Timeout = d
+
+		case "retries":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 0 {
+				return nil, opts, fmt.Errorf("invalid retries %q: must be a non-negative integer", val)
+			}
+			if cmd == ":iter" && n > 0 {
+				return nil, opts, fmt.Errorf("retries can't be used with :iter queries, whose rows have already been returned")
+			}
+			opts.Retries = n
+
+		case "nullable":
+			for _, name := range strings.Split(val, ",") {
+				name = strings.TrimSpace(name)
+				found := false
+				for i := range cols {
+					if cols[i].Name == name {
+						cols[i].NotNull = false
+						found = true
+					}
+				}
+				if !found {
+					return nil, opts, fmt.Errorf("nullable names %q, which isn't an output column of the query", name)
+				}
+				opts.Nullable = append(opts.Nullable, name)
+			}
+		}
+	}
+	return rest, opts, nil
+}
@@ -2,14 +2,17 @@ package dinosql
 
 import (
 	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"unicode"
 
 	"github.com/kyleconroy/sqlc/internal/catalog"
@@ -60,27 +63,14 @@ func (e *ParserErr) Error() string {
 }
 
 func ReadSQLFiles(path string) ([]string, error) {
-	f, err := os.Stat(path)
+	files, err := listFiles(path)
 	if err != nil {
-		return nil, fmt.Errorf("path %s does not exist", path)
-	}
-
-	var files []string
-	if f.IsDir() {
-		listing, err := ioutil.ReadDir(path)
-		if err != nil {
-			return nil, err
-		}
-		for _, f := range listing {
-			files = append(files, filepath.Join(path, f.Name()))
-		}
-	} else {
-		files = append(files, path)
+		return nil, err
 	}
 
 	var sql []string
 	for _, filename := range files {
-		if !strings.HasSuffix(filename, ".sql") {
+		if filename != Stdin && !strings.HasSuffix(filename, ".sql") {
 			continue
 		}
 		if strings.HasPrefix(filepath.Base(filename), ".") {
@@ -92,30 +82,92 @@ func ReadSQLFiles(path string) ([]string, error) {
 		}
 		sql = append(sql, filename)
 	}
-	return sql, nil
+	return flywayOrder(sql), nil
+}
+
+// ReadSchemaFiles returns the schema files named by path. Along with the SQL
+// files returned by ReadSQLFiles, these include Atlas HCL files and Liquibase
+// XML changelogs.
+func ReadSchemaFiles(path string) ([]string, error) {
+	files, err := listFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	var schema []string
+	for _, filename := range files {
+		if filename == Stdin {
+			schema = append(schema, filename)
+			continue
+		}
+		if strings.HasPrefix(filepath.Base(filename), ".") {
+			continue
+		}
+		switch filepath.Ext(filename) {
+		case ".sql":
+			if !strings.HasSuffix(filename, ".down.sql") {
+				schema = append(schema, filename)
+			}
+		case ".hcl":
+			schema = append(schema, filename)
+		case ".xml":
+			if IsLiquibaseChangelog(filename) {
+				schema = append(schema, filename)
+			}
+		}
+	}
+	return flywayOrder(schema), nil
+}
+
+// schemaSQL returns the DDL held in a schema file
+func schemaSQL(filename string) (string, error) {
+	switch filepath.Ext(filename) {
+	case ".hcl":
+		blob, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return "", err
+		}
+		return AtlasSQL(string(blob))
+	case ".xml":
+		return LiquibaseSQL(filename)
+	}
+	blob, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	return RemoveRollbackStatements(string(blob)), nil
 }
 
 func ParseCatalog(schema string) (core.Catalog, error) {
-	files, err := ReadSQLFiles(schema)
+	return ParseCatalogSources(schema, nil)
+}
+
+// ParseCatalogSources parses the schema like ParseCatalog, but uses the SQL in
+// sources, keyed by file name, in place of the contents of those files on
+// disk. Editors use it to check files which haven't been saved.
+func ParseCatalogSources(schema string, sources map[string]string) (core.Catalog, error) {
+	files, err := ReadSchemaFiles(schema)
 	if err != nil {
 		return core.Catalog{}, err
 	}
 
+	// Files are read and parsed concurrently, then applied to the catalog in
+	// order, as later migrations depend on earlier ones
+	parsed := parseSchemaFiles(files, sources)
+
 	merr := NewParserErr()
 	c := core.NewCatalog()
-	for _, filename := range files {
-		blob, err := ioutil.ReadFile(filename)
-		if err != nil {
-			merr.Add(filename, "", 0, err)
+	for i, filename := range files {
+		file := parsed[i]
+		if file.readErr != nil {
+			merr.Add(filename, "", 0, file.readErr)
 			continue
 		}
-		contents := RemoveRollbackStatements(string(blob))
-		tree, err := pg.Parse(contents)
-		if err != nil {
-			merr.Add(filename, contents, 0, err)
+		contents := file.contents
+		if file.parseErr != nil {
+			merr.Add(filename, contents, 0, file.parseErr)
 			continue
 		}
-		for _, stmt := range tree.Statements {
+		for _, stmt := range file.tree.Statements {
 			if err := validateFuncCall(&c, stmt); err != nil {
 				merr.Add(filename, contents, location(stmt), err)
 				continue
@@ -124,6 +176,9 @@ func ParseCatalog(schema string) (core.Catalog, error) {
 				merr.Add(filename, contents, location(stmt), err)
 				continue
 			}
+			if raw, ok := stmt.(nodes.RawStmt); ok {
+				traceStatement(filename, contents, raw)
+			}
 		}
 	}
 
@@ -137,6 +192,46 @@ func ParseCatalog(schema string) (core.Catalog, error) {
 	return c, nil
 }
 
+type schemaFile struct {
+	contents string
+	tree     pg.ParsetreeList
+	readErr  error
+	parseErr error
+}
+
+// parseSchemaFiles reads and parses each of files, using GOMAXPROCS
+// goroutines. A file's SQL is taken from sources if it's there.
+func parseSchemaFiles(files []string, sources map[string]string) []schemaFile {
+	parsed := make([]schemaFile, len(files))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(files) {
+		workers = len(files)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				filename, file := files[i], &parsed[i]
+				if source, ok := sources[filename]; ok && (filepath.Ext(filename) == ".sql" || filename == Stdin) {
+					file.contents = RemoveRollbackStatements(source)
+				} else if file.contents, file.readErr = schemaSQL(filename); file.readErr != nil {
+					continue
+				}
+				file.tree, file.parseErr = pg.Parse(file.contents)
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return parsed
+}
+
 func updateCatalog(c *core.Catalog, tree pg.ParsetreeList) error {
 	for _, stmt := range tree.Statements {
 		if err := validateFuncCall(c, stmt); err != nil {
@@ -183,6 +278,17 @@ type Query struct {
 	Name     string
 	Cmd      string // TODO: Pick a better name. One of: one, many, exec, execrows
 	Comments []string
+	Tables   []core.FQN
+
+	Options QueryOptions
+
+	// VetDisable lists the `sqlc vet` rules turned off for the query by a
+	// `-- vet-disable:` comment
+	VetDisable []string
+
+	// Stmt is the statement as written, before stars are expanded. It isn't
+	// kept in the query cache.
+	Stmt nodes.Node `json:"-"`
 
 	// XXX: Hack
 	Filename string
@@ -195,50 +301,116 @@ type Result struct {
 
 type ParserOpts struct {
 	UsePositionalParameters bool
+
+	// Generated holds query sources, keyed by the path they're written to,
+	// which are parsed after the files in the queries path.
+	Generated map[string]string
+
+	// Only, if set, limits parsing to the queries with these names
+	Only map[string]bool
+
+	// Sources holds the contents of query files, keyed by path, which are
+	// used in place of reading them. The queries on Stdin must be passed
+	// here.
+	Sources map[string]string
+
+	// VetRules names the custom `sqlc vet` rules, which `-- vet-disable:`
+	// comments may turn off along with the built-in ones
+	VetRules []string
+
+	// Cache, if set along with CacheKey, holds the queries parsed from each
+	// file. CacheKey must change whenever anything besides the file itself
+	// would change its queries: the catalog, settings and sqlc version.
+	Cache    QueryCache
+	CacheKey string
+}
+
+// A QueryCache stores the queries parsed from a file. Queries returned by Get
+// have no Stmt.
+type QueryCache interface {
+	Get(key string) ([]*Query, bool)
+	Put(key string, queries []*Query)
 }
 
 func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, error) {
-	f, err := os.Stat(queries)
+	files, err := listFiles(queries)
 	if err != nil {
-		return nil, fmt.Errorf("path %s does not exist", queries)
-	}
-
-	var files []string
-	if f.IsDir() {
-		listing, err := ioutil.ReadDir(queries)
-		if err != nil {
-			return nil, err
-		}
-		for _, f := range listing {
-			files = append(files, filepath.Join(queries, f.Name()))
-		}
-	} else {
-		files = append(files, queries)
+		return nil, err
 	}
 
 	merr := NewParserErr()
 	var q []*Query
 	set := map[string]struct{}{}
+	var sources []string
+	var generated []string
+	for filename := range opts.Generated {
+		generated = append(generated, filename)
+	}
+	sort.Strings(generated)
+files:
 	for _, filename := range files {
-		if !strings.HasSuffix(filename, ".sql") {
+		for _, gen := range generated {
+			// Skip generated files left over from an earlier run
+			if filepath.Clean(filename) == filepath.Clean(gen) {
+				continue files
+			}
+			// Queries are generated into a file named after their source,
+			// so the two would be written to the same file
+			if filepath.Base(filename) == filepath.Base(gen) {
+				merr.Add(filename, "", 0, fmt.Errorf("query file has the same name as the generated %s", filepath.Base(gen)))
+				continue files
+			}
+		}
+		sources = append(sources, filename)
+	}
+	for _, filename := range append(sources, generated...) {
+		if filename != Stdin && !strings.HasSuffix(filename, ".sql") {
 			continue
 		}
 		if strings.HasPrefix(filepath.Base(filename), ".") {
 			continue
 		}
-		blob, err := ioutil.ReadFile(filename)
-		if err != nil {
-			merr.Add(filename, "", 0, err)
-			continue
+		source, ok := opts.Generated[filename]
+		if !ok {
+			source, ok = opts.Sources[filename]
+		}
+		if !ok {
+			blob, err := ioutil.ReadFile(filename)
+			if err != nil {
+				merr.Add(filename, "", 0, err)
+				continue
+			}
+			source = string(blob)
+		}
+		var key string
+		if opts.Cache != nil && opts.CacheKey != "" && opts.Only == nil {
+			sum := sha256.Sum256([]byte(opts.CacheKey + "\x00" + filepath.Base(filename) + "\x00" + source))
+			key = hex.EncodeToString(sum[:])
+			if cached, ok := opts.Cache.Get(key); ok {
+				for _, query := range cached {
+					if query.Name != "" {
+						if _, exists := set[query.Name]; exists {
+							merr.Add(filename, source, 0, fmt.Errorf("duplicate query name: %s", query.Name))
+							continue
+						}
+						set[query.Name] = struct{}{}
+					}
+					q = append(q, query)
+				}
+				continue
+			}
 		}
-		source := string(blob)
 		tree, err := pg.Parse(source)
 		if err != nil {
 			merr.Add(filename, source, 0, err)
 			continue
 		}
+		errs, start := len(merr.Errs), len(q)
 		for _, stmt := range tree.Statements {
-			query, err := parseQuery(c, stmt, source, opts.UsePositionalParameters)
+			if opts.Only != nil && !opts.Only[queryName(source, stmt)] {
+				continue
+			}
+			query, err := parseQuery(c, stmt, source, opts.UsePositionalParameters, opts.VetRules)
 			if err == errUnsupportedStatementType {
 				continue
 			}
@@ -258,11 +430,14 @@ func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, err
 				q = append(q, query)
 			}
 		}
+		if key != "" && len(merr.Errs) == errs {
+			opts.Cache.Put(key, q[start:])
+		}
 	}
 	if len(merr.Errs) > 0 {
 		return nil, merr
 	}
-	if len(q) == 0 {
+	if len(q) == 0 && opts.Only == nil {
 		return nil, fmt.Errorf("path %s contains no queries", queries)
 	}
 	return &Result{
@@ -271,6 +446,20 @@ func ParseQueries(c core.Catalog, queries string, opts ParserOpts) (*Result, err
 	}, nil
 }
 
+// queryName returns the name given to a statement by its metadata comment
+func queryName(source string, stmt nodes.Node) string {
+	raw, ok := stmt.(nodes.RawStmt)
+	if !ok {
+		return ""
+	}
+	rawSQL, err := pluckQuery(source, raw)
+	if err != nil {
+		return ""
+	}
+	name, _, _ := ParseMetadata(strings.TrimSpace(rawSQL), CommentSyntaxDash)
+	return name
+}
+
 func location(node nodes.Node) int {
 	switch n := node.(type) {
 	case nodes.Query:
@@ -329,6 +518,41 @@ func rangeVars(root nodes.Node) []nodes.RangeVar {
 	return vars
 }
 
+// rangeTable returns the name of the table rv refers to. Tables which aren't
+// schema-qualified are looked for in pg_catalog when public doesn't have them,
+// so system catalogs such as pg_class can be queried by their names.
+func rangeTable(c core.Catalog, rv *nodes.RangeVar) (core.FQN, error) {
+	fqn, err := catalog.ParseRange(rv)
+	if err != nil || rv.Schemaname != nil {
+		return fqn, err
+	}
+	if _, ok := c.Schemas[fqn.Schema].Tables[fqn.Rel]; !ok {
+		if _, ok := c.Schemas["pg_catalog"].Tables[fqn.Rel]; ok {
+			fqn.Schema = "pg_catalog"
+		}
+	}
+	return fqn, nil
+}
+
+// referencedTables returns the tables in c named by rvs, without duplicates.
+// Common table expressions are left out.
+func referencedTables(c core.Catalog, rvs []nodes.RangeVar) []core.FQN {
+	var tables []core.FQN
+	seen := map[core.FQN]bool{}
+	for i := range rvs {
+		fqn, err := rangeTable(c, &rvs[i])
+		if err != nil || seen[fqn] {
+			continue
+		}
+		if _, ok := c.Schemas[fqn.Schema].Tables[fqn.Rel]; !ok {
+			continue
+		}
+		seen[fqn] = true
+		tables = append(tables, fqn)
+	}
+	return tables
+}
+
 // A query name must be a valid Go identifier
 //
 // https://golang.org/ref/spec#Identifiers
@@ -370,7 +594,7 @@ func ParseMetadata(t string, commentStyle CommentSyntax) (string, string, error)
 			part = part[:len(part)-1] // removes the trailing "*/" element
 		}
 		if len(part) == 2 {
-			return "", "", fmt.Errorf("missing query type [':one', ':many', ':exec', ':execrows']: %s", line)
+			return "", "", fmt.Errorf("missing query type [':one', ':many', ':iter', ':exec', ':execrows']: %s", line)
 		}
 		if len(part) != 4 {
 			return "", "", fmt.Errorf("invalid query comment: %s", line)
@@ -378,7 +602,7 @@ func ParseMetadata(t string, commentStyle CommentSyntax) (string, string, error)
 		queryName := part[2]
 		queryType := strings.TrimSpace(part[3])
 		switch queryType {
-		case ":one", ":many", ":exec", ":execrows":
+		case ":one", ":many", ":iter", ":exec", ":execrows":
 		default:
 			return "", "", fmt.Errorf("invalid query type: %s", queryType)
 		}
@@ -392,7 +616,7 @@ func ParseMetadata(t string, commentStyle CommentSyntax) (string, string, error)
 
 func validateCmd(n nodes.Node, name, cmd string) error {
 	// TODO: Convert cmd to an enum
-	if !(cmd == ":many" || cmd == ":one") {
+	if !(cmd == ":many" || cmd == ":one" || cmd == ":iter") {
 		return nil
 	}
 	var list nodes.List
@@ -416,7 +640,7 @@ func validateCmd(n nodes.Node, name, cmd string) error {
 
 var errUnsupportedStatementType = errors.New("parseQuery: unsupported statement type")
 
-func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameters bool) (*Query, error) {
+func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameters bool, customRules []string) (*Query, error) {
 	if err := validateParamRef(stmt); err != nil {
 		return nil, err
 	}
@@ -470,7 +694,8 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 		refs = uniqueParamRefs(refs)
 		sort.Slice(refs, func(i, j int) bool { return refs[i].ref.Number < refs[j].ref.Number })
 	}
-	params, err := resolveCatalogRefs(c, rvs, refs, namedParams)
+	explain := newInferenceTrace()
+	params, err := resolveCatalogRefs(c, rvs, refs, namedParams, explain)
 	if err != nil {
 		return nil, err
 	}
@@ -479,10 +704,12 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 	if err != nil {
 		return nil, err
 	}
+	qc.trace = explain
 	cols, err := outputColumns(qc, raw.Stmt)
 	if err != nil {
 		return nil, err
 	}
+	explain.log(name)
 
 	expandEdits, err := expand(qc, raw)
 	if err != nil {
@@ -506,6 +733,14 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 	if err != nil {
 		return nil, err
 	}
+	comments, vetDisable, err := vetDirectives(comments, customRules)
+	if err != nil {
+		return nil, err
+	}
+	comments, opts, err := queryOptions(comments, cmd, cols)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Query{
 		Cmd:      cmd,
@@ -514,6 +749,11 @@ func parseQuery(c core.Catalog, stmt nodes.Node, source string, rewriteParameter
 		Params:   params,
 		Columns:  cols,
 		SQL:      trimmed,
+		Tables:   referencedTables(c, rvs),
+		Stmt:     raw.Stmt,
+
+		Options:    opts,
+		VetDisable: vetDisable,
 	}, nil
 }
 
@@ -690,6 +930,7 @@ func editQuery(raw string, a []edit) (string, error) {
 type QueryCatalog struct {
 	catalog core.Catalog
 	ctes    map[string]core.Table
+	trace   *inferenceTrace
 }
 
 func buildQueryCatalog(c core.Catalog, node nodes.Node) (*QueryCatalog, error) {
@@ -775,7 +1016,7 @@ func sourceTables(qc *QueryCatalog, node nodes.Node) ([]core.Table, error) {
 	for _, item := range list.Items {
 		switch n := item.(type) {
 		case nodes.RangeVar:
-			fqn, err := catalog.ParseRange(&n)
+			fqn, err := rangeTable(qc.catalog, &n)
 			if err != nil {
 				return nil, err
 			}
@@ -842,15 +1083,30 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 			if res.Name != nil {
 				name = *res.Name
 			}
+			op := join(n.Name, "")
+			if cop, notNull, ok := exprOperator(qc.catalog, tables, n); ok {
+				// Tests are assumed to return a value, as comparisons are
+				col := typeColumn(name, cop.ReturnType)
+				col.NotNull = notNull
+				qc.trace.column(col, "result of the operator %s on %s", op, cop.Left)
+				cols = append(cols, col)
+				continue
+			}
 			switch {
-			case postgres.IsComparisonOperator(join(n.Name, "")):
+			case postgres.IsComparisonOperator(op):
 				// TODO: Generate a name for these operations
-				cols = append(cols, core.Column{Name: name, DataType: "bool", NotNull: true})
-			case postgres.IsMathematicalOperator(join(n.Name, "")):
+				col := core.Column{Name: name, DataType: "bool", NotNull: true}
+				qc.trace.column(col, "result of the comparison operator %s", op)
+				cols = append(cols, col)
+			case postgres.IsMathematicalOperator(op):
 				// TODO: Generate correct numeric type
-				cols = append(cols, core.Column{Name: name, DataType: "pg_catalog.int4", NotNull: true})
+				col := core.Column{Name: name, DataType: "pg_catalog.int4", NotNull: true}
+				qc.trace.column(col, "result of the mathematical operator %s is assumed to be an integer", op)
+				cols = append(cols, col)
 			default:
-				cols = append(cols, core.Column{Name: name, DataType: "any", NotNull: false})
+				col := core.Column{Name: name, DataType: "any", NotNull: false}
+				qc.trace.column(col, "the type of the operator %s is unknown", op)
+				cols = append(cols, col)
 			}
 
 		case nodes.CaseExpr:
@@ -873,9 +1129,12 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 				// TODO Validate column names
 				col := catalog.ToColumn(tc.TypeName)
 				col.Name = name
+				qc.trace.column(col, "the ELSE result of the CASE is cast to its type")
 				cols = append(cols, col)
 			} else {
-				cols = append(cols, core.Column{Name: name, DataType: "any", NotNull: false})
+				col := core.Column{Name: name, DataType: "any", NotNull: false}
+				qc.trace.column(col, "the type of a CASE is only known when its ELSE result is cast")
+				cols = append(cols, col)
 			}
 
 		case nodes.CoalesceExpr:
@@ -887,6 +1146,7 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 					}
 					for _, c := range columns {
 						c.NotNull = true
+						qc.trace.column(c, "type of the first column passed to COALESCE, which is assumed to return a value")
 						cols = append(cols, c)
 					}
 				}
@@ -905,14 +1165,16 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 						if res.Name != nil {
 							cname = *res.Name
 						}
-						cols = append(cols, core.Column{
+						col := core.Column{
 							Table:    t.ID,
 							Name:     cname,
 							Scope:    scope,
 							DataType: c.DataType,
 							NotNull:  c.NotNull,
 							IsArray:  c.IsArray,
-						})
+						}
+						qc.trace.column(col, "expanded from * as column %s.%s", t.Name, c.Name)
+						cols = append(cols, col)
 					}
 				}
 				continue
@@ -922,6 +1184,13 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 			if err != nil {
 				return nil, err
 			}
+			for _, c := range columns {
+				if c.Table.Rel != "" {
+					qc.trace.column(c, "column %s of table %s", join(n.Fields, "."), c.Table.Rel)
+				} else {
+					qc.trace.column(c, "column %s", join(n.Fields, "."))
+				}
+			}
 			cols = append(cols, columns...)
 
 		case nodes.FuncCall:
@@ -935,11 +1204,20 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 				name = *res.Name
 			}
 
-			fun, err := qc.catalog.LookupFunctionN(fqn, len(n.Args.Items))
+			fun, err := lookupFuncCall(qc.catalog, tables, fqn, n)
 			if err == nil {
-				cols = append(cols, core.Column{Name: name, DataType: fun.ReturnType, NotNull: true})
+				col := typeColumn(name, fun.ReturnType)
+				col.NotNull = !fun.Nullable
+				if fun.Nullable {
+					qc.trace.column(col, "return type of the function %s, which may return NULL", fun.Name)
+				} else {
+					qc.trace.column(col, "return type of the function %s, which is assumed to return a value", fun.Name)
+				}
+				cols = append(cols, col)
 			} else {
-				cols = append(cols, core.Column{Name: name, DataType: "any"})
+				col := core.Column{Name: name, DataType: "any"}
+				qc.trace.column(col, "the function %s with %d arguments isn't in the catalog", fqn.Rel, len(n.Args.Items))
+				cols = append(cols, col)
 			}
 
 		case nodes.TypeCast:
@@ -956,6 +1234,30 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 			// TODO Validate column names
 			col := catalog.ToColumn(n.TypeName)
 			col.Name = name
+			qc.trace.column(col, "cast to its type")
+			cols = append(cols, col)
+
+		case nodes.XmlExpr:
+			name := ""
+			if res.Name != nil {
+				name = *res.Name
+			}
+			col := core.Column{Name: name, DataType: "xml", NotNull: true}
+			if n.Op == nodes.IS_DOCUMENT {
+				col.DataType = "bool"
+			}
+			qc.trace.column(col, "result of an XML expression")
+			cols = append(cols, col)
+
+		case nodes.XmlSerialize:
+			if n.TypeName == nil {
+				return nil, errors.New("no type name in XMLSERIALIZE")
+			}
+			col := catalog.ToColumn(n.TypeName)
+			if res.Name != nil {
+				col.Name = *res.Name
+			}
+			qc.trace.column(col, "XMLSERIALIZE to its type")
 			cols = append(cols, col)
 
 		default:
@@ -963,13 +1265,115 @@ func outputColumns(qc *QueryCatalog, node nodes.Node) ([]core.Column, error) {
 			if res.Name != nil {
 				name = *res.Name
 			}
-			cols = append(cols, core.Column{Name: name, DataType: "any", NotNull: false})
+			col := core.Column{Name: name, DataType: "any", NotNull: false}
+			qc.trace.column(col, "the type of a %s expression is unknown", strings.TrimPrefix(fmt.Sprintf("%T", n), "nodes."))
+			cols = append(cols, col)
 
 		}
 	}
 	return cols, nil
 }
 
+// exprOperator returns the catalog operator used by n, when its left operand
+// is of a type the operator takes, such as an hstore column or the result of
+// now(). The second result reports whether the operator's result is known not
+// to be NULL.
+func exprOperator(c core.Catalog, tables []core.Table, n nodes.A_Expr) (core.Operator, bool, bool) {
+	left, ok := operandColumn(c, tables, n.Lexpr)
+	if !ok || left.DataType == "" {
+		return core.Operator{}, false, false
+	}
+	right, rightKnown := operandColumn(c, tables, n.Rexpr)
+	op := join(n.Name, "")
+	cop, found := c.LookupOperator(op, arrayType(left), arrayType(right))
+	if !found && right.DataType != "" {
+		// Literals and other operands may be cast to the type
+		cop, found = c.LookupOperator(op, arrayType(left), "")
+	}
+	notNull := cop.ReturnType == "bool" || (!cop.Nullable && left.NotNull && rightKnown && right.NotNull)
+	return cop, notNull, found
+}
+
+// operandColumn returns a column with the type of an operator's operand, and
+// whether it's known not to be NULL. The type is empty for literals which may
+// be of any type.
+func operandColumn(c core.Catalog, tables []core.Table, node nodes.Node) (core.Column, bool) {
+	switch n := node.(type) {
+	case nodes.A_Const:
+		switch n.Val.(type) {
+		case nodes.Null:
+			return core.Column{}, true
+		case nodes.String:
+			return core.Column{DataType: "text", NotNull: true}, true
+		}
+		return core.Column{NotNull: true}, true
+	case nodes.A_ArrayExpr:
+		return core.Column{DataType: "text", IsArray: true, NotNull: true}, true
+	case nodes.ColumnRef:
+		cols, err := outputColumnRefs(nodes.ResTarget{}, tables, n)
+		if err != nil {
+			return core.Column{}, false
+		}
+		return cols[0], true
+	case nodes.FuncCall:
+		fqn, err := catalog.ParseList(n.Funcname)
+		if err != nil {
+			return core.Column{}, false
+		}
+		fun, err := lookupFuncCall(c, tables, fqn, n)
+		if err != nil {
+			return core.Column{}, false
+		}
+		col := typeColumn("", fun.ReturnType)
+		col.NotNull = !fun.Nullable
+		return col, true
+	case nodes.ParamRef:
+		return core.Column{NotNull: true}, true
+	case nodes.TypeCast:
+		if n.TypeName == nil {
+			return core.Column{}, false
+		}
+		col := catalog.ToColumn(n.TypeName)
+		arg, ok := operandColumn(c, tables, n.Arg)
+		col.NotNull = ok && arg.NotNull
+		return col, true
+	}
+	return core.Column{}, false
+}
+
+// lookupFuncCall returns the function fqn called by n, preferring one which
+// takes the types of n's arguments, such as lower of a range rather than of
+// text. Otherwise the first function taking as many arguments is used.
+func lookupFuncCall(c core.Catalog, tables []core.Table, fqn core.FQN, n nodes.FuncCall) (core.Function, error) {
+	args := make([]string, len(n.Args.Items))
+	for i, arg := range n.Args.Items {
+		if col, ok := operandColumn(c, tables, arg); ok {
+			args[i] = arrayType(col)
+		}
+	}
+	if fun, err := c.LookupFunctionArgs(fqn, args); err == nil {
+		return fun, nil
+	}
+	return c.LookupFunctionN(fqn, len(n.Args.Items))
+}
+
+// arrayType returns the type of col, ending in [] if it's an array
+func arrayType(col core.Column) string {
+	if col.IsArray {
+		return col.DataType + "[]"
+	}
+	return col.DataType
+}
+
+// typeColumn returns a column named name of the type typ, which is an array
+// if typ ends in []
+func typeColumn(name, typ string) core.Column {
+	if strings.HasSuffix(typ, "[]") {
+		return core.Column{Name: name, DataType: strings.TrimSuffix(typ, "[]"), IsArray: true}
+	}
+	return core.Column{Name: name, DataType: typ}
+}
+
 func outputColumnRefs(res nodes.ResTarget, tables []core.Table, node nodes.ColumnRef) ([]core.Column, error) {
 	parts := stringSlice(node.Fields)
 	var name, alias string
@@ -1070,6 +1474,9 @@ func (p paramSearch) Visit(node nodes.Node) ast.Visitor {
 	case nodes.FuncCall:
 		p.parent = node
 
+	case nodes.XmlExpr:
+		p.parent = node
+
 	case nodes.InsertStmt:
 		if s, ok := n.SelectStmt.(nodes.SelectStmt); ok {
 			for i, item := range s.TargetList.Items {
@@ -1184,7 +1591,7 @@ func search(root nodes.Node, f func(nodes.Node) bool) nodes.List {
 	return ns.list
 }
 
-func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, names map[int]string) ([]Parameter, error) {
+func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, names map[int]string, explain *inferenceTrace) ([]Parameter, error) {
 	aliasMap := map[string]core.FQN{}
 	// TODO: Deprecate defaultTable
 	var defaultTable *core.FQN
@@ -1201,7 +1608,7 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 		if rv.Relname == nil {
 			continue
 		}
-		fqn, err := catalog.ParseRange(&rv)
+		fqn, err := rangeTable(c, &rv)
 		if err != nil {
 			return nil, err
 		}
@@ -1243,24 +1650,28 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 		switch n := ref.parent.(type) {
 
 		case limitOffset:
-			a = append(a, Parameter{
+			p := Parameter{
 				Number: ref.ref.Number,
 				Column: core.Column{
 					Name:     parameterName(ref.ref.Number, "offset"),
 					DataType: "integer",
 					NotNull:  true,
 				},
-			})
+			}
+			explain.param(p, "used as the OFFSET")
+			a = append(a, p)
 
 		case limitCount:
-			a = append(a, Parameter{
+			p := Parameter{
 				Number: ref.ref.Number,
 				Column: core.Column{
 					Name:     parameterName(ref.ref.Number, "limit"),
 					DataType: "integer",
 					NotNull:  true,
 				},
-			})
+			}
+			explain.param(p, "used as the LIMIT")
+			a = append(a, p)
 
 		case nodes.A_Expr:
 			// TODO: While this works for a wide range of simple expressions,
@@ -1307,21 +1718,33 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 
 				var found int
 				for _, table := range search {
-					if c, ok := typeMap[table.Schema][table.Rel][key]; ok {
+					if col, ok := typeMap[table.Schema][table.Rel][key]; ok {
 						found += 1
+						column := key
 						if ref.name != "" {
 							key = ref.name
 						}
-						a = append(a, Parameter{
+						p := Parameter{
 							Number: ref.ref.Number,
 							Column: core.Column{
 								Name:     parameterName(ref.ref.Number, key),
-								DataType: c.DataType,
-								NotNull:  c.NotNull,
-								IsArray:  c.IsArray,
-								Table:    c.Table,
+								DataType: col.DataType,
+								NotNull:  col.NotNull,
+								IsArray:  col.IsArray,
+								Table:    col.Table,
 							},
-						})
+						}
+						op := join(n.Name, "")
+						if cop, ok := c.LookupOperator(op, arrayType(col), ""); ok {
+							// An extension's operator, such as hstore's ?, takes
+							// another type on its right
+							p.Column = typeColumn(p.Column.Name, cop.Right)
+							p.Column.NotNull = true
+							explain.param(p, "the right operand of %s %s, used with column %s of %s", cop.Left, op, column, table.Rel)
+						} else {
+							explain.param(p, "compared with column %s of %s using %s", column, table.Rel, op)
+						}
+						a = append(a, p)
 					}
 				}
 				if found == 0 {
@@ -1365,13 +1788,15 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 					continue
 				}
 				if fun.Arguments == nil {
-					a = append(a, Parameter{
+					p := Parameter{
 						Number: ref.ref.Number,
 						Column: core.Column{
 							Name:     parameterName(ref.ref.Number, fun.Name),
 							DataType: "any",
 						},
-					})
+					}
+					explain.param(p, "argument %d of the function %s, which isn't in the catalog", i+1, fun.Name)
+					a = append(a, p)
 					continue
 				}
 				if i >= len(fun.Arguments) {
@@ -1382,14 +1807,13 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 				if name == "" {
 					name = fun.Name
 				}
-				a = append(a, Parameter{
+				p := Parameter{
 					Number: ref.ref.Number,
-					Column: core.Column{
-						Name:     parameterName(ref.ref.Number, name),
-						DataType: arg.DataType,
-						NotNull:  true,
-					},
-				})
+					Column: typeColumn(parameterName(ref.ref.Number, name), arg.DataType),
+				}
+				p.Column.NotNull = true
+				explain.param(p, "argument %d of the function %s", i+1, fun.Name)
+				a = append(a, p)
 			}
 
 		case nodes.ResTarget:
@@ -1410,7 +1834,7 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 				rel = fqn.Rel
 			}
 			if c, ok := typeMap[schema][rel][key]; ok {
-				a = append(a, Parameter{
+				p := Parameter{
 					Number: ref.ref.Number,
 					Column: core.Column{
 						Name:     parameterName(ref.ref.Number, key),
@@ -1419,7 +1843,9 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 						IsArray:  c.IsArray,
 						Table:    c.Table,
 					},
-				})
+				}
+				explain.param(p, "assigned to column %s of %s", key, rel)
+				a = append(a, p)
 			} else {
 				return nil, core.Error{
 					Code:     "42703",
@@ -1434,13 +1860,31 @@ func resolveCatalogRefs(c core.Catalog, rvs []nodes.RangeVar, args []paramRef, n
 			}
 			col := catalog.ToColumn(n.TypeName)
 			col.Name = parameterName(ref.ref.Number, col.Name)
-			a = append(a, Parameter{
+			p := Parameter{
 				Number: ref.ref.Number,
 				Column: col,
-			})
+			}
+			explain.param(p, "cast to its type")
+			a = append(a, p)
 
 		case nodes.ParamRef:
-			a = append(a, Parameter{Number: ref.ref.Number})
+			p := Parameter{Number: ref.ref.Number}
+			explain.param(p, "nothing it's used with has a type")
+			a = append(a, p)
+
+		case nodes.XmlExpr:
+			// XMLPARSE parses text, and the content of XMLELEMENT is
+			// written as text
+			p := Parameter{
+				Number: ref.ref.Number,
+				Column: core.Column{
+					Name:     parameterName(ref.ref.Number, "xml"),
+					DataType: "text",
+					NotNull:  true,
+				},
+			}
+			explain.param(p, "an argument of an XML expression")
+			a = append(a, p)
 
 		default:
 			fmt.Printf("unsupported reference type: %T", n)
@@ -1,6 +1,10 @@
 package dinosql
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -184,3 +188,167 @@ func TestExpand(t *testing.T) {
 		t.Errorf("mismatch:\nexpected: %s\n  acutal: %s", expected, actual)
 	}
 }
+
+func TestReadSQLFilesGlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-glob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{
+		"migrations/002_users.sql",
+		"migrations/001_init.sql",
+		"migrations/001_init.down.sql",
+		"migrations/notes.txt",
+		"queries/users.sql",
+		"queries/admin/audit.sql",
+		"queries/admin/reports/daily.sql",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte("SELECT 1;"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		pattern string
+		files   []string
+	}{
+		{"migrations/*.sql", []string{"migrations/001_init.sql", "migrations/002_users.sql"}},
+		{"migrations", []string{"migrations/001_init.sql", "migrations/002_users.sql"}},
+		{"queries/**/*.sql", []string{"queries/admin/audit.sql", "queries/admin/reports/daily.sql", "queries/users.sql"}},
+		{"queries/*/*.sql", []string{"queries/admin/audit.sql"}},
+		{"**/0?1_*.sql", []string{"migrations/001_init.sql"}},
+	} {
+		files, err := ReadSQLFiles(filepath.Join(dir, test.pattern))
+		if err != nil {
+			t.Errorf("%s: %s", test.pattern, err)
+			continue
+		}
+		var rel []string
+		for _, f := range files {
+			r, _ := filepath.Rel(dir, f)
+			rel = append(rel, filepath.ToSlash(r))
+		}
+		if diff := cmp.Diff(test.files, rel); diff != "" {
+			t.Errorf("%s differed (-want +got):\n%s", test.pattern, diff)
+		}
+	}
+
+	if _, err := ReadSQLFiles(filepath.Join(dir, "schema/*.sql")); err == nil {
+		t.Errorf("expected an error for a pattern which matches no files")
+	}
+}
+
+type memoryCache map[string][]*Query
+
+func (c memoryCache) Get(key string) ([]*Query, bool) {
+	q, ok := c[key]
+	return q, ok
+}
+
+func (c memoryCache) Put(key string, queries []*Query) {
+	c[key] = queries
+}
+
+func TestParseQueriesCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"schema.sql":  "CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL);\n",
+		"authors.sql": "-- name: GetAuthor :one\nSELECT * FROM authors WHERE id = $1;\n",
+		"broken.sql":  "-- name: ListBooks :many\nSELECT * FROM books;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c, err := ParseCatalog(filepath.Join(dir, "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := memoryCache{}
+	opts := ParserOpts{Cache: cache, CacheKey: "v1"}
+	first, err := ParseQueries(c, filepath.Join(dir, "authors.sql"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cache) != 1 {
+		t.Fatalf("expected one cached file, got %d", len(cache))
+	}
+	second, err := ParseQueries(c, filepath.Join(dir, "authors.sql"), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(first.Queries, second.Queries); diff != "" {
+		t.Errorf("cached queries differ (-want +got):\n%s", diff)
+	}
+
+	opts.CacheKey = "v2"
+	if _, err := ParseQueries(c, filepath.Join(dir, "authors.sql"), opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache) != 2 {
+		t.Errorf("expected a new key to miss the cache, got %d entries", len(cache))
+	}
+
+	if _, err := ParseQueries(c, filepath.Join(dir, "broken.sql"), opts); err == nil {
+		t.Fatal("expected an error for a missing table")
+	}
+	if len(cache) != 2 {
+		t.Errorf("expected files with errors not to be cached, got %d entries", len(cache))
+	}
+}
+
+func TestParseCatalogAppliesFilesInOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	// Each migration adds a column to the table created by the first, so
+	// they only apply in order
+	files := map[string]string{
+		"000_init.sql": "CREATE TABLE events (id BIGSERIAL PRIMARY KEY);",
+		"017_bad.sql":  "ALTER TABLE events ADD COLUMN;",
+	}
+	for i := 1; i < 40; i++ {
+		if i == 17 {
+			continue
+		}
+		files[fmt.Sprintf("%03d_col.sql", i)] = fmt.Sprintf("ALTER TABLE events ADD COLUMN c%d text;", i)
+	}
+	files["041_missing.sql"] = "ALTER TABLE missing ADD COLUMN c text;"
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := ParseCatalog(dir)
+	perr, ok := err.(*ParserErr)
+	if !ok {
+		t.Fatalf("expected parser errors, got %v", err)
+	}
+	var failed []string
+	for _, e := range perr.Errs {
+		failed = append(failed, filepath.Base(e.Filename))
+	}
+	if diff := cmp.Diff([]string{"017_bad.sql", "041_missing.sql"}, failed); diff != "" {
+		t.Errorf("errors differed (-want +got):\n%s", diff)
+	}
+	var cols []string
+	for _, col := range c.Schemas["public"].Tables["events"].Columns {
+		cols = append(cols, col.Name)
+	}
+	if len(cols) != 39 || cols[0] != "id" || cols[1] != "c1" || cols[38] != "c39" {
+		t.Errorf("columns were added out of order: %v", cols)
+	}
+}
@@ -0,0 +1,347 @@
+package dinosql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// PluginRequest is written as JSON to the standard input of a vet plugin.
+// The catalog leaves out the built-in schemas.
+type PluginRequest struct {
+	Version string        `json:"version"`
+	Catalog PluginCatalog `json:"catalog"`
+	Queries []PluginQuery `json:"queries"`
+}
+
+// PluginCatalog holds the schemas of a PluginRequest, sorted by name
+type PluginCatalog struct {
+	Schemas []PluginSchema `json:"schemas"`
+}
+
+// A PluginSchema holds the tables, types and functions of a schema, each
+// sorted by name
+type PluginSchema struct {
+	OID       uint32           `json:"oid"`
+	Name      string           `json:"name"`
+	Comment   string           `json:"comment"`
+	Tables    []PluginTable    `json:"tables"`
+	Types     []PluginType     `json:"types"`
+	Functions []PluginFunction `json:"functions"`
+}
+
+type PluginTable struct {
+	OID         uint32             `json:"oid"`
+	Name        string             `json:"name"`
+	Comment     string             `json:"comment"`
+	Columns     []PluginColumn     `json:"columns"`
+	Indexes     []PluginIndex      `json:"indexes"`
+	ForeignKeys []PluginForeignKey `json:"foreign_keys"`
+}
+
+// A PluginColumn is a column of a table, or of a query's parameters or
+// results. Table is set for query columns which come from a table.
+type PluginColumn struct {
+	OID        uint32 `json:"oid"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	NotNull    bool   `json:"not_null"`
+	IsArray    bool   `json:"is_array"`
+	HasDefault bool   `json:"has_default"`
+	Comment    string `json:"comment"`
+	Table      string `json:"table,omitempty"`
+}
+
+// A PluginIndex has an empty column for each indexed expression
+type PluginIndex struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Unique  bool     `json:"unique"`
+	Primary bool     `json:"primary"`
+}
+
+// A PluginForeignKey has no ref_columns if it refers to the primary key
+type PluginForeignKey struct {
+	Columns    []string `json:"columns"`
+	RefTable   string   `json:"ref_table"`
+	RefColumns []string `json:"ref_columns"`
+}
+
+// A PluginType is a type created in a schema. Kind is one of enum,
+// composite, domain or base, for a type created by an extension. Values is
+// set for enums, and BaseType for domains.
+type PluginType struct {
+	OID      uint32   `json:"oid"`
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"`
+	Comment  string   `json:"comment"`
+	Values   []string `json:"values,omitempty"`
+	BaseType string   `json:"base_type,omitempty"`
+}
+
+type PluginFunction struct {
+	Name       string           `json:"name"`
+	Arguments  []PluginArgument `json:"arguments"`
+	ReturnType string           `json:"return_type"`
+	Comment    string           `json:"comment"`
+}
+
+type PluginArgument struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	HasDefault bool   `json:"has_default"`
+}
+
+// A PluginQuery is a compiled query. Tables are named as in custom rules.
+type PluginQuery struct {
+	Filename string            `json:"filename"`
+	Name     string            `json:"name"`
+	Cmd      string            `json:"cmd"`
+	SQL      string            `json:"sql"`
+	Comments []string          `json:"comments"`
+	Params   []PluginParameter `json:"params"`
+	Columns  []PluginColumn    `json:"columns"`
+	Tables   []string          `json:"tables"`
+}
+
+type PluginParameter struct {
+	Number int          `json:"number"`
+	Column PluginColumn `json:"column"`
+}
+
+// PluginResponse is read as JSON from the standard output of a vet plugin
+type PluginResponse struct {
+	Errors []PluginError `json:"errors"`
+}
+
+// A PluginError is a rule a query fails. Rule is optional, and is reported
+// after the plugin's name.
+type PluginError struct {
+	Filename string `json:"filename"`
+	Query    string `json:"query"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+}
+
+// VetPlugins runs the plugins in settings which aren't disabled over the
+// queries in r. A query can disable a plugin, or one of its rules as
+// plugin/rule, with a `-- vet-disable:` comment. The plugins run in dir, and
+// their standard error is passed through to stderr.
+func VetPlugins(dir string, r *Result, settings config.Vet, stderr io.Writer) ([]VetErr, error) {
+	var plugins []config.VetPlugin
+	for _, plugin := range settings.Plugins {
+		if !contains(settings.Disable, plugin.Name) {
+			plugins = append(plugins, plugin)
+		}
+	}
+	if len(plugins) == 0 {
+		return nil, nil
+	}
+
+	req := PluginRequest{
+		Version: "1",
+		Catalog: pluginCatalog(r.Catalog),
+		Queries: []PluginQuery{},
+	}
+	for _, q := range r.Queries {
+		req.Queries = append(req.Queries, pluginQuery(q))
+	}
+	blob, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	queries := map[string]*Query{}
+	for _, q := range r.Queries {
+		queries[q.Filename+"\x00"+q.Name] = q
+	}
+
+	var errs []VetErr
+	for _, plugin := range plugins {
+		path := plugin.Cmd
+		if strings.Contains(path, "/") && !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		var stdout bytes.Buffer
+		cmd := exec.Command(path, plugin.Args...)
+		cmd.Dir = dir
+		cmd.Stdin = bytes.NewReader(blob)
+		cmd.Stdout = &stdout
+		cmd.Stderr = stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("vet plugin %s failed: %w", plugin.Name, err)
+		}
+		var resp PluginResponse
+		if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+			return nil, fmt.Errorf("vet plugin %s returned invalid output: %w", plugin.Name, err)
+		}
+		for _, e := range resp.Errors {
+			rule := plugin.Name
+			if e.Rule != "" {
+				rule += "/" + e.Rule
+			}
+			if q, ok := queries[e.Filename+"\x00"+e.Query]; ok {
+				if contains(q.VetDisable, plugin.Name) || contains(q.VetDisable, rule) {
+					continue
+				}
+			}
+			errs = append(errs, VetErr{
+				Filename: e.Filename,
+				Query:    e.Query,
+				Rule:     rule,
+				Message:  e.Message,
+			})
+		}
+	}
+	return errs, nil
+}
+
+func pluginCatalog(c core.Catalog) PluginCatalog {
+	var names []string
+	for name := range c.Schemas {
+		switch name {
+		case "pg_catalog", "pg_temp", "sqlc":
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	catalog := PluginCatalog{Schemas: []PluginSchema{}}
+	for _, name := range names {
+		schema := c.Schemas[name]
+		ps := PluginSchema{
+			OID:       schema.OID,
+			Name:      schema.Name,
+			Comment:   schema.Comment,
+			Tables:    []PluginTable{},
+			Types:     []PluginType{},
+			Functions: []PluginFunction{},
+		}
+		for _, table := range schema.Tables {
+			ps.Tables = append(ps.Tables, pluginTable(table))
+		}
+		sort.Slice(ps.Tables, func(i, j int) bool { return ps.Tables[i].Name < ps.Tables[j].Name })
+		for _, typ := range schema.Types {
+			ps.Types = append(ps.Types, pluginType(typ))
+		}
+		sort.Slice(ps.Types, func(i, j int) bool { return ps.Types[i].Name < ps.Types[j].Name })
+		var funcs []string
+		for name := range schema.Funcs {
+			funcs = append(funcs, name)
+		}
+		sort.Strings(funcs)
+		for _, name := range funcs {
+			for _, fun := range schema.Funcs[name] {
+				pf := PluginFunction{
+					Name:       fun.Name,
+					Arguments:  []PluginArgument{},
+					ReturnType: fun.ReturnType,
+					Comment:    fun.Comment,
+				}
+				for _, arg := range fun.Arguments {
+					pf.Arguments = append(pf.Arguments, PluginArgument{
+						Name:       arg.Name,
+						Type:       arg.DataType,
+						HasDefault: arg.HasDefault,
+					})
+				}
+				ps.Functions = append(ps.Functions, pf)
+			}
+		}
+		catalog.Schemas = append(catalog.Schemas, ps)
+	}
+	return catalog
+}
+
+func pluginTable(table core.Table) PluginTable {
+	pt := PluginTable{
+		OID:         table.OID,
+		Name:        table.Name,
+		Comment:     table.Comment,
+		Columns:     pluginColumns(table.Columns),
+		Indexes:     []PluginIndex{},
+		ForeignKeys: []PluginForeignKey{},
+	}
+	for _, index := range table.Indexes {
+		pt.Indexes = append(pt.Indexes, PluginIndex{
+			Name:    index.Name,
+			Columns: append([]string{}, index.Columns...),
+			Unique:  index.Unique,
+			Primary: index.Primary,
+		})
+	}
+	for _, fk := range table.ForeignKeys {
+		pt.ForeignKeys = append(pt.ForeignKeys, PluginForeignKey{
+			Columns:    append([]string{}, fk.Columns...),
+			RefTable:   tableName(fk.RefTable),
+			RefColumns: append([]string{}, fk.RefColumns...),
+		})
+	}
+	return pt
+}
+
+func pluginType(typ core.Type) PluginType {
+	switch t := typ.(type) {
+	case core.Enum:
+		return PluginType{OID: t.OID, Name: t.Name, Kind: "enum", Comment: t.Comment, Values: t.Vals}
+	case core.CompositeType:
+		return PluginType{OID: t.OID, Name: t.Name, Kind: "composite"}
+	case core.Domain:
+		return PluginType{OID: t.OID, Name: t.Name, Kind: "domain", BaseType: t.BaseType}
+	case core.BaseType:
+		return PluginType{OID: t.OID, Name: t.Name, Kind: "base"}
+	}
+	return PluginType{}
+}
+
+func pluginColumns(columns []core.Column) []PluginColumn {
+	out := []PluginColumn{}
+	for _, col := range columns {
+		out = append(out, pluginColumn(col))
+	}
+	return out
+}
+
+func pluginColumn(col core.Column) PluginColumn {
+	pc := PluginColumn{
+		OID:        col.OID,
+		Name:       col.Name,
+		Type:       col.DataType,
+		NotNull:    col.NotNull,
+		IsArray:    col.IsArray,
+		HasDefault: col.HasDefault,
+		Comment:    col.Comment,
+	}
+	if col.Table.Rel != "" {
+		pc.Table = tableName(col.Table)
+	}
+	return pc
+}
+
+func pluginQuery(q *Query) PluginQuery {
+	pq := PluginQuery{
+		Filename: q.Filename,
+		Name:     q.Name,
+		Cmd:      q.Cmd,
+		SQL:      q.SQL,
+		Comments: append([]string{}, q.Comments...),
+		Params:   []PluginParameter{},
+		Columns:  pluginColumns(q.Columns),
+		Tables:   []string{},
+	}
+	for _, p := range q.Params {
+		pq.Params = append(pq.Params, PluginParameter{Number: p.Number, Column: pluginColumn(p.Column)})
+	}
+	for _, fqn := range q.Tables {
+		pq.Tables = append(pq.Tables, tableName(fqn))
+	}
+	return pq
+}
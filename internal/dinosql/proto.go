@@ -0,0 +1,287 @@
+package dinosql
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// ProtoFilename is the name of the protobuf schema written by `emit_proto`
+const ProtoFilename = "query.proto"
+
+// A ProtoMessage mirrors a model, parameter or row struct
+type ProtoMessage struct {
+	Name   string
+	Struct GoStruct
+	Fields []ProtoField
+}
+
+type ProtoField struct {
+	Name   string
+	GoName string
+	PbName string
+	Type   string
+	Number int
+	To     string
+	From   string
+
+	// Unsupported holds the Go type of a field which has no protobuf
+	// equivalent. The field is left out of the message, and its number is
+	// reserved.
+	Unsupported string
+}
+
+// A protoHelper converts a nullable Go type to and from a protobuf message
+type protoHelper struct {
+	Name    string
+	GoType  string
+	PbType  string
+	IsNull  string
+	ToPb    string
+	Zero    string
+	FromPb  string
+	Pointer bool
+}
+
+var protoScalars = map[string]string{
+	"string":          "string",
+	"bool":            "bool",
+	"int32":           "int32",
+	"int64":           "int64",
+	"float32":         "float",
+	"float64":         "double",
+	"[]byte":          "bytes",
+	"json.RawMessage": "bytes",
+}
+
+// protoWrappers maps Go scalars to the wrapperspb message used when they are
+// nullable, along with its constructor
+var protoWrappers = map[string][2]string{
+	"string":  {"StringValue", "String"},
+	"bool":    {"BoolValue", "Bool"},
+	"int32":   {"Int32Value", "Int32"},
+	"int64":   {"Int64Value", "Int64"},
+	"float64": {"DoubleValue", "Double"},
+}
+
+// sql.Null types and the name of their value field
+var protoNullTypes = map[string]string{
+	"sql.NullString":  "String",
+	"sql.NullBool":    "Bool",
+	"sql.NullInt32":   "Int32",
+	"sql.NullInt64":   "Int64",
+	"sql.NullFloat64": "Float64",
+}
+
+type protoGen struct {
+	enums     map[string]bool
+	helpers   map[string]protoHelper
+	timestamp bool
+}
+
+// protoMessages returns a message for each model struct and each emitted
+// parameter and row struct, along with the helpers their conversions use
+func protoMessages(structs []GoStruct, queries []GoQuery, enums []GoEnum, settings config.CombinedSettings) ([]ProtoMessage, []protoHelper, bool) {
+	g := protoGen{enums: map[string]bool{}, helpers: map[string]protoHelper{}}
+	qualifier := ModelsQualifier(settings)
+	for _, enum := range enums {
+		g.enums[qualifier+enum.Name] = true
+	}
+
+	var messages []ProtoMessage
+	for _, s := range structs {
+		messages = append(messages, g.message(strings.TrimPrefix(s.Name, qualifier), s))
+	}
+	seen := map[string]bool{}
+	for _, q := range queries {
+		for _, v := range []GoQueryValue{q.Arg, q.Ret} {
+			if v.EmitStruct() && !seen[v.Struct.Name] {
+				seen[v.Struct.Name] = true
+				messages = append(messages, g.message(v.Struct.Name, *v.Struct))
+			}
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool { return messages[i].Name < messages[j].Name })
+
+	var helpers []protoHelper
+	for _, h := range g.helpers {
+		helpers = append(helpers, h)
+	}
+	sort.Slice(helpers, func(i, j int) bool { return helpers[i].Name < helpers[j].Name })
+	return messages, helpers, g.timestamp
+}
+
+func (g *protoGen) message(name string, s GoStruct) ProtoMessage {
+	m := ProtoMessage{Name: name, Struct: s}
+	// Fields are numbered by their position in the struct, so supporting
+	// another type doesn't renumber the fields after it
+	for i, f := range s.Fields {
+		pf := ProtoField{Name: toSnakeCase(f.Name), GoName: f.Name, Number: i + 1}
+		pf.PbName = protoGoName(pf.Name)
+		pf.Type, pf.To, pf.From = g.convert(f.Type)
+		if pf.Type == "" {
+			pf.Unsupported = f.Type
+		} else {
+			pf.To = fmt.Sprintf(pf.To, "v."+f.Name)
+			pf.From = fmt.Sprintf(pf.From, "m.Get"+pf.PbName+"()")
+		}
+		m.Fields = append(m.Fields, pf)
+	}
+	return m
+}
+
+// convert returns the protobuf type for a Go type, and the expressions which
+// convert a value to and from it. The type is empty if there isn't one.
+func (g *protoGen) convert(goType string) (string, string, string) {
+	if t, ok := protoScalars[goType]; ok {
+		return t, "%s", "%s"
+	}
+	switch goType {
+	case "int16":
+		return "int32", "int32(%s)", "int16(%s)"
+	case "time.Time":
+		g.timestamp = true
+		return "google.protobuf.Timestamp", "timestamppb.New(%s)", "%s.AsTime()"
+	case "sql.NullTime", "*time.Time":
+		g.timestamp = true
+		h := protoHelper{GoType: goType, PbType: "*timestamppb.Timestamp"}
+		if goType == "sql.NullTime" {
+			h.Name, h.IsNull, h.ToPb, h.Zero, h.FromPb = "nullTime", "!v.Valid", "timestamppb.New(v.Time)", "sql.NullTime{}", "sql.NullTime{Time: m.AsTime(), Valid: true}"
+		} else {
+			h.Name, h.IsNull, h.ToPb, h.Zero, h.FromPb, h.Pointer = "timePtr", "v == nil", "timestamppb.New(*v)", "nil", "m.AsTime()", true
+		}
+		return g.helper(h)
+	}
+	if field, ok := protoNullTypes[goType]; ok {
+		scalar := strings.ToLower(field)
+		w := protoWrappers[scalar]
+		return g.helper(protoHelper{
+			Name:   "null" + field,
+			GoType: goType,
+			PbType: "*wrapperspb." + w[0],
+			IsNull: "!v.Valid",
+			ToPb:   "wrapperspb." + w[1] + "(v." + field + ")",
+			Zero:   goType + "{}",
+			FromPb: goType + "{" + field + ": m.Value, Valid: true}",
+		})
+	}
+	if strings.HasPrefix(goType, "*") {
+		scalar := strings.TrimPrefix(goType, "*")
+		if w, ok := protoWrappers[scalar]; ok {
+			return g.helper(protoHelper{
+				Name:    scalar + "Ptr",
+				GoType:  goType,
+				PbType:  "*wrapperspb." + w[0],
+				IsNull:  "v == nil",
+				ToPb:    "wrapperspb." + w[1] + "(*v)",
+				Zero:    "nil",
+				FromPb:  "m.Value",
+				Pointer: true,
+			})
+		}
+	}
+	if g.enums[goType] {
+		return "string", "string(%s)", goType + "(%s)"
+	}
+	if strings.HasPrefix(goType, "[]") {
+		elem := strings.TrimPrefix(goType, "[]")
+		if t, ok := protoScalars[elem]; ok && t != "bytes" {
+			return "repeated " + t, "%s", "%s"
+		}
+	}
+	return "", "", ""
+}
+
+func (g *protoGen) helper(h protoHelper) (string, string, string) {
+	g.helpers[h.Name] = h
+	typ := "google.protobuf." + strings.TrimPrefix(h.PbType, "*wrapperspb.")
+	if strings.HasSuffix(h.PbType, "Timestamp") {
+		typ = "google.protobuf.Timestamp"
+	}
+	return typ, h.Name + "ToProto(%s)", h.Name + "FromProto(%s)"
+}
+
+// protoGoName returns the name protoc-gen-go gives the Go field for a
+// protobuf field, e.g. UserId for user_id
+func protoGoName(name string) string {
+	var b strings.Builder
+	for i, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if i > 0 && unicode.IsDigit(rune(part[0])) {
+			b.WriteString("_")
+		}
+		b.WriteString(strings.Title(part))
+	}
+	return b.String()
+}
+
+// ProtoQualifier returns the package qualifier for the messages generated by
+// protoc-gen-go, e.g. "pb."
+func ProtoQualifier(settings config.CombinedSettings) string {
+	return path.Base(settings.Go.ProtoGoPackage) + "."
+}
+
+func usesWrappers(helpers []protoHelper) bool {
+	for _, h := range helpers {
+		if strings.HasPrefix(h.PbType, "*wrapperspb.") {
+			return true
+		}
+	}
+	return false
+}
+
+// protoSchema returns the protobuf schema for messages
+func protoSchema(messages []ProtoMessage, helpers []protoHelper, timestamp bool, settings config.CombinedSettings) (string, error) {
+	pkg := settings.Go.ProtoPackage
+	if pkg == "" {
+		pkg = settings.Go.Package
+	}
+	var imports []string
+	if timestamp {
+		imports = append(imports, "google/protobuf/timestamp.proto")
+	}
+	if usesWrappers(helpers) {
+		imports = append(imports, "google/protobuf/wrappers.proto")
+	}
+	tmpl := template.Must(template.New("proto").Parse(protoSchemaTmpl))
+	var b bytes.Buffer
+	err := tmpl.Execute(&b, map[string]interface{}{
+		"Package":   pkg,
+		"GoPackage": settings.Go.ProtoGoPackage,
+		"Imports":   imports,
+		"Messages":  messages,
+	})
+	return b.String(), err
+}
+
+const protoSchemaTmpl = `// Code generated by sqlc. DO NOT EDIT.
+
+syntax = "proto3";
+
+package {{.Package}};
+{{range .Imports}}
+import "{{.}}";
+{{- end}}
+
+option go_package = "{{.GoPackage}}";
+{{range .Messages}}
+message {{.Name}} {
+  {{- range .Fields}}
+  {{- if .Unsupported}}
+  // {{.Name}} is left out: {{.Unsupported}} is not supported
+  reserved {{.Number}};
+  {{- else}}
+  {{.Type}} {{.Name}} = {{.Number}};
+  {{- end}}
+  {{- end}}
+}
+{{end -}}
+`
@@ -0,0 +1,185 @@
+package dinosql
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+// TestContainerFilename is the name of the file generated by
+// `emit_test_container`
+const TestContainerFilename = "testcontainer.go"
+
+// TestContainerBuildTag is the build tag the file generated by
+// `emit_test_container` requires, so builds which aren't tests don't depend
+// on testing, testcontainers-go and the driver
+const TestContainerBuildTag = "sqlc_testcontainer"
+
+// DefaultTestContainerImage is the image the test database runs when
+// `test_container_image` isn't set
+const DefaultTestContainerImage = "postgres:16-alpine"
+
+type testSchemaFile struct {
+	Name string
+	SQL  string
+}
+
+// Literal returns the DDL of the file as a Go string literal, raw where the
+// DDL allows it
+func (f testSchemaFile) Literal() string {
+	if strings.Contains(f.SQL, "`") || strings.Contains(f.SQL, "\r") {
+		return strconv.Quote(f.SQL)
+	}
+	return "`" + f.SQL + "`"
+}
+
+// TestContainer returns the contents of a file with test helpers which start
+// PostgreSQL in a container, using testcontainers-go, and apply the schema
+// files to it. The DDL of the files under schema is copied into the generated
+// code, so the tests don't depend on where they're run from. The files are
+// named relative to dir.
+func TestContainer(schema, dir string, settings config.CombinedSettings) (string, error) {
+	files, err := ReadSchemaFiles(schema)
+	if err != nil {
+		return "", err
+	}
+	var schemaFiles []testSchemaFile
+	for _, filename := range files {
+		sql, err := schemaSQL(filename)
+		if err != nil {
+			return "", err
+		}
+		name, err := filepath.Rel(dir, filename)
+		if err != nil || strings.HasPrefix(name, "..") {
+			name = filepath.Base(filename)
+		}
+		schemaFiles = append(schemaFiles, testSchemaFile{Name: filepath.ToSlash(name), SQL: sql})
+	}
+
+	golang := settings.Go
+	tctx := struct {
+		tmplCtx
+		Image   string
+		Schema  []testSchemaFile
+		Queries bool
+	}{
+		tmplCtx: tmplCtx{Package: golang.Package, BuildTags: TestContainerBuildTag},
+		Image:   golang.TestContainerImage,
+		Schema:  schemaFiles,
+		Queries: !golang.EmitModelsOnly,
+	}
+	if tags := golang.BuildTags; strings.Contains(tags, "||") {
+		tctx.BuildTags = "(" + tags + ") && " + TestContainerBuildTag
+	} else if tags != "" {
+		tctx.BuildTags = tags + " && " + TestContainerBuildTag
+	}
+	if tctx.Image == "" {
+		tctx.Image = DefaultTestContainerImage
+	}
+	if golang.FileHeader != "" {
+		tctx.Header = strings.Split(strings.TrimRight(golang.FileHeader, "\n"), "\n")
+	}
+
+	funcMap := template.FuncMap{
+		"lowerTitle": LowerTitle,
+		"join":       strings.Join,
+		"comment":    DoubleSlashComment,
+		"imports":    func(string) [][]string { return nil },
+	}
+	tmpl := template.Must(template.New("table").Funcs(funcMap).Parse(templateSet))
+	template.Must(tmpl.New("testContainerFile").Parse(testContainerTmpl))
+
+	var b bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&b, "testContainerFile", &tctx); err != nil {
+		return "", err
+	}
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("source error: %w", err)
+	}
+	return string(code), nil
+}
+
+const testContainerTmpl = `{{template "header" .}}
+package {{.Package}}
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// TestContainerImage is the image of the database started by NewTestDB
+const TestContainerImage = "{{.Image}}"
+
+// testSchema holds the DDL of each schema file, applied in order by NewTestDB
+var testSchema = []struct {
+	Name string
+	SQL  string
+}{
+	{{- range .Schema}}
+	{ {{printf "%q" .Name}}, {{.Literal}} },
+	{{- end}}
+}
+
+// NewTestDB starts a PostgreSQL container, applies the schema to a new
+// database in it and returns a connection to the database. The connection is
+// closed and the container removed when the test and its subtests complete.
+func NewTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	ctx := context.Background()
+	container, err := postgres.Run(ctx, TestContainerImage,
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		),
+	)
+	if err != nil {
+		tb.Fatalf("start database container: %s", err)
+	}
+	tb.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			tb.Errorf("remove database container: %s", err)
+		}
+	})
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		tb.Fatalf("get database connection string: %s", err)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("open database: %s", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	for _, file := range testSchema {
+		if _, err := db.ExecContext(ctx, file.SQL); err != nil {
+			tb.Fatalf("apply schema file %s: %s", file.Name, err)
+		}
+	}
+	return db
+}
+{{- if .Queries}}
+
+// NewTestQueries returns Queries for a database started by NewTestDB
+func NewTestQueries(tb testing.TB) *Queries {
+	tb.Helper()
+	return New(NewTestDB(tb))
+}
+{{- end}}
+`
@@ -0,0 +1,74 @@
+schema "public" {}
+
+schema "billing" {
+  comment = "Invoices and payments"
+}
+
+enum "status" {
+  schema = schema.billing
+  values = ["open", "paid"]
+}
+
+table "users" {
+  schema = schema.public
+  comment = "Everyone who can sign in"
+  column "id" {
+    type = bigint
+    identity {}
+  }
+  column "email" {
+    type = varchar(255)
+  }
+  column "bio" {
+    type = text
+    null = true
+  }
+  column "created_at" {
+    type    = timestamp_with_time_zone
+    default = sql("now()")
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  index "users_email_idx" {
+    unique  = true
+    columns = [column.email]
+  }
+}
+
+table "invoices" {
+  schema = schema.billing
+  column "id" {
+    type = bigint
+  }
+  column "user_id" {
+    type = bigint
+  }
+  column "status" {
+    type    = enum.status
+    default = "open"
+  }
+  column "tags" {
+    type = sql("text[]")
+    null = true
+  }
+  primary_key {
+    columns = [column.id]
+  }
+  foreign_key "invoices_user_fk" {
+    columns     = [column.user_id]
+    ref_columns = [table.users.column.id]
+    on_delete   = CASCADE
+  }
+}
+
+view "open_invoices" {
+  schema = schema.billing
+  as     = <<-SQL
+    SELECT id, user_id FROM billing.invoices WHERE status = 'open'
+  SQL
+}
+
+trigger "audit" {
+  on = table.users
+}
@@ -0,0 +1 @@
+CREATE OR REPLACE VIEW user_names AS SELECT full_name FROM users;
@@ -0,0 +1,5 @@
+--liquibase formatted sql
+
+--changeset sqlc:4
+ALTER TABLE books ADD COLUMN isbn text;
+--rollback ALTER TABLE books DROP COLUMN isbn;
@@ -0,0 +1,142 @@
+package dinosql
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+
+	nodes "github.com/lfittl/pg_query_go/nodes"
+)
+
+// The kinds of debug tracing which can be enabled with SetTrace
+const (
+	// TraceCatalog logs each statement applied to the catalog
+	TraceCatalog = "catalog"
+	// TraceInference logs why each column and parameter of a query got its
+	// type and nullability
+	TraceInference = "inference"
+)
+
+var tracer struct {
+	sync.Mutex
+	w     io.Writer
+	kinds map[string]bool
+}
+
+// SetTrace writes the named kinds of debug tracing to w. Tracing is shared by
+// every parse, and writes whole lines, so it's safe to use while packages are
+// parsed concurrently.
+func SetTrace(kinds []string, w io.Writer) error {
+	enabled := map[string]bool{}
+	for _, kind := range kinds {
+		switch kind {
+		case TraceCatalog, TraceInference:
+			enabled[kind] = true
+		default:
+			return fmt.Errorf("unknown debug trace %q: must be %s or %s", kind, TraceCatalog, TraceInference)
+		}
+	}
+	tracer.Lock()
+	defer tracer.Unlock()
+	tracer.w = w
+	tracer.kinds = enabled
+	return nil
+}
+
+// Tracing reports whether the kind of tracing is enabled
+func Tracing(kind string) bool {
+	tracer.Lock()
+	defer tracer.Unlock()
+	return tracer.w != nil && tracer.kinds[kind]
+}
+
+func trace(kind string, lines ...string) {
+	tracer.Lock()
+	defer tracer.Unlock()
+	if tracer.w == nil || !tracer.kinds[kind] {
+		return
+	}
+	for _, line := range lines {
+		fmt.Fprintf(tracer.w, "%s: %s\n", kind, line)
+	}
+}
+
+// traceStatement logs a statement applied to the catalog, with its location
+// and the start of its SQL
+func traceStatement(filename, source string, raw nodes.RawStmt) {
+	if !Tracing(TraceCatalog) {
+		return
+	}
+	sql := source[raw.StmtLocation:]
+	if raw.StmtLen > 0 && raw.StmtLocation+raw.StmtLen <= len(source) {
+		sql = source[raw.StmtLocation : raw.StmtLocation+raw.StmtLen]
+	}
+	var words []string
+	for _, line := range strings.Split(sql, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+			words = append(words, strings.Fields(line)...)
+		}
+	}
+	sql = strings.Join(words, " ")
+	if len(sql) > 80 {
+		sql = sql[:77] + "..."
+	}
+	line, _ := lineno(source, raw.StmtLocation)
+	trace(TraceCatalog, fmt.Sprintf("%s:%d: %s", filename, line, sql))
+}
+
+// An inferenceTrace collects the reasons for the types of a query's columns
+// and parameters, so they're logged together. A nil trace collects nothing.
+type inferenceTrace struct {
+	lines []string
+}
+
+func newInferenceTrace() *inferenceTrace {
+	if !Tracing(TraceInference) {
+		return nil
+	}
+	return &inferenceTrace{}
+}
+
+func (t *inferenceTrace) column(col core.Column, format string, args ...interface{}) {
+	if t != nil {
+		t.lines = append(t.lines, fmt.Sprintf("  column %s: %s: %s", displayName(col.Name), describeColumn(col), fmt.Sprintf(format, args...)))
+	}
+}
+
+func (t *inferenceTrace) param(p Parameter, format string, args ...interface{}) {
+	if t != nil {
+		t.lines = append(t.lines, fmt.Sprintf("  $%d %s: %s: %s", p.Number, displayName(p.Column.Name), describeColumn(p.Column), fmt.Sprintf(format, args...)))
+	}
+}
+
+// log writes the trace for the named query
+func (t *inferenceTrace) log(name string) {
+	if t != nil {
+		trace(TraceInference, append([]string{"query " + name}, t.lines...)...)
+	}
+}
+
+func displayName(name string) string {
+	if name == "" {
+		return "(unnamed)"
+	}
+	return name
+}
+
+func describeColumn(col core.Column) string {
+	typ := col.DataType
+	if typ == "" {
+		typ = "unknown"
+	}
+	if col.IsArray {
+		typ += "[]"
+	}
+	if col.NotNull {
+		return typ + " not null"
+	}
+	return typ + " null"
+}
@@ -0,0 +1,56 @@
+package dinosql
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for name, contents := range map[string]string{
+		"schema.sql":  "-- Authors\nCREATE TABLE authors (id BIGSERIAL PRIMARY KEY, bio text);\n\nCREATE INDEX authors_bio ON authors (bio);\n",
+		"authors.sql": "-- name: GetBio :one\nSELECT bio, count(*) FROM authors WHERE id = $1 LIMIT $2;\n",
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := SetTrace([]string{TraceCatalog, TraceInference}, &out); err != nil {
+		t.Fatal(err)
+	}
+	defer SetTrace(nil, nil)
+	c, err := ParseCatalog(filepath.Join(dir, "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseQueries(c, filepath.Join(dir, "authors.sql"), ParserOpts{}); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := filepath.Join(dir, "schema.sql")
+	want := "catalog: " + schema + ":2: CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, bio text)\n" +
+		"catalog: " + schema + ":4: CREATE INDEX authors_bio ON authors (bio)\n" +
+		"inference: query GetBio\n" +
+		"inference:   $1 id: bigserial not null: compared with column id of authors using =\n" +
+		"inference:   $2 limit: integer not null: used as the LIMIT\n" +
+		"inference:   column bio: text null: column bio of table authors\n" +
+		"inference:   column count: bigint not null: return type of the function count, which is assumed to return a value\n"
+	if diff := cmp.Diff(want, out.String()); diff != "" {
+		t.Errorf("unexpected trace (-want +got):\n%s", diff)
+	}
+
+	if err := SetTrace([]string{"types"}, &out); err == nil {
+		t.Error("expected an error for an unknown trace")
+	}
+}
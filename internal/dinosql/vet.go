@@ -0,0 +1,483 @@
+package dinosql
+
+import (
+	"fmt"
+	"strings"
+
+	pg "github.com/lfittl/pg_query_go"
+	nodes "github.com/lfittl/pg_query_go/nodes"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/expr"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+	"github.com/kyleconroy/sqlc/internal/postgresql/ast"
+)
+
+// A VetErr is a rule violation found by `sqlc vet`
+type VetErr struct {
+	Filename string
+	Query    string
+	Rule     string
+	Message  string
+}
+
+func (e VetErr) Error() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", e.Filename, e.Query, e.Message, e.Rule)
+}
+
+type vetRule struct {
+	name  string
+	check func(c core.Catalog, q *Query, settings config.Vet) string
+}
+
+// vetRules must be kept in sync with config.VetRules
+var vetRules = []vetRule{
+	{"no-select-star", vetSelectStar},
+	{"where-required", vetWhereRequired},
+	{"max-joins", vetMaxJoins},
+	{"unindexed-where", vetUnindexedWhere},
+}
+
+// Vet runs the built-in and custom rules which aren't disabled in settings
+// over the queries in r. Custom rules which use explain are left to
+// VetExplain.
+func Vet(r *Result, settings config.Vet) []VetErr {
+	disabled := map[string]bool{}
+	for _, name := range settings.Disable {
+		disabled[name] = true
+	}
+	custom := compileRules(settings, false)
+	var errs []VetErr
+	for _, q := range r.Queries {
+		if q.Stmt == nil {
+			continue
+		}
+		skip := map[string]bool{}
+		for _, name := range q.VetDisable {
+			skip[name] = true
+		}
+		for _, rule := range vetRules {
+			if disabled[rule.name] || skip[rule.name] {
+				continue
+			}
+			if msg := rule.check(r.Catalog, q, settings); msg != "" {
+				errs = append(errs, VetErr{
+					Filename: q.Filename,
+					Query:    q.Name,
+					Rule:     rule.name,
+					Message:  msg,
+				})
+			}
+		}
+		for _, rule := range custom {
+			if disabled[rule.Name] || skip[rule.Name] {
+				continue
+			}
+			if msg := rule.check(q, nil); msg != "" {
+				errs = append(errs, VetErr{
+					Filename: q.Filename,
+					Query:    q.Name,
+					Rule:     rule.Name,
+					Message:  msg,
+				})
+			}
+		}
+	}
+	return errs
+}
+
+// A customRule is a compiled custom vet rule
+type customRule struct {
+	config.VetRule
+	prog *expr.Program
+	err  error
+}
+
+// compileRules compiles the custom rules in settings which use explain, or
+// those which don't
+func compileRules(settings config.Vet, explain bool) []customRule {
+	var rules []customRule
+	for _, rule := range settings.Rules {
+		prog, err := expr.Compile(rule.Rule, config.VetRuleVars)
+		if err == nil && prog.Uses("explain") != explain {
+			continue
+		}
+		if err != nil && explain {
+			// Rules which don't compile are reported once, by Vet
+			continue
+		}
+		rules = append(rules, customRule{rule, prog, err})
+	}
+	return rules
+}
+
+// check returns the rule's message if q fails it. plan is the plan of q,
+// which is only needed by rules using explain.
+func (rule customRule) check(q *Query, plan *planNode) string {
+	if rule.err != nil {
+		return fmt.Sprintf("invalid rule: %s", rule.err)
+	}
+	env := map[string]interface{}{"query": queryEnv(q)}
+	if plan != nil {
+		env["explain"] = planEnv(*plan)
+	}
+	v, err := rule.prog.Eval(env)
+	if err != nil {
+		return fmt.Sprintf("error evaluating rule: %s", err)
+	}
+	failed, ok := v.(bool)
+	if !ok {
+		return fmt.Sprintf("rule must be true or false, got %v", v)
+	}
+	if !failed {
+		return ""
+	}
+	if rule.Message != "" {
+		return rule.Message
+	}
+	return "query matches " + rule.Rule
+}
+
+// queryEnv returns the `query` variable of custom rules
+func queryEnv(q *Query) map[string]interface{} {
+	tables := []interface{}{}
+	for _, fqn := range q.Tables {
+		tables = append(tables, tableName(fqn))
+	}
+	return map[string]interface{}{
+		"name":     q.Name,
+		"cmd":      q.Cmd,
+		"sql":      q.SQL,
+		"filename": q.Filename,
+		"params":   float64(len(q.Params)),
+		"columns":  float64(len(q.Columns)),
+		"tables":   tables,
+	}
+}
+
+// tableName returns the name of a table, qualified with its schema unless
+// that's public
+func tableName(fqn core.FQN) string {
+	if fqn.Schema != "" && fqn.Schema != "public" {
+		return fqn.Schema + "." + fqn.Rel
+	}
+	return fqn.Rel
+}
+
+// vetDirectives removes the `-- vet-disable: rule, ...` comments from a
+// query's comments, returning the rules they name. custom names the custom
+// rules and plugins, which may be disabled too. A plugin's rules are named
+// plugin/rule.
+func vetDirectives(comments []string, custom []string) ([]string, []string, error) {
+	var rest, disabled []string
+	for _, comment := range comments {
+		directive := strings.TrimSpace(comment)
+		if !strings.HasPrefix(directive, "vet-disable:") {
+			rest = append(rest, comment)
+			continue
+		}
+		for _, name := range strings.Split(strings.TrimPrefix(directive, "vet-disable:"), ",") {
+			name = strings.TrimSpace(name)
+			plugin := strings.SplitN(name, "/", 2)[0]
+			if !isVetRule(name) && !contains(custom, name) && !(plugin != name && contains(custom, plugin)) {
+				return nil, nil, fmt.Errorf("invalid vet rule %q in vet-disable: must be one of %s", name, strings.Join(append(append([]string{}, config.VetRules...), custom...), ", "))
+			}
+			disabled = append(disabled, name)
+		}
+	}
+	return rest, disabled, nil
+}
+
+func isVetRule(name string) bool {
+	for _, rule := range vetRules {
+		if rule.name == name {
+			return true
+		}
+	}
+	return contains(explainRules, name)
+}
+
+// vetSelectStar reports `*` in the target list of a SELECT, including those of
+// subqueries and common table expressions. Returned columns change whenever
+// the table does.
+func vetSelectStar(c core.Catalog, q *Query, settings config.Vet) string {
+	var star bool
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		sel, ok := node.(nodes.SelectStmt)
+		if !ok {
+			return
+		}
+		for _, item := range sel.TargetList.Items {
+			res, ok := item.(nodes.ResTarget)
+			if !ok {
+				continue
+			}
+			if ref, ok := res.Val.(nodes.ColumnRef); ok && HasStarRef(ref) {
+				star = true
+			}
+		}
+	}), q.Stmt)
+	if star {
+		return "SELECT * returns every column; list the columns instead"
+	}
+	return ""
+}
+
+// vetWhereRequired reports UPDATE and DELETE statements which affect every row
+// of a table
+func vetWhereRequired(c core.Catalog, q *Query, settings config.Vet) string {
+	switch n := q.Stmt.(type) {
+	case nodes.DeleteStmt:
+		if n.WhereClause == nil {
+			return "DELETE without a WHERE clause deletes every row"
+		}
+	case nodes.UpdateStmt:
+		if n.WhereClause == nil {
+			return "UPDATE without a WHERE clause updates every row"
+		}
+	}
+	return ""
+}
+
+// vetMaxJoins reports queries with more joins than `max_joins`. Both JOIN
+// clauses and extra FROM items count.
+func vetMaxJoins(c core.Catalog, q *Query, settings config.Vet) string {
+	max := settings.MaxJoins
+	if max == 0 {
+		max = config.DefaultVetMaxJoins
+	}
+	var joins int
+	countFrom := func(from nodes.List) {
+		if len(from.Items) > 1 {
+			joins += len(from.Items) - 1
+		}
+	}
+	ast.Walk(ast.VisitorFunc(func(node nodes.Node) {
+		switch n := node.(type) {
+		case nodes.JoinExpr:
+			joins++
+		case nodes.SelectStmt:
+			countFrom(n.FromClause)
+		case nodes.UpdateStmt:
+			// The updated table is joined to each FROM item
+			joins += len(n.FromClause.Items)
+		case nodes.DeleteStmt:
+			joins += len(n.UsingClause.Items)
+		}
+	}), q.Stmt)
+	if joins > max {
+		return fmt.Sprintf("query has %d joins, more than the limit of %d", joins, max)
+	}
+	return ""
+}
+
+// vetUnindexedWhere reports tables filtered by equality predicates in a WHERE
+// clause when none of the filtered columns leads an index of the table. It's a
+// heuristic for full table scans: only top-level conditions joined with AND
+// are considered, and conditions comparing two columns are left to the planner.
+func vetUnindexedWhere(c core.Catalog, q *Query, settings config.Vet) string {
+	var rvs []nodes.Node
+	var where nodes.Node
+	switch n := q.Stmt.(type) {
+	case nodes.SelectStmt:
+		rvs = search(n.FromClause, func(node nodes.Node) bool {
+			_, ok := node.(nodes.RangeVar)
+			return ok
+		}).Items
+		where = n.WhereClause
+	case nodes.UpdateStmt:
+		rvs = append([]nodes.Node{*n.Relation}, n.FromClause.Items...)
+		where = n.WhereClause
+	case nodes.DeleteStmt:
+		rvs = append([]nodes.Node{*n.Relation}, n.UsingClause.Items...)
+		where = n.WhereClause
+	}
+	if where == nil {
+		return ""
+	}
+
+	type source struct {
+		name  string
+		table core.Table
+	}
+	var sources []source
+	for _, item := range rvs {
+		rv, ok := item.(nodes.RangeVar)
+		if !ok {
+			continue
+		}
+		fqn, err := rangeTable(c, &rv)
+		if err != nil {
+			continue
+		}
+		// Common table expressions and unknown tables have no indexes to check
+		table, ok := c.Schemas[fqn.Schema].Tables[fqn.Rel]
+		if !ok {
+			continue
+		}
+		name := fqn.Rel
+		if rv.Alias != nil {
+			name = *rv.Alias.Aliasname
+		}
+		sources = append(sources, source{name, table})
+	}
+
+	filtered := make([][]string, len(sources))
+	for _, ref := range equalityColumns(where) {
+		var parts []string
+		for _, item := range ref.Fields.Items {
+			if s, ok := item.(nodes.String); ok {
+				parts = append(parts, s.Str)
+			}
+		}
+		if len(parts) == 0 || len(parts) > 2 {
+			continue
+		}
+		col := parts[len(parts)-1]
+		for i, src := range sources {
+			if len(parts) == 2 && parts[0] != src.name {
+				continue
+			}
+			if hasColumn(src.table, col) {
+				if !contains(filtered[i], col) {
+					filtered[i] = append(filtered[i], col)
+				}
+				break
+			}
+		}
+	}
+
+	var unindexed []string
+	for i, src := range sources {
+		if len(filtered[i]) == 0 || leadsIndex(src.table, filtered[i]) {
+			continue
+		}
+		unindexed = append(unindexed, fmt.Sprintf("%s (%s)", src.table.Name, strings.Join(filtered[i], ", ")))
+	}
+	if len(unindexed) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("no index covers the WHERE conditions on %s, so the query may scan the whole table", strings.Join(unindexed, ", "))
+}
+
+// equalityColumns returns the columns compared for equality with a value in
+// the AND-ed conditions of where
+func equalityColumns(where nodes.Node) []nodes.ColumnRef {
+	switch n := where.(type) {
+	case nodes.BoolExpr:
+		if n.Boolop != nodes.AND_EXPR {
+			return nil
+		}
+		var refs []nodes.ColumnRef
+		for _, arg := range n.Args.Items {
+			refs = append(refs, equalityColumns(arg)...)
+		}
+		return refs
+	case nodes.A_Expr:
+		if n.Kind != nodes.AEXPR_OP || len(n.Name.Items) != 1 {
+			return nil
+		}
+		if op, ok := n.Name.Items[0].(nodes.String); !ok || op.Str != "=" {
+			return nil
+		}
+		left, lok := n.Lexpr.(nodes.ColumnRef)
+		right, rok := n.Rexpr.(nodes.ColumnRef)
+		switch {
+		case lok && !rok:
+			return []nodes.ColumnRef{left}
+		case rok && !lok:
+			return []nodes.ColumnRef{right}
+		}
+	}
+	return nil
+}
+
+func hasColumn(table core.Table, name string) bool {
+	for _, col := range table.Columns {
+		if col.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// leadsIndex reports whether one of cols is the first column of an index on
+// table, so the index can be used to find the rows
+func leadsIndex(table core.Table, cols []string) bool {
+	for _, index := range table.Indexes {
+		if len(index.Columns) > 0 && contains(cols, index.Columns[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// FixSelectStar rewrites the queries in source which the no-select-star rule
+// reports, replacing each `*` in a SELECT with the columns it expands to in c.
+// Queries which disable the rule are left alone. custom names the custom vet
+// rules, which the queries may also disable.
+func FixSelectStar(c core.Catalog, source string, custom []string) (string, error) {
+	tree, err := pg.Parse(source)
+	if err != nil {
+		return "", err
+	}
+	out := source
+	// Statements are rewritten from the last, so the locations of earlier
+	// ones stay the same
+	for i := len(tree.Statements) - 1; i >= 0; i-- {
+		raw, ok := tree.Statements[i].(nodes.RawStmt)
+		if !ok || raw.StmtLen == 0 {
+			continue
+		}
+		rawSQL, err := pluckQuery(source, raw)
+		if err != nil {
+			return "", err
+		}
+		_, comments, err := stripComments(strings.TrimSpace(rawSQL))
+		if err != nil {
+			return "", err
+		}
+		_, disabled, err := vetDirectives(comments, custom)
+		if err != nil {
+			return "", err
+		}
+		if contains(disabled, "no-select-star") {
+			continue
+		}
+		qc, err := buildQueryCatalog(c, raw.Stmt)
+		if err != nil {
+			return "", err
+		}
+		var edits []edit
+		for _, sel := range search(raw, func(node nodes.Node) bool {
+			_, ok := node.(nodes.SelectStmt)
+			return ok
+		}).Items {
+			e, err := expandStmt(qc, raw, sel)
+			if err != nil {
+				return "", err
+			}
+			edits = append(edits, e...)
+		}
+		fixed, err := editQuery(rawSQL, edits)
+		if err != nil {
+			return "", err
+		}
+		out = out[:raw.StmtLocation] + fixed + out[raw.StmtLocation+raw.StmtLen:]
+	}
+	if out != source {
+		if _, err := pg.Parse(out); err != nil {
+			return "", fmt.Errorf("expanded queries are invalid: %w", err)
+		}
+	}
+	return out, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+//go:build go1.18
+// +build go1.18
+
+package dolphin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/catalog"
+)
+
+// FuzzParse translates whatever the TiDB parser accepts into catalog
+// statements. Its AST leaves missing clauses, such as a SELECT's FROM, nil.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"CREATE TABLE venues (id int PRIMARY KEY, name text NOT NULL)",
+		"CREATE TABLE a (id int); ALTER TABLE a ADD COLUMN b text, DROP COLUMN b",
+		"ALTER TABLE a ADD COLUMN (c int, d text), DROP COLUMN IF EXISTS e",
+		"DROP TABLE IF EXISTS a, s.b; SELECT id, name FROM a JOIN b ON a.id = b.id",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		stmts, err := NewParser().Parse(strings.NewReader(sql))
+		if err != nil {
+			return
+		}
+		catalog.Build(stmts)
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"io"
 	"io/ioutil"
 
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
 
 	"github.com/pingcap/parser"
 	pcast "github.com/pingcap/parser/ast"
@@ -169,13 +169,17 @@ func (p *Parser) Parse(r io.Reader) ([]ast.Statement, error) {
 		case *pcast.SelectStmt:
 			sel := &ast.SelectStmt{}
 			var tables []ast.Node
-			visit(n.From, func(n pcast.Node) {
-				name, ok := n.(*pcast.TableName)
-				if !ok {
-					return
-				}
-				tables = append(tables, parseTableName(name))
-			})
+			// n.From is a nil pointer, not a nil interface, without a FROM
+			// clause
+			if n.From != nil {
+				visit(n.From, func(n pcast.Node) {
+					name, ok := n.(*pcast.TableName)
+					if !ok {
+						return
+					}
+					tables = append(tables, parseTableName(name))
+				})
+			}
 			var cols []ast.Node
 			visit(n.Fields, func(n pcast.Node) {
 				col, ok := n.(*pcast.ColumnName)
@@ -0,0 +1,144 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const listTickets = `-- name: ListTickets :many
+SELECT id, states, history, emails, points FROM tickets WHERE states && $1
+`
+
+func (q *Queries) ListTickets(ctx context.Context, states []Status) ([]Ticket, error) {
+	rows, err := q.db.QueryContext(ctx, listTickets, pq.Array(states))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Ticket
+	for rows.Next() {
+		var i Ticket
+		if err := rows.Scan(
+			&i.ID,
+			pq.Array(&i.States),
+			pq.Array(&i.History),
+			pq.Array(&i.Emails),
+			pq.Array(&i.Points),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTicketsByEmail = `-- name: ListTicketsByEmail :many
+SELECT id, emails FROM tickets WHERE $1::email = ANY(emails)
+`
+
+type ListTicketsByEmailRow struct {
+	ID     int32
+	Emails []string
+}
+
+func (q *Queries) ListTicketsByEmail(ctx context.Context, dollar_1 string) ([]ListTicketsByEmailRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTicketsByEmail, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTicketsByEmailRow
+	for rows.Next() {
+		var i ListTicketsByEmailRow
+		if err := rows.Scan(&i.ID, pq.Array(&i.Emails)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTicketsInStates = `-- name: ListTicketsInStates :many
+SELECT id FROM tickets WHERE states[1] = ANY($1::status[])
+`
+
+func (q *Queries) ListTicketsInStates(ctx context.Context, dollar_1 []Status) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listTicketsInStates, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTicketsWithState = `-- name: ListTicketsWithState :many
+SELECT id FROM tickets WHERE $1::status = ANY(states)
+`
+
+func (q *Queries) ListTicketsWithState(ctx context.Context, dollar_1 Status) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listTicketsWithState, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setStates = `-- name: SetStates :exec
+UPDATE tickets SET states = $1, points = $2 WHERE id = $3
+`
+
+type SetStatesParams struct {
+	States []Status
+	Points []string
+	ID     int32
+}
+
+func (q *Queries) SetStates(ctx context.Context, arg SetStatesParams) error {
+	_, err := q.db.ExecContext(ctx, setStates, pq.Array(arg.States), pq.Array(arg.Points), arg.ID)
+	return err
+}
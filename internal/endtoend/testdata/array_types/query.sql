@@ -0,0 +1,25 @@
+CREATE TYPE status AS ENUM ('open', 'closed');
+CREATE DOMAIN email AS text CHECK (VALUE LIKE '%@%');
+CREATE TYPE point2 AS (x int, y int);
+CREATE TABLE tickets (
+    id       serial PRIMARY KEY,
+    states   status[] NOT NULL,
+    history  status[],
+    emails   email[] NOT NULL,
+    points   point2[] NOT NULL
+);
+
+-- name: ListTickets :many
+SELECT * FROM tickets WHERE states && $1;
+
+-- name: ListTicketsWithState :many
+SELECT id FROM tickets WHERE $1::status = ANY(states);
+
+-- name: SetStates :exec
+UPDATE tickets SET states = $1, points = $2 WHERE id = $3;
+
+-- name: ListTicketsInStates :many
+SELECT id FROM tickets WHERE states[1] = ANY($1::status[]);
+
+-- name: ListTicketsByEmail :many
+SELECT id, emails FROM tickets WHERE $1::email = ANY(emails);
@@ -0,0 +1,89 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const createFile = `-- name: CreateFile :exec
+INSERT INTO files (contents, checksum, flags, mask) VALUES ($1, sha256($1), $2, $3)
+`
+
+type CreateFileParams struct {
+	Contents []byte
+	Flags    string
+	Mask     sql.NullString
+}
+
+func (q *Queries) CreateFile(ctx context.Context, arg CreateFileParams) error {
+	_, err := q.db.ExecContext(ctx, createFile, arg.Contents, arg.Flags, arg.Mask)
+	return err
+}
+
+const getFileByChecksum = `-- name: GetFileByChecksum :one
+SELECT id, contents, flags FROM files WHERE checksum = digest($1, 'sha256')
+`
+
+type GetFileByChecksumRow struct {
+	ID       int32
+	Contents []byte
+	Flags    string
+}
+
+func (q *Queries) GetFileByChecksum(ctx context.Context, data []byte) (GetFileByChecksumRow, error) {
+	row := q.db.QueryRowContext(ctx, getFileByChecksum, data)
+	var i GetFileByChecksumRow
+	err := row.Scan(&i.ID, &i.Contents, &i.Flags)
+	return i, err
+}
+
+const listFiles = `-- name: ListFiles :many
+SELECT id, encode(contents, 'hex') AS hex, checksum, flags & B'00001111' AS low_flags, mask
+FROM files
+WHERE contents = $1 OR flags = $2
+`
+
+type ListFilesParams struct {
+	Contents []byte
+	Flags    string
+}
+
+type ListFilesRow struct {
+	ID       int32
+	Hex      string
+	Checksum []byte
+	LowFlags string
+	Mask     sql.NullString
+}
+
+func (q *Queries) ListFiles(ctx context.Context, arg ListFilesParams) ([]ListFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFiles, arg.Contents, arg.Flags)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFilesRow
+	for rows.Next() {
+		var i ListFilesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Hex,
+			&i.Checksum,
+			&i.LowFlags,
+			&i.Mask,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
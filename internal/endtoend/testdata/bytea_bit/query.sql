@@ -0,0 +1,20 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE files (
+    id       serial PRIMARY KEY,
+    contents bytea NOT NULL,
+    checksum bytea,
+    flags    bit(8) NOT NULL,
+    mask     varbit
+);
+
+-- name: GetFileByChecksum :one
+SELECT id, contents, flags FROM files WHERE checksum = digest($1, 'sha256');
+
+-- name: ListFiles :many
+SELECT id, encode(contents, 'hex') AS hex, checksum, flags & B'00001111' AS low_flags, mask
+FROM files
+WHERE contents = $1 OR flags = $2;
+
+-- name: CreateFile :exec
+INSERT INTO files (contents, checksum, flags, mask) VALUES ($1, sha256($1), $2, $3);
@@ -3,6 +3,7 @@
 package querytest
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -27,6 +28,47 @@ func (e *FooMood) Scan(src interface{}) error {
 	return nil
 }
 
+type NullFooMood struct {
+	FooMood FooMood
+	Valid   bool // Valid is true if FooMood is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFooMood) Scan(value interface{}) error {
+	if value == nil {
+		ns.FooMood, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FooMood.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFooMood) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FooMood), nil
+}
+
+func (e FooMood) Valid() bool {
+	switch e {
+	case FooMoodSad,
+		FooMoodOk,
+		FooMoodHappy:
+		return true
+	}
+	return false
+}
+
+func AllFooMoodValues() []FooMood {
+	return []FooMood{
+		FooMoodSad,
+		FooMoodOk,
+		FooMoodHappy,
+	}
+}
+
 // this is the bar table
 type Bar struct {
 	// this is the baz column
@@ -0,0 +1,102 @@
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: ListUsers :many
+SELECT * FROM users
+ORDER BY id;
+
+-- name: CreateUser :one
+INSERT INTO users (name, email)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateUser :one
+UPDATE users
+SET name = $2,
+    email = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit.events;
+
+-- name: CreateAuditEvent :one
+INSERT INTO audit.events (action, at)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM "order"
+WHERE id = $1;
+
+-- name: ListOrders :many
+SELECT * FROM "order"
+ORDER BY id;
+
+-- name: CreateOrder :one
+INSERT INTO "order" ("desc", "Total")
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateOrder :one
+UPDATE "order"
+SET "desc" = $2,
+    "Total" = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteOrder :exec
+DELETE FROM "order"
+WHERE id = $1;
+
+-- name: GetAccount :one
+SELECT * FROM accounts
+WHERE account_id = $1;
+
+-- name: ListAccounts :many
+SELECT * FROM accounts
+ORDER BY account_id;
+
+-- name: CreateAccount :one
+INSERT INTO accounts (account_id, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateAccount :one
+UPDATE accounts
+SET name = $2,
+    created_at = $3
+WHERE account_id = $1
+RETURNING *;
+
+-- name: DeleteAccount :exec
+DELETE FROM accounts
+WHERE account_id = $1;
+
+-- name: GetMembership :one
+SELECT * FROM memberships
+WHERE user_id = $1 AND group_id = $2;
+
+-- name: ListMemberships :many
+SELECT * FROM memberships
+ORDER BY user_id, group_id;
+
+-- name: CreateMembership :one
+INSERT INTO memberships (user_id, group_id, role)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateMembership :one
+UPDATE memberships
+SET role = $3
+WHERE user_id = $1 AND group_id = $2
+RETURNING *;
+
+-- name: DeleteMembership :exec
+DELETE FROM memberships
+WHERE user_id = $1 AND group_id = $2;
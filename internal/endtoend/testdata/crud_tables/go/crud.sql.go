@@ -0,0 +1,421 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: crud.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createAccount = `-- name: CreateAccount :one
+INSERT INTO accounts (account_id, name)
+VALUES ($1, $2)
+RETURNING account_id, name, created_at
+`
+
+type CreateAccountParams struct {
+	AccountID string
+	Name      string
+}
+
+func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, createAccount, arg.AccountID, arg.Name)
+	var i Account
+	err := row.Scan(&i.AccountID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :one
+INSERT INTO audit.events (action, at)
+VALUES ($1, $2)
+RETURNING action, at
+`
+
+type CreateAuditEventParams struct {
+	Action string
+	At     time.Time
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	row := q.db.QueryRowContext(ctx, createAuditEvent, arg.Action, arg.At)
+	var i AuditEvent
+	err := row.Scan(&i.Action, &i.At)
+	return i, err
+}
+
+const createMembership = `-- name: CreateMembership :one
+INSERT INTO memberships (user_id, group_id, role)
+VALUES ($1, $2, $3)
+RETURNING user_id, group_id, role
+`
+
+type CreateMembershipParams struct {
+	UserID  int32
+	GroupID int32
+	Role    string
+}
+
+func (q *Queries) CreateMembership(ctx context.Context, arg CreateMembershipParams) (Membership, error) {
+	row := q.db.QueryRowContext(ctx, createMembership, arg.UserID, arg.GroupID, arg.Role)
+	var i Membership
+	err := row.Scan(&i.UserID, &i.GroupID, &i.Role)
+	return i, err
+}
+
+const createOrder = `-- name: CreateOrder :one
+INSERT INTO "order" ("desc", "Total")
+VALUES ($1, $2)
+RETURNING id, "desc", Total
+`
+
+type CreateOrderParams struct {
+	Desc  string
+	Total int32
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, createOrder, arg.Desc, arg.Total)
+	var i Order
+	err := row.Scan(&i.ID, &i.Desc, &i.Total)
+	return i, err
+}
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (name, email)
+VALUES ($1, $2)
+RETURNING id, name, email
+`
+
+type CreateUserParams struct {
+	Name  string
+	Email sql.NullString
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, createUser, arg.Name, arg.Email)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const deleteAccount = `-- name: DeleteAccount :exec
+DELETE FROM accounts
+WHERE account_id = $1
+`
+
+func (q *Queries) DeleteAccount(ctx context.Context, accountID string) error {
+	_, err := q.db.ExecContext(ctx, deleteAccount, accountID)
+	return err
+}
+
+const deleteMembership = `-- name: DeleteMembership :exec
+DELETE FROM memberships
+WHERE user_id = $1 AND group_id = $2
+`
+
+type DeleteMembershipParams struct {
+	UserID  int32
+	GroupID int32
+}
+
+func (q *Queries) DeleteMembership(ctx context.Context, arg DeleteMembershipParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMembership, arg.UserID, arg.GroupID)
+	return err
+}
+
+const deleteOrder = `-- name: DeleteOrder :exec
+DELETE FROM "order"
+WHERE id = $1
+`
+
+func (q *Queries) DeleteOrder(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteOrder, id)
+	return err
+}
+
+const deleteUser = `-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1
+`
+
+func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteUser, id)
+	return err
+}
+
+const getAccount = `-- name: GetAccount :one
+SELECT account_id, name, created_at FROM accounts
+WHERE account_id = $1
+`
+
+func (q *Queries) GetAccount(ctx context.Context, accountID string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, accountID)
+	var i Account
+	err := row.Scan(&i.AccountID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getMembership = `-- name: GetMembership :one
+SELECT user_id, group_id, role FROM memberships
+WHERE user_id = $1 AND group_id = $2
+`
+
+type GetMembershipParams struct {
+	UserID  int32
+	GroupID int32
+}
+
+func (q *Queries) GetMembership(ctx context.Context, arg GetMembershipParams) (Membership, error) {
+	row := q.db.QueryRowContext(ctx, getMembership, arg.UserID, arg.GroupID)
+	var i Membership
+	err := row.Scan(&i.UserID, &i.GroupID, &i.Role)
+	return i, err
+}
+
+const getOrder = `-- name: GetOrder :one
+SELECT id, "desc", Total FROM "order"
+WHERE id = $1
+`
+
+func (q *Queries) GetOrder(ctx context.Context, id int32) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrder, id)
+	var i Order
+	err := row.Scan(&i.ID, &i.Desc, &i.Total)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, email FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const listAccounts = `-- name: ListAccounts :many
+SELECT account_id, name, created_at FROM accounts
+ORDER BY account_id
+`
+
+func (q *Queries) ListAccounts(ctx context.Context) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccounts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(&i.AccountID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT action, at FROM audit.events
+`
+
+func (q *Queries) ListAuditEvents(ctx context.Context) ([]AuditEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditEvent
+	for rows.Next() {
+		var i AuditEvent
+		if err := rows.Scan(&i.Action, &i.At); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMemberships = `-- name: ListMemberships :many
+SELECT user_id, group_id, role FROM memberships
+ORDER BY user_id, group_id
+`
+
+func (q *Queries) ListMemberships(ctx context.Context) ([]Membership, error) {
+	rows, err := q.db.QueryContext(ctx, listMemberships)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Membership
+	for rows.Next() {
+		var i Membership
+		if err := rows.Scan(&i.UserID, &i.GroupID, &i.Role); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrders = `-- name: ListOrders :many
+SELECT id, "desc", Total FROM "order"
+ORDER BY id
+`
+
+func (q *Queries) ListOrders(ctx context.Context) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(&i.ID, &i.Desc, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+ORDER BY id
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAccount = `-- name: UpdateAccount :one
+UPDATE accounts
+SET name = $2,
+    created_at = $3
+WHERE account_id = $1
+RETURNING account_id, name, created_at
+`
+
+type UpdateAccountParams struct {
+	AccountID string
+	Name      string
+	CreatedAt time.Time
+}
+
+func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccount, arg.AccountID, arg.Name, arg.CreatedAt)
+	var i Account
+	err := row.Scan(&i.AccountID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const updateMembership = `-- name: UpdateMembership :one
+UPDATE memberships
+SET role = $3
+WHERE user_id = $1 AND group_id = $2
+RETURNING user_id, group_id, role
+`
+
+type UpdateMembershipParams struct {
+	UserID  int32
+	GroupID int32
+	Role    string
+}
+
+func (q *Queries) UpdateMembership(ctx context.Context, arg UpdateMembershipParams) (Membership, error) {
+	row := q.db.QueryRowContext(ctx, updateMembership, arg.UserID, arg.GroupID, arg.Role)
+	var i Membership
+	err := row.Scan(&i.UserID, &i.GroupID, &i.Role)
+	return i, err
+}
+
+const updateOrder = `-- name: UpdateOrder :one
+UPDATE "order"
+SET "desc" = $2,
+    "Total" = $3
+WHERE id = $1
+RETURNING id, "desc", Total
+`
+
+type UpdateOrderParams struct {
+	ID    int32
+	Desc  string
+	Total int32
+}
+
+func (q *Queries) UpdateOrder(ctx context.Context, arg UpdateOrderParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, updateOrder, arg.ID, arg.Desc, arg.Total)
+	var i Order
+	err := row.Scan(&i.ID, &i.Desc, &i.Total)
+	return i, err
+}
+
+const updateUser = `-- name: UpdateUser :one
+UPDATE users
+SET name = $2,
+    email = $3
+WHERE id = $1
+RETURNING id, name, email
+`
+
+type UpdateUserParams struct {
+	ID    int32
+	Name  string
+	Email sql.NullString
+}
+
+func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUser, arg.ID, arg.Name, arg.Email)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
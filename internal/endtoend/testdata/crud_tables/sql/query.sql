@@ -0,0 +1,2 @@
+-- name: FindUserByEmail :one
+SELECT * FROM users WHERE email = $1;
@@ -0,0 +1,31 @@
+CREATE TABLE users (
+  id    SERIAL PRIMARY KEY,
+  name  text   NOT NULL,
+  email text
+);
+
+CREATE SCHEMA audit;
+
+CREATE TABLE audit.events (
+  action text NOT NULL,
+  at     timestamp NOT NULL
+);
+
+CREATE TABLE "order" (
+  id     SERIAL PRIMARY KEY,
+  "desc" text NOT NULL,
+  "Total" integer NOT NULL
+);
+
+CREATE TABLE accounts (
+  account_id text PRIMARY KEY,
+  name       text NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE memberships (
+  user_id  integer NOT NULL,
+  group_id integer NOT NULL,
+  role     text NOT NULL,
+  PRIMARY KEY (user_id, group_id)
+);
@@ -0,0 +1,6 @@
+-- name: CountUsers :one
+SELECT count(*) FROM users;
+
+-- stderr
+-- # package querytest
+-- crud.sql:1:1: query file has the same name as the generated crud.sql
@@ -0,0 +1,4 @@
+CREATE TABLE users (
+  id   SERIAL PRIMARY KEY,
+  name text NOT NULL
+);
@@ -0,0 +1,45 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"cloud.google.com/go/civil"
+)
+
+const listEventsOn = `-- name: ListEventsOn :many
+SELECT id, created_at, updated_at, day, end_day, starts, ends FROM events WHERE day = $1
+`
+
+func (q *Queries) ListEventsOn(ctx context.Context, day civil.Date) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsOn, day)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Day,
+			&i.EndDay,
+			&i.Starts,
+			&i.Ends,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
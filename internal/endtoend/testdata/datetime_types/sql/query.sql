@@ -0,0 +1,12 @@
+CREATE TABLE events (
+  id          SERIAL PRIMARY KEY,
+  created_at  timestamptz NOT NULL,
+  updated_at  timestamp,
+  day         date NOT NULL,
+  end_day     date,
+  starts      time NOT NULL,
+  ends        time
+);
+
+-- name: ListEventsOn :many
+SELECT * FROM events WHERE day = $1;
@@ -0,0 +1,30 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+type Conn interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	BeginTx(context.Context, *sql.TxOptions) (*sql.Tx, error)
+}
+
+func New(db Conn) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db Conn
+}
+
+func (q *Queries) WithTx(tx Conn) *Queries {
+	return &Queries{
+		db: tx,
+	}
+}
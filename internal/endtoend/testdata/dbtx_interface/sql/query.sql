@@ -0,0 +1,17 @@
+CREATE TABLE users (
+  id    SERIAL PRIMARY KEY,
+  name  text   NOT NULL,
+  email text
+);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListUsers :many
+SELECT * FROM users;
+
+-- name: RenameUser :exec
+UPDATE users SET name = $2 WHERE id = $1;
+
+-- name: DeleteUsersWithoutEmail :execrows
+DELETE FROM users WHERE email IS NULL;
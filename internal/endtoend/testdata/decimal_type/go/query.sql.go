@@ -0,0 +1,37 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+const listInvoicesOver = `-- name: ListInvoicesOver :many
+SELECT id, total, discount FROM invoices WHERE total > $1
+`
+
+func (q *Queries) ListInvoicesOver(ctx context.Context, total decimal.Decimal) ([]Invoice, error) {
+	rows, err := q.db.QueryContext(ctx, listInvoicesOver, total)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Invoice
+	for rows.Next() {
+		var i Invoice
+		if err := rows.Scan(&i.ID, &i.Total, &i.Discount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
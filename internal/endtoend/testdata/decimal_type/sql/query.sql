@@ -0,0 +1,8 @@
+CREATE TABLE invoices (
+  id       SERIAL PRIMARY KEY,
+  total    numeric(12, 2) NOT NULL,
+  discount decimal
+);
+
+-- name: ListInvoicesOver :many
+SELECT * FROM invoices WHERE total > $1;
@@ -0,0 +1,52 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package override
+
+import (
+	"context"
+	"database/sql"
+
+	"net/mail"
+)
+
+const createUser = `-- name: CreateUser :exec
+INSERT INTO users (email, backup, contact, age, balance) VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateUserParams struct {
+	Email   mail.Address
+	Backup  sql.NullString
+	Contact sql.NullString
+	Age     int32
+	Balance int64
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
+	_, err := q.db.ExecContext(ctx, createUser,
+		arg.Email,
+		arg.Backup,
+		arg.Contact,
+		arg.Age,
+		arg.Balance,
+	)
+	return err
+}
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, backup, contact, age, balance FROM users WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email mail.Address) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.Backup,
+		&i.Contact,
+		&i.Age,
+		&i.Balance,
+	)
+	return i, err
+}
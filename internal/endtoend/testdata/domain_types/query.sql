@@ -0,0 +1,20 @@
+CREATE SCHEMA billing;
+CREATE DOMAIN email AS text CHECK (VALUE LIKE '%@%');
+CREATE DOMAIN positive_int AS integer NOT NULL CHECK (VALUE > 0);
+CREATE DOMAIN billing.cents AS bigint;
+CREATE DOMAIN contact AS email;
+
+CREATE TABLE users (
+    id       serial PRIMARY KEY,
+    email    email NOT NULL,
+    backup   email,
+    contact  contact,
+    age      positive_int,
+    balance  billing.cents NOT NULL
+);
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1;
+
+-- name: CreateUser :exec
+INSERT INTO users (email, backup, contact, age, balance) VALUES ($1, $2, $3, $4, $5);
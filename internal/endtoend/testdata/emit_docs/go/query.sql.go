@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, status, tags FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		pq.Array(&i.Tags),
+	)
+	return i, err
+}
+
+const listActiveUserActions = `-- name: ListActiveUserActions :many
+WITH active AS (
+  SELECT id FROM users WHERE status = 'active'
+)
+SELECT action FROM audit.events
+JOIN active ON active.id = audit.events.user_id
+`
+
+func (q *Queries) ListActiveUserActions(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUserActions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var action string
+		if err := rows.Scan(&action); err != nil {
+			return nil, err
+		}
+		items = append(items, action)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
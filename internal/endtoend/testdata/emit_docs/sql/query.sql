@@ -0,0 +1,9 @@
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListActiveUserActions :many
+WITH active AS (
+  SELECT id FROM users WHERE status = 'active'
+)
+SELECT action FROM audit.events
+JOIN active ON active.id = audit.events.user_id;
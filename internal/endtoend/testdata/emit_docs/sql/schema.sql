@@ -0,0 +1,20 @@
+CREATE SCHEMA audit;
+
+COMMENT ON SCHEMA audit IS 'Changes made by users';
+
+CREATE TYPE status AS ENUM ('active', 'disabled');
+
+CREATE TABLE users (
+  id serial NOT NULL,
+  name text NOT NULL,
+  status status NOT NULL,
+  tags text[]
+);
+
+COMMENT ON TABLE users IS 'Everyone who can sign in';
+COMMENT ON COLUMN users.name IS 'Display name | shown on profiles';
+
+CREATE TABLE audit.events (
+  user_id integer NOT NULL,
+  action text NOT NULL
+);
@@ -0,0 +1,4 @@
+CREATE TABLE bar (id serial not null);
+
+-- name: ListBar :many
+SELECT * FROM bar;
@@ -0,0 +1,2 @@
+-- name: ListPostsByUser :many
+SELECT * FROM posts WHERE user_id = $1;
@@ -0,0 +1,10 @@
+CREATE TABLE users (
+  id   SERIAL PRIMARY KEY,
+  name text   NOT NULL
+);
+
+CREATE TABLE posts (
+  id      SERIAL PRIMARY KEY,
+  user_id int    NOT NULL REFERENCES users(id),
+  body    text   NOT NULL
+);
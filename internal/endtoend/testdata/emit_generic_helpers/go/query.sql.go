@@ -0,0 +1,64 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const deleteUsersWithoutEmail = `-- name: DeleteUsersWithoutEmail :execrows
+DELETE FROM users WHERE email IS NULL
+`
+
+func (q *Queries) DeleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUsersWithoutEmail)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, email FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	return scanGetUser(row)
+}
+
+func scanGetUser(row scanner) (User, error) {
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	return queryMany(rows, err, scanListUsers)
+}
+
+func scanListUsers(row scanner) (User, error) {
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const renameUser = `-- name: RenameUser :exec
+UPDATE users SET name = $2 WHERE id = $1
+`
+
+type RenameUserParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) RenameUser(ctx context.Context, arg RenameUserParams) error {
+	_, err := q.db.ExecContext(ctx, renameUser, arg.ID, arg.Name)
+	return err
+}
@@ -0,0 +1,176 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.deleteUsersWithoutEmailStmt, err = db.PrepareContext(ctx, deleteUsersWithoutEmail); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUsersWithoutEmail: %w", err)
+	}
+	if q.getUserStmt, err = db.PrepareContext(ctx, getUser); err != nil {
+		return nil, fmt.Errorf("error preparing query GetUser: %w", err)
+	}
+	if q.listUsersStmt, err = db.PrepareContext(ctx, listUsers); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUsers: %w", err)
+	}
+	if q.renameUserStmt, err = db.PrepareContext(ctx, renameUser); err != nil {
+		return nil, fmt.Errorf("error preparing query RenameUser: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.deleteUsersWithoutEmailStmt != nil {
+		if cerr := q.deleteUsersWithoutEmailStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUsersWithoutEmailStmt: %w", cerr)
+		}
+	}
+	if q.getUserStmt != nil {
+		if cerr := q.getUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getUserStmt: %w", cerr)
+		}
+	}
+	if q.listUsersStmt != nil {
+		if cerr := q.listUsersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUsersStmt: %w", cerr)
+		}
+	}
+	if q.renameUserStmt != nil {
+		if cerr := q.renameUserStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing renameUserStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                          DBTX
+	hooks                       QueryHooks
+	tx                          *sql.Tx
+	deleteUsersWithoutEmailStmt *sql.Stmt
+	getUserStmt                 *sql.Stmt
+	listUsersStmt               *sql.Stmt
+	renameUserStmt              *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                          tx,
+		hooks:                       q.hooks,
+		tx:                          tx,
+		deleteUsersWithoutEmailStmt: q.deleteUsersWithoutEmailStmt,
+		getUserStmt:                 q.getUserStmt,
+		listUsersStmt:               q.listUsersStmt,
+		renameUserStmt:              q.renameUserStmt,
+	}
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// queryMany scans every row returned by a query using scan and closes rows.
+func queryMany[T any](rows *sql.Rows, err error, scan func(scanner) (T, error)) ([]T, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []T
+	for rows.Next() {
+		i, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// QueryHooks is implemented by types that observe the queries executed by
+// Queries, e.g. to record tracing spans or log slow queries.
+type QueryHooks interface {
+	// BeforeQuery is called before a query is executed. The returned context
+	// is used to execute the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name, query string, args []interface{}) context.Context
+	// AfterQuery is called once a query has completed or failed.
+	AfterQuery(ctx context.Context, name, query string, args []interface{}, duration time.Duration, err error)
+}
+
+// WithHooks returns a copy of q which calls hooks around every query.
+func (q *Queries) WithHooks(hooks QueryHooks) *Queries {
+	hooked := *q
+	hooked.hooks = hooks
+	return &hooked
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.hooks == nil {
+		return ctx, func(err error) error { return err }
+	}
+	ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	start := time.Now()
+	return ctx, func(err error) error {
+		q.hooks.AfterQuery(ctx, name, query, args, time.Since(start), err)
+		return err
+	}
+}
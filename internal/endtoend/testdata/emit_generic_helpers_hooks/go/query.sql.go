@@ -0,0 +1,71 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const deleteUsersWithoutEmail = `-- name: DeleteUsersWithoutEmail :execrows
+DELETE FROM users WHERE email IS NULL
+`
+
+func (q *Queries) DeleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	ctx, endQuery := q.startQuery(ctx, "DeleteUsersWithoutEmail", deleteUsersWithoutEmail)
+	result, err := q.exec(ctx, q.deleteUsersWithoutEmailStmt, deleteUsersWithoutEmail)
+	if err != nil {
+		return 0, endQuery(err)
+	}
+	affected, err := result.RowsAffected()
+	return affected, endQuery(err)
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, email FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	ctx, endQuery := q.startQuery(ctx, "GetUser", getUser, id)
+	row := q.queryRow(ctx, q.getUserStmt, getUser, id)
+	i, err := scanGetUser(row)
+	return i, endQuery(err)
+}
+
+func scanGetUser(row scanner) (User, error) {
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	ctx, endQuery := q.startQuery(ctx, "ListUsers", listUsers)
+	rows, err := q.query(ctx, q.listUsersStmt, listUsers)
+	items, err := queryMany(rows, err, scanListUsers)
+	return items, endQuery(err)
+}
+
+func scanListUsers(row scanner) (User, error) {
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const renameUser = `-- name: RenameUser :exec
+UPDATE users SET name = $2 WHERE id = $1
+`
+
+type RenameUserParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) RenameUser(ctx context.Context, arg RenameUserParams) error {
+	ctx, endQuery := q.startQuery(ctx, "RenameUser", renameUser, arg.ID, arg.Name)
+	_, err := q.exec(ctx, q.renameUserStmt, renameUser, arg.ID, arg.Name)
+	return endQuery(err)
+}
@@ -0,0 +1,65 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// UserByIDLoader loads and caches User rows by ID.
+// Foreign keys: Pet.OwnerID, Pet.SitterID.
+//
+// Fetch is called with the keys which aren't cached yet and returns their
+// rows in any order. Use one loader per request.
+type UserByIDLoader struct {
+	Fetch func(ctx context.Context, keys []int32) ([]User, error)
+
+	mu    sync.Mutex
+	cache map[int32]User
+}
+
+// LoadAll returns the rows for keys. Keys without a row are left out.
+func (l *UserByIDLoader) LoadAll(ctx context.Context, keys []int32) (map[int32]User, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cache == nil {
+		l.cache = map[int32]User{}
+	}
+	var missing []int32
+	for _, key := range keys {
+		if _, ok := l.cache[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		rows, err := l.Fetch(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			l.cache[row.ID] = row
+		}
+	}
+	out := make(map[int32]User, len(keys))
+	for _, key := range keys {
+		if row, ok := l.cache[key]; ok {
+			out[key] = row
+		}
+	}
+	return out, nil
+}
+
+// Load returns the row for key, or sql.ErrNoRows if there isn't one.
+func (l *UserByIDLoader) Load(ctx context.Context, key int32) (User, error) {
+	rows, err := l.LoadAll(ctx, []int32{key})
+	if err != nil {
+		return User{}, err
+	}
+	row, ok := rows[key]
+	if !ok {
+		return User{}, sql.ErrNoRows
+	}
+	return row, nil
+}
@@ -0,0 +1,2 @@
+-- name: ListUsersByIDs :many
+SELECT * FROM users WHERE id = ANY($1::int[]);
@@ -0,0 +1,20 @@
+CREATE TYPE status AS ENUM ('active', 'disabled');
+
+CREATE TABLE users (
+  id serial NOT NULL,
+  name text NOT NULL,
+  bio text,
+  status status NOT NULL,
+  settings jsonb NOT NULL,
+  created_at timestamp NOT NULL
+);
+
+COMMENT ON TABLE users IS 'Everyone who can sign in';
+
+CREATE TABLE pets (
+  id serial NOT NULL,
+  name text NOT NULL,
+  owner_id integer NOT NULL REFERENCES users,
+  sitter_id integer REFERENCES users (id),
+  tags text[] NOT NULL
+);
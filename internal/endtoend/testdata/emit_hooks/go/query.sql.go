@@ -0,0 +1,77 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const deleteUsersWithoutEmail = `-- name: DeleteUsersWithoutEmail :execrows
+DELETE FROM users WHERE email IS NULL
+`
+
+func (q *Queries) DeleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	ctx, endQuery := q.startQuery(ctx, "DeleteUsersWithoutEmail", deleteUsersWithoutEmail)
+	result, err := q.db.ExecContext(ctx, deleteUsersWithoutEmail)
+	if err != nil {
+		return 0, endQuery(err)
+	}
+	affected, err := result.RowsAffected()
+	return affected, endQuery(err)
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, email FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	ctx, endQuery := q.startQuery(ctx, "GetUser", getUser, id)
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, endQuery(err)
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	ctx, endQuery := q.startQuery(ctx, "ListUsers", listUsers)
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, endQuery(err)
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+			return nil, endQuery(err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, endQuery(err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, endQuery(err)
+	}
+	return items, endQuery(nil)
+}
+
+const renameUser = `-- name: RenameUser :exec
+UPDATE users SET name = $2 WHERE id = $1
+`
+
+type RenameUserParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) RenameUser(ctx context.Context, arg RenameUserParams) error {
+	ctx, endQuery := q.startQuery(ctx, "RenameUser", renameUser, arg.ID, arg.Name)
+	_, err := q.db.ExecContext(ctx, renameUser, arg.ID, arg.Name)
+	return endQuery(err)
+}
@@ -0,0 +1,66 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db           DBTX
+	interceptors []Interceptor
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:           tx,
+		interceptors: q.interceptors,
+	}
+}
+
+// QueryInfo describes the query run by an Interceptor.
+type QueryInfo struct {
+	Name  string
+	Cmd   string
+	Query string
+}
+
+// Interceptor wraps the execution of a query. It runs the query by calling
+// next, and may call it more than once, e.g. to retry a serialization
+// failure, or with a different db to route the query to a replica.
+type Interceptor func(ctx context.Context, info QueryInfo, db DBTX, next func(context.Context, DBTX) error) error
+
+// WithInterceptors returns a copy of q which runs every query through
+// interceptors. The first interceptor is the outermost.
+func (q *Queries) WithInterceptors(interceptors ...Interceptor) *Queries {
+	intercepted := *q
+	intercepted.interceptors = append(append([]Interceptor{}, q.interceptors...), interceptors...)
+	return &intercepted
+}
+
+func (q *Queries) intercept(ctx context.Context, info QueryInfo, run func(context.Context, *Queries) error) error {
+	next := func(ctx context.Context, db DBTX) error {
+		c := *q
+		c.db = db
+		return run(ctx, &c)
+	}
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := q.interceptors[i], next
+		next = func(ctx context.Context, db DBTX) error {
+			return interceptor(ctx, info, db, inner)
+		}
+	}
+	return next(ctx, q.db)
+}
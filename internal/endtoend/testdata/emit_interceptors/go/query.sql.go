@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const deleteUsersWithoutEmail = `-- name: DeleteUsersWithoutEmail :execrows
+DELETE FROM users WHERE email IS NULL
+`
+
+func (q *Queries) DeleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	var result int64
+	err := q.intercept(ctx, QueryInfo{Name: "DeleteUsersWithoutEmail", Cmd: ":execrows", Query: deleteUsersWithoutEmail}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.deleteUsersWithoutEmail(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) deleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteUsersWithoutEmail)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, email FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	var result User
+	err := q.intercept(ctx, QueryInfo{Name: "GetUser", Cmd: ":one", Query: getUser}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.getUser(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) getUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name, &i.Email)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	var result []User
+	err := q.intercept(ctx, QueryInfo{Name: "ListUsers", Cmd: ":many", Query: listUsers}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.listUsers(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const renameUser = `-- name: RenameUser :exec
+UPDATE users SET name = $2 WHERE id = $1
+`
+
+type RenameUserParams struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) RenameUser(ctx context.Context, arg RenameUserParams) error {
+	return q.intercept(ctx, QueryInfo{Name: "RenameUser", Cmd: ":exec", Query: renameUser}, func(ctx context.Context, q *Queries) error {
+		return q.renameUser(ctx, arg)
+	})
+}
+
+func (q *Queries) renameUser(ctx context.Context, arg RenameUserParams) error {
+	_, err := q.db.ExecContext(ctx, renameUser, arg.ID, arg.Name)
+	return err
+}
@@ -0,0 +1,203 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.deleteEventStmt, err = db.PrepareContext(ctx, deleteEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteEvent: %w", err)
+	}
+	if q.getEventStmt, err = db.PrepareContext(ctx, getEvent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetEvent: %w", err)
+	}
+	if q.iterEventsByKindStmt, err = db.PrepareContext(ctx, iterEventsByKind); err != nil {
+		return nil, fmt.Errorf("error preparing query IterEventsByKind: %w", err)
+	}
+	if q.listEventsStmt, err = db.PrepareContext(ctx, listEvents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEvents: %w", err)
+	}
+	if q.listEventsByKindStmt, err = db.PrepareContext(ctx, listEventsByKind); err != nil {
+		return nil, fmt.Errorf("error preparing query ListEventsByKind: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.deleteEventStmt != nil {
+		if cerr := q.deleteEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteEventStmt: %w", cerr)
+		}
+	}
+	if q.getEventStmt != nil {
+		if cerr := q.getEventStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getEventStmt: %w", cerr)
+		}
+	}
+	if q.iterEventsByKindStmt != nil {
+		if cerr := q.iterEventsByKindStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing iterEventsByKindStmt: %w", cerr)
+		}
+	}
+	if q.listEventsStmt != nil {
+		if cerr := q.listEventsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEventsStmt: %w", cerr)
+		}
+	}
+	if q.listEventsByKindStmt != nil {
+		if cerr := q.listEventsByKindStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listEventsByKindStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                   DBTX
+	hooks                QueryHooks
+	interceptors         []Interceptor
+	tx                   *sql.Tx
+	deleteEventStmt      *sql.Stmt
+	getEventStmt         *sql.Stmt
+	iterEventsByKindStmt *sql.Stmt
+	listEventsStmt       *sql.Stmt
+	listEventsByKindStmt *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                   tx,
+		hooks:                q.hooks,
+		interceptors:         q.interceptors,
+		tx:                   tx,
+		deleteEventStmt:      q.deleteEventStmt,
+		getEventStmt:         q.getEventStmt,
+		iterEventsByKindStmt: q.iterEventsByKindStmt,
+		listEventsStmt:       q.listEventsStmt,
+		listEventsByKindStmt: q.listEventsByKindStmt,
+	}
+}
+
+// QueryInfo describes the query run by an Interceptor.
+type QueryInfo struct {
+	Name  string
+	Cmd   string
+	Query string
+}
+
+// Interceptor wraps the execution of a query. It runs the query by calling
+// next, and may call it more than once, e.g. to retry a serialization
+// failure, or with a different db to route the query to a replica.
+type Interceptor func(ctx context.Context, info QueryInfo, db DBTX, next func(context.Context, DBTX) error) error
+
+// WithInterceptors returns a copy of q which runs every query through
+// interceptors. The first interceptor is the outermost.
+func (q *Queries) WithInterceptors(interceptors ...Interceptor) *Queries {
+	intercepted := *q
+	intercepted.interceptors = append(append([]Interceptor{}, q.interceptors...), interceptors...)
+	return &intercepted
+}
+
+func (q *Queries) intercept(ctx context.Context, info QueryInfo, run func(context.Context, *Queries) error) error {
+	next := func(ctx context.Context, db DBTX) error {
+		c := *q
+		if db != q.db {
+			// The statements of q were prepared on q.db, so run the query
+			// on db without them
+			c = Queries{
+				hooks:        q.hooks,
+				interceptors: q.interceptors,
+			}
+		}
+		c.db = db
+		return run(ctx, &c)
+	}
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := q.interceptors[i], next
+		next = func(ctx context.Context, db DBTX) error {
+			return interceptor(ctx, info, db, inner)
+		}
+	}
+	return next(ctx, q.db)
+}
+
+// QueryHooks is implemented by types that observe the queries executed by
+// Queries, e.g. to record tracing spans or log slow queries.
+type QueryHooks interface {
+	// BeforeQuery is called before a query is executed. The returned context
+	// is used to execute the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name, query string, args []interface{}) context.Context
+	// AfterQuery is called once a query has completed or failed.
+	AfterQuery(ctx context.Context, name, query string, args []interface{}, duration time.Duration, err error)
+}
+
+// WithHooks returns a copy of q which calls hooks around every query.
+func (q *Queries) WithHooks(hooks QueryHooks) *Queries {
+	hooked := *q
+	hooked.hooks = hooks
+	return &hooked
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.hooks == nil {
+		return ctx, func(err error) error { return err }
+	}
+	ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	start := time.Now()
+	return ctx, func(err error) error {
+		q.hooks.AfterQuery(ctx, name, query, args, time.Since(start), err)
+		return err
+	}
+}
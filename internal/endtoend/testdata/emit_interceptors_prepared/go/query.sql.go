@@ -0,0 +1,243 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"iter"
+)
+
+const deleteEvent = `-- name: DeleteEvent :exec
+DELETE FROM events WHERE id = $1
+`
+
+func (q *Queries) DeleteEvent(ctx context.Context, id int64) error {
+	return q.intercept(ctx, QueryInfo{Name: "DeleteEvent", Cmd: ":exec", Query: deleteEvent}, func(ctx context.Context, q *Queries) error {
+		return q.deleteEvent(ctx, id)
+	})
+}
+
+func (q *Queries) deleteEvent(ctx context.Context, id int64) error {
+	ctx, endQuery := q.startQuery(ctx, "DeleteEvent", deleteEvent, id)
+	_, err := q.exec(ctx, q.deleteEventStmt, deleteEvent, id)
+	return endQuery(err)
+}
+
+const getEvent = `-- name: GetEvent :one
+SELECT id, kind, payload FROM events WHERE id = $1
+`
+
+func (q *Queries) GetEvent(ctx context.Context, id int64) (Event, error) {
+	var result Event
+	err := q.intercept(ctx, QueryInfo{Name: "GetEvent", Cmd: ":one", Query: getEvent}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.getEvent(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) getEvent(ctx context.Context, id int64) (Event, error) {
+	ctx, endQuery := q.startQuery(ctx, "GetEvent", getEvent, id)
+	row := q.queryRow(ctx, q.getEventStmt, getEvent, id)
+	var i Event
+	err := row.Scan(&i.ID, &i.Kind, &i.Payload)
+	return i, endQuery(err)
+}
+
+const iterEventsByKind = `-- name: IterEventsByKind :iter
+SELECT id, kind, payload FROM events WHERE kind = $1 ORDER BY id
+`
+
+func (q *Queries) IterEventsByKind(ctx context.Context, kind string) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		// Once yield returns false it mustn't be called again, even if an
+		// interceptor runs the query again
+		stopped := false
+		err := q.intercept(ctx, QueryInfo{Name: "IterEventsByKind", Cmd: ":iter", Query: iterEventsByKind}, func(ctx context.Context, q *Queries) error {
+			var err error
+			q.iterEventsByKind(ctx, kind)(func(row Event, rowErr error) bool {
+				if rowErr != nil {
+					err = rowErr
+					return false
+				}
+				if stopped || !yield(row, nil) {
+					stopped = true
+					return false
+				}
+				return true
+			})
+			return err
+		})
+		if err != nil && !stopped {
+			var zero Event
+			yield(zero, err)
+		}
+	}
+}
+
+func (q *Queries) iterEventsByKind(ctx context.Context, kind string) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		ctx, endQuery := q.startQuery(ctx, "IterEventsByKind", iterEventsByKind, kind)
+		var zero Event
+		rows, err := q.query(ctx, q.iterEventsByKindStmt, iterEventsByKind, kind)
+		if err != nil {
+			yield(zero, endQuery(err))
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var i Event
+			if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+				yield(zero, endQuery(err))
+				return
+			}
+			if !yield(i, nil) {
+				endQuery(nil)
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, endQuery(err))
+			return
+		}
+		endQuery(nil)
+	}
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT id, kind, payload FROM events ORDER BY id
+`
+
+func (q *Queries) ListEvents(ctx context.Context) ([]Event, error) {
+	var result []Event
+	err := q.intercept(ctx, QueryInfo{Name: "ListEvents", Cmd: ":many", Query: listEvents}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.listEvents(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listEvents(ctx context.Context) ([]Event, error) {
+	ctx, endQuery := q.startQuery(ctx, "ListEvents", listEvents)
+	rows, err := q.query(ctx, q.listEventsStmt, listEvents)
+	if err != nil {
+		return nil, endQuery(err)
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return nil, endQuery(err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, endQuery(err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, endQuery(err)
+	}
+	return items, endQuery(nil)
+}
+
+// ForEachListEvents calls fn for each row returned by ListEvents,
+// without loading every row into memory. Iteration stops at the first error.
+func (q *Queries) ForEachListEvents(ctx context.Context, fn func(Event) error) error {
+	return q.intercept(ctx, QueryInfo{Name: "ForEachListEvents", Cmd: ":many", Query: listEvents}, func(ctx context.Context, q *Queries) error {
+		return q.forEachListEvents(ctx, fn)
+	})
+}
+
+func (q *Queries) forEachListEvents(ctx context.Context, fn func(Event) error) error {
+	ctx, endQuery := q.startQuery(ctx, "ForEachListEvents", listEvents)
+	rows, err := q.query(ctx, q.listEventsStmt, listEvents)
+	if err != nil {
+		return endQuery(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return endQuery(err)
+		}
+		if err := fn(i); err != nil {
+			return endQuery(err)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return endQuery(err)
+	}
+	return endQuery(rows.Err())
+}
+
+const listEventsByKind = `-- name: ListEventsByKind :many
+SELECT id, kind, payload FROM events WHERE kind = $1 ORDER BY id
+`
+
+func (q *Queries) ListEventsByKind(ctx context.Context, kind string) ([]Event, error) {
+	var result []Event
+	err := q.intercept(ctx, QueryInfo{Name: "ListEventsByKind", Cmd: ":many", Query: listEventsByKind}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.listEventsByKind(ctx, kind)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listEventsByKind(ctx context.Context, kind string) ([]Event, error) {
+	ctx, endQuery := q.startQuery(ctx, "ListEventsByKind", listEventsByKind, kind)
+	rows, err := q.query(ctx, q.listEventsByKindStmt, listEventsByKind, kind)
+	if err != nil {
+		return nil, endQuery(err)
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return nil, endQuery(err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, endQuery(err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, endQuery(err)
+	}
+	return items, endQuery(nil)
+}
+
+// ForEachListEventsByKind calls fn for each row returned by ListEventsByKind,
+// without loading every row into memory. Iteration stops at the first error.
+func (q *Queries) ForEachListEventsByKind(ctx context.Context, kind string, fn func(Event) error) error {
+	return q.intercept(ctx, QueryInfo{Name: "ForEachListEventsByKind", Cmd: ":many", Query: listEventsByKind}, func(ctx context.Context, q *Queries) error {
+		return q.forEachListEventsByKind(ctx, kind, fn)
+	})
+}
+
+func (q *Queries) forEachListEventsByKind(ctx context.Context, kind string, fn func(Event) error) error {
+	ctx, endQuery := q.startQuery(ctx, "ForEachListEventsByKind", listEventsByKind, kind)
+	rows, err := q.query(ctx, q.listEventsByKindStmt, listEventsByKind, kind)
+	if err != nil {
+		return endQuery(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return endQuery(err)
+		}
+		if err := fn(i); err != nil {
+			return endQuery(err)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return endQuery(err)
+	}
+	return endQuery(rows.Err())
+}
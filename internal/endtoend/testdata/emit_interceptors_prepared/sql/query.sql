@@ -0,0 +1,20 @@
+CREATE TABLE events (
+  id      BIGSERIAL PRIMARY KEY,
+  kind    text      NOT NULL,
+  payload text      NOT NULL
+);
+
+-- name: GetEvent :one
+SELECT * FROM events WHERE id = $1;
+
+-- name: ListEvents :many
+SELECT * FROM events ORDER BY id;
+
+-- name: ListEventsByKind :many
+SELECT * FROM events WHERE kind = $1 ORDER BY id;
+
+-- name: IterEventsByKind :iter
+SELECT * FROM events WHERE kind = $1 ORDER BY id;
+
+-- name: DeleteEvent :exec
+DELETE FROM events WHERE id = $1;
@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+)
+
+const deleteAuthor = `-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteAuthor, id)
+	return err
+}
+
+const deleteAuthors = `-- name: DeleteAuthors :execrows
+DELETE FROM authors
+WHERE name = $1
+`
+
+func (q *Queries) DeleteAuthors(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAuthors, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, created_at FROM authors
+WHERE id = $1
+`
+
+// Get a single author by ID
+func (q *Queries) GetAuthor(ctx context.Context, id int32) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, created_at FROM authors
+WHERE created_at > $1
+`
+
+func (q *Queries) ListAuthors(ctx context.Context, createdAt time.Time) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthors, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
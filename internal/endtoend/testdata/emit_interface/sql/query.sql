@@ -0,0 +1,22 @@
+CREATE TABLE authors (
+  id         SERIAL PRIMARY KEY,
+  name       text   NOT NULL,
+  created_at timestamp NOT NULL
+);
+
+-- name: GetAuthor :one
+-- Get a single author by ID
+SELECT * FROM authors
+WHERE id = $1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors
+WHERE created_at > $1;
+
+-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: DeleteAuthors :execrows
+DELETE FROM authors
+WHERE name = $1;
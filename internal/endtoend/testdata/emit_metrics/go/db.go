@@ -0,0 +1,57 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db      DBTX
+	metrics QueryMetrics
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:      tx,
+		metrics: q.metrics,
+	}
+}
+
+// QueryMetrics records the outcome of every query, e.g. as a counter and a
+// latency histogram labelled with the query name.
+type QueryMetrics interface {
+	ObserveQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// WithMetrics returns a copy of q which reports every query to metrics.
+func (q *Queries) WithMetrics(metrics QueryMetrics) *Queries {
+	measured := *q
+	measured.metrics = metrics
+	return &measured
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.metrics == nil {
+		return ctx, func(err error) error { return err }
+	}
+	start := time.Now()
+	return ctx, func(err error) error {
+		duration := time.Since(start)
+		q.metrics.ObserveQuery(ctx, name, duration, err)
+		return err
+	}
+}
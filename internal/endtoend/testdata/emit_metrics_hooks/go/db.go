@@ -0,0 +1,84 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db      DBTX
+	hooks   QueryHooks
+	metrics QueryMetrics
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:      tx,
+		hooks:   q.hooks,
+		metrics: q.metrics,
+	}
+}
+
+// QueryHooks is implemented by types that observe the queries executed by
+// Queries, e.g. to record tracing spans or log slow queries.
+type QueryHooks interface {
+	// BeforeQuery is called before a query is executed. The returned context
+	// is used to execute the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name, query string, args []interface{}) context.Context
+	// AfterQuery is called once a query has completed or failed.
+	AfterQuery(ctx context.Context, name, query string, args []interface{}, duration time.Duration, err error)
+}
+
+// WithHooks returns a copy of q which calls hooks around every query.
+func (q *Queries) WithHooks(hooks QueryHooks) *Queries {
+	hooked := *q
+	hooked.hooks = hooks
+	return &hooked
+}
+
+// QueryMetrics records the outcome of every query, e.g. as a counter and a
+// latency histogram labelled with the query name.
+type QueryMetrics interface {
+	ObserveQuery(ctx context.Context, name string, duration time.Duration, err error)
+}
+
+// WithMetrics returns a copy of q which reports every query to metrics.
+func (q *Queries) WithMetrics(metrics QueryMetrics) *Queries {
+	measured := *q
+	measured.metrics = metrics
+	return &measured
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.hooks == nil && q.metrics == nil {
+		return ctx, func(err error) error { return err }
+	}
+	if q.hooks != nil {
+		ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	}
+	start := time.Now()
+	return ctx, func(err error) error {
+		duration := time.Since(start)
+		if q.hooks != nil {
+			q.hooks.AfterQuery(ctx, name, query, args, duration, err)
+		}
+		if q.metrics != nil {
+			q.metrics.ObserveQuery(ctx, name, duration, err)
+		}
+		return err
+	}
+}
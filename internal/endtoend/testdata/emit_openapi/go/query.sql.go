@@ -0,0 +1,61 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, bio, status, previous_status, tags, created_at FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Status,
+		&i.PreviousStatus,
+		pq.Array(&i.Tags),
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listUserNames = `-- name: ListUserNames :many
+SELECT id, name FROM users
+`
+
+type ListUserNamesRow struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) ListUserNames(ctx context.Context) ([]ListUserNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserNamesRow
+	for rows.Next() {
+		var i ListUserNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
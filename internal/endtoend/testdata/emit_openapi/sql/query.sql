@@ -0,0 +1,5 @@
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListUserNames :many
+SELECT id, name FROM users;
@@ -0,0 +1,14 @@
+CREATE TYPE status AS ENUM ('active', 'disabled');
+
+CREATE TABLE users (
+  id bigserial NOT NULL,
+  name text NOT NULL,
+  bio text,
+  status status NOT NULL,
+  previous_status status,
+  tags text[] NOT NULL,
+  created_at timestamp NOT NULL
+);
+
+COMMENT ON TABLE users IS 'Everyone who can sign in';
+COMMENT ON COLUMN users.name IS 'Display name';
@@ -0,0 +1,50 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+)
+
+const listProfilesByBio = `-- name: ListProfilesByBio :many
+SELECT id, bio, age, followers, rating, score, verified, born_on, updated_at, mood, avatar, tags FROM profiles WHERE bio = $1
+`
+
+func (q *Queries) ListProfilesByBio(ctx context.Context, bio *string) ([]Profile, error) {
+	rows, err := q.db.QueryContext(ctx, listProfilesByBio, bio)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Profile
+	for rows.Next() {
+		var i Profile
+		if err := rows.Scan(
+			&i.ID,
+			&i.Bio,
+			&i.Age,
+			&i.Followers,
+			&i.Rating,
+			&i.Score,
+			&i.Verified,
+			&i.BornOn,
+			&i.UpdatedAt,
+			&i.Mood,
+			&i.Avatar,
+			pq.Array(&i.Tags),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,19 @@
+CREATE TYPE mood AS ENUM ('happy', 'sad');
+
+CREATE TABLE profiles (
+  id         SERIAL      PRIMARY KEY,
+  bio        text,
+  age        integer,
+  followers  bigint,
+  rating     real,
+  score      float,
+  verified   boolean,
+  born_on    date,
+  updated_at timestamptz,
+  mood       mood,
+  avatar     bytea,
+  tags       text[]
+);
+
+-- name: ListProfilesByBio :many
+SELECT * FROM profiles WHERE bio = $1;
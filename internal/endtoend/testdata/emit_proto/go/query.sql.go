@@ -0,0 +1,63 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (name, bio, status, tags, created_at)
+VALUES ($1, $2, $3, $4, now())
+RETURNING id, created_at
+`
+
+type CreateUserParams struct {
+	Name   string
+	Bio    sql.NullString
+	Status Status
+	Tags   []string
+}
+
+type CreateUserRow struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
+	row := q.db.QueryRowContext(ctx, createUser,
+		arg.Name,
+		arg.Bio,
+		arg.Status,
+		pq.Array(arg.Tags),
+	)
+	var i CreateUserRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, bio, age, status, tags, address, created_at, deleted_at FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int64) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Age,
+		&i.Status,
+		pq.Array(&i.Tags),
+		&i.Address,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,7 @@
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: CreateUser :one
+INSERT INTO users (name, bio, status, tags, created_at)
+VALUES ($1, $2, $3, $4, now())
+RETURNING id, created_at;
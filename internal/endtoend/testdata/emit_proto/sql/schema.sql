@@ -0,0 +1,13 @@
+CREATE TYPE status AS ENUM ('active', 'disabled');
+
+CREATE TABLE users (
+  id bigserial NOT NULL,
+  name text NOT NULL,
+  bio text,
+  age smallint,
+  status status NOT NULL,
+  tags text[] NOT NULL,
+  address inet,
+  created_at timestamp NOT NULL,
+  deleted_at timestamp
+);
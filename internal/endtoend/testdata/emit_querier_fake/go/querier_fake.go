@@ -0,0 +1,74 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeQuerier is a fake implementation of the generated queries for use in
+// tests. Each method records its arguments and then calls the matching Func
+// field, returning zero values when the field is nil.
+type FakeQuerier struct {
+	mu sync.Mutex
+
+	DeleteUsersWithoutEmailFunc  func(ctx context.Context) (int64, error)
+	DeleteUsersWithoutEmailCalls int
+
+	GetUserFunc  func(ctx context.Context, id int32) (User, error)
+	GetUserCalls []int32
+
+	ListUsersFunc  func(ctx context.Context) ([]User, error)
+	ListUsersCalls int
+
+	RenameUserFunc  func(ctx context.Context, arg RenameUserParams) error
+	RenameUserCalls []RenameUserParams
+}
+
+var _ Querier = (*FakeQuerier)(nil)
+
+func (f *FakeQuerier) DeleteUsersWithoutEmail(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	f.DeleteUsersWithoutEmailCalls++
+	fn := f.DeleteUsersWithoutEmailFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return 0, nil
+	}
+	return fn(ctx)
+}
+
+func (f *FakeQuerier) GetUser(ctx context.Context, id int32) (User, error) {
+	f.mu.Lock()
+	f.GetUserCalls = append(f.GetUserCalls, id)
+	fn := f.GetUserFunc
+	f.mu.Unlock()
+	if fn == nil {
+		var zero User
+		return zero, nil
+	}
+	return fn(ctx, id)
+}
+
+func (f *FakeQuerier) ListUsers(ctx context.Context) ([]User, error) {
+	f.mu.Lock()
+	f.ListUsersCalls++
+	fn := f.ListUsersFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx)
+}
+
+func (f *FakeQuerier) RenameUser(ctx context.Context, arg RenameUserParams) error {
+	f.mu.Lock()
+	f.RenameUserCalls = append(f.RenameUserCalls, arg)
+	fn := f.RenameUserFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, arg)
+}
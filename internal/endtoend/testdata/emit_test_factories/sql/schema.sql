@@ -0,0 +1,21 @@
+CREATE TYPE status AS ENUM ('active', 'disabled');
+
+CREATE TABLE users (
+  id serial NOT NULL,
+  name text NOT NULL,
+  bio text,
+  status status NOT NULL,
+  tags text[] NOT NULL,
+  settings jsonb NOT NULL,
+  age integer NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE events (
+  id bigserial
+);
+
+CREATE TABLE "order" (
+  "desc" integer NOT NULL,
+  "Total" text NOT NULL
+);
@@ -0,0 +1,15 @@
+CREATE TYPE status AS ENUM (
+  'in progress',
+  'on-hold',
+  'DONE',
+  '1st-review',
+  ''
+);
+
+CREATE TABLE tasks (
+  id     SERIAL PRIMARY KEY,
+  status status NOT NULL
+);
+
+-- name: ListByStatus :many
+SELECT * FROM tasks WHERE status = $1;
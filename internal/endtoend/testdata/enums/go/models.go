@@ -3,6 +3,7 @@
 package enum
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -29,3 +30,52 @@ func (e *Foobar) Scan(src interface{}) error {
 	}
 	return nil
 }
+
+type NullFoobar struct {
+	Foobar Foobar
+	Valid  bool // Valid is true if Foobar is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFoobar) Scan(value interface{}) error {
+	if value == nil {
+		ns.Foobar, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.Foobar.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFoobar) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.Foobar), nil
+}
+
+func (e Foobar) Valid() bool {
+	switch e {
+	case FoobarFooA,
+		FoobarFooB,
+		FoobarFooC,
+		FoobarFooD,
+		FoobarFooe,
+		FoobarFoof,
+		FoobarFoog:
+		return true
+	}
+	return false
+}
+
+func AllFoobarValues() []Foobar {
+	return []Foobar{
+		FoobarFooA,
+		FoobarFooB,
+		FoobarFooC,
+		FoobarFooD,
+		FoobarFooe,
+		FoobarFoof,
+		FoobarFoog,
+	}
+}
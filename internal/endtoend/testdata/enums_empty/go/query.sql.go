@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const listSlots = `-- name: ListSlots :many
+SELECT id, kind FROM slots
+`
+
+func (q *Queries) ListSlots(ctx context.Context) ([]Slot, error) {
+	rows, err := q.db.QueryContext(ctx, listSlots)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Slot
+	for rows.Next() {
+		var i Slot
+		if err := rows.Scan(&i.ID, &i.Kind); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,9 @@
+CREATE TYPE placeholder AS ENUM ();
+
+CREATE TABLE slots (
+  id BIGSERIAL PRIMARY KEY,
+  kind placeholder
+);
+
+-- name: ListSlots :many
+SELECT * FROM slots;
@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getAccount = `-- name: GetAccount :one
+SELECT id, user_id FROM user_accounts WHERE id = $1
+`
+
+func (q *Queries) GetAccount(ctx context.Context, id int32) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccount, id)
+	var i Account
+	err := row.Scan(&i.ID, &i.UserID)
+	return i, err
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, spotify_url FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]Users, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Users
+	for rows.Next() {
+		var i Users
+		if err := rows.Scan(&i.ID, &i.SpotifyURL); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,15 @@
+CREATE TABLE users (
+  id          SERIAL PRIMARY KEY,
+  spotify_url text NOT NULL
+);
+
+CREATE TABLE user_accounts (
+  id      SERIAL PRIMARY KEY,
+  user_id integer NOT NULL
+);
+
+-- name: ListUsers :many
+SELECT * FROM users;
+
+-- name: GetAccount :one
+SELECT * FROM user_accounts WHERE id = $1;
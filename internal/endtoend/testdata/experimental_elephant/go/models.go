@@ -3,6 +3,7 @@
 package querytest
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -26,6 +27,47 @@ func (e *Mood) Scan(src interface{}) error {
 	return nil
 }
 
+type NullMood struct {
+	Mood  Mood
+	Valid bool // Valid is true if Mood is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMood) Scan(value interface{}) error {
+	if value == nil {
+		ns.Mood, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.Mood.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMood) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.Mood), nil
+}
+
+func (e Mood) Valid() bool {
+	switch e {
+	case MoodSad,
+		MoodOk,
+		MoodHappy:
+		return true
+	}
+	return false
+}
+
+func AllMoodValues() []Mood {
+	return []Mood{
+		MoodSad,
+		MoodOk,
+		MoodHappy,
+	}
+}
+
 type Baz struct {
 	Name  string
 	Email string
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const getUserByEmail = `-- name: GetUserByEmail :one
+SELECT id, email, nickname FROM users WHERE email = $1
+`
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmail, email)
+	var i User
+	err := row.Scan(&i.ID, &i.Email, &i.Nickname)
+	return i, err
+}
+
+const lastEmail = `-- name: LastEmail :one
+SELECT max(email) FROM users
+`
+
+func (q *Queries) LastEmail(ctx context.Context) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, lastEmail)
+	var max sql.NullString
+	err := row.Scan(&max)
+	return max, err
+}
+
+const listUsersByNickname = `-- name: ListUsersByNickname :many
+SELECT id, nickname = $1 AS exact, strpos(email, $2) AS at FROM users WHERE nickname IS NOT NULL
+`
+
+type ListUsersByNicknameParams struct {
+	Nickname  string
+	Substring string
+}
+
+type ListUsersByNicknameRow struct {
+	ID    int32
+	Exact bool
+	At    int32
+}
+
+func (q *Queries) ListUsersByNickname(ctx context.Context, arg ListUsersByNicknameParams) ([]ListUsersByNicknameRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByNickname, arg.Nickname, arg.Substring)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersByNicknameRow
+	for rows.Next() {
+		var i ListUsersByNicknameRow
+		if err := rows.Scan(&i.ID, &i.Exact, &i.At); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
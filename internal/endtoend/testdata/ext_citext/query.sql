@@ -0,0 +1,16 @@
+CREATE EXTENSION IF NOT EXISTS citext;
+
+CREATE TABLE users (
+    id       SERIAL PRIMARY KEY,
+    email    citext NOT NULL UNIQUE,
+    nickname citext
+);
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1;
+
+-- name: ListUsersByNickname :many
+SELECT id, nickname = $1 AS exact, strpos(email, $2) AS at FROM users WHERE nickname IS NOT NULL;
+
+-- name: LastEmail :one
+SELECT max(email) FROM users;
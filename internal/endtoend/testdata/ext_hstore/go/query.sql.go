@@ -0,0 +1,108 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/lib/pq/hstore"
+)
+
+const productAttrs = `-- name: ProductAttrs :one
+SELECT attrs -> ARRAY['color', 'size'] AS vals, attrs @> extra AS contains, attrs - 'color' AS rest
+FROM products
+WHERE id = $1
+`
+
+type ProductAttrsRow struct {
+	Vals     []string
+	Contains bool
+	Rest     hstore.Hstore
+}
+
+func (q *Queries) ProductAttrs(ctx context.Context, id int32) (ProductAttrsRow, error) {
+	row := q.db.QueryRowContext(ctx, productAttrs, id)
+	var i ProductAttrsRow
+	err := row.Scan(pq.Array(&i.Vals), &i.Contains, &i.Rest)
+	return i, err
+}
+
+const productsWithAllAttrs = `-- name: ProductsWithAllAttrs :many
+SELECT id, attrs, extra FROM products WHERE attrs ?& $1
+`
+
+func (q *Queries) ProductsWithAllAttrs(ctx context.Context, attrs []string) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, productsWithAllAttrs, pq.Array(attrs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(&i.ID, &i.Attrs, &i.Extra); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const productsWithAttr = `-- name: ProductsWithAttr :many
+SELECT id, attrs -> 'color' AS color, akeys(attrs) AS keys
+FROM products
+WHERE attrs ? $1
+`
+
+type ProductsWithAttrRow struct {
+	ID    int32
+	Color sql.NullString
+	Keys  []string
+}
+
+func (q *Queries) ProductsWithAttr(ctx context.Context, attrs string) ([]ProductsWithAttrRow, error) {
+	rows, err := q.db.QueryContext(ctx, productsWithAttr, attrs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ProductsWithAttrRow
+	for rows.Next() {
+		var i ProductsWithAttrRow
+		if err := rows.Scan(&i.ID, &i.Color, pq.Array(&i.Keys)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setAttr = `-- name: SetAttr :exec
+UPDATE products SET attrs = attrs || hstore($1, $2) WHERE id = $3
+`
+
+type SetAttrParams struct {
+	Key   string
+	Value string
+	ID    int32
+}
+
+func (q *Queries) SetAttr(ctx context.Context, arg SetAttrParams) error {
+	_, err := q.db.ExecContext(ctx, setAttr, arg.Key, arg.Value, arg.ID)
+	return err
+}
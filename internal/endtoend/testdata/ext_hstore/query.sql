@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS hstore;
+
+CREATE TABLE products (
+    id      SERIAL PRIMARY KEY,
+    attrs   hstore NOT NULL,
+    extra   hstore
+);
+
+-- name: ProductsWithAttr :many
+SELECT id, attrs -> 'color' AS color, akeys(attrs) AS keys
+FROM products
+WHERE attrs ? $1;
+
+-- name: ProductAttrs :one
+SELECT attrs -> ARRAY['color', 'size'] AS vals, attrs @> extra AS contains, attrs - 'color' AS rest
+FROM products
+WHERE id = $1;
+
+-- name: SetAttr :exec
+UPDATE products SET attrs = attrs || hstore($1, $2) WHERE id = $3;
+
+-- name: ProductsWithAllAttrs :many
+SELECT * FROM products WHERE attrs ?& $1;
@@ -6,6 +6,11 @@ import (
 	"database/sql"
 )
 
+type Category struct {
+	ID   int32
+	Path string
+}
+
 type Foo struct {
 	QualifiedName sql.NullString
 	NameQuery     sql.NullString
@@ -0,0 +1,131 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const commonAncestor = `-- name: CommonAncestor :one
+SELECT lca(a.path, b.path) FROM categories a, categories b WHERE a.id = $1 AND b.id = $2
+`
+
+type CommonAncestorParams struct {
+	ID   int32
+	ID_2 int32
+}
+
+func (q *Queries) CommonAncestor(ctx context.Context, arg CommonAncestorParams) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, commonAncestor, arg.ID, arg.ID_2)
+	var lca sql.NullString
+	err := row.Scan(&lca)
+	return lca, err
+}
+
+const listAncestors = `-- name: ListAncestors :many
+SELECT id, path FROM categories WHERE path @> $1
+`
+
+func (q *Queries) ListAncestors(ctx context.Context, path string) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listAncestors, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Category
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(&i.ID, &i.Path); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDescendants = `-- name: ListDescendants :many
+SELECT id, path, nlevel(path) AS depth FROM categories WHERE path <@ $1
+`
+
+type ListDescendantsRow struct {
+	ID    int32
+	Path  string
+	Depth int32
+}
+
+func (q *Queries) ListDescendants(ctx context.Context, path string) ([]ListDescendantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDescendants, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDescendantsRow
+	for rows.Next() {
+		var i ListDescendantsRow
+		if err := rows.Scan(&i.ID, &i.Path, &i.Depth); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMatching = `-- name: ListMatching :many
+SELECT id, subpath(path, 0, 1) AS root FROM categories WHERE path ~ $1
+`
+
+type ListMatchingRow struct {
+	ID   int32
+	Root string
+}
+
+func (q *Queries) ListMatching(ctx context.Context, path string) ([]ListMatchingRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMatching, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMatchingRow
+	for rows.Next() {
+		var i ListMatchingRow
+		if err := rows.Scan(&i.ID, &i.Root); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setPath = `-- name: SetPath :exec
+UPDATE categories SET path = $1 WHERE id = $2
+`
+
+type SetPathParams struct {
+	Path string
+	ID   int32
+}
+
+func (q *Queries) SetPath(ctx context.Context, arg SetPathParams) error {
+	_, err := q.db.ExecContext(ctx, setPath, arg.Path, arg.ID)
+	return err
+}
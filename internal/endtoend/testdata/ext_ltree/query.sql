@@ -7,3 +7,23 @@ CREATE TABLE foo (
 );
 
 SELECT * FROM foo;
+
+CREATE TABLE categories (
+    id   SERIAL PRIMARY KEY,
+    path ltree NOT NULL
+);
+
+-- name: ListDescendants :many
+SELECT id, path, nlevel(path) AS depth FROM categories WHERE path <@ $1;
+
+-- name: ListAncestors :many
+SELECT id, path FROM categories WHERE path @> $1;
+
+-- name: ListMatching :many
+SELECT id, subpath(path, 0, 1) AS root FROM categories WHERE path ~ $1;
+
+-- name: CommonAncestor :one
+SELECT lca(a.path, b.path) FROM categories a, categories b WHERE a.id = $1 AND b.id = $2;
+
+-- name: SetPath :exec
+UPDATE categories SET path = $1 WHERE id = $2;
@@ -0,0 +1,10 @@
+CREATE SCHEMA gis;
+CREATE EXTENSION postgis WITH SCHEMA gis;
+
+CREATE TABLE parcels (
+    id       SERIAL PRIMARY KEY,
+    boundary gis.geometry NOT NULL
+);
+
+-- name: ListParcels :many
+SELECT * FROM parcels;
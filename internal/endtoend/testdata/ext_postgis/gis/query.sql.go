@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package gis
+
+import (
+	"context"
+)
+
+const listParcels = `-- name: ListParcels :many
+SELECT id, boundary FROM parcels
+`
+
+func (q *Queries) ListParcels(ctx context.Context) ([]Parcel, error) {
+	rows, err := q.db.QueryContext(ctx, listParcels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Parcel
+	for rows.Next() {
+		var i Parcel
+		if err := rows.Scan(&i.ID, &i.Boundary); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
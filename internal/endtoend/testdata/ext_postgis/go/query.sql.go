@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const createPlace = `-- name: CreatePlace :one
+INSERT INTO places (name, location) VALUES ($1, ST_SetSRID(ST_MakePoint($2, $3), 4326))
+RETURNING id, name, location, outline
+`
+
+type CreatePlaceParams struct {
+	Name string
+	X    float64
+	Y    float64
+}
+
+func (q *Queries) CreatePlace(ctx context.Context, arg CreatePlaceParams) (Place, error) {
+	row := q.db.QueryRowContext(ctx, createPlace, arg.Name, arg.X, arg.Y)
+	var i Place
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Location,
+		&i.Outline,
+	)
+	return i, err
+}
+
+const nearbyPlaces = `-- name: NearbyPlaces :many
+SELECT id, name, ST_AsGeoJSON(location) AS geojson
+FROM places
+WHERE ST_DWithin(location, ST_MakePoint($1, $2), $3, true)
+`
+
+type NearbyPlacesParams struct {
+	X              float64
+	Y              float64
+	DistanceMeters float64
+}
+
+type NearbyPlacesRow struct {
+	ID      int32
+	Name    string
+	Geojson string
+}
+
+func (q *Queries) NearbyPlaces(ctx context.Context, arg NearbyPlacesParams) ([]NearbyPlacesRow, error) {
+	rows, err := q.db.QueryContext(ctx, nearbyPlaces, arg.X, arg.Y, arg.DistanceMeters)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []NearbyPlacesRow
+	for rows.Next() {
+		var i NearbyPlacesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.Geojson); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const placeArea = `-- name: PlaceArea :one
+SELECT ST_Area(outline) AS area, ST_X(ST_GeomFromText($1)) AS x
+FROM places
+WHERE id = $2
+`
+
+type PlaceAreaParams struct {
+	Wkt string
+	ID  int32
+}
+
+type PlaceAreaRow struct {
+	Area float64
+	X    float64
+}
+
+func (q *Queries) PlaceArea(ctx context.Context, arg PlaceAreaParams) (PlaceAreaRow, error) {
+	row := q.db.QueryRowContext(ctx, placeArea, arg.Wkt, arg.ID)
+	var i PlaceAreaRow
+	err := row.Scan(&i.Area, &i.X)
+	return i, err
+}
@@ -0,0 +1,23 @@
+CREATE EXTENSION IF NOT EXISTS postgis;
+CREATE EXTENSION IF NOT EXISTS postgis;
+
+CREATE TABLE places (
+    id       SERIAL PRIMARY KEY,
+    name     TEXT NOT NULL,
+    location geography(Point, 4326) NOT NULL,
+    outline  geometry
+);
+
+-- name: NearbyPlaces :many
+SELECT id, name, ST_AsGeoJSON(location) AS geojson
+FROM places
+WHERE ST_DWithin(location, ST_MakePoint($1, $2), $3, true);
+
+-- name: CreatePlace :one
+INSERT INTO places (name, location) VALUES ($1, ST_SetSRID(ST_MakePoint($2, $3), 4326))
+RETURNING *;
+
+-- name: PlaceArea :one
+SELECT ST_Area(outline) AS area, ST_X(ST_GeomFromText($1)) AS x
+FROM places
+WHERE id = $2;
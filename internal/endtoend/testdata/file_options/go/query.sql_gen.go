@@ -0,0 +1,24 @@
+//go:build !nodb
+
+// Code generated by sqlc. DO NOT EDIT.
+// Owner: @example/data-team
+//
+// Regenerate with `sqlc generate`.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT id, name FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
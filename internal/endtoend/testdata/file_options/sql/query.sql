@@ -0,0 +1,7 @@
+CREATE TABLE users (
+  id   SERIAL PRIMARY KEY,
+  name text   NOT NULL
+);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
@@ -0,0 +1,85 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeQuerier is a fake implementation of the generated queries for use in
+// tests. Each method records its arguments and then calls the matching Func
+// field, returning zero values when the field is nil.
+type FakeQuerier struct {
+	mu sync.Mutex
+
+	ListEventsFunc  func(ctx context.Context) ([]Event, error)
+	ListEventsCalls int
+
+	ListEventsByKindFunc  func(ctx context.Context, kind string) ([]Event, error)
+	ListEventsByKindCalls []string
+
+	ListKindsFunc  func(ctx context.Context) ([]string, error)
+	ListKindsCalls int
+}
+
+var _ Querier = (*FakeQuerier)(nil)
+
+func (f *FakeQuerier) ListEvents(ctx context.Context) ([]Event, error) {
+	f.mu.Lock()
+	f.ListEventsCalls++
+	fn := f.ListEventsFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx)
+}
+
+func (f *FakeQuerier) ForEachListEvents(ctx context.Context, fn func(Event) error) error {
+	items, err := f.ListEvents(ctx)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FakeQuerier) ListEventsByKind(ctx context.Context, kind string) ([]Event, error) {
+	f.mu.Lock()
+	f.ListEventsByKindCalls = append(f.ListEventsByKindCalls, kind)
+	fn := f.ListEventsByKindFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx, kind)
+}
+
+func (f *FakeQuerier) ForEachListEventsByKind(ctx context.Context, kind string, fn func(Event) error) error {
+	items, err := f.ListEventsByKind(ctx, kind)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *FakeQuerier) ListKinds(ctx context.Context) ([]string, error) {
+	f.mu.Lock()
+	f.ListKindsCalls++
+	fn := f.ListKindsFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx)
+}
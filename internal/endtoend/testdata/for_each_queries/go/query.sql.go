@@ -0,0 +1,135 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const listEvents = `-- name: ListEvents :many
+SELECT id, kind, payload FROM events ORDER BY id
+`
+
+func (q *Queries) ListEvents(ctx context.Context) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ForEachListEvents calls fn for each row returned by ListEvents,
+// without loading every row into memory. Iteration stops at the first error.
+func (q *Queries) ForEachListEvents(ctx context.Context, fn func(Event) error) error {
+	rows, err := q.db.QueryContext(ctx, listEvents)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const listEventsByKind = `-- name: ListEventsByKind :many
+SELECT id, kind, payload FROM events WHERE kind = $1 ORDER BY id
+`
+
+func (q *Queries) ListEventsByKind(ctx context.Context, kind string) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEventsByKind, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ForEachListEventsByKind calls fn for each row returned by ListEventsByKind,
+// without loading every row into memory. Iteration stops at the first error.
+func (q *Queries) ForEachListEventsByKind(ctx context.Context, kind string, fn func(Event) error) error {
+	rows, err := q.db.QueryContext(ctx, listEventsByKind, kind)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+const listKinds = `-- name: ListKinds :many
+SELECT DISTINCT kind FROM events
+`
+
+func (q *Queries) ListKinds(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listKinds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var kind string
+		if err := rows.Scan(&kind); err != nil {
+			return nil, err
+		}
+		items = append(items, kind)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,14 @@
+CREATE TABLE events (
+  id      BIGSERIAL PRIMARY KEY,
+  kind    text      NOT NULL,
+  payload text      NOT NULL
+);
+
+-- name: ListEvents :many
+SELECT * FROM events ORDER BY id;
+
+-- name: ListEventsByKind :many
+SELECT * FROM events WHERE kind = $1 ORDER BY id;
+
+-- name: ListKinds :many
+SELECT DISTINCT kind FROM events;
@@ -0,0 +1,8 @@
+CREATE TABLE events (id BIGSERIAL PRIMARY KEY);
+
+-- name: GetEvent :one
+SELECT * FROM events WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- error generating code: for_each_queries: query "GetEvent" must be a :many query
@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const matchSaved = `-- name: MatchSaved :many
+SELECT id, search @@ saved AS matches FROM articles WHERE search @@ $1
+`
+
+type MatchSavedRow struct {
+	ID      int32
+	Matches bool
+}
+
+func (q *Queries) MatchSaved(ctx context.Context, search string) ([]MatchSavedRow, error) {
+	rows, err := q.db.QueryContext(ctx, matchSaved, search)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MatchSavedRow
+	for rows.Next() {
+		var i MatchSavedRow
+		if err := rows.Scan(&i.ID, &i.Matches); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchArticles = `-- name: SearchArticles :many
+SELECT id, title,
+    ts_rank(search, websearch_to_tsquery('english', $1)) AS rank,
+    ts_headline('english', body, websearch_to_tsquery('english', $1)) AS headline
+FROM articles
+WHERE search @@ websearch_to_tsquery('english', $1)
+ORDER BY rank DESC
+`
+
+type SearchArticlesRow struct {
+	ID       int32
+	Title    string
+	Rank     float32
+	Headline string
+}
+
+func (q *Queries) SearchArticles(ctx context.Context, query string) ([]SearchArticlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchArticles, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchArticlesRow
+	for rows.Next() {
+		var i SearchArticlesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Rank,
+			&i.Headline,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateSearch = `-- name: UpdateSearch :exec
+UPDATE articles
+SET search = setweight(to_tsvector('english', title), 'A') || to_tsvector('english', body)
+WHERE id = $1
+`
+
+func (q *Queries) UpdateSearch(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, updateSearch, id)
+	return err
+}
@@ -0,0 +1,23 @@
+CREATE TABLE articles (
+    id     serial PRIMARY KEY,
+    title  text NOT NULL,
+    body   text NOT NULL,
+    search tsvector NOT NULL,
+    saved  tsquery
+);
+
+-- name: SearchArticles :many
+SELECT id, title,
+    ts_rank(search, websearch_to_tsquery('english', $1)) AS rank,
+    ts_headline('english', body, websearch_to_tsquery('english', $1)) AS headline
+FROM articles
+WHERE search @@ websearch_to_tsquery('english', $1)
+ORDER BY rank DESC;
+
+-- name: MatchSaved :many
+SELECT id, search @@ saved AS matches FROM articles WHERE search @@ $1;
+
+-- name: UpdateSearch :exec
+UPDATE articles
+SET search = setweight(to_tsvector('english', title), 'A') || to_tsvector('english', body)
+WHERE id = $1;
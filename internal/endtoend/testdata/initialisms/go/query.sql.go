@@ -0,0 +1,25 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getProductBySKU = `-- name: GetProductBySKU :one
+SELECT id, sku, http_url FROM products WHERE sku = $1
+`
+
+type GetProductBySKURow struct {
+	ID      int32
+	SKU     string
+	HTTPURL string
+}
+
+func (q *Queries) GetProductBySKU(ctx context.Context, sku string) (GetProductBySKURow, error) {
+	row := q.db.QueryRowContext(ctx, getProductBySKU, sku)
+	var i GetProductBySKURow
+	err := row.Scan(&i.ID, &i.SKU, &i.HTTPURL)
+	return i, err
+}
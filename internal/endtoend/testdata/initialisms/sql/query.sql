@@ -0,0 +1,9 @@
+CREATE TABLE products (
+  id        SERIAL PRIMARY KEY,
+  sku       text   NOT NULL,
+  http_url  text   NOT NULL,
+  uuid      uuid   NOT NULL
+);
+
+-- name: GetProductBySKU :one
+SELECT id, sku, http_url FROM products WHERE sku = $1;
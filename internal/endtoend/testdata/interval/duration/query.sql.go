@@ -0,0 +1,131 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package duration
+
+import (
+	"context"
+	"time"
+)
+
+const createJob = `-- name: CreateJob :exec
+INSERT INTO jobs (started_at, timeout, backoff) VALUES ($1, $2, $3)
+`
+
+type CreateJobParams struct {
+	StartedAt time.Time
+	Timeout   Duration
+	Backoff   *Duration
+}
+
+func (q *Queries) CreateJob(ctx context.Context, arg CreateJobParams) error {
+	_, err := q.db.ExecContext(ctx, createJob, arg.StartedAt, arg.Timeout, arg.Backoff)
+	return err
+}
+
+const listDeadlines = `-- name: ListDeadlines :many
+SELECT
+    id,
+    started_at + timeout AS deadline,
+    now() - started_at AS elapsed,
+    timeout * 2 AS doubled,
+    timeout + backoff AS total
+FROM jobs
+WHERE timeout > $1
+`
+
+type ListDeadlinesRow struct {
+	ID       int32
+	Deadline time.Time
+	Elapsed  Duration
+	Doubled  Duration
+	Total    *Duration
+}
+
+func (q *Queries) ListDeadlines(ctx context.Context, timeout Duration) ([]ListDeadlinesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listDeadlines, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeadlinesRow
+	for rows.Next() {
+		var i ListDeadlinesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Deadline,
+			&i.Elapsed,
+			&i.Doubled,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT id, started_at, timeout, backoff FROM jobs
+`
+
+func (q *Queries) ListJobs(ctx context.Context) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, listJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(
+			&i.ID,
+			&i.StartedAt,
+			&i.Timeout,
+			&i.Backoff,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStartedSince = `-- name: ListStartedSince :many
+SELECT id FROM jobs WHERE started_at > now() - $1::interval
+`
+
+func (q *Queries) ListStartedSince(ctx context.Context, since Duration) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listStartedSince, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
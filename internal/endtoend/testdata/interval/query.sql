@@ -0,0 +1,25 @@
+CREATE TABLE jobs (
+    id         serial PRIMARY KEY,
+    started_at timestamptz NOT NULL,
+    timeout    interval NOT NULL,
+    backoff    interval
+);
+
+-- name: ListJobs :many
+SELECT * FROM jobs;
+
+-- name: ListDeadlines :many
+SELECT
+    id,
+    started_at + timeout AS deadline,
+    now() - started_at AS elapsed,
+    timeout * 2 AS doubled,
+    timeout + backoff AS total
+FROM jobs
+WHERE timeout > $1;
+
+-- name: ListStartedSince :many
+SELECT id FROM jobs WHERE started_at > now() - sqlc.arg(since)::interval;
+
+-- name: CreateJob :exec
+INSERT INTO jobs (started_at, timeout, backoff) VALUES ($1, $2, $3);
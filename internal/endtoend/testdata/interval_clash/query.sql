@@ -0,0 +1,11 @@
+CREATE TABLE durations (
+  id BIGSERIAL PRIMARY KEY,
+  length interval NOT NULL
+);
+
+-- name: ListDurations :many
+SELECT * FROM durations;
+
+-- stderr
+-- # package querytest
+-- error generating code: the struct for table public.durations is named Duration, which is the type generated for interval columns; rename the struct
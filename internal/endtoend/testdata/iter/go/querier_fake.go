@@ -0,0 +1,46 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"iter"
+	"sync"
+)
+
+// FakeQuerier is a fake implementation of the generated queries for use in
+// tests. Each method records its arguments and then calls the matching Func
+// field, returning zero values when the field is nil.
+type FakeQuerier struct {
+	mu sync.Mutex
+
+	IterEventIDsFunc  func(ctx context.Context) iter.Seq2[int64, error]
+	IterEventIDsCalls int
+
+	IterEventsByKindFunc  func(ctx context.Context, kind string) iter.Seq2[Event, error]
+	IterEventsByKindCalls []string
+}
+
+var _ Querier = (*FakeQuerier)(nil)
+
+func (f *FakeQuerier) IterEventIDs(ctx context.Context) iter.Seq2[int64, error] {
+	f.mu.Lock()
+	f.IterEventIDsCalls++
+	fn := f.IterEventIDsFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return func(yield func(int64, error) bool) {}
+	}
+	return fn(ctx)
+}
+
+func (f *FakeQuerier) IterEventsByKind(ctx context.Context, kind string) iter.Seq2[Event, error] {
+	f.mu.Lock()
+	f.IterEventsByKindCalls = append(f.IterEventsByKindCalls, kind)
+	fn := f.IterEventsByKindFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return func(yield func(Event, error) bool) {}
+	}
+	return fn(ctx, kind)
+}
@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"iter"
+)
+
+const iterEventIDs = `-- name: IterEventIDs :iter
+SELECT id FROM events
+`
+
+func (q *Queries) IterEventIDs(ctx context.Context) iter.Seq2[int64, error] {
+	return func(yield func(int64, error) bool) {
+		var zero int64
+		rows, err := q.db.QueryContext(ctx, iterEventIDs)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(id, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+	}
+}
+
+const iterEventsByKind = `-- name: IterEventsByKind :iter
+SELECT id, kind, payload FROM events WHERE kind = $1 ORDER BY id
+`
+
+func (q *Queries) IterEventsByKind(ctx context.Context, kind string) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		var zero Event
+		rows, err := q.db.QueryContext(ctx, iterEventsByKind, kind)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var i Event
+			if err := rows.Scan(&i.ID, &i.Kind, &i.Payload); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(i, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.iterEventIDsStmt, err = db.PrepareContext(ctx, iterEventIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query IterEventIDs: %w", err)
+	}
+	if q.iterEventsByKindStmt, err = db.PrepareContext(ctx, iterEventsByKind); err != nil {
+		return nil, fmt.Errorf("error preparing query IterEventsByKind: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.iterEventIDsStmt != nil {
+		if cerr := q.iterEventIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing iterEventIDsStmt: %w", cerr)
+		}
+	}
+	if q.iterEventsByKindStmt != nil {
+		if cerr := q.iterEventsByKindStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing iterEventsByKindStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                   DBTX
+	hooks                QueryHooks
+	tx                   *sql.Tx
+	iterEventIDsStmt     *sql.Stmt
+	iterEventsByKindStmt *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                   tx,
+		hooks:                q.hooks,
+		tx:                   tx,
+		iterEventIDsStmt:     q.iterEventIDsStmt,
+		iterEventsByKindStmt: q.iterEventsByKindStmt,
+	}
+}
+
+// QueryHooks is implemented by types that observe the queries executed by
+// Queries, e.g. to record tracing spans or log slow queries.
+type QueryHooks interface {
+	// BeforeQuery is called before a query is executed. The returned context
+	// is used to execute the query and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, name, query string, args []interface{}) context.Context
+	// AfterQuery is called once a query has completed or failed.
+	AfterQuery(ctx context.Context, name, query string, args []interface{}, duration time.Duration, err error)
+}
+
+// WithHooks returns a copy of q which calls hooks around every query.
+func (q *Queries) WithHooks(hooks QueryHooks) *Queries {
+	hooked := *q
+	hooked.hooks = hooks
+	return &hooked
+}
+
+func (q *Queries) startQuery(ctx context.Context, name, query string, args ...interface{}) (context.Context, func(error) error) {
+	if q.hooks == nil {
+		return ctx, func(err error) error { return err }
+	}
+	ctx = q.hooks.BeforeQuery(ctx, name, query, args)
+	start := time.Now()
+	return ctx, func(err error) error {
+		q.hooks.AfterQuery(ctx, name, query, args, time.Since(start), err)
+		return err
+	}
+}
@@ -0,0 +1,11 @@
+CREATE TABLE events (
+  id      BIGSERIAL PRIMARY KEY,
+  kind    text      NOT NULL,
+  payload text      NOT NULL
+);
+
+-- name: IterEventsByKind :iter
+SELECT * FROM events WHERE kind = $1 ORDER BY id;
+
+-- name: IterEventIDs :iter
+SELECT id FROM events;
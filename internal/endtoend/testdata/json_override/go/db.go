@@ -0,0 +1,60 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+// jsonColumn sends the value v points to as JSON, and unmarshals JSON into
+// it. It's used for the columns of overrides with json set.
+type jsonColumn struct {
+	v interface{}
+}
+
+// Scan implements the Scanner interface.
+func (c jsonColumn) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case nil:
+		return json.Unmarshal([]byte("null"), c.v)
+	case []byte:
+		return json.Unmarshal(s, c.v)
+	case string:
+		return json.Unmarshal([]byte(s), c.v)
+	}
+	return fmt.Errorf("unsupported scan type for JSON: %T", src)
+}
+
+// Value implements the driver Valuer interface. A nil value is sent as NULL.
+func (c jsonColumn) Value() (driver.Value, error) {
+	b, err := json.Marshal(c.v)
+	if err != nil || string(b) == "null" {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db: tx,
+	}
+}
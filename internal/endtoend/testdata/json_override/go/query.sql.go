@@ -0,0 +1,69 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"encoding/json"
+
+	"example.com/prefs"
+)
+
+const createUser = `-- name: CreateUser :one
+INSERT INTO users (settings, profile, raw) VALUES ($1, $2, $3) RETURNING id
+`
+
+type CreateUserParams struct {
+	Settings prefs.Settings
+	Profile  *prefs.Profile
+	Raw      json.RawMessage
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (int32, error) {
+	row := q.db.QueryRowContext(ctx, createUser, jsonColumn{arg.Settings}, jsonColumn{arg.Profile}, arg.Raw)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getSettings = `-- name: GetSettings :one
+SELECT settings FROM users WHERE id = $1
+`
+
+func (q *Queries) GetSettings(ctx context.Context, id int32) (prefs.Settings, error) {
+	row := q.db.QueryRowContext(ctx, getSettings, id)
+	var settings prefs.Settings
+	err := row.Scan(jsonColumn{&settings})
+	return settings, err
+}
+
+const getUser = `-- name: GetUser :one
+SELECT id, settings, profile, raw FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		jsonColumn{&i.Settings},
+		jsonColumn{&i.Profile},
+		&i.Raw,
+	)
+	return i, err
+}
+
+const updateSettings = `-- name: UpdateSettings :exec
+UPDATE users SET settings = $1 WHERE id = $2
+`
+
+type UpdateSettingsParams struct {
+	Settings prefs.Settings
+	ID       int32
+}
+
+func (q *Queries) UpdateSettings(ctx context.Context, arg UpdateSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, updateSettings, jsonColumn{arg.Settings}, arg.ID)
+	return err
+}
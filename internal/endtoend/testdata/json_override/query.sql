@@ -0,0 +1,18 @@
+CREATE TABLE users (
+    id       SERIAL PRIMARY KEY,
+    settings jsonb NOT NULL,
+    profile  json,
+    raw      jsonb NOT NULL
+);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: GetSettings :one
+SELECT settings FROM users WHERE id = $1;
+
+-- name: CreateUser :one
+INSERT INTO users (settings, profile, raw) VALUES ($1, $2, $3) RETURNING id;
+
+-- name: UpdateSettings :exec
+UPDATE users SET settings = $1 WHERE id = $2;
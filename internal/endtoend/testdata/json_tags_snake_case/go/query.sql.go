@@ -0,0 +1,30 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT first_name, account_id FROM users
+WHERE id = $1 AND account_id = $2
+`
+
+type GetUserParams struct {
+	ID        int32 `json:"id"`
+	AccountID int32 `json:"account_id"`
+}
+
+type GetUserRow struct {
+	FirstName string `json:"first_name"`
+	AccountID int32  `json:"account_id"`
+}
+
+func (q *Queries) GetUser(ctx context.Context, arg GetUserParams) (GetUserRow, error) {
+	row := q.db.QueryRowContext(ctx, getUser, arg.ID, arg.AccountID)
+	var i GetUserRow
+	err := row.Scan(&i.FirstName, &i.AccountID)
+	return i, err
+}
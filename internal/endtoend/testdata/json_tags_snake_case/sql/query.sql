@@ -0,0 +1,11 @@
+CREATE TABLE users (
+  id             SERIAL PRIMARY KEY,
+  first_name     varchar(255) NOT NULL,
+  last_name      varchar(255),
+  "LastLoggedIn" timestamp,
+  account_id     integer NOT NULL
+);
+
+-- name: GetUser :one
+SELECT first_name, account_id FROM users
+WHERE id = $1 AND account_id = $2;
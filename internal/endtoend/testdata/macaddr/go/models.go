@@ -3,10 +3,62 @@
 package querytest
 
 import (
+	"database/sql/driver"
+	"fmt"
 	"net"
 )
 
 type Foo struct {
 	Bar  bool
-	Addr net.HardwareAddr
+	Addr MacAddr
+}
+
+// MacAddr holds a macaddr or macaddr8 value. NULL is scanned as a nil
+// HardwareAddr.
+type MacAddr struct {
+	net.HardwareAddr
+}
+
+// Scan implements the Scanner interface.
+func (m *MacAddr) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*m = MacAddr{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("unsupported scan type for MacAddr: %T", value)
+	}
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+	m.HardwareAddr = addr
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (m MacAddr) Value() (driver.Value, error) {
+	if m.HardwareAddr == nil {
+		return nil, nil
+	}
+	return m.HardwareAddr.String(), nil
+}
+
+// MarshalText encodes the address as it's written by PostgreSQL.
+func (m MacAddr) MarshalText() ([]byte, error) {
+	return []byte(m.HardwareAddr.String()), nil
+}
+
+// UnmarshalText decodes an address written by MarshalText.
+func (m *MacAddr) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = MacAddr{}
+		return nil
+	}
+	return m.Scan(text)
 }
@@ -0,0 +1,8 @@
+CREATE TABLE invoices (
+  id       int NOT NULL,
+  total    decimal(12, 2) NOT NULL,
+  discount decimal(12, 2)
+);
+
+/* name: ListInvoicesOver :many */
+SELECT * FROM invoices WHERE total > ?;
@@ -0,0 +1,42 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const listProfiles = `-- name: ListProfiles :many
+select id, bio, age, score, verified, updated_at from profiles
+`
+
+func (q *Queries) ListProfiles(ctx context.Context) ([]Profile, error) {
+	rows, err := q.db.QueryContext(ctx, listProfiles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Profile
+	for rows.Next() {
+		var i Profile
+		if err := rows.Scan(
+			&i.ID,
+			&i.Bio,
+			&i.Age,
+			&i.Score,
+			&i.Verified,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
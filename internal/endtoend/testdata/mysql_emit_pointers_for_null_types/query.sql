@@ -0,0 +1,11 @@
+CREATE TABLE profiles (
+  id         int NOT NULL,
+  bio        text,
+  age        int,
+  score      float,
+  verified   boolean,
+  updated_at timestamp
+);
+
+/* name: ListProfiles :many */
+SELECT * FROM profiles;
@@ -0,0 +1,4 @@
+CREATE TABLE tasks (
+    state ENUM('open', 'in progress', 'done') NOT NULL,
+    priority ENUM('low', 'high') NOT NULL
+) ENGINE=InnoDB;
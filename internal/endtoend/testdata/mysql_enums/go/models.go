@@ -3,6 +3,7 @@
 package querytest
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -25,23 +26,43 @@ func (e *FirstNameType) Scan(src interface{}) error {
 	return nil
 }
 
-type UserIDType string
+type NullFirstNameType struct {
+	FirstNameType FirstNameType
+	Valid         bool // Valid is true if FirstNameType is not NULL
+}
 
-const (
-	one UserIDType = "one"
-	two UserIDType = "two"
-)
+// Scan implements the Scanner interface.
+func (ns *NullFirstNameType) Scan(value interface{}) error {
+	if value == nil {
+		ns.FirstNameType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FirstNameType.Scan(value)
+}
 
-func (e *UserIDType) Scan(src interface{}) error {
-	switch s := src.(type) {
-	case []byte:
-		*e = UserIDType(s)
-	case string:
-		*e = UserIDType(s)
-	default:
-		return fmt.Errorf("unsupported scan type for UserIDType: %T", src)
+// Value implements the driver Valuer interface.
+func (ns NullFirstNameType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FirstNameType), nil
+}
+
+func (e FirstNameType) Valid() bool {
+	switch e {
+	case john,
+		albert:
+		return true
+	}
+	return false
+}
+
+func AllFirstNameTypeValues() []FirstNameType {
+	return []FirstNameType{
+		john,
+		albert,
 	}
-	return nil
 }
 
 type LastNameType string
@@ -63,6 +84,103 @@ func (e *LastNameType) Scan(src interface{}) error {
 	return nil
 }
 
+type NullLastNameType struct {
+	LastNameType LastNameType
+	Valid        bool // Valid is true if LastNameType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullLastNameType) Scan(value interface{}) error {
+	if value == nil {
+		ns.LastNameType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.LastNameType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullLastNameType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.LastNameType), nil
+}
+
+func (e LastNameType) Valid() bool {
+	switch e {
+	case smith,
+		frank:
+		return true
+	}
+	return false
+}
+
+func AllLastNameTypeValues() []LastNameType {
+	return []LastNameType{
+		smith,
+		frank,
+	}
+}
+
+type UserIDType string
+
+const (
+	one UserIDType = "one"
+	two UserIDType = "two"
+)
+
+func (e *UserIDType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserIDType(s)
+	case string:
+		*e = UserIDType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserIDType: %T", src)
+	}
+	return nil
+}
+
+type NullUserIDType struct {
+	UserIDType UserIDType
+	Valid      bool // Valid is true if UserIDType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserIDType) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserIDType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserIDType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserIDType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserIDType), nil
+}
+
+func (e UserIDType) Valid() bool {
+	switch e {
+	case one,
+		two:
+		return true
+	}
+	return false
+}
+
+func AllUserIDTypeValues() []UserIDType {
+	return []UserIDType{
+		one,
+		two,
+	}
+}
+
 type Example struct {
 	FirstName FirstNameType
 	UserID    UserIDType
@@ -4,6 +4,7 @@ package querytest
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -28,6 +29,49 @@ func (e *JobStatusType) Scan(src interface{}) error {
 	return nil
 }
 
+type NullJobStatusType struct {
+	JobStatusType JobStatusType
+	Valid         bool // Valid is true if JobStatusType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullJobStatusType) Scan(value interface{}) error {
+	if value == nil {
+		ns.JobStatusType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.JobStatusType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullJobStatusType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.JobStatusType), nil
+}
+
+func (e JobStatusType) Valid() bool {
+	switch e {
+	case APPLIED,
+		PENDING,
+		ACCEPTED,
+		REJECTED:
+		return true
+	}
+	return false
+}
+
+func AllJobStatusTypeValues() []JobStatusType {
+	return []JobStatusType{
+		APPLIED,
+		PENDING,
+		ACCEPTED,
+		REJECTED,
+	}
+}
+
 type Order struct {
 	ID     int     `json:"id"`
 	Price  float64 `json:"price"`
@@ -0,0 +1,41 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"time"
+)
+
+const listUserTimes = `-- name: ListUserTimes :many
+select id, created_at from users
+`
+
+type ListUserTimesRow struct {
+	ID           int
+	CreatedAtUTC time.Time
+}
+
+func (q *Queries) ListUserTimes(ctx context.Context) ([]ListUserTimesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserTimes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserTimesRow
+	for rows.Next() {
+		var i ListUserTimesRow
+		if err := rows.Scan(&i.ID, &i.CreatedAtUTC); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,8 @@
+CREATE TABLE users (
+  id         int       NOT NULL,
+  name       text      NOT NULL,
+  created_at timestamp NOT NULL
+);
+
+/* name: ListUserTimes :many */
+SELECT id, created_at FROM users;
@@ -0,0 +1,79 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const createHost = `-- name: CreateHost :exec
+INSERT INTO hosts (addr, subnet, mac, mac8) VALUES ($1, $2, $3, $4)
+`
+
+type CreateHostParams struct {
+	Addr   Inet
+	Subnet Inet
+	Mac    MacAddr
+	Mac8   MacAddr
+}
+
+func (q *Queries) CreateHost(ctx context.Context, arg CreateHostParams) error {
+	_, err := q.db.ExecContext(ctx, createHost,
+		arg.Addr,
+		arg.Subnet,
+		arg.Mac,
+		arg.Mac8,
+	)
+	return err
+}
+
+const getHost = `-- name: GetHost :one
+SELECT id, addr, subnet, mac, mac8 FROM hosts WHERE addr = $1
+`
+
+func (q *Queries) GetHost(ctx context.Context, addr Inet) (Host, error) {
+	row := q.db.QueryRowContext(ctx, getHost, addr)
+	var i Host
+	err := row.Scan(
+		&i.ID,
+		&i.Addr,
+		&i.Subnet,
+		&i.Mac,
+		&i.Mac8,
+	)
+	return i, err
+}
+
+const listHostsIn = `-- name: ListHostsIn :many
+SELECT id, host(addr) AS address, mac FROM hosts WHERE addr << $1
+`
+
+type ListHostsInRow struct {
+	ID      int32
+	Address string
+	Mac     MacAddr
+}
+
+func (q *Queries) ListHostsIn(ctx context.Context, addr Inet) ([]ListHostsInRow, error) {
+	rows, err := q.db.QueryContext(ctx, listHostsIn, addr)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHostsInRow
+	for rows.Next() {
+		var i ListHostsInRow
+		if err := rows.Scan(&i.ID, &i.Address, &i.Mac); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
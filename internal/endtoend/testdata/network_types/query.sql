@@ -0,0 +1,16 @@
+CREATE TABLE hosts (
+    id        serial PRIMARY KEY,
+    addr      inet NOT NULL,
+    subnet    cidr,
+    mac       macaddr NOT NULL,
+    mac8      macaddr8
+);
+
+-- name: GetHost :one
+SELECT * FROM hosts WHERE addr = $1;
+
+-- name: ListHostsIn :many
+SELECT id, host(addr) AS address, mac FROM hosts WHERE addr << $1;
+
+-- name: CreateHost :exec
+INSERT INTO hosts (addr, subnet, mac, mac8) VALUES ($1, $2, $3, $4);
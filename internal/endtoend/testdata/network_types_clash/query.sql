@@ -0,0 +1,11 @@
+CREATE TABLE inets (
+  id BIGSERIAL PRIMARY KEY,
+  address inet NOT NULL
+);
+
+-- name: ListInets :many
+SELECT * FROM inets;
+
+-- stderr
+-- # package querytest
+-- error generating code: the struct for table public.inets is named Inet, which is the type generated for inet and cidr columns; rename the struct
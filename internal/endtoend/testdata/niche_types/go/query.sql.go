@@ -0,0 +1,156 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const listAccounts = `-- name: ListAccounts :many
+SELECT id, balance, credit, member_ids, large, source, label FROM accounts WHERE balance > $1 AND source = $2
+`
+
+type ListAccountsParams struct {
+	Balance string
+	Source  string
+}
+
+func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listAccounts, arg.Balance, arg.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Account
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Balance,
+			&i.Credit,
+			pq.Array(&i.MemberIds),
+			&i.Large,
+			&i.Source,
+			&i.Label,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsWithMembers = `-- name: ListAccountsWithMembers :many
+SELECT id FROM accounts WHERE member_ids && $1
+`
+
+func (q *Queries) ListAccountsWithMembers(ctx context.Context, memberIds []uuid.UUID) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listAccountsWithMembers, pq.Array(memberIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listIndexes = `-- name: ListIndexes :many
+SELECT indexname, indexdef, tablespace FROM pg_indexes WHERE tablename = $1
+`
+
+type ListIndexesRow struct {
+	Indexname  string
+	Indexdef   string
+	Tablespace sql.NullString
+}
+
+func (q *Queries) ListIndexes(ctx context.Context, tablename string) ([]ListIndexesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listIndexes, tablename)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListIndexesRow
+	for rows.Next() {
+		var i ListIndexesRow
+		if err := rows.Scan(&i.Indexname, &i.Indexdef, &i.Tablespace); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTables = `-- name: ListTables :many
+SELECT c.oid, c.relname, n.nspname, c.relkind, c.reltuples
+FROM pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = $1
+`
+
+type ListTablesRow struct {
+	Oid       uint32
+	Relname   string
+	Nspname   string
+	Relkind   string
+	Reltuples float32
+}
+
+func (q *Queries) ListTables(ctx context.Context, nspname string) ([]ListTablesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTables, nspname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTablesRow
+	for rows.Next() {
+		var i ListTablesRow
+		if err := rows.Scan(
+			&i.Oid,
+			&i.Relname,
+			&i.Nspname,
+			&i.Relkind,
+			&i.Reltuples,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,24 @@
+CREATE TABLE accounts (
+    id         serial PRIMARY KEY,
+    balance    money NOT NULL,
+    credit     money,
+    member_ids uuid[] NOT NULL,
+    large      oid,
+    source     regclass NOT NULL,
+    label      name NOT NULL
+);
+
+-- name: ListAccounts :many
+SELECT * FROM accounts WHERE balance > $1 AND source = $2;
+
+-- name: ListAccountsWithMembers :many
+SELECT id FROM accounts WHERE member_ids && $1;
+
+-- name: ListTables :many
+SELECT c.oid, c.relname, n.nspname, c.relkind, c.reltuples
+FROM pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = $1;
+
+-- name: ListIndexes :many
+SELECT indexname, indexdef, tablespace FROM pg_indexes WHERE tablename = $1;
@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const countJobsByKind = `-- name: CountJobsByKind :one
+SELECT count(*) FROM jobs WHERE kind = $1
+`
+
+func (q *Queries) CountJobsByKind(ctx context.Context, kind ParamStatus) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countJobsByKind, kind)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listJobs = `-- name: ListJobs :many
+SELECT id, status, kind FROM jobs
+`
+
+func (q *Queries) ListJobs(ctx context.Context) ([]Job, error) {
+	rows, err := q.db.QueryContext(ctx, listJobs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Job
+	for rows.Next() {
+		var i Job
+		if err := rows.Scan(&i.ID, &i.Status, &i.Kind); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotes = `-- name: ListNotes :many
+SELECT id, note FROM audit_log WHERE note = $1
+`
+
+func (q *Queries) ListNotes(ctx context.Context, note string) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listNotes, note)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(&i.ID, &i.Note); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,28 @@
+CREATE TYPE job_status AS ENUM ('pending', 'done');
+CREATE TYPE unused_status AS ENUM ('a', 'b');
+CREATE TYPE param_status AS ENUM ('x', 'y');
+
+CREATE TABLE jobs (
+  id     SERIAL PRIMARY KEY,
+  status job_status NOT NULL,
+  kind   param_status NOT NULL
+);
+
+CREATE TABLE unused_table (
+  id     SERIAL PRIMARY KEY,
+  status unused_status NOT NULL
+);
+
+CREATE TABLE audit_log (
+  id   SERIAL PRIMARY KEY,
+  note text NOT NULL
+);
+
+-- name: ListJobs :many
+SELECT * FROM jobs;
+
+-- name: CountJobsByKind :one
+SELECT count(*) FROM jobs WHERE kind = $1;
+
+-- name: ListNotes :many
+SELECT id, note FROM audit_log WHERE note = $1;
@@ -4,6 +4,7 @@ package ondeck
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"time"
 )
@@ -27,6 +28,45 @@ func (e *Status) Scan(src interface{}) error {
 	return nil
 }
 
+type NullStatus struct {
+	Status Status
+	Valid  bool // Valid is true if Status is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.Status, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.Status.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.Status), nil
+}
+
+func (e Status) Valid() bool {
+	switch e {
+	case StatusOpen,
+		StatusClosed:
+		return true
+	}
+	return false
+}
+
+func AllStatusValues() []Status {
+	return []Status{
+		StatusOpen,
+		StatusClosed,
+	}
+}
+
 type City struct {
 	Slug string
 	Name string
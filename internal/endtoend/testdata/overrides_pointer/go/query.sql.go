@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package override
+
+import (
+	"context"
+)
+
+const getBio = `-- name: GetBio :one
+SELECT bio FROM foo WHERE name = $1
+`
+
+func (q *Queries) GetBio(ctx context.Context, name string) (*string, error) {
+	row := q.db.QueryRowContext(ctx, getBio, name)
+	var bio *string
+	err := row.Scan(&bio)
+	return bio, err
+}
+
+const listFoo = `-- name: ListFoo :many
+SELECT name, bio, retyped, total FROM foo
+`
+
+func (q *Queries) ListFoo(ctx context.Context) ([]Foo, error) {
+	rows, err := q.db.QueryContext(ctx, listFoo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Foo
+	for rows.Next() {
+		var i Foo
+		if err := rows.Scan(
+			&i.Name,
+			&i.Bio,
+			&i.Retyped,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
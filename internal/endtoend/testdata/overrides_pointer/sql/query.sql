@@ -0,0 +1,5 @@
+-- name: ListFoo :many
+SELECT * FROM foo;
+
+-- name: GetBio :one
+SELECT bio FROM foo WHERE name = $1;
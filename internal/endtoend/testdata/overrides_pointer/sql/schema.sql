@@ -0,0 +1,6 @@
+CREATE TABLE foo (
+    name      text NOT NULL,
+    bio       text,
+    retyped   text NOT NULL,
+    total     bigint NOT NULL
+);
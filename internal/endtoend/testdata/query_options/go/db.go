@@ -0,0 +1,49 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db: tx,
+	}
+}
+
+// retry calls fn until it succeeds or has been retried the given number of
+// times, waiting a little longer after each failure. sql.ErrNoRows isn't
+// retried, and nor is anything once ctx is done.
+func retry(ctx context.Context, retries int, fn func() error) error {
+	wait := 50 * time.Millisecond
+	for i := 0; ; i++ {
+		err := fn()
+		if err == nil || err == sql.ErrNoRows || i == retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
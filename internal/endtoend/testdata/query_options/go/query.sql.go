@@ -0,0 +1,106 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deleteAuthor = `-- name: DeleteAuthor :exec
+DELETE FROM authors
+WHERE id = $1
+`
+
+func (q *Queries) DeleteAuthor(ctx context.Context, id int64) error {
+	return retry(ctx, 1, func() error {
+		return q.deleteAuthor(ctx, id)
+	})
+}
+
+func (q *Queries) deleteAuthor(ctx context.Context, id int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := q.db.ExecContext(ctx, deleteAuthor, id)
+	return err
+}
+
+const deleteAuthors = `-- name: DeleteAuthors :execrows
+DELETE FROM authors
+`
+
+func (q *Queries) DeleteAuthors(ctx context.Context) (int64, error) {
+	var result int64
+	err := retry(ctx, 3, func() error {
+		var err error
+		result, err = q.deleteAuthors(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) deleteAuthors(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAuthors)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio FROM authors
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	var result Author
+	err := retry(ctx, 2, func() error {
+		var err error
+		result, err = q.getAuthor(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) getAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(&i.ID, &i.Name, &i.Bio)
+	return i, err
+}
+
+const listAuthorNames = `-- name: ListAuthorNames :many
+SELECT id, name FROM authors
+ORDER BY id
+`
+
+type ListAuthorNamesRow struct {
+	ID   int64
+	Name sql.NullString
+}
+
+// Names may be missing from imported authors.
+func (q *Queries) ListAuthorNames(ctx context.Context) ([]ListAuthorNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorNamesRow
+	for rows.Next() {
+		var i ListAuthorNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,86 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+type Queries struct {
+	db           DBTX
+	interceptors []Interceptor
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:           tx,
+		interceptors: q.interceptors,
+	}
+}
+
+// retry calls fn until it succeeds or has been retried the given number of
+// times, waiting a little longer after each failure. sql.ErrNoRows isn't
+// retried, and nor is anything once ctx is done.
+func retry(ctx context.Context, retries int, fn func() error) error {
+	wait := 50 * time.Millisecond
+	for i := 0; ; i++ {
+		err := fn()
+		if err == nil || err == sql.ErrNoRows || i == retries || ctx.Err() != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// QueryInfo describes the query run by an Interceptor.
+type QueryInfo struct {
+	Name  string
+	Cmd   string
+	Query string
+}
+
+// Interceptor wraps the execution of a query. It runs the query by calling
+// next, and may call it more than once, e.g. to retry a serialization
+// failure, or with a different db to route the query to a replica.
+type Interceptor func(ctx context.Context, info QueryInfo, db DBTX, next func(context.Context, DBTX) error) error
+
+// WithInterceptors returns a copy of q which runs every query through
+// interceptors. The first interceptor is the outermost.
+func (q *Queries) WithInterceptors(interceptors ...Interceptor) *Queries {
+	intercepted := *q
+	intercepted.interceptors = append(append([]Interceptor{}, q.interceptors...), interceptors...)
+	return &intercepted
+}
+
+func (q *Queries) intercept(ctx context.Context, info QueryInfo, run func(context.Context, *Queries) error) error {
+	next := func(ctx context.Context, db DBTX) error {
+		c := *q
+		c.db = db
+		return run(ctx, &c)
+	}
+	for i := len(q.interceptors) - 1; i >= 0; i-- {
+		interceptor, inner := q.interceptors[i], next
+		next = func(ctx context.Context, db DBTX) error {
+			return interceptor(ctx, info, db, inner)
+		}
+	}
+	return next(ctx, q.db)
+}
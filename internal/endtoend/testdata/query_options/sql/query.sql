@@ -0,0 +1,26 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text      NOT NULL,
+  bio  text
+);
+
+-- name: GetAuthor :one
+-- retries: 2
+SELECT * FROM authors
+WHERE id = $1 LIMIT 1;
+
+-- name: ListAuthorNames :many
+-- Names may be missing from imported authors.
+-- nullable: name
+SELECT id, name FROM authors
+ORDER BY id;
+
+-- name: DeleteAuthor :exec
+-- retries: 1
+-- timeout: 5s
+DELETE FROM authors
+WHERE id = $1;
+
+-- name: DeleteAuthors :execrows
+-- retries: 3
+DELETE FROM authors;
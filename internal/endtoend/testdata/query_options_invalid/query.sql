@@ -0,0 +1,30 @@
+CREATE TABLE authors (id BIGSERIAL PRIMARY KEY, name text NOT NULL);
+
+-- name: GetAuthor :one
+-- timeout: soon
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListAuthors :many
+-- retries: -1
+SELECT * FROM authors;
+
+-- name: IterAuthors :iter
+-- retries: 2
+SELECT * FROM authors;
+
+-- name: ListNames :many
+-- nullable: bio
+SELECT name FROM authors;
+
+-- name: DeleteAuthor :exec
+-- retries: 1
+-- retries: 2
+DELETE FROM authors WHERE id = $1;
+
+-- stderr
+-- # package querytest
+-- query.sql:5:1: invalid timeout "soon": must be a positive duration, such as 5s or 250ms
+-- query.sql:9:1: invalid retries "-1": must be a non-negative integer
+-- query.sql:13:1: retries can't be used with :iter queries, whose rows have already been returned
+-- query.sql:17:1: nullable names "bio", which isn't an output column of the query
+-- query.sql:22:1: query option "retries" given more than once
@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package always
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countAuthors = `-- name: CountAuthors :one
+SELECT count(*) FROM authors
+`
+
+func (q *Queries) CountAuthors(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuthors)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio, born FROM authors WHERE id = $1
+`
+
+type GetAuthorArgs struct {
+	ID int64
+}
+
+func (q *Queries) GetAuthor(ctx context.Context, arg GetAuthorArgs) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, arg.ID)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Born,
+	)
+	return i, err
+}
+
+const listAuthorsBetween = `-- name: ListAuthorsBetween :many
+SELECT id, name, bio, born FROM authors WHERE id > $1 AND id < $2 AND name = $3
+`
+
+type ListAuthorsBetweenArgs struct {
+	ID   int64
+	ID_2 int64
+	Name string
+}
+
+func (q *Queries) ListAuthorsBetween(ctx context.Context, arg ListAuthorsBetweenArgs) ([]Author, error) {
+	var result []Author
+	err := retry(ctx, 2, func() error {
+		var err error
+		result, err = q.listAuthorsBetween(ctx, arg)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listAuthorsBetween(ctx context.Context, arg ListAuthorsBetweenArgs) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsBetween, arg.ID, arg.ID_2, arg.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Bio,
+			&i.Born,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAuthor = `-- name: UpdateAuthor :exec
+UPDATE authors SET name = $1, bio = $2, born = $3 WHERE id = $4
+`
+
+type UpdateAuthorArgs struct {
+	Name string
+	Bio  sql.NullString
+	Born sql.NullTime
+	ID   int64
+}
+
+func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorArgs) error {
+	_, err := q.db.ExecContext(ctx, updateAuthor,
+		arg.Name,
+		arg.Bio,
+		arg.Born,
+		arg.ID,
+	)
+	return err
+}
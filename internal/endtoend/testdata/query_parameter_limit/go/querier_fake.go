@@ -0,0 +1,83 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeQuerier is a fake implementation of the generated queries for use in
+// tests. Each method records its arguments and then calls the matching Func
+// field, returning zero values when the field is nil.
+type FakeQuerier struct {
+	mu sync.Mutex
+
+	CountAuthorsFunc  func(ctx context.Context) (int64, error)
+	CountAuthorsCalls int
+
+	GetAuthorFunc  func(ctx context.Context, id int64) (Author, error)
+	GetAuthorCalls []int64
+
+	ListAuthorsBetweenFunc  func(ctx context.Context, id int64, id_2 int64, name string) ([]Author, error)
+	ListAuthorsBetweenCalls []struct {
+		ID   int64
+		ID_2 int64
+		Name string
+	}
+
+	UpdateAuthorFunc  func(ctx context.Context, arg UpdateAuthorParams) error
+	UpdateAuthorCalls []UpdateAuthorParams
+}
+
+var _ Querier = (*FakeQuerier)(nil)
+
+func (f *FakeQuerier) CountAuthors(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	f.CountAuthorsCalls++
+	fn := f.CountAuthorsFunc
+	f.mu.Unlock()
+	if fn == nil {
+		var zero int64
+		return zero, nil
+	}
+	return fn(ctx)
+}
+
+func (f *FakeQuerier) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	f.mu.Lock()
+	f.GetAuthorCalls = append(f.GetAuthorCalls, id)
+	fn := f.GetAuthorFunc
+	f.mu.Unlock()
+	if fn == nil {
+		var zero Author
+		return zero, nil
+	}
+	return fn(ctx, id)
+}
+
+func (f *FakeQuerier) ListAuthorsBetween(ctx context.Context, id int64, id_2 int64, name string) ([]Author, error) {
+	f.mu.Lock()
+	f.ListAuthorsBetweenCalls = append(f.ListAuthorsBetweenCalls, struct {
+		ID   int64
+		ID_2 int64
+		Name string
+	}{id, id_2, name})
+	fn := f.ListAuthorsBetweenFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil, nil
+	}
+	return fn(ctx, id, id_2, name)
+}
+
+func (f *FakeQuerier) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) error {
+	f.mu.Lock()
+	f.UpdateAuthorCalls = append(f.UpdateAuthorCalls, arg)
+	fn := f.UpdateAuthorFunc
+	f.mu.Unlock()
+	if fn == nil {
+		return nil
+	}
+	return fn(ctx, arg)
+}
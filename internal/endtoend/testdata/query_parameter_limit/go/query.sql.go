@@ -0,0 +1,135 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+)
+
+const countAuthors = `-- name: CountAuthors :one
+SELECT count(*) FROM authors
+`
+
+func (q *Queries) CountAuthors(ctx context.Context) (int64, error) {
+	var result int64
+	err := q.intercept(ctx, QueryInfo{Name: "CountAuthors", Cmd: ":one", Query: countAuthors}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.countAuthors(ctx)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) countAuthors(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAuthors)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio, born FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	var result Author
+	err := q.intercept(ctx, QueryInfo{Name: "GetAuthor", Cmd: ":one", Query: getAuthor}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.getAuthor(ctx, id)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) getAuthor(ctx context.Context, id int64) (Author, error) {
+	row := q.db.QueryRowContext(ctx, getAuthor, id)
+	var i Author
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Bio,
+		&i.Born,
+	)
+	return i, err
+}
+
+const listAuthorsBetween = `-- name: ListAuthorsBetween :many
+SELECT id, name, bio, born FROM authors WHERE id > $1 AND id < $2 AND name = $3
+`
+
+func (q *Queries) ListAuthorsBetween(ctx context.Context, id int64, id_2 int64, name string) ([]Author, error) {
+	var result []Author
+	err := q.intercept(ctx, QueryInfo{Name: "ListAuthorsBetween", Cmd: ":many", Query: listAuthorsBetween}, func(ctx context.Context, q *Queries) error {
+		var err error
+		result, err = q.listAuthorsBetween(ctx, id, id_2, name)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listAuthorsBetween(ctx context.Context, id int64, id_2 int64, name string) ([]Author, error) {
+	var result []Author
+	err := retry(ctx, 2, func() error {
+		var err error
+		result, err = q.listAuthorsBetweenOnce(ctx, id, id_2, name)
+		return err
+	})
+	return result, err
+}
+
+func (q *Queries) listAuthorsBetweenOnce(ctx context.Context, id int64, id_2 int64, name string) ([]Author, error) {
+	rows, err := q.db.QueryContext(ctx, listAuthorsBetween, id, id_2, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Bio,
+			&i.Born,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateAuthor = `-- name: UpdateAuthor :exec
+UPDATE authors SET name = $1, bio = $2, born = $3 WHERE id = $4
+`
+
+type UpdateAuthorParams struct {
+	Name string
+	Bio  sql.NullString
+	Born sql.NullTime
+	ID   int64
+}
+
+func (q *Queries) UpdateAuthor(ctx context.Context, arg UpdateAuthorParams) error {
+	return q.intercept(ctx, QueryInfo{Name: "UpdateAuthor", Cmd: ":exec", Query: updateAuthor}, func(ctx context.Context, q *Queries) error {
+		return q.updateAuthor(ctx, arg)
+	})
+}
+
+func (q *Queries) updateAuthor(ctx context.Context, arg UpdateAuthorParams) error {
+	_, err := q.db.ExecContext(ctx, updateAuthor,
+		arg.Name,
+		arg.Bio,
+		arg.Born,
+		arg.ID,
+	)
+	return err
+}
@@ -0,0 +1,19 @@
+CREATE TABLE authors (
+    id        BIGSERIAL PRIMARY KEY,
+    name      text NOT NULL,
+    bio       text,
+    born      date
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListAuthorsBetween :many
+-- retries: 2
+SELECT * FROM authors WHERE id > $1 AND id < $2 AND name = $3;
+
+-- name: UpdateAuthor :exec
+UPDATE authors SET name = $1, bio = $2, born = $3 WHERE id = $4;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
@@ -0,0 +1,131 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+const deleteEvents = `-- name: DeleteEvents :execrows
+DELETE FROM events
+`
+
+func (q *Queries) DeleteEvents(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	ctx, endQuery := q.startQuery(ctx, "DeleteEvents", deleteEvents)
+	result, err := q.db.ExecContext(ctx, deleteEvents)
+	if err != nil {
+		return 0, endQuery(err)
+	}
+	affected, err := result.RowsAffected()
+	return affected, endQuery(err)
+}
+
+const getEvent = `-- name: GetEvent :one
+SELECT id, kind FROM events WHERE id = $1
+`
+
+func (q *Queries) GetEvent(ctx context.Context, id int64) (Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 1500*time.Millisecond)
+	defer cancel()
+	ctx, endQuery := q.startQuery(ctx, "GetEvent", getEvent, id)
+	row := q.db.QueryRowContext(ctx, getEvent, id)
+	var i Event
+	err := row.Scan(&i.ID, &i.Kind)
+	return i, endQuery(err)
+}
+
+const iterEvents = `-- name: IterEvents :iter
+SELECT id, kind FROM events WHERE kind = $1 ORDER BY id
+`
+
+func (q *Queries) IterEvents(ctx context.Context, kind string) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+		ctx, endQuery := q.startQuery(ctx, "IterEvents", iterEvents, kind)
+		var zero Event
+		rows, err := q.db.QueryContext(ctx, iterEvents, kind)
+		if err != nil {
+			yield(zero, endQuery(err))
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var i Event
+			if err := rows.Scan(&i.ID, &i.Kind); err != nil {
+				yield(zero, endQuery(err))
+				return
+			}
+			if !yield(i, nil) {
+				endQuery(nil)
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, endQuery(err))
+			return
+		}
+		endQuery(nil)
+	}
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT id, kind FROM events ORDER BY id
+`
+
+func (q *Queries) ListEvents(ctx context.Context) ([]Event, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	ctx, endQuery := q.startQuery(ctx, "ListEvents", listEvents)
+	rows, err := q.db.QueryContext(ctx, listEvents)
+	if err != nil {
+		return nil, endQuery(err)
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind); err != nil {
+			return nil, endQuery(err)
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, endQuery(err)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, endQuery(err)
+	}
+	return items, endQuery(nil)
+}
+
+// ForEachListEvents calls fn for each row returned by ListEvents,
+// without loading every row into memory. Iteration stops at the first error.
+func (q *Queries) ForEachListEvents(ctx context.Context, fn func(Event) error) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	ctx, endQuery := q.startQuery(ctx, "ForEachListEvents", listEvents)
+	rows, err := q.db.QueryContext(ctx, listEvents)
+	if err != nil {
+		return endQuery(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(&i.ID, &i.Kind); err != nil {
+			return endQuery(err)
+		}
+		if err := fn(i); err != nil {
+			return endQuery(err)
+		}
+	}
+	if err := rows.Close(); err != nil {
+		return endQuery(err)
+	}
+	return endQuery(rows.Err())
+}
@@ -0,0 +1,20 @@
+CREATE TABLE events (
+  id   BIGSERIAL PRIMARY KEY,
+  kind text      NOT NULL
+);
+
+-- name: GetEvent :one
+-- timeout: 1500ms
+SELECT * FROM events WHERE id = $1;
+
+-- name: ListEvents :many
+-- timeout: 2m
+SELECT * FROM events ORDER BY id;
+
+-- name: IterEvents :iter
+-- timeout: 30s
+SELECT * FROM events WHERE kind = $1 ORDER BY id;
+
+-- name: DeleteEvents :execrows
+-- timeout: 1h
+DELETE FROM events;
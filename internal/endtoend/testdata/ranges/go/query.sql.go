@@ -0,0 +1,204 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const createSlot = `-- name: CreateSlot :one
+INSERT INTO slots (during, seats, blackouts)
+VALUES (tstzrange($1, $2), int4range($3, $4, '[]'), $5)
+RETURNING id, during, seats, blackouts
+`
+
+type CreateSlotParams struct {
+	Lower     time.Time
+	Upper     time.Time
+	Lower_2   int32
+	Upper_2   int32
+	Blackouts string
+}
+
+func (q *Queries) CreateSlot(ctx context.Context, arg CreateSlotParams) (Slot, error) {
+	row := q.db.QueryRowContext(ctx, createSlot,
+		arg.Lower,
+		arg.Upper,
+		arg.Lower_2,
+		arg.Upper_2,
+		arg.Blackouts,
+	)
+	var i Slot
+	err := row.Scan(
+		&i.ID,
+		&i.During,
+		&i.Seats,
+		&i.Blackouts,
+	)
+	return i, err
+}
+
+const listBlackouts = `-- name: ListBlackouts :many
+SELECT id, lower(blackouts) FROM slots WHERE blackouts @> $1::date
+`
+
+type ListBlackoutsRow struct {
+	ID    int32
+	Lower sql.NullTime
+}
+
+func (q *Queries) ListBlackouts(ctx context.Context, dollar_1 time.Time) ([]ListBlackoutsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listBlackouts, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBlackoutsRow
+	for rows.Next() {
+		var i ListBlackoutsRow
+		if err := rows.Scan(&i.ID, &i.Lower); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverlappingSlots = `-- name: ListOverlappingSlots :many
+SELECT id, isempty(seats), upper_inf(during) FROM slots WHERE during && $1
+`
+
+type ListOverlappingSlotsRow struct {
+	ID       int32
+	Isempty  bool
+	UpperInf bool
+}
+
+func (q *Queries) ListOverlappingSlots(ctx context.Context, during string) ([]ListOverlappingSlotsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listOverlappingSlots, during)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOverlappingSlotsRow
+	for rows.Next() {
+		var i ListOverlappingSlotsRow
+		if err := rows.Scan(&i.ID, &i.Isempty, &i.UpperInf); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlotsAt = `-- name: ListSlotsAt :many
+SELECT id, lower(during), upper(during) FROM slots WHERE during @> $1::timestamptz
+`
+
+type ListSlotsAtRow struct {
+	ID    int32
+	Lower sql.NullTime
+	Upper sql.NullTime
+}
+
+func (q *Queries) ListSlotsAt(ctx context.Context, dollar_1 time.Time) ([]ListSlotsAtRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlotsAt, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSlotsAtRow
+	for rows.Next() {
+		var i ListSlotsAtRow
+		if err := rows.Scan(&i.ID, &i.Lower, &i.Upper); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlotsOpenNow = `-- name: ListSlotsOpenNow :many
+SELECT id, now() <@ during AS open FROM slots
+`
+
+type ListSlotsOpenNowRow struct {
+	ID   int32
+	Open bool
+}
+
+func (q *Queries) ListSlotsOpenNow(ctx context.Context) ([]ListSlotsOpenNowRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlotsOpenNow)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSlotsOpenNowRow
+	for rows.Next() {
+		var i ListSlotsOpenNowRow
+		if err := rows.Scan(&i.ID, &i.Open); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSlotsWithin = `-- name: ListSlotsWithin :many
+SELECT id, during * $1 AS overlap FROM slots WHERE during <@ $1
+`
+
+type ListSlotsWithinRow struct {
+	ID      int32
+	Overlap string
+}
+
+func (q *Queries) ListSlotsWithin(ctx context.Context, during string) ([]ListSlotsWithinRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSlotsWithin, during)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSlotsWithinRow
+	for rows.Next() {
+		var i ListSlotsWithinRow
+		if err := rows.Scan(&i.ID, &i.Overlap); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,26 @@
+CREATE TABLE slots (
+    id        serial PRIMARY KEY,
+    during    tstzrange NOT NULL,
+    seats     int4range,
+    blackouts datemultirange NOT NULL
+);
+
+-- name: CreateSlot :one
+INSERT INTO slots (during, seats, blackouts)
+VALUES (tstzrange($1, $2), int4range($3, $4, '[]'), $5)
+RETURNING *;
+
+-- name: ListSlotsAt :many
+SELECT id, lower(during), upper(during) FROM slots WHERE during @> $1::timestamptz;
+
+-- name: ListOverlappingSlots :many
+SELECT id, isempty(seats), upper_inf(during) FROM slots WHERE during && $1;
+
+-- name: ListSlotsWithin :many
+SELECT id, during * $1 AS overlap FROM slots WHERE during <@ $1;
+
+-- name: ListBlackouts :many
+SELECT id, lower(blackouts) FROM slots WHERE blackouts @> $1::date;
+
+-- name: ListSlotsOpenNow :many
+SELECT id, now() <@ during AS open FROM slots;
@@ -0,0 +1,18 @@
+CREATE TABLE users (
+  id         SERIAL      PRIMARY KEY,
+  created_at timestamptz NOT NULL
+);
+
+CREATE TABLE posts (
+  id         SERIAL      PRIMARY KEY,
+  created_at timestamptz NOT NULL
+);
+
+-- name: ListUsers :many
+SELECT * FROM users;
+
+-- name: ListUserTimes :many
+SELECT id, created_at FROM users WHERE created_at > $1;
+
+-- name: ListPosts :many
+SELECT * FROM posts;
@@ -0,0 +1,110 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const getUserSummary = `-- name: GetUserSummary :one
+SELECT id, name FROM users WHERE id = $1
+`
+
+type GetUserSummaryRow struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) GetUserSummary(ctx context.Context, id int32) (GetUserSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserSummary, id)
+	var i GetUserSummaryRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const listUserEmails = `-- name: ListUserEmails :many
+SELECT id, email FROM users
+`
+
+type ListUserEmailsRow struct {
+	ID    int32
+	Email string
+}
+
+func (q *Queries) ListUserEmails(ctx context.Context) ([]ListUserEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserEmailsRow
+	for rows.Next() {
+		var i ListUserEmailsRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserSummaries = `-- name: ListUserSummaries :many
+SELECT id, name FROM users ORDER BY name
+`
+
+func (q *Queries) ListUserSummaries(ctx context.Context) ([]GetUserSummaryRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserSummaries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUserSummaryRow
+	for rows.Next() {
+		var i GetUserSummaryRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email FROM users
+`
+
+func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.Name, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,17 @@
+CREATE TABLE users (
+  id    SERIAL PRIMARY KEY,
+  name  text   NOT NULL,
+  email text   NOT NULL
+);
+
+-- name: GetUserSummary :one
+SELECT id, name FROM users WHERE id = $1;
+
+-- name: ListUserSummaries :many
+SELECT id, name FROM users ORDER BY name;
+
+-- name: ListUserEmails :many
+SELECT id, email FROM users;
+
+-- name: ListUsers :many
+SELECT id, name, email FROM users;
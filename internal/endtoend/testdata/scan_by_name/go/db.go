@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+// scanByName scans the current row of rows, matching each column to the
+// pointer of the same name in dest, which it empties. Columns without a
+// pointer are skipped, so a query may return new columns, but it's an error
+// for a pointer's column to be missing.
+func scanByName(rows *sql.Rows, dest map[string]interface{}) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		target, ok := dest[col]
+		if !ok {
+			target = new(interface{})
+		}
+		targets[i] = target
+		delete(dest, col)
+	}
+	for col := range dest {
+		return fmt.Errorf("column %q isn't returned by the query", col)
+	}
+	return rows.Scan(targets...)
+}
+
+// scanRowByName scans the first row of rows like scanByName, returning
+// sql.ErrNoRows if there isn't one, and closes rows.
+func scanRowByName(rows *sql.Rows, err error, dest map[string]interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := scanByName(rows, dest); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+type Queries struct {
+	db DBTX
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db: tx,
+	}
+}
@@ -0,0 +1,179 @@
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type DBTX interface {
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+	PrepareContext(context.Context, string) (*sql.Stmt, error)
+	QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error)
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}
+
+func New(db DBTX) *Queries {
+	return &Queries{db: db}
+}
+
+// scanByName scans the current row of rows, matching each column to the
+// pointer of the same name in dest, which it empties. Columns without a
+// pointer are skipped, so a query may return new columns, but it's an error
+// for a pointer's column to be missing.
+func scanByName(rows *sql.Rows, dest map[string]interface{}) error {
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	targets := make([]interface{}, len(cols))
+	for i, col := range cols {
+		target, ok := dest[col]
+		if !ok {
+			target = new(interface{})
+		}
+		targets[i] = target
+		delete(dest, col)
+	}
+	for col := range dest {
+		return fmt.Errorf("column %q isn't returned by the query", col)
+	}
+	return rows.Scan(targets...)
+}
+
+// scanRowByName scans the first row of rows like scanByName, returning
+// sql.ErrNoRows if there isn't one, and closes rows.
+func scanRowByName(rows *sql.Rows, err error, dest map[string]interface{}) error {
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := scanByName(rows, dest); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
+	q := Queries{db: db}
+	var err error
+	if q.countAuthorsStmt, err = db.PrepareContext(ctx, countAuthors); err != nil {
+		return nil, fmt.Errorf("error preparing query CountAuthors: %w", err)
+	}
+	if q.createAuthorStmt, err = db.PrepareContext(ctx, createAuthor); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAuthor: %w", err)
+	}
+	if q.getAuthorStmt, err = db.PrepareContext(ctx, getAuthor); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAuthor: %w", err)
+	}
+	if q.iterAuthorsStmt, err = db.PrepareContext(ctx, iterAuthors); err != nil {
+		return nil, fmt.Errorf("error preparing query IterAuthors: %w", err)
+	}
+	if q.listAuthorPairsStmt, err = db.PrepareContext(ctx, listAuthorPairs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAuthorPairs: %w", err)
+	}
+	if q.listAuthorsStmt, err = db.PrepareContext(ctx, listAuthors); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAuthors: %w", err)
+	}
+	return &q, nil
+}
+
+func (q *Queries) Close() error {
+	var err error
+	if q.countAuthorsStmt != nil {
+		if cerr := q.countAuthorsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countAuthorsStmt: %w", cerr)
+		}
+	}
+	if q.createAuthorStmt != nil {
+		if cerr := q.createAuthorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAuthorStmt: %w", cerr)
+		}
+	}
+	if q.getAuthorStmt != nil {
+		if cerr := q.getAuthorStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAuthorStmt: %w", cerr)
+		}
+	}
+	if q.iterAuthorsStmt != nil {
+		if cerr := q.iterAuthorsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing iterAuthorsStmt: %w", cerr)
+		}
+	}
+	if q.listAuthorPairsStmt != nil {
+		if cerr := q.listAuthorPairsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAuthorPairsStmt: %w", cerr)
+		}
+	}
+	if q.listAuthorsStmt != nil {
+		if cerr := q.listAuthorsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAuthorsStmt: %w", cerr)
+		}
+	}
+	return err
+}
+
+func (q *Queries) exec(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (sql.Result, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).ExecContext(ctx, args...)
+	case stmt != nil:
+		return stmt.ExecContext(ctx, args...)
+	default:
+		return q.db.ExecContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) query(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) (*sql.Rows, error) {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryContext(ctx, args...)
+	default:
+		return q.db.QueryContext(ctx, query, args...)
+	}
+}
+
+func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, args ...interface{}) *sql.Row {
+	switch {
+	case stmt != nil && q.tx != nil:
+		return q.tx.StmtContext(ctx, stmt).QueryRowContext(ctx, args...)
+	case stmt != nil:
+		return stmt.QueryRowContext(ctx, args...)
+	default:
+		return q.db.QueryRowContext(ctx, query, args...)
+	}
+}
+
+type Queries struct {
+	db                  DBTX
+	tx                  *sql.Tx
+	countAuthorsStmt    *sql.Stmt
+	createAuthorStmt    *sql.Stmt
+	getAuthorStmt       *sql.Stmt
+	iterAuthorsStmt     *sql.Stmt
+	listAuthorPairsStmt *sql.Stmt
+	listAuthorsStmt     *sql.Stmt
+}
+
+func (q *Queries) WithTx(tx *sql.Tx) *Queries {
+	return &Queries{
+		db:                  tx,
+		tx:                  tx,
+		countAuthorsStmt:    q.countAuthorsStmt,
+		createAuthorStmt:    q.createAuthorStmt,
+		getAuthorStmt:       q.getAuthorStmt,
+		iterAuthorsStmt:     q.iterAuthorsStmt,
+		listAuthorPairsStmt: q.listAuthorPairsStmt,
+		listAuthorsStmt:     q.listAuthorsStmt,
+	}
+}
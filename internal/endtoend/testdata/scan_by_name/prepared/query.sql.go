@@ -0,0 +1,165 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+
+	"github.com/lib/pq"
+)
+
+const countAuthors = `-- name: CountAuthors :one
+SELECT count(*) FROM authors
+`
+
+func (q *Queries) CountAuthors(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countAuthorsStmt, countAuthors)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAuthor = `-- name: CreateAuthor :one
+INSERT INTO authors (name, bio, tags) VALUES ($1, $2, $3) RETURNING id, name, bio, tags
+`
+
+type CreateAuthorParams struct {
+	Name string
+	Bio  sql.NullString
+	Tags []string
+}
+
+func (q *Queries) CreateAuthor(ctx context.Context, arg CreateAuthorParams) (Author, error) {
+	rows, err := q.query(ctx, q.createAuthorStmt, createAuthor, arg.Name, arg.Bio, pq.Array(arg.Tags))
+	var i Author
+	err = scanRowByName(rows, err, map[string]interface{}{
+		"id":   &i.ID,
+		"name": &i.Name,
+		"bio":  &i.Bio,
+		"tags": pq.Array(&i.Tags),
+	})
+	return i, err
+}
+
+const getAuthor = `-- name: GetAuthor :one
+SELECT id, name, bio, tags FROM authors WHERE id = $1
+`
+
+func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error) {
+	rows, err := q.query(ctx, q.getAuthorStmt, getAuthor, id)
+	var i Author
+	err = scanRowByName(rows, err, map[string]interface{}{
+		"id":   &i.ID,
+		"name": &i.Name,
+		"bio":  &i.Bio,
+		"tags": pq.Array(&i.Tags),
+	})
+	return i, err
+}
+
+const iterAuthors = `-- name: IterAuthors :iter
+SELECT id, name, bio FROM authors ORDER BY id
+`
+
+type IterAuthorsRow struct {
+	ID   int64
+	Name string
+	Bio  sql.NullString
+}
+
+func (q *Queries) IterAuthors(ctx context.Context) iter.Seq2[IterAuthorsRow, error] {
+	return func(yield func(IterAuthorsRow, error) bool) {
+		var zero IterAuthorsRow
+		rows, err := q.query(ctx, q.iterAuthorsStmt, iterAuthors)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var i IterAuthorsRow
+			if err := scanByName(rows, map[string]interface{}{
+				"id":   &i.ID,
+				"name": &i.Name,
+				"bio":  &i.Bio,
+			}); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(i, nil) {
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+	}
+}
+
+const listAuthorPairs = `-- name: ListAuthorPairs :many
+SELECT a.id, b.id FROM authors a JOIN authors b ON a.name = b.name
+`
+
+type ListAuthorPairsRow struct {
+	ID   int64
+	ID_2 int64
+}
+
+func (q *Queries) ListAuthorPairs(ctx context.Context) ([]ListAuthorPairsRow, error) {
+	rows, err := q.query(ctx, q.listAuthorPairsStmt, listAuthorPairs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAuthorPairsRow
+	for rows.Next() {
+		var i ListAuthorPairsRow
+		if err := rows.Scan(&i.ID, &i.ID_2); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuthors = `-- name: ListAuthors :many
+SELECT id, name, bio, tags FROM authors ORDER BY name
+`
+
+func (q *Queries) ListAuthors(ctx context.Context) ([]Author, error) {
+	rows, err := q.query(ctx, q.listAuthorsStmt, listAuthors)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Author
+	for rows.Next() {
+		var i Author
+		if err := scanByName(rows, map[string]interface{}{
+			"id":   &i.ID,
+			"name": &i.Name,
+			"bio":  &i.Bio,
+			"tags": pq.Array(&i.Tags),
+		}); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,24 @@
+CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name TEXT      NOT NULL,
+  bio  TEXT,
+  tags TEXT[]    NOT NULL
+);
+
+-- name: GetAuthor :one
+SELECT * FROM authors WHERE id = $1;
+
+-- name: ListAuthors :many
+SELECT * FROM authors ORDER BY name;
+
+-- name: IterAuthors :iter
+SELECT id, name, bio FROM authors ORDER BY id;
+
+-- name: CreateAuthor :one
+INSERT INTO authors (name, bio, tags) VALUES ($1, $2, $3) RETURNING *;
+
+-- name: CountAuthors :one
+SELECT count(*) FROM authors;
+
+-- name: ListAuthorPairs :many
+SELECT a.id, b.id FROM authors a JOIN authors b ON a.name = b.name;
@@ -3,6 +3,7 @@
 package querytest
 
 import (
+	"database/sql/driver"
 	"fmt"
 )
 
@@ -25,6 +26,45 @@ func (e *FooTypeUserRole) Scan(src interface{}) error {
 	return nil
 }
 
+type NullFooTypeUserRole struct {
+	FooTypeUserRole FooTypeUserRole
+	Valid           bool // Valid is true if FooTypeUserRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullFooTypeUserRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.FooTypeUserRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.FooTypeUserRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullFooTypeUserRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.FooTypeUserRole), nil
+}
+
+func (e FooTypeUserRole) Valid() bool {
+	switch e {
+	case FooTypeUserRoleAdmin,
+		FooTypeUserRoleUser:
+		return true
+	}
+	return false
+}
+
+func AllFooTypeUserRoleValues() []FooTypeUserRole {
+	return []FooTypeUserRole{
+		FooTypeUserRoleAdmin,
+		FooTypeUserRoleUser,
+	}
+}
+
 type FooUser struct {
-	Role FooTypeUserRole
+	Role NullFooTypeUserRole
 }
@@ -0,0 +1,72 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/kyleconroy/sqlc/internal/endtoend/testdata/shared_models/models"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT id, name, status, last_status FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id int32) (models.User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i models.User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Status,
+		&i.LastStatus,
+	)
+	return i, err
+}
+
+const listUsersByStatus = `-- name: ListUsersByStatus :many
+SELECT id, name FROM users WHERE status = $1
+`
+
+type ListUsersByStatusRow struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) ListUsersByStatus(ctx context.Context, status models.Status) ([]ListUsersByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUsersByStatusRow
+	for rows.Next() {
+		var i ListUsersByStatusRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setLastStatus = `-- name: SetLastStatus :exec
+UPDATE users SET last_status = $2 WHERE id = $1
+`
+
+type SetLastStatusParams struct {
+	ID         int32
+	LastStatus models.NullStatus
+}
+
+func (q *Queries) SetLastStatus(ctx context.Context, arg SetLastStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setLastStatus, arg.ID, arg.LastStatus)
+	return err
+}
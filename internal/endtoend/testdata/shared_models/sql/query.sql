@@ -0,0 +1,8 @@
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListUsersByStatus :many
+SELECT id, name FROM users WHERE status = $1;
+
+-- name: SetLastStatus :exec
+UPDATE users SET last_status = $2 WHERE id = $1;
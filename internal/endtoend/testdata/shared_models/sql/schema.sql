@@ -0,0 +1,8 @@
+CREATE TYPE status AS ENUM ('open', 'closed');
+
+CREATE TABLE users (
+  id     SERIAL PRIMARY KEY,
+  name   text   NOT NULL,
+  status status NOT NULL,
+  last_status status
+);
@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+)
+
+const listEmails = `-- name: ListEmails :many
+SELECT id, email FROM users
+`
+
+type ListEmailsRow struct {
+	ID    int32  `db:"id" json:"id"`
+	Email string `db:"email" json:"email" validate:"required,email"`
+}
+
+func (q *Queries) ListEmails(ctx context.Context) ([]ListEmailsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listEmails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListEmailsRow
+	for rows.Next() {
+		var i ListEmailsRow
+		if err := rows.Scan(&i.ID, &i.Email); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateEmail = `-- name: UpdateEmail :exec
+UPDATE users SET email = $2 WHERE id = $1
+`
+
+type UpdateEmailParams struct {
+	ID    int32  `db:"id" json:"id"`
+	Email string `db:"email" json:"email" validate:"required,email"`
+}
+
+func (q *Queries) UpdateEmail(ctx context.Context, arg UpdateEmailParams) error {
+	_, err := q.db.ExecContext(ctx, updateEmail, arg.ID, arg.Email)
+	return err
+}
@@ -0,0 +1,12 @@
+CREATE TABLE users (
+  id    SERIAL PRIMARY KEY,
+  name  text NOT NULL,
+  email text NOT NULL,
+  bio   text
+);
+
+-- name: UpdateEmail :exec
+UPDATE users SET email = $2 WHERE id = $1;
+
+-- name: ListEmails :many
+SELECT id, email FROM users;
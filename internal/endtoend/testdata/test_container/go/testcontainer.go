@@ -0,0 +1,80 @@
+//go:build sqlc_testcontainer
+
+// Code generated by sqlc. DO NOT EDIT.
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// TestContainerImage is the image of the database started by NewTestDB
+const TestContainerImage = "postgres:15"
+
+// testSchema holds the DDL of each schema file, applied in order by NewTestDB
+var testSchema = []struct {
+	Name string
+	SQL  string
+}{
+	{"sql/migrations/001_authors.sql", `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name TEXT      NOT NULL,
+  bio  TEXT
+);`},
+	{"sql/migrations/002_comments.sql", "COMMENT ON COLUMN authors.bio IS 'A `short` biography';"},
+}
+
+// NewTestDB starts a PostgreSQL container, applies the schema to a new
+// database in it and returns a connection to the database. The connection is
+// closed and the container removed when the test and its subtests complete.
+func NewTestDB(tb testing.TB) *sql.DB {
+	tb.Helper()
+	ctx := context.Background()
+	container, err := postgres.Run(ctx, TestContainerImage,
+		postgres.WithDatabase("test"),
+		postgres.WithUsername("test"),
+		postgres.WithPassword("test"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(time.Minute),
+		),
+	)
+	if err != nil {
+		tb.Fatalf("start database container: %s", err)
+	}
+	tb.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(container); err != nil {
+			tb.Errorf("remove database container: %s", err)
+		}
+	})
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		tb.Fatalf("get database connection string: %s", err)
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatalf("open database: %s", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	for _, file := range testSchema {
+		if _, err := db.ExecContext(ctx, file.SQL); err != nil {
+			tb.Fatalf("apply schema file %s: %s", file.Name, err)
+		}
+	}
+	return db
+}
+
+// NewTestQueries returns Queries for a database started by NewTestDB
+func NewTestQueries(tb testing.TB) *Queries {
+	tb.Helper()
+	return New(NewTestDB(tb))
+}
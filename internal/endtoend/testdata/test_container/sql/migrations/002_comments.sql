@@ -0,0 +1 @@
+COMMENT ON COLUMN authors.bio IS 'A `short` biography';
@@ -0,0 +1,49 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/lib/pq"
+)
+
+const getUser = `-- name: GetUser :one
+SELECT id, parent_id, team_ids FROM users WHERE id = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUser, id)
+	var i User
+	err := row.Scan(&i.ID, &i.ParentID, pq.Array(&i.TeamIds))
+	return i, err
+}
+
+const listChildren = `-- name: ListChildren :many
+SELECT id, parent_id, team_ids FROM users WHERE parent_id = $1
+`
+
+func (q *Queries) ListChildren(ctx context.Context, parentID *uuid.UUID) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listChildren, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(&i.ID, &i.ParentID, pq.Array(&i.TeamIds)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
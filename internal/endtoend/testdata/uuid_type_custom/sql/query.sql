@@ -0,0 +1,11 @@
+CREATE TABLE users (
+  id        uuid PRIMARY KEY,
+  parent_id uuid,
+  team_ids  uuid[] NOT NULL
+);
+
+-- name: GetUser :one
+SELECT * FROM users WHERE id = $1;
+
+-- name: ListChildren :many
+SELECT * FROM users WHERE parent_id = $1;
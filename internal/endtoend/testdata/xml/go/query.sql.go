@@ -0,0 +1,96 @@
+// Code generated by sqlc. DO NOT EDIT.
+// source: query.sql
+
+package querytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+const createFeed = `-- name: CreateFeed :exec
+INSERT INTO feeds (doc, summary) VALUES (xmlparse(document $1), $2)
+`
+
+type CreateFeedParams struct {
+	Xml     string
+	Summary sql.NullString
+}
+
+func (q *Queries) CreateFeed(ctx context.Context, arg CreateFeedParams) error {
+	_, err := q.db.ExecContext(ctx, createFeed, arg.Xml, arg.Summary)
+	return err
+}
+
+const listFeeds = `-- name: ListFeeds :many
+SELECT id, doc, summary FROM feeds
+`
+
+func (q *Queries) ListFeeds(ctx context.Context) ([]Feed, error) {
+	rows, err := q.db.QueryContext(ctx, listFeeds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Feed
+	for rows.Next() {
+		var i Feed
+		if err := rows.Scan(&i.ID, &i.Doc, &i.Summary); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTitles = `-- name: ListTitles :many
+SELECT id,
+    xpath('/rss/channel/title/text()', doc) AS titles,
+    xmlelement(name feed, xmlattributes(id AS id), summary) AS element,
+    xmlserialize(document doc AS text) AS body
+FROM feeds
+WHERE xpath_exists($1, doc)
+`
+
+type ListTitlesRow struct {
+	ID      int32
+	Titles  []string
+	Element string
+	Body    string
+}
+
+func (q *Queries) ListTitles(ctx context.Context, xpath string) ([]ListTitlesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTitles, xpath)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTitlesRow
+	for rows.Next() {
+		var i ListTitlesRow
+		if err := rows.Scan(
+			&i.ID,
+			pq.Array(&i.Titles),
+			&i.Element,
+			&i.Body,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,19 @@
+CREATE TABLE feeds (
+    id      serial PRIMARY KEY,
+    doc     xml NOT NULL,
+    summary xml
+);
+
+-- name: ListFeeds :many
+SELECT * FROM feeds;
+
+-- name: ListTitles :many
+SELECT id,
+    xpath('/rss/channel/title/text()', doc) AS titles,
+    xmlelement(name feed, xmlattributes(id AS id), summary) AS element,
+    xmlserialize(document doc AS text) AS body
+FROM feeds
+WHERE xpath_exists($1, doc);
+
+-- name: CreateFeed :exec
+INSERT INTO feeds (doc, summary) VALUES (xmlparse(document $1), $2);
@@ -0,0 +1,473 @@
+package expr
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// A Program is a compiled expression. Values are bools, float64 numbers,
+// strings, []interface{} lists and map[string]interface{} maps.
+type Program struct {
+	root node
+	vars map[string]bool
+}
+
+// The functions which can be called as methods, with their number of
+// arguments. exists and all are macros: `list.exists(x, predicate)` binds x to
+// each item in turn.
+var methods = map[string]int{
+	"contains":   1,
+	"startsWith": 1,
+	"endsWith":   1,
+	"matches":    1,
+	"size":       0,
+	"exists":     2,
+	"all":        2,
+}
+
+// Compile parses src, checking that it only refers to the named variables and
+// known functions
+func Compile(src string, vars []string) (*Program, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpr(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	prog := &Program{root: root, vars: map[string]bool{}}
+	known := map[string]bool{}
+	for _, name := range vars {
+		known[name] = true
+	}
+	if err := prog.check(root, known); err != nil {
+		return nil, err
+	}
+	return prog, nil
+}
+
+func (p *Program) check(n node, scope map[string]bool) error {
+	switch n := n.(type) {
+	case ident:
+		if !scope[n.name] {
+			return fmt.Errorf("undefined variable %q", n.name)
+		}
+		p.vars[n.name] = true
+	case unary:
+		return p.check(n.x, scope)
+	case binary:
+		if err := p.check(n.x, scope); err != nil {
+			return err
+		}
+		return p.check(n.y, scope)
+	case member:
+		return p.check(n.x, scope)
+	case index:
+		if err := p.check(n.x, scope); err != nil {
+			return err
+		}
+		return p.check(n.i, scope)
+	case list:
+		for _, item := range n.items {
+			if err := p.check(item, scope); err != nil {
+				return err
+			}
+		}
+	case call:
+		args := n.args
+		if n.recv == nil {
+			if n.name != "size" {
+				return fmt.Errorf("undefined function %q at column %d", n.name, n.pos+1)
+			}
+			if len(args) != 1 {
+				return fmt.Errorf("size takes 1 argument, got %d", len(args))
+			}
+		} else {
+			want, ok := methods[n.name]
+			if !ok {
+				return fmt.Errorf("undefined function %q at column %d", n.name, n.pos+1)
+			}
+			if len(args) != want {
+				return fmt.Errorf("%s takes %d arguments, got %d", n.name, want, len(args))
+			}
+			if err := p.check(n.recv, scope); err != nil {
+				return err
+			}
+		}
+		if n.name == "exists" || n.name == "all" {
+			v, ok := args[0].(ident)
+			if !ok {
+				return fmt.Errorf("the first argument of %s must be a variable name", n.name)
+			}
+			inner := map[string]bool{v.name: true}
+			for name := range scope {
+				inner[name] = true
+			}
+			return p.checkScoped(args[1], inner, v.name)
+		}
+		for _, arg := range args {
+			if err := p.check(arg, scope); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkScoped checks n with a macro variable bound, which isn't counted as a
+// use of any outer variable of the same name
+func (p *Program) checkScoped(n node, scope map[string]bool, bound string) error {
+	used := p.vars[bound]
+	err := p.check(n, scope)
+	if !used {
+		delete(p.vars, bound)
+	}
+	return err
+}
+
+// Uses reports whether the expression refers to the named variable, so
+// callers can skip computing variables that aren't needed
+func (p *Program) Uses(name string) bool {
+	return p.vars[name]
+}
+
+// Eval evaluates the expression with the variables in env
+func (p *Program) Eval(env map[string]interface{}) (interface{}, error) {
+	return eval(p.root, env)
+}
+
+func eval(n node, env map[string]interface{}) (interface{}, error) {
+	switch n := n.(type) {
+	case literal:
+		return n.val, nil
+	case ident:
+		v, ok := env[n.name]
+		if !ok {
+			return nil, fmt.Errorf("undefined variable %q", n.name)
+		}
+		return v, nil
+	case list:
+		items := []interface{}{}
+		for _, item := range n.items {
+			v, err := eval(item, env)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	case unary:
+		x, err := eval(n.x, env)
+		if err != nil {
+			return nil, err
+		}
+		switch n.op {
+		case "!":
+			b, ok := x.(bool)
+			if !ok {
+				return nil, fmt.Errorf("! needs a bool, got %s", typeName(x))
+			}
+			return !b, nil
+		default:
+			f, ok := x.(float64)
+			if !ok {
+				return nil, fmt.Errorf("- needs a number, got %s", typeName(x))
+			}
+			return -f, nil
+		}
+	case binary:
+		return evalBinary(n, env)
+	case member:
+		x, err := eval(n.x, env)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := x.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("can't select %s from %s", n.name, typeName(x))
+		}
+		v, ok := m[n.name]
+		if !ok {
+			return nil, fmt.Errorf("no field %s, must be one of %s", n.name, strings.Join(keys(m), ", "))
+		}
+		return v, nil
+	case index:
+		x, err := eval(n.x, env)
+		if err != nil {
+			return nil, err
+		}
+		i, err := eval(n.i, env)
+		if err != nil {
+			return nil, err
+		}
+		switch x := x.(type) {
+		case []interface{}:
+			f, ok := i.(float64)
+			if !ok || f != math.Trunc(f) {
+				return nil, fmt.Errorf("lists are indexed by whole numbers, got %s", typeName(i))
+			}
+			if f < 0 || f >= float64(len(x)) {
+				return nil, fmt.Errorf("index %v out of range for a list of %d", f, len(x))
+			}
+			return x[int(f)], nil
+		case map[string]interface{}:
+			key, ok := i.(string)
+			if !ok {
+				return nil, fmt.Errorf("maps are indexed by strings, got %s", typeName(i))
+			}
+			v, ok := x[key]
+			if !ok {
+				return nil, fmt.Errorf("no key %q", key)
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("can't index %s", typeName(x))
+	case call:
+		return evalCall(n, env)
+	}
+	return nil, fmt.Errorf("unknown expression %T", n)
+}
+
+func evalBinary(n binary, env map[string]interface{}) (interface{}, error) {
+	x, err := eval(n.x, env)
+	if err != nil {
+		return nil, err
+	}
+	// || and && only evaluate their right side when it's needed
+	if n.op == "||" || n.op == "&&" {
+		a, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs bools, got %s", n.op, typeName(x))
+		}
+		if (n.op == "||") == a {
+			return a, nil
+		}
+		y, err := eval(n.y, env)
+		if err != nil {
+			return nil, err
+		}
+		b, ok := y.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs bools, got %s", n.op, typeName(y))
+		}
+		return b, nil
+	}
+	y, err := eval(n.y, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "==", "!=":
+		if typeName(x) != typeName(y) {
+			return nil, fmt.Errorf("can't compare %s and %s", typeName(x), typeName(y))
+		}
+		return reflect.DeepEqual(x, y) == (n.op == "=="), nil
+	case "in":
+		switch y := y.(type) {
+		case []interface{}:
+			for _, item := range y {
+				if reflect.DeepEqual(x, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case map[string]interface{}:
+			key, ok := x.(string)
+			if !ok {
+				return nil, fmt.Errorf("maps have string keys, got %s", typeName(x))
+			}
+			_, found := y[key]
+			return found, nil
+		}
+		return nil, fmt.Errorf("in needs a list or map, got %s", typeName(y))
+	case "+":
+		switch a := x.(type) {
+		case string:
+			if b, ok := y.(string); ok {
+				return a + b, nil
+			}
+		case []interface{}:
+			if b, ok := y.([]interface{}); ok {
+				return append(append([]interface{}{}, a...), b...), nil
+			}
+		}
+	case "<", "<=", ">", ">=":
+		if a, ok := x.(string); ok {
+			b, ok := y.(string)
+			if !ok {
+				return nil, fmt.Errorf("can't compare %s and %s", typeName(x), typeName(y))
+			}
+			return compare(n.op, strings.Compare(a, b)), nil
+		}
+	}
+
+	a, aok := x.(float64)
+	b, bok := y.(float64)
+	if !aok || !bok {
+		return nil, fmt.Errorf("%s isn't defined for %s and %s", n.op, typeName(x), typeName(y))
+	}
+	switch n.op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return a / b, nil
+	case "%":
+		if b == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(a, b), nil
+	}
+	switch {
+	case a < b:
+		return compare(n.op, -1), nil
+	case a > b:
+		return compare(n.op, 1), nil
+	}
+	return compare(n.op, 0), nil
+}
+
+func compare(op string, cmp int) bool {
+	switch op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+func evalCall(n call, env map[string]interface{}) (interface{}, error) {
+	if n.recv == nil {
+		x, err := eval(n.args[0], env)
+		if err != nil {
+			return nil, err
+		}
+		return size(x)
+	}
+	x, err := eval(n.recv, env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.name {
+	case "size":
+		return size(x)
+	case "exists", "all":
+		items, ok := x.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s needs a list, got %s", n.name, typeName(x))
+		}
+		name := n.args[0].(ident).name
+		inner := map[string]interface{}{}
+		for k, v := range env {
+			inner[k] = v
+		}
+		for _, item := range items {
+			inner[name] = item
+			v, err := eval(n.args[1], inner)
+			if err != nil {
+				return nil, err
+			}
+			b, ok := v.(bool)
+			if !ok {
+				return nil, fmt.Errorf("the predicate of %s must be a bool, got %s", n.name, typeName(v))
+			}
+			if b == (n.name == "exists") {
+				return b, nil
+			}
+		}
+		return n.name == "all", nil
+	}
+
+	arg, err := eval(n.args[0], env)
+	if err != nil {
+		return nil, err
+	}
+	if n.name == "contains" {
+		if items, ok := x.([]interface{}); ok {
+			for _, item := range items {
+				if reflect.DeepEqual(arg, item) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+	}
+	s, ok := x.(string)
+	if !ok {
+		return nil, fmt.Errorf("%s needs a string, got %s", n.name, typeName(x))
+	}
+	a, ok := arg.(string)
+	if !ok {
+		return nil, fmt.Errorf("the argument of %s must be a string, got %s", n.name, typeName(arg))
+	}
+	switch n.name {
+	case "contains":
+		return strings.Contains(s, a), nil
+	case "startsWith":
+		return strings.HasPrefix(s, a), nil
+	case "endsWith":
+		return strings.HasSuffix(s, a), nil
+	}
+	re, err := regexp.Compile(a)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern for matches: %w", err)
+	}
+	return re.MatchString(s), nil
+}
+
+func size(x interface{}) (interface{}, error) {
+	switch x := x.(type) {
+	case string:
+		return float64(len(x)), nil
+	case []interface{}:
+		return float64(len(x)), nil
+	case map[string]interface{}:
+		return float64(len(x)), nil
+	}
+	return nil, fmt.Errorf("size needs a string, list or map, got %s", typeName(x))
+}
+
+func typeName(x interface{}) string {
+	switch x.(type) {
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "map"
+	}
+	return fmt.Sprintf("%T", x)
+}
+
+func keys(m map[string]interface{}) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
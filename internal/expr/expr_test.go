@@ -0,0 +1,108 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEval(t *testing.T) {
+	env := map[string]interface{}{
+		"query": map[string]interface{}{
+			"name":   "DeleteAuthor",
+			"sql":    "DELETE FROM authors WHERE id = $1",
+			"params": float64(1),
+			"tables": []interface{}{"authors"},
+		},
+	}
+	for _, test := range []struct {
+		src  string
+		want interface{}
+	}{
+		{`query.name == "DeleteAuthor"`, true},
+		{`query.name.startsWith('Get') || query.sql.contains("DELETE")`, true},
+		{`query.params > 0 && query.params <= 2`, true},
+		{`!(query.params >= 1)`, false},
+		{`'authors' in query.tables`, true},
+		{`query.tables.exists(t, t.endsWith('s'))`, true},
+		{`query.tables.all(t, t == 'books')`, false},
+		{`size(query.tables) + query.tables.size() * 2`, float64(3)},
+		{`query["name"].matches("^Delete[A-Z]")`, true},
+		{`[1, 2] + [3]`, []interface{}{float64(1), float64(2), float64(3)}},
+		{`query.tables[0] + "!"`, "authors!"},
+		{`-query.params % 2`, float64(-1)},
+		// The right side isn't evaluated, so its error isn't reported
+		{`false && query.missing`, false},
+	} {
+		prog, err := Compile(test.src, []string{"query"})
+		if err != nil {
+			t.Errorf("%s: %s", test.src, err)
+			continue
+		}
+		got, err := prog.Eval(env)
+		if err != nil {
+			t.Errorf("%s: %s", test.src, err)
+			continue
+		}
+		if diff := cmp.Diff(test.want, got); diff != "" {
+			t.Errorf("%s: differed (-want +got):\n%s", test.src, diff)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, test := range []struct {
+		src string
+		err string
+	}{
+		{`query.name ==`, "unexpected end of expression"},
+		{`query.name = 'x'`, `unexpected '=' at column 12`},
+		{`'unterminated`, "unterminated string at column 1"},
+		{`explain.cost > 10`, `undefined variable "explain"`},
+		{`query.name.lower()`, `undefined function "lower" at column 12`},
+		{`query.sql.contains()`, "contains takes 1 arguments, got 0"},
+		{`query.tables.exists('t', true)`, "the first argument of exists must be a variable name"},
+	} {
+		_, err := Compile(test.src, []string{"query"})
+		if err == nil {
+			t.Errorf("%s: expected an error", test.src)
+			continue
+		}
+		if diff := cmp.Diff(test.err, err.Error()); diff != "" {
+			t.Errorf("%s: differed (-want +got):\n%s", test.src, diff)
+		}
+	}
+
+	env := map[string]interface{}{"query": map[string]interface{}{"name": "GetAuthor"}}
+	for _, test := range []struct {
+		src string
+		err string
+	}{
+		{`query.name > 1`, "can't compare string and number"},
+		{`query.missing`, "no field missing, must be one of name"},
+		{`query.name && true`, "&& needs bools, got string"},
+		{`[1, 2][2]`, "index 2 out of range for a list of 2"},
+		{`[1, 2][100000000000000000000]`, "index 1e+20 out of range for a list of 2"},
+	} {
+		prog, err := Compile(test.src, []string{"query"})
+		if err != nil {
+			t.Fatalf("%s: %s", test.src, err)
+		}
+		_, err = prog.Eval(env)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.src)
+			continue
+		}
+		if diff := cmp.Diff(test.err, err.Error()); diff != "" {
+			t.Errorf("%s: differed (-want +got):\n%s", test.src, diff)
+		}
+	}
+
+	prog, err := Compile(`query.tables.exists(query, query == 'x')`, []string{"query", "explain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !prog.Uses("query") || prog.Uses("explain") {
+		t.Errorf("unexpected variable uses")
+	}
+}
@@ -0,0 +1,334 @@
+// Package expr implements the small expression language used to write custom
+// `sqlc vet` rules. It's modelled on CEL: expressions combine literals,
+// variables, operators and a few functions, and evaluate to a single value.
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lex splits src into tokens. Strings use single or double quotes, with
+// backslash escapes.
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, src[start:i], start})
+		case unicode.IsDigit(rune(c)):
+			start := i
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, src[start:i], start})
+		case c == '\'' || c == '"':
+			start := i
+			var s strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, fmt.Errorf("unterminated string at column %d", start+1)
+				}
+				if src[i] == c {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					i++
+					switch src[i] {
+					case 'n':
+						s.WriteByte('\n')
+					case 't':
+						s.WriteByte('\t')
+					default:
+						s.WriteByte(src[i])
+					}
+					i++
+					continue
+				}
+				s.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, token{tokString, s.String(), start})
+		default:
+			op := ""
+			for _, candidate := range []string{"||", "&&", "==", "!=", "<=", ">=", "<", ">", "!", "+", "-", "*", "/", "%", "(", ")", "[", "]", ",", "."} {
+				if strings.HasPrefix(src[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at column %d", c, i+1)
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+type node interface{}
+
+type (
+	literal struct{ val interface{} }
+	ident   struct{ name string }
+	unary   struct {
+		op string
+		x  node
+	}
+	binary struct {
+		op   string
+		x, y node
+	}
+	member struct {
+		x    node
+		name string
+	}
+	index struct{ x, i node }
+	list  struct{ items []node }
+	// call is a function call, or a method call when recv is set
+	call struct {
+		recv node
+		name string
+		args []node
+		pos  int
+	}
+)
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// back undoes next, which stays at the end of the tokens
+func (p *parser) back(t token) {
+	if t.kind != tokEOF {
+		p.pos--
+	}
+}
+
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokEOF {
+		return fmt.Errorf("unexpected end of expression")
+	}
+	return fmt.Errorf("unexpected %q at column %d", t.text, t.pos+1)
+}
+
+// The binary operators, from the loosest binding to the tightest
+var precedence = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!=", "<", "<=", ">", ">=", "in"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *parser) binaryOp(level int) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp && !(t.kind == tokIdent && t.text == "in") {
+		return "", false
+	}
+	for _, op := range precedence[level] {
+		if t.text == op {
+			p.pos++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseExpr(level int) (node, error) {
+	if level == len(precedence) {
+		return p.parseUnary()
+	}
+	x, err := p.parseExpr(level + 1)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.binaryOp(level)
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseExpr(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		x = binary{op, x, y}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	for _, op := range []string{"!", "-"} {
+		if p.accept(op) {
+			x, err := p.parseUnary()
+			if err != nil {
+				return nil, err
+			}
+			return unary{op, x}, nil
+		}
+	}
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (node, error) {
+	x, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch {
+		case p.accept("."):
+			t := p.next()
+			if t.kind != tokIdent {
+				p.back(t)
+				return nil, p.unexpected()
+			}
+			if p.accept("(") {
+				args, err := p.parseList(")")
+				if err != nil {
+					return nil, err
+				}
+				x = call{x, t.text, args, t.pos}
+			} else {
+				x = member{x, t.text}
+			}
+		case p.accept("["):
+			i, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			x = index{x, i}
+		default:
+			return x, nil
+		}
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at column %d", t.text, t.pos+1)
+		}
+		return literal{f}, nil
+	case tokString:
+		return literal{t.text}, nil
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return literal{true}, nil
+		case "false":
+			return literal{false}, nil
+		}
+		if p.accept("(") {
+			args, err := p.parseList(")")
+			if err != nil {
+				return nil, err
+			}
+			return call{nil, t.text, args, t.pos}, nil
+		}
+		return ident{t.text}, nil
+	case tokOp:
+		switch t.text {
+		case "(":
+			x, err := p.parseExpr(0)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return x, nil
+		case "[":
+			items, err := p.parseList("]")
+			if err != nil {
+				return nil, err
+			}
+			return list{items}, nil
+		}
+	}
+	p.back(t)
+	return nil, p.unexpected()
+}
+
+// parseList parses comma separated expressions up to the closing token
+func (p *parser) parseList(end string) ([]node, error) {
+	var items []node
+	if p.accept(end) {
+		return items, nil
+	}
+	for {
+		x, err := p.parseExpr(0)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, x)
+		if p.accept(end) {
+			return items, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
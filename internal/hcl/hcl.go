@@ -0,0 +1,379 @@
+// Package hcl parses the subset of HCL used by Atlas schema files: blocks,
+// attributes, strings, numbers, lists, heredocs, references such as
+// column.id and function calls such as varchar(255).
+package hcl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Body holds the attributes and blocks within a file or block
+type Body struct {
+	Attrs  []Attr
+	Blocks []Block
+}
+
+type Attr struct {
+	Name  string
+	Value Expr
+	Line  int
+}
+
+type Block struct {
+	Type   string
+	Labels []string
+	Body   Body
+	Line   int
+}
+
+// Attr returns the value of the attribute called name, if there is one
+func (b Body) Attr(name string) (Expr, bool) {
+	for _, a := range b.Attrs {
+		if a.Name == name {
+			return a.Value, true
+		}
+	}
+	return Expr{}, false
+}
+
+// BlocksOf returns the blocks of the given type
+func (b Body) BlocksOf(typ string) []Block {
+	var blocks []Block
+	for _, block := range b.Blocks {
+		if block.Type == typ {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// Kinds of expression
+const (
+	String = iota
+	Number
+	Bool
+	Null
+	List
+	Ref
+	Call
+)
+
+type Expr struct {
+	Kind int
+	// Str holds the value of strings, the text of numbers and bools, and the
+	// dotted name of references and calls
+	Str string
+	// Items holds the items of lists and the arguments of calls
+	Items []Expr
+	// Raw is the expression's source text
+	Raw string
+}
+
+type Error struct {
+	Line int
+	Err  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+type token struct {
+	kind  rune // 'i' ident, 's' string, 'n' number, or punctuation
+	text  string
+	line  int
+	start int
+	end   int
+}
+
+type parser struct {
+	src  string
+	toks []token
+	pos  int
+}
+
+// Parse parses an HCL file
+func Parse(src string) (Body, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return Body{}, err
+	}
+	p := &parser{src: src, toks: toks}
+	body, err := p.body(false)
+	if err != nil {
+		return Body{}, err
+	}
+	return body, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+	line := 1
+	if len(p.toks) > 0 {
+		line = p.toks[len(p.toks)-1].line
+	}
+	return token{kind: 0, line: line, start: len(p.src), end: len(p.src)}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.toks) {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) expect(kind rune) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		want := strconv.QuoteRune(kind)
+		if kind == 'i' {
+			want = "a name"
+		}
+		return t, &Error{Line: t.line, Err: fmt.Sprintf("expected %s, got %s", want, describe(t))}
+	}
+	return t, nil
+}
+
+func describe(t token) string {
+	if t.kind == 0 {
+		return "end of file"
+	}
+	return strconv.Quote(t.text)
+}
+
+func (p *parser) body(nested bool) (Body, error) {
+	var body Body
+	for {
+		t := p.peek()
+		if t.kind == 0 {
+			if nested {
+				return body, &Error{Line: t.line, Err: "unclosed block"}
+			}
+			return body, nil
+		}
+		if t.kind == '}' && nested {
+			p.next()
+			return body, nil
+		}
+		name, err := p.expect('i')
+		if err != nil {
+			return body, err
+		}
+		if p.peek().kind == '=' {
+			p.next()
+			value, err := p.expr()
+			if err != nil {
+				return body, err
+			}
+			body.Attrs = append(body.Attrs, Attr{Name: name.text, Value: value, Line: name.line})
+			continue
+		}
+		block := Block{Type: name.text, Line: name.line}
+		for p.peek().kind == 's' || p.peek().kind == 'i' {
+			block.Labels = append(block.Labels, p.next().text)
+		}
+		if _, err := p.expect('{'); err != nil {
+			return body, err
+		}
+		block.Body, err = p.body(true)
+		if err != nil {
+			return body, err
+		}
+		body.Blocks = append(body.Blocks, block)
+	}
+}
+
+func (p *parser) expr() (Expr, error) {
+	t := p.next()
+	var e Expr
+	switch t.kind {
+	case 's':
+		e = Expr{Kind: String, Str: t.text}
+	case 'n':
+		e = Expr{Kind: Number, Str: t.text}
+	case '[':
+		e.Kind = List
+		for p.peek().kind != ']' {
+			item, err := p.expr()
+			if err != nil {
+				return e, err
+			}
+			e.Items = append(e.Items, item)
+			if p.peek().kind != ',' {
+				break
+			}
+			p.next()
+		}
+		end, err := p.expect(']')
+		if err != nil {
+			return e, err
+		}
+		e.Raw = p.src[t.start:end.end]
+		return e, nil
+	case 'i':
+		switch t.text {
+		case "true", "false":
+			e = Expr{Kind: Bool, Str: t.text}
+		case "null":
+			e = Expr{Kind: Null, Str: t.text}
+		default:
+			e = Expr{Kind: Ref, Str: t.text}
+			end := t
+			for p.peek().kind == '.' {
+				p.next()
+				part, err := p.expect('i')
+				if err != nil {
+					return e, err
+				}
+				e.Str += "." + part.text
+				end = part
+			}
+			if p.peek().kind == '(' {
+				p.next()
+				e.Kind = Call
+				for p.peek().kind != ')' {
+					arg, err := p.expr()
+					if err != nil {
+						return e, err
+					}
+					e.Items = append(e.Items, arg)
+					if p.peek().kind != ',' {
+						break
+					}
+					p.next()
+				}
+				var err error
+				end, err = p.expect(')')
+				if err != nil {
+					return e, err
+				}
+			}
+			e.Raw = p.src[t.start:end.end]
+			return e, nil
+		}
+	default:
+		return e, &Error{Line: t.line, Err: fmt.Sprintf("expected a value, got %s", describe(t))}
+	}
+	e.Raw = p.src[t.start:t.end]
+	return e, nil
+}
+
+func isIdent(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && (r == '-' || unicode.IsDigit(r))
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	line := 1
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#' || strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, &Error{Line: line, Err: "unclosed comment"}
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == '"':
+			start := i
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(src) || src[i] == '\n' {
+					return nil, &Error{Line: line, Err: "unclosed string"}
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					switch src[i+1] {
+					case 'n':
+						b.WriteByte('\n')
+					case 't':
+						b.WriteByte('\t')
+					default:
+						b.WriteByte(src[i+1])
+					}
+					i += 2
+					continue
+				}
+				b.WriteByte(src[i])
+				i++
+			}
+			toks = append(toks, token{kind: 's', text: b.String(), line: line, start: start, end: i})
+		case strings.HasPrefix(src[i:], "<<"):
+			start, startLine := i, line
+			i += 2
+			if i < len(src) && src[i] == '-' {
+				i++
+			}
+			nl := strings.IndexByte(src[i:], '\n')
+			if nl < 0 {
+				return nil, &Error{Line: line, Err: "unclosed heredoc"}
+			}
+			marker := strings.TrimSpace(src[i : i+nl])
+			i += nl + 1
+			line++
+			var lines []string
+			for {
+				if i >= len(src) {
+					return nil, &Error{Line: startLine, Err: "unclosed heredoc"}
+				}
+				end := strings.IndexByte(src[i:], '\n')
+				if end < 0 {
+					end = len(src) - i
+				}
+				text := src[i : i+end]
+				i += end
+				if i < len(src) {
+					i++
+					line++
+				}
+				if strings.TrimSpace(text) == marker {
+					break
+				}
+				lines = append(lines, text)
+			}
+			toks = append(toks, token{kind: 's', text: strings.Join(lines, "\n"), line: startLine, start: start, end: i})
+		case unicode.IsDigit(c) || (c == '-' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+			start := i
+			i++
+			for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+				i++
+			}
+			toks = append(toks, token{kind: 'n', text: src[start:i], line: line, start: start, end: i})
+		case isIdent(c, true):
+			start := i
+			for i < len(src) && isIdent(rune(src[i]), false) {
+				i++
+			}
+			toks = append(toks, token{kind: 'i', text: src[start:i], line: line, start: start, end: i})
+		case strings.ContainsRune("{}[]()=,.", c):
+			toks = append(toks, token{kind: c, text: string(c), line: line, start: i, end: i + 1})
+			i++
+		default:
+			return nil, &Error{Line: line, Err: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return toks, nil
+}
@@ -0,0 +1,211 @@
+package introspect
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/kyleconroy/sqlc/internal/mysql"
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+const myTablesQuery = `
+SELECT TABLE_NAME, TABLE_COMMENT
+FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE'
+ORDER BY TABLE_NAME`
+
+const myColumnsQuery = `
+SELECT TABLE_NAME, COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE = 'NO',
+       EXTRA LIKE '%auto_increment%', COLUMN_COMMENT
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME, ORDINAL_POSITION`
+
+const myIndexesQuery = `
+SELECT TABLE_NAME, INDEX_NAME, NON_UNIQUE = 0, COALESCE(COLUMN_NAME, '')
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE()
+ORDER BY TABLE_NAME, INDEX_NAME, SEQ_IN_INDEX`
+
+// A MySQLTable is a table of a MySQL database
+type MySQLTable struct {
+	Name    string
+	Comment string
+	Columns []MySQLColumn
+	// Indexes include the primary key, named PRIMARY
+	Indexes []pg.Index
+}
+
+// A MySQLColumn is a column of a MySQL table. Type is the full column type,
+// such as varchar(255), int(10) unsigned or enum('draft','published').
+type MySQLColumn struct {
+	Name          string
+	Type          string
+	NotNull       bool
+	AutoIncrement bool
+	Comment       string
+}
+
+// MySQL returns the tables, columns, keys and comments of the current
+// database of a MySQL connection, read from information_schema. Enum values
+// are part of the column types. db must use a MySQL driver.
+func MySQL(db *sql.DB) ([]MySQLTable, error) {
+	var tables []MySQLTable
+	byName := map[string]int{}
+
+	rows, err := db.Query(myTablesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table MySQLTable
+		if err := rows.Scan(&table.Name, &table.Comment); err != nil {
+			return nil, err
+		}
+		byName[table.Name] = len(tables)
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(myColumnsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rel string
+		var col MySQLColumn
+		if err := rows.Scan(&rel, &col.Name, &col.Type, &col.NotNull, &col.AutoIncrement, &col.Comment); err != nil {
+			return nil, err
+		}
+		i, ok := byName[rel]
+		if !ok {
+			// Columns of views
+			continue
+		}
+		tables[i].Columns = append(tables[i].Columns, col)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = db.Query(myIndexesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rel, name, col string
+		var unique bool
+		if err := rows.Scan(&rel, &name, &unique, &col); err != nil {
+			return nil, err
+		}
+		i, ok := byName[rel]
+		if !ok {
+			continue
+		}
+		indexes := tables[i].Indexes
+		if n := len(indexes); n > 0 && indexes[n-1].Name == name {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, col)
+			continue
+		}
+		tables[i].Indexes = append(indexes, pg.Index{
+			Name:    name,
+			Columns: []string{col},
+			Unique:  unique,
+			Primary: name == "PRIMARY",
+		})
+	}
+	return tables, rows.Err()
+}
+
+// MySQLCatalog returns the schema the MySQL engine builds from the tables of
+// the current database of a MySQL connection
+func MySQLCatalog(db *sql.DB) (*mysql.Schema, error) {
+	tables, err := MySQL(db)
+	if err != nil {
+		return nil, err
+	}
+	return mysqlSchema(tables)
+}
+
+// mysqlSchema reads the DDL for tables back as the MySQL engine would read a
+// schema file
+func mysqlSchema(tables []MySQLTable) (*mysql.Schema, error) {
+	schema := mysql.NewSchema()
+	for _, stmt := range MySQLDDL(tables) {
+		tree, err := sqlparser.Parse(stmt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", stmt, err)
+		}
+		if ddl, ok := tree.(*sqlparser.DDL); ok {
+			schema.Add(ddl)
+		}
+	}
+	return schema, nil
+}
+
+// MySQLDDL returns the CREATE TABLE statements for tables, which the MySQL
+// engine can read back. Indexes on expressions are left out.
+func MySQLDDL(tables []MySQLTable) []string {
+	sorted := append([]MySQLTable{}, tables...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var stmts []string
+	for _, table := range sorted {
+		var lines []string
+		for _, col := range table.Columns {
+			line := "  " + myQuote(col.Name) + " " + col.Type
+			if col.NotNull {
+				line += " NOT NULL"
+			}
+			if col.AutoIncrement {
+				line += " AUTO_INCREMENT"
+			}
+			if col.Comment != "" {
+				line += " COMMENT " + myLiteral(col.Comment)
+			}
+			lines = append(lines, line)
+		}
+	indexes:
+		for _, index := range table.Indexes {
+			cols := make([]string, len(index.Columns))
+			for i, col := range index.Columns {
+				if col == "" {
+					continue indexes
+				}
+				cols[i] = myQuote(col)
+			}
+			switch {
+			case index.Primary:
+				lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(cols, ", ")))
+			case index.Unique:
+				lines = append(lines, fmt.Sprintf("  UNIQUE KEY %s (%s)", myQuote(index.Name), strings.Join(cols, ", ")))
+			default:
+				lines = append(lines, fmt.Sprintf("  KEY %s (%s)", myQuote(index.Name), strings.Join(cols, ", ")))
+			}
+		}
+		stmt := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", myQuote(table.Name), strings.Join(lines, ",\n"))
+		if table.Comment != "" {
+			stmt += " COMMENT=" + myLiteral(table.Comment)
+		}
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+func myQuote(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+func myLiteral(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
@@ -0,0 +1,102 @@
+package introspect
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"vitess.io/vitess/go/vt/sqlparser"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+func TestMySQLDDL(t *testing.T) {
+	tables := []MySQLTable{
+		{
+			Name:    "posts",
+			Comment: "Blog posts",
+			Columns: []MySQLColumn{
+				{Name: "id", Type: "bigint(20) unsigned", NotNull: true, AutoIncrement: true},
+				{Name: "author_id", Type: "bigint(20)", NotNull: true},
+				{Name: "state", Type: "enum('draft','published')", NotNull: true, Comment: "Isn't public until published"},
+				{Name: "title", Type: "varchar(255)"},
+			},
+			Indexes: []pg.Index{
+				{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Primary: true},
+				{Name: "posts_title", Columns: []string{"title"}, Unique: true},
+				{Name: "posts_author_state", Columns: []string{"author_id", "state"}},
+				{Name: "posts_lower_title", Columns: []string{""}},
+			},
+		},
+		{
+			Name:    "authors",
+			Columns: []MySQLColumn{{Name: "id", Type: "bigint(20)", NotNull: true}},
+		},
+	}
+	expected := []string{
+		"CREATE TABLE `authors` (\n  `id` bigint(20) NOT NULL\n)",
+		"CREATE TABLE `posts` (\n" +
+			"  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"  `author_id` bigint(20) NOT NULL,\n" +
+			"  `state` enum('draft','published') NOT NULL COMMENT 'Isn''t public until published',\n" +
+			"  `title` varchar(255),\n" +
+			"  PRIMARY KEY (`id`),\n" +
+			"  UNIQUE KEY `posts_title` (`title`),\n" +
+			"  KEY `posts_author_state` (`author_id`, `state`)\n" +
+			") COMMENT='Blog posts'",
+	}
+	stmts := MySQLDDL(tables)
+	if diff := cmp.Diff(expected, stmts); diff != "" {
+		t.Fatalf("ddl mismatch (-want +got):\n%s", diff)
+	}
+
+	// The MySQL engine reads the tables back with the same columns
+	for i, stmt := range stmts {
+		tree, err := sqlparser.Parse(stmt)
+		if err != nil {
+			t.Fatalf("%s: %s", stmt, err)
+		}
+		ddl, ok := tree.(*sqlparser.DDL)
+		if !ok || ddl.TableSpec == nil {
+			t.Fatalf("%s: not a CREATE TABLE", stmt)
+		}
+		table := tables[1-i]
+		if len(ddl.TableSpec.Columns) != len(table.Columns) {
+			t.Fatalf("%s: read %d columns", stmt, len(ddl.TableSpec.Columns))
+		}
+		for j, col := range ddl.TableSpec.Columns {
+			if col.Name.String() != table.Columns[j].Name || bool(col.Type.NotNull) != table.Columns[j].NotNull {
+				t.Errorf("%s: column %d read as %s", table.Name, j, sqlparser.String(col))
+			}
+		}
+	}
+	if vals := mysqlEnumValues(t, stmts[1]); !cmp.Equal(vals, []string{"'draft'", "'published'"}) {
+		t.Errorf("unexpected enum values %v", vals)
+	}
+
+	schema, err := mysqlSchema(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		cols, ok := schema.Table(table.Name)
+		if !ok {
+			t.Fatalf("%s isn't in the catalog", table.Name)
+		}
+		if len(cols) != len(table.Columns) {
+			t.Errorf("%s: catalog has %d columns", table.Name, len(cols))
+		}
+	}
+}
+
+func mysqlEnumValues(t *testing.T, stmt string) []string {
+	tree, err := sqlparser.Parse(stmt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, col := range tree.(*sqlparser.DDL).TableSpec.Columns {
+		if col.Type.Type == "enum" {
+			return col.Type.EnumValues
+		}
+	}
+	return nil
+}
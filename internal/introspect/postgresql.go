@@ -0,0 +1,174 @@
+// Package introspect builds catalogs from the structure of live databases
+package introspect
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/kyleconroy/sqlc/internal/pg"
+)
+
+const pgColumnsQuery = `
+SELECT c.relname, a.attname, tn.nspname,
+       CASE WHEN t.typcategory = 'A' THEN et.typname ELSE t.typname END,
+       t.typcategory = 'A', a.atttypmod, a.attnotnull, a.atthasdef
+FROM pg_catalog.pg_attribute a
+JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+LEFT JOIN pg_catalog.pg_type et ON et.oid = t.typelem AND t.typcategory = 'A'
+JOIN pg_catalog.pg_namespace tn ON tn.oid = COALESCE(et.typnamespace, t.typnamespace)
+WHERE n.nspname = $1 AND c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY c.relname, a.attnum`
+
+const pgIndexesQuery = `
+SELECT c.relname, i.relname, x.indisunique, x.indisprimary,
+       ARRAY(
+         SELECT COALESCE(a.attname::text, '')
+         FROM unnest(x.indkey) WITH ORDINALITY AS k(attnum, ord)
+         LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = x.indrelid AND a.attnum = k.attnum
+         ORDER BY k.ord
+       )
+FROM pg_catalog.pg_index x
+JOIN pg_catalog.pg_class c ON c.oid = x.indrelid
+JOIN pg_catalog.pg_class i ON i.oid = x.indexrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = $1
+ORDER BY c.relname, i.relname`
+
+const pgEnumsQuery = `
+SELECT t.typname, e.enumlabel
+FROM pg_catalog.pg_enum e
+JOIN pg_catalog.pg_type t ON t.oid = e.enumtypid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+WHERE n.nspname = $1
+ORDER BY t.typname, e.enumsortorder`
+
+// PostgreSQL returns a catalog of the tables, columns, indexes and enums in
+// schemas of a PostgreSQL database. Schemas which don't exist are left out.
+//
+// Column types are named as the schema and type name in pg_type, e.g.
+// pg_catalog.int4, rather than as they were written in CREATE TABLE. The
+// modifiers of built-in types are decoded to those written, e.g. 255 for
+// varchar(255).
+func PostgreSQL(db *sql.DB, schemas []string) (pg.Catalog, error) {
+	c := pg.NewCatalog()
+	for _, name := range schemas {
+		var exists bool
+		row := db.QueryRow("SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_namespace WHERE nspname = $1)", name)
+		if err := row.Scan(&exists); err != nil {
+			return c, err
+		}
+		if !exists {
+			delete(c.Schemas, name)
+			continue
+		}
+		schema := pg.NewSchema()
+		schema.Name = name
+		if err := pgTables(db, name, schema); err != nil {
+			return c, err
+		}
+		if err := pgEnums(db, name, schema); err != nil {
+			return c, err
+		}
+		c.Schemas[name] = schema
+	}
+	return c, nil
+}
+
+func pgTables(db *sql.DB, name string, schema pg.Schema) error {
+	rows, err := db.Query(pgColumnsQuery, name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rel, typeSchema string
+		var typmod int32
+		var col pg.Column
+		if err := rows.Scan(&rel, &col.Name, &typeSchema, &col.DataType, &col.IsArray, &typmod, &col.NotNull, &col.HasDefault); err != nil {
+			return err
+		}
+		if typeSchema != "public" {
+			col.DataType = typeSchema + "." + col.DataType
+		}
+		col.Typmods = pgTypmods(col.DataType, typmod)
+		col.Table = pg.FQN{Schema: name, Rel: rel}
+		table := schema.Tables[rel]
+		table.Name = rel
+		table.Columns = append(table.Columns, col)
+		schema.Tables[rel] = table
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.Query(pgIndexesQuery, name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rel string
+		var index pg.Index
+		if err := rows.Scan(&rel, &index.Name, &index.Unique, &index.Primary, pq.Array(&index.Columns)); err != nil {
+			return err
+		}
+		table, ok := schema.Tables[rel]
+		if !ok {
+			// Indexes on materialized views
+			continue
+		}
+		table.Indexes = append(table.Indexes, index)
+		schema.Tables[rel] = table
+	}
+	return rows.Err()
+}
+
+// pgTypmods decodes the atttypmod of a column of a built-in type to the
+// modifiers written in its declaration
+func pgTypmods(dataType string, typmod int32) []int32 {
+	if typmod < 0 {
+		return nil
+	}
+	switch dataType {
+	case "pg_catalog.varchar", "pg_catalog.bpchar":
+		return []int32{typmod - 4}
+	case "pg_catalog.numeric":
+		typmod -= 4
+		if scale := typmod & 0xffff; scale != 0 {
+			return []int32{typmod >> 16 & 0xffff, scale}
+		}
+		return []int32{typmod >> 16 & 0xffff}
+	case "pg_catalog.time", "pg_catalog.timetz", "pg_catalog.timestamp", "pg_catalog.timestamptz",
+		"pg_catalog.bit", "pg_catalog.varbit":
+		return []int32{typmod}
+	case "pg_catalog.interval":
+		mods := []int32{typmod >> 16 & 0x7fff}
+		if precision := typmod & 0xffff; precision != 0xffff {
+			mods = append(mods, precision)
+		}
+		return mods
+	}
+	return nil
+}
+
+func pgEnums(db *sql.DB, name string, schema pg.Schema) error {
+	rows, err := db.Query(pgEnumsQuery, name)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var typ, val string
+		if err := rows.Scan(&typ, &val); err != nil {
+			return err
+		}
+		enum, _ := schema.Types[typ].(pg.Enum)
+		enum.Name = typ
+		enum.Vals = append(enum.Vals, val)
+		schema.Types[typ] = enum
+	}
+	return rows.Err()
+}
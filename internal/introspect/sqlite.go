@@ -0,0 +1,147 @@
+package introspect
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+	"github.com/kyleconroy/sqlc/pkg/sql/catalog"
+)
+
+// A SQLiteObject is an entry of the sqlite_master table: a table, index,
+// view or trigger, with the statement which created it
+type SQLiteObject struct {
+	Type    string
+	Name    string
+	Table   string
+	SQL     string
+	RowID   int64
+	Builtin bool
+
+	// Columns of a table, as listed by PRAGMA table_info
+	Columns []SQLiteColumn
+}
+
+// A SQLiteColumn is a column of a SQLite table. Type is the declared type,
+// which is empty for a column that holds values of any type.
+type SQLiteColumn struct {
+	Name       string
+	Type       string
+	NotNull    bool
+	PrimaryKey bool
+}
+
+// SQLite returns the tables, indexes, views and triggers of the SQLite
+// database at path, in the order they were created, read from sqlite_master
+// and PRAGMA table_info. The database is opened read only, through SQLite
+// itself, so changes still in a write-ahead log are seen. Objects which
+// SQLite creates itself, such as sqlite_sequence and the indexes behind
+// UNIQUE constraints, are marked as builtin.
+func SQLite(path string) ([]SQLiteObject, error) {
+	// SQLite creates a missing file, even when opened read only
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", "file:"+url.PathEscape(path)+"?mode=ro")
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	objects, err := sqliteObjects(db)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return objects, nil
+}
+
+func sqliteObjects(db *sql.DB) ([]SQLiteObject, error) {
+	rows, err := db.Query("SELECT rowid, type, name, tbl_name, COALESCE(sql, '') FROM sqlite_master ORDER BY rowid")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var objects []SQLiteObject
+	for rows.Next() {
+		var obj SQLiteObject
+		if err := rows.Scan(&obj.RowID, &obj.Type, &obj.Name, &obj.Table, &obj.SQL); err != nil {
+			return nil, err
+		}
+		obj.Builtin = obj.SQL == "" || strings.HasPrefix(obj.Name, "sqlite_")
+		objects = append(objects, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, obj := range objects {
+		if obj.Type != "table" {
+			continue
+		}
+		if objects[i].Columns, err = sqliteColumns(db, obj.Name); err != nil {
+			return nil, err
+		}
+	}
+	return objects, nil
+}
+
+func sqliteColumns(db *sql.DB, table string) ([]SQLiteColumn, error) {
+	rows, err := db.Query("SELECT name, type, \"notnull\", pk FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var cols []SQLiteColumn
+	for rows.Next() {
+		var col SQLiteColumn
+		var pk int
+		if err := rows.Scan(&col.Name, &col.Type, &col.NotNull, &pk); err != nil {
+			return nil, err
+		}
+		col.PrimaryKey = pk > 0
+		cols = append(cols, col)
+	}
+	return cols, rows.Err()
+}
+
+// SQLiteDDL returns the statements which created objects, leaving out those
+// SQLite creates itself
+func SQLiteDDL(objects []SQLiteObject) []string {
+	var stmts []string
+	for _, obj := range objects {
+		if !obj.Builtin {
+			stmts = append(stmts, obj.SQL)
+		}
+	}
+	return stmts
+}
+
+// SQLiteCatalog returns the catalog the SQLite engine builds from the tables
+// of objects. Columns without a declared type have the type any, as they do
+// when read from a schema file.
+func SQLiteCatalog(objects []SQLiteObject) (*catalog.Catalog, error) {
+	var stmts []ast.Statement
+	for _, obj := range objects {
+		if obj.Type != "table" || obj.Builtin {
+			continue
+		}
+		stmt := &ast.CreateTableStmt{Name: &ast.TableName{Name: obj.Name}}
+		for _, col := range obj.Columns {
+			typ := col.Type
+			if typ == "" {
+				typ = "any"
+			}
+			stmt.Cols = append(stmt.Cols, &ast.ColumnDef{
+				Colname:   col.Name,
+				TypeName:  &ast.TypeName{Name: typ},
+				IsNotNull: col.NotNull,
+			})
+		}
+		stmts = append(stmts, ast.Statement{Raw: &ast.RawStmt{Stmt: stmt}})
+	}
+	return catalog.Build(stmts)
+}
@@ -0,0 +1,121 @@
+package introspect
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSQLite(t *testing.T) {
+	objects, err := SQLite("testdata/app.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cols []string
+	for i := 0; i < 30; i++ {
+		cols = append(cols, fmt.Sprintf("column_%02d TEXT NOT NULL DEFAULT ''", i))
+	}
+	wide := "CREATE TABLE wide (\n  id INTEGER PRIMARY KEY,\n  " + strings.Join(cols, ",\n  ") + "\n)"
+
+	var got []string
+	for _, obj := range objects {
+		got = append(got, fmt.Sprintf("%s %s %s %t", obj.Type, obj.Name, obj.Table, obj.Builtin))
+	}
+	expected := []string{
+		"table authors authors false",
+		"index sqlite_autoindex_authors_1 authors true",
+		"table sqlite_sequence sqlite_sequence true",
+		"table wide wide false",
+	}
+	for i := 0; i < 40; i++ {
+		expected = append(expected, fmt.Sprintf("table t%02d t%02d false", i, i))
+	}
+	expected = append(expected,
+		"index authors_bio authors false",
+		"view author_names author_names false",
+		"trigger authors_touch authors false",
+	)
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("objects differed (-want +got):\n%s", diff)
+	}
+
+	stmts := SQLiteDDL(objects)
+	if len(stmts) != 45 {
+		t.Fatalf("expected 45 statements, got %d", len(stmts))
+	}
+	if diff := cmp.Diff("CREATE TABLE authors (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT NOT NULL UNIQUE, bio TEXT)", stmts[0]); diff != "" {
+		t.Errorf("authors differed (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wide, stmts[1]); diff != "" {
+		t.Errorf("wide differed (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("CREATE TRIGGER authors_touch AFTER UPDATE ON authors BEGIN SELECT 1; END", stmts[44]); diff != "" {
+		t.Errorf("trigger differed (-want +got):\n%s", diff)
+	}
+
+	c, err := SQLiteCatalog(objects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authors []string
+	for _, table := range c.Schemas[0].Tables {
+		if table.Rel.Name != "authors" {
+			continue
+		}
+		for _, col := range table.Columns {
+			authors = append(authors, fmt.Sprintf("%s %s %t", col.Name, col.Type.Name, col.IsNotNull))
+		}
+	}
+	expectedCols := []string{"id INTEGER false", "name TEXT true", "bio TEXT false"}
+	if diff := cmp.Diff(expectedCols, authors); diff != "" {
+		t.Errorf("authors columns differed (-want +got):\n%s", diff)
+	}
+}
+
+// Tables created since the last checkpoint are still in the write-ahead log
+func TestSQLiteWAL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "app.db")
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		"PRAGMA journal_mode=WAL",
+		"PRAGMA wal_autocheckpoint=0",
+		"CREATE TABLE events (id INTEGER PRIMARY KEY, at TEXT NOT NULL)",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := SQLite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0].Name != "events" || len(objects[0].Columns) != 2 {
+		t.Errorf("unexpected objects %+v", objects)
+	}
+}
+
+func TestSQLiteInvalid(t *testing.T) {
+	if _, err := SQLite("sqlite.go"); err == nil || !strings.Contains(err.Error(), "not a database") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := SQLite("testdata/missing.db"); !os.IsNotExist(err) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
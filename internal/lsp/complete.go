@@ -0,0 +1,134 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+// Keywords which are followed by a table name
+var tableKeywords = map[string]bool{
+	"from":   true,
+	"join":   true,
+	"into":   true,
+	"update": true,
+	"table":  true,
+}
+
+// tableRefs matches the tables named in a statement, with their aliases
+var tableRefs = regexp.MustCompile(`(?i)\b(?:from|join|into|update)\s+([A-Za-z_][\w.]*)(?:\s+(?:as\s+)?([A-Za-z_]\w*))?`)
+
+func isWordByte(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// statement returns the text of the statement around offset i
+func statement(text string, i int) string {
+	start := strings.LastIndexByte(text[:i], ';') + 1
+	end := strings.IndexByte(text[i:], ';')
+	if end < 0 {
+		return text[start:]
+	}
+	return text[start : i+end]
+}
+
+// tables returns the user defined tables of a catalog, keyed by the name
+// queries refer to them by
+func tables(c core.Catalog) map[string]core.Table {
+	all := map[string]core.Table{}
+	for name, schema := range c.Schemas {
+		if name == "pg_catalog" {
+			continue
+		}
+		for _, t := range schema.Tables {
+			if name == "public" {
+				all[t.Name] = t
+			} else {
+				all[name+"."+t.Name] = t
+			}
+		}
+	}
+	return all
+}
+
+// complete returns the table or column names which may be typed at pos: the
+// columns of a table after its name or alias and a dot, tables after FROM and
+// the like, and otherwise the columns of the tables in the statement followed
+// by every table.
+func (s *Server) complete(filename string, pos Position) []CompletionItem {
+	items := []CompletionItem{}
+	c, ok := s.catalogs[filename]
+	if !ok {
+		return items
+	}
+	text := s.docs[filename]
+	i := offset(text, pos)
+	start := i
+	for start > 0 && isWordByte(text[start-1]) {
+		start--
+	}
+	all := tables(c)
+	stmt := statement(text, i)
+
+	columns := func(t core.Table) {
+		for _, col := range t.Columns {
+			items = append(items, CompletionItem{Label: col.Name, Kind: KindField, Detail: t.Name + "." + col.Name + " " + col.DataType})
+		}
+	}
+
+	if start > 0 && text[start-1] == '.' {
+		q := start - 1
+		for q > 0 && (isWordByte(text[q-1]) || text[q-1] == '.') {
+			q--
+		}
+		qualifier := text[q : start-1]
+		if t, ok := all[qualifier]; ok {
+			columns(t)
+			return items
+		}
+		for _, m := range tableRefs.FindAllStringSubmatch(stmt, -1) {
+			if strings.EqualFold(m[2], qualifier) {
+				if t, ok := all[m[1]]; ok {
+					columns(t)
+				}
+			}
+		}
+		if len(items) > 0 {
+			return items
+		}
+		// A schema name is completed with its tables
+		for name := range all {
+			if strings.HasPrefix(name, qualifier+".") {
+				items = append(items, CompletionItem{Label: strings.TrimPrefix(name, qualifier+"."), Kind: KindClass, Detail: "table " + name})
+			}
+		}
+		sortItems(items)
+		return items
+	}
+
+	prev := strings.Fields(text[:start])
+	if n := len(prev); n == 0 || !tableKeywords[strings.ToLower(prev[n-1])] {
+		seen := map[string]bool{}
+		for _, m := range tableRefs.FindAllStringSubmatch(stmt, -1) {
+			if t, ok := all[m[1]]; ok && !seen[m[1]] {
+				seen[m[1]] = true
+				columns(t)
+			}
+		}
+	}
+	var names []string
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		items = append(items, CompletionItem{Label: name, Kind: KindClass, Detail: "table " + name})
+	}
+	return items
+}
+
+func sortItems(items []CompletionItem) {
+	sort.Slice(items, func(i, j int) bool { return items[i].Label < items[j].Label })
+}
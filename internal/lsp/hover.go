@@ -0,0 +1,81 @@
+package lsp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+)
+
+var nameComment = regexp.MustCompile(`^\s*(?:--|/\*)\s*name:\s*(\w+)`)
+
+// hover describes the Go method generated for the query at pos, and the
+// structs holding its parameters and columns. Kotlin packages have no hover.
+func (s *Server) hover(filename string, pos Position) *Hover {
+	res, ok := s.results[filename]
+	if !ok || res.pkg.Kotlin {
+		return nil
+	}
+	lines := strings.Split(s.docs[filename], "\n")
+	if pos.Line >= len(lines) {
+		return nil
+	}
+	// The query is named by the nearest name comment above the cursor
+	var name string
+	line := pos.Line
+	for ; line >= 0; line-- {
+		if m := nameComment.FindStringSubmatch(lines[line]); m != nil {
+			name = m[1]
+			break
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	for _, q := range res.result.GoQueries(res.pkg.Combo) {
+		if q.MethodName != name {
+			continue
+		}
+		var b strings.Builder
+		b.WriteString("```go\n")
+		b.WriteString(signature(q))
+		for _, v := range []dinosql.GoQueryValue{q.Arg, q.Ret} {
+			if v.EmitStruct() {
+				b.WriteString("\n\ntype " + v.Struct.Name + " struct {\n")
+				for _, f := range v.Struct.Fields {
+					fmt.Fprintf(&b, "\t%s %s\n", f.Name, f.Type)
+				}
+				b.WriteString("}")
+			}
+		}
+		b.WriteString("\n```")
+		return &Hover{
+			Contents: MarkupContent{Kind: "markdown", Value: b.String()},
+			Range:    &Range{Start: Position{Line: line}, End: Position{Line: line, Character: len(lines[line])}},
+		}
+	}
+	return nil
+}
+
+// signature returns the signature of the method generated for a query
+func signature(q dinosql.GoQuery) string {
+	args := "ctx context.Context"
+	if arg := q.Arg.Pair(); arg != "" {
+		args += ", " + arg
+	}
+	var ret string
+	switch q.Cmd {
+	case ":one":
+		ret = "(" + q.Ret.Type() + ", error)"
+	case ":many":
+		ret = "([]" + q.Ret.Type() + ", error)"
+	case ":iter":
+		ret = "iter.Seq2[" + q.Ret.Type() + ", error]"
+	case ":execrows":
+		ret = "(int64, error)"
+	default:
+		ret = "error"
+	}
+	return fmt.Sprintf("func (q *Queries) %s(%s) %s", q.MethodName, args, ret)
+}
@@ -0,0 +1,118 @@
+package lsp
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	"github.com/kyleconroy/sqlc/internal/remote"
+)
+
+// A pkg is a PostgreSQL package of a project, with its schema and queries
+// paths made absolute
+type pkg struct {
+	Name   string
+	Combo  config.CombinedSettings
+	Schema string
+	// Queries is the path of the package's queries
+	Queries string
+	Kotlin  bool
+}
+
+var errNoConfig = errors.New("no sqlc.yaml or sqlc.json file")
+
+// findConfig returns the path of the configuration file in the directory
+// holding filename, or the nearest directory above it
+func findConfig(filename string) (string, error) {
+	dir := filepath.Dir(filename)
+	for {
+		for _, name := range []string{"sqlc.yaml", "sqlc.json"} {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errNoConfig
+		}
+		dir = parent
+	}
+}
+
+// packages returns the PostgreSQL packages of the project which filename is
+// part of. Other engines aren't supported.
+func packages(filename string) ([]pkg, error) {
+	path, err := findConfig(filename)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	conf, err := config.ParseConfig(bytes.NewReader(blob))
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(path)
+	var pkgs []pkg
+	for _, sql := range conf.SQL {
+		if sql.Engine != config.EnginePostgreSQL {
+			continue
+		}
+		p := pkg{
+			Combo:   config.Combine(conf, sql),
+			Schema:  filepath.Join(dir, sql.Schema),
+			Queries: filepath.Join(dir, sql.Queries),
+		}
+		if remote.IsURL(sql.Schema) {
+			p.Schema, err = remote.Fetch(sql.Schema)
+			if err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case sql.Gen.Go != nil:
+			p.Name = p.Combo.Go.Package
+		case sql.Gen.Kotlin != nil:
+			p.Name = p.Combo.Kotlin.Package
+			p.Kotlin = true
+		}
+		pkgs = append(pkgs, p)
+	}
+	return pkgs, nil
+}
+
+// isQueryFile reports whether filename holds queries of the package, which may
+// be a new file that hasn't been saved yet
+func (p pkg) isQueryFile(filename string) bool {
+	if filepath.Ext(filename) != ".sql" {
+		return false
+	}
+	if filepath.Dir(filename) == p.Queries {
+		return true
+	}
+	return contains(dinosql.ReadSQLFiles, p.Queries, filename)
+}
+
+func (p pkg) isSchemaFile(filename string) bool {
+	return contains(dinosql.ReadSchemaFiles, p.Schema, filename)
+}
+
+func contains(list func(string) ([]string, error), path, filename string) bool {
+	files, err := list(path)
+	if err != nil {
+		return false
+	}
+	for _, f := range files {
+		if f == filename {
+			return true
+		}
+	}
+	return false
+}
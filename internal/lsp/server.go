@@ -0,0 +1,292 @@
+// Package lsp implements sqlc-ls, a language server for the query and schema
+// files of sqlc projects. It reports the errors sqlc generate would as files
+// are edited, completes table and column names from the catalog, and shows
+// the Go types inferred for a query's parameters and columns on hover.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/dinosql"
+	core "github.com/kyleconroy/sqlc/internal/pg"
+)
+
+type Server struct {
+	out io.Writer
+	log io.Writer
+
+	// docs holds the contents of open documents, keyed by file name
+	docs map[string]string
+	// catalogs holds the catalog each document was last checked against
+	catalogs map[string]core.Catalog
+	// results holds the last queries parsed without errors from each
+	// document, with the package they belong to
+	results map[string]checked
+
+	shutdown bool
+}
+
+type checked struct {
+	pkg    pkg
+	result *dinosql.Result
+}
+
+// Serve answers the requests read from in until the client exits, writing
+// responses and notifications to out. Problems which can't be reported to the
+// client are logged to log.
+func Serve(in io.Reader, out, log io.Writer) error {
+	s := &Server{
+		out:      out,
+		log:      log,
+		docs:     map[string]string{},
+		catalogs: map[string]core.Catalog{},
+		results:  map[string]checked{},
+	}
+	r := bufio.NewReader(in)
+	for {
+		msg, err := readMessage(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if msg.Method == "exit" {
+			if !s.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(msg *message) error {
+	if msg.Error != nil {
+		return s.reply(nil, nil, msg.Error)
+	}
+	result, rerr := s.dispatch(msg)
+	if msg.ID == nil {
+		if rerr != nil {
+			fmt.Fprintf(s.log, "%s: %s\n", msg.Method, rerr.Message)
+		}
+		return nil
+	}
+	return s.reply(msg.ID, result, rerr)
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}, rerr *responseError) error {
+	if id == nil {
+		null := json.RawMessage("null")
+		id = &null
+	}
+	resp := &message{ID: id, Result: result, Error: rerr}
+	if rerr == nil && result == nil {
+		// Result must be present in a successful response, even if null
+		resp.Result = json.RawMessage("null")
+	}
+	return writeMessage(s.out, resp)
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	blob, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return writeMessage(s.out, &message{Method: method, Params: blob})
+}
+
+func (s *Server) dispatch(msg *message) (interface{}, *responseError) {
+	decode := func(v interface{}) *responseError {
+		if err := json.Unmarshal(msg.Params, v); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch msg.Method {
+	case "initialize":
+		var res initializeResult
+		res.Capabilities.TextDocumentSync = syncFull
+		res.Capabilities.HoverProvider = true
+		res.Capabilities.CompletionProvider.TriggerCharacters = []string{"."}
+		res.ServerInfo.Name = "sqlc-ls"
+		return res, nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		s.docs[uriPath(params.TextDocument.URI)] = params.TextDocument.Text
+		return nil, s.checkAll()
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			s.docs[uriPath(params.TextDocument.URI)] = params.ContentChanges[n-1].Text
+		}
+		return nil, s.checkAll()
+
+	case "textDocument/didSave":
+		return nil, s.checkAll()
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		filename := uriPath(params.TextDocument.URI)
+		delete(s.docs, filename)
+		delete(s.catalogs, filename)
+		delete(s.results, filename)
+		if err := s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		}); err != nil {
+			return nil, &responseError{Message: err.Error()}
+		}
+		// Closing a schema file reverts it to its saved contents
+		return nil, s.checkAll()
+
+	case "textDocument/completion":
+		var params positionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.complete(uriPath(params.TextDocument.URI), params.Position), nil
+
+	case "textDocument/hover":
+		var params positionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if hover := s.hover(uriPath(params.TextDocument.URI), params.Position); hover != nil {
+			return hover, nil
+		}
+		return nil, nil
+	}
+	if strings.HasPrefix(msg.Method, "$/") {
+		// Optional notifications and requests may be ignored
+		return nil, nil
+	}
+	return nil, &responseError{Code: codeMethodNotFound, Message: "method not found: " + msg.Method}
+}
+
+// checkAll checks every open document, as a change to a schema file can
+// break the queries in another
+func (s *Server) checkAll() *responseError {
+	var names []string
+	for filename := range s.docs {
+		names = append(names, filename)
+	}
+	sort.Strings(names)
+	for _, filename := range names {
+		diags := s.check(filename)
+		if err := s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         pathURI(filename),
+			Diagnostics: diags,
+		}); err != nil {
+			return &responseError{Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// check returns the problems in an open document, checking it as part of each
+// package whose schema or queries include it
+func (s *Server) check(filename string) []Diagnostic {
+	text := s.docs[filename]
+	diags := []Diagnostic{}
+	seen := map[string]bool{}
+	add := func(fileErr dinosql.FileErr) {
+		key := fmt.Sprintf("%d:%d:%s", fileErr.Line, fileErr.Column, fileErr.Err)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		start := position(text, fileErr.Line, fileErr.Column)
+		end := position(text, fileErr.Line, len(text)+1)
+		diags = append(diags, Diagnostic{
+			Range:    Range{Start: start, End: end},
+			Severity: SeverityError,
+			Source:   "sqlc",
+			Message:  fileErr.Err.Error(),
+		})
+	}
+
+	pkgs, err := packages(filename)
+	if err != nil {
+		if err != errNoConfig {
+			fmt.Fprintf(s.log, "%s: %s\n", filename, err)
+		}
+		return diags
+	}
+	for _, p := range pkgs {
+		query, schema := p.isQueryFile(filename), p.isSchemaFile(filename)
+		if !query && !schema {
+			continue
+		}
+		c, err := dinosql.ParseCatalogSources(p.Schema, s.docs)
+		s.catalogs[filename] = c
+		if err != nil {
+			if perr, ok := err.(*dinosql.ParserErr); ok {
+				for _, fileErr := range perr.Errs {
+					if fileErr.Filename == filename {
+						add(fileErr)
+					}
+				}
+			}
+			continue
+		}
+		if !query {
+			continue
+		}
+		result, err := dinosql.ParseQueries(c, p.Queries, dinosql.ParserOpts{
+			UsePositionalParameters: p.Kotlin,
+			Sources:                 map[string]string{filename: text},
+		})
+		if perr, ok := err.(*dinosql.ParserErr); ok {
+			for _, fileErr := range perr.Errs {
+				if fileErr.Filename == filename {
+					add(fileErr)
+				}
+			}
+		}
+		if err == nil {
+			s.results[filename] = checked{pkg: p, result: result}
+		}
+	}
+	return diags
+}
+
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
+
+func pathURI(filename string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(filename)}
+	return u.String()
+}
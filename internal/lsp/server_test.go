@@ -0,0 +1,183 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const lspConfig = `version: "1"
+packages:
+  - path: "db"
+    name: "db"
+    schema: "schema.sql"
+    queries: "query.sql"
+`
+
+const lspSchema = `CREATE TABLE authors (
+  id   BIGSERIAL PRIMARY KEY,
+  name text NOT NULL,
+  bio  text
+);
+`
+
+type session struct {
+	in bytes.Buffer
+	id int
+}
+
+func (s *session) send(method string, params interface{}) {
+	msg := &message{Method: method, Params: mustMarshal(params)}
+	if !strings.HasPrefix(method, "textDocument/did") && method != "initialized" && method != "exit" {
+		s.id++
+		id := json.RawMessage(mustMarshal(s.id))
+		msg.ID = &id
+	}
+	writeMessage(&s.in, msg)
+}
+
+func mustMarshal(v interface{}) []byte {
+	blob, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return blob
+}
+
+type received struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+	Result json.RawMessage  `json:"result"`
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sqlc-ls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	query := "-- name: GetAuthor :one\nSELECT * FROM authors WHERE id = $1;\n"
+	for name, contents := range map[string]string{
+		"sqlc.yaml":  lspConfig,
+		"schema.sql": lspSchema,
+		"query.sql":  query,
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	uri := pathURI(filepath.Join(dir, "query.sql"))
+	doc := TextDocumentIdentifier{URI: uri}
+
+	var s session
+	s.send("initialize", map[string]interface{}{})
+	s.send("initialized", map[string]interface{}{})
+	s.send("textDocument/didOpen", didOpenParams{TextDocument: TextDocumentItem{
+		URI:  uri,
+		Text: "-- name: GetAuthor :one\nSELECT missing FROM authors WHERE id = $1;\n",
+	}})
+	s.send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   doc,
+		"contentChanges": []map[string]string{{"text": query + "\n-- name: ListAuthors :many\nSELECT a. FROM authors a;\n"}},
+	})
+	s.send("textDocument/completion", positionParams{TextDocument: doc, Position: Position{Line: 4, Character: 9}})
+	s.send("textDocument/didChange", map[string]interface{}{
+		"textDocument":   doc,
+		"contentChanges": []map[string]string{{"text": query}},
+	})
+	s.send("textDocument/completion", positionParams{TextDocument: doc, Position: Position{Line: 1, Character: 14}})
+	s.send("textDocument/hover", positionParams{TextDocument: doc, Position: Position{Line: 1, Character: 3}})
+	s.send("shutdown", nil)
+	s.send("exit", nil)
+
+	var out bytes.Buffer
+	if err := Serve(&s.in, &out, ioutil.Discard); err != nil {
+		t.Fatal(err)
+	}
+
+	var diagnostics [][]Diagnostic
+	results := map[string]json.RawMessage{}
+	r := bufio.NewReader(&out)
+	for {
+		header, err := r.ReadString('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var length int
+		if _, err := fmt.Sscanf(header, "Content-Length: %d", &length); err != nil {
+			t.Fatalf("bad header %q", header)
+		}
+		r.ReadString('\n')
+		body := make([]byte, length)
+		if _, err := io.ReadFull(r, body); err != nil {
+			t.Fatal(err)
+		}
+		var msg received
+		if err := json.Unmarshal(body, &msg); err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case msg.Method == "textDocument/publishDiagnostics":
+			var params publishDiagnosticsParams
+			json.Unmarshal(msg.Params, &params)
+			diagnostics = append(diagnostics, params.Diagnostics)
+		case msg.ID != nil:
+			results[string(*msg.ID)] = msg.Result
+		}
+	}
+
+	if len(diagnostics) != 3 {
+		t.Fatalf("expected diagnostics after each change, got %d", len(diagnostics))
+	}
+	if len(diagnostics[0]) != 1 || !strings.Contains(diagnostics[0][0].Message, `column "missing" does not exist`) {
+		t.Errorf("unexpected diagnostics for a missing column: %+v", diagnostics[0])
+	} else if diagnostics[0][0].Range.Start.Line != 1 {
+		t.Errorf("expected the error on line 1, got %+v", diagnostics[0][0].Range)
+	}
+	if len(diagnostics[2]) != 0 {
+		t.Errorf("expected no diagnostics once fixed, got %+v", diagnostics[2])
+	}
+
+	labels := func(id string) []string {
+		var items []CompletionItem
+		json.Unmarshal(results[id], &items)
+		var names []string
+		for _, item := range items {
+			names = append(names, item.Label)
+		}
+		return names
+	}
+	if got := strings.Join(labels("2"), ","); got != "id,name,bio" {
+		t.Errorf("expected the alias's columns, got %s", got)
+	}
+	if got := strings.Join(labels("3"), ","); got != "authors" {
+		t.Errorf("expected tables after FROM, got %s", got)
+	}
+
+	var hover Hover
+	json.Unmarshal(results["4"], &hover)
+	want := "func (q *Queries) GetAuthor(ctx context.Context, id int64) (Author, error)"
+	if !strings.Contains(hover.Contents.Value, want) {
+		t.Errorf("expected %q in hover:\n%s", want, hover.Contents.Value)
+	}
+}
+
+func TestReadMessageLength(t *testing.T) {
+	for _, length := range []string{"-1", "abc", "1099511627776"} {
+		r := bufio.NewReader(strings.NewReader("Content-Length: " + length + "\r\n\r\n{}"))
+		if _, err := readMessage(r); err == nil || !strings.Contains(err.Error(), "invalid Content-Length") {
+			t.Errorf("Content-Length %s: expected an error, got %v", length, err)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/jinzhu/inflection"
 	"vitess.io/vitess/go/vt/sqlparser"
 
 	"github.com/kyleconroy/sqlc/internal/config"
@@ -24,19 +23,26 @@ type Result struct {
 	Queries []*Query
 }
 
-// Enums generates parser-agnostic GoEnum types
+// Enums generates parser-agnostic GoEnum types, sorted by name. Enums with the
+// same name keep the order of their tables' names.
 func (r *Result) Enums(settings config.CombinedSettings) []dinosql.GoEnum {
 	var enums []dinosql.GoEnum
-	for _, table := range r.Schema.tables {
-		for _, col := range table {
+	for _, tableName := range r.Schema.tableNames() {
+		for _, col := range r.Schema.tables[tableName] {
 			if col.Type.Type == "enum" {
 				constants := []dinosql.GoConstant{}
 				enumName := r.enumNameFromColDef(col)
 				for _, c := range col.Type.EnumValues {
 					stripped := stripInnerQuotes(c)
+					name := stripped
+					if settings.Go.EnumValueStyle == config.EnumValueStylePascal {
+						name = dinosql.EnumConstantName(enumName, enumName, stripped, settings)
+					} else if rename, ok := dinosql.EnumValueRename(enumName, stripped, settings); ok {
+						name = rename
+					}
 					constants = append(constants, dinosql.GoConstant{
 						// TODO: maybe add the struct name call to capitalize the name here
-						Name:  stripped,
+						Name:  name,
 						Value: stripped,
 						Type:  enumName,
 					})
@@ -51,6 +57,7 @@ func (r *Result) Enums(settings config.CombinedSettings) []dinosql.GoEnum {
 			}
 		}
 	}
+	sort.SliceStable(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	return enums
 }
 
@@ -66,29 +73,31 @@ func (pGen PackageGenerator) enumNameFromColDef(col *sqlparser.ColumnDefinition)
 // Structs marshels each query into a go struct for generation
 func (r *Result) Structs(settings config.CombinedSettings) []dinosql.GoStruct {
 	var structs []dinosql.GoStruct
-	for tableName, cols := range r.Schema.tables {
+	for _, tableName := range r.Schema.tableNames() {
+		cols := r.Schema.tables[tableName]
 		s := dinosql.GoStruct{
-			Name:  inflection.Singular(dinosql.StructName(tableName, settings)),
-			Table: core.FQN{tableName, "", ""}, // TODO: Complete hack. Only need for equality check to see if struct can be reused between queries
+			Name:  dinosql.ModelsQualifier(settings) + dinosql.ModelName(tableName, settings),
+			Table: core.FQN{Catalog: tableName}, // TODO: Complete hack. Only need for equality check to see if struct can be reused between queries
 		}
 
 		for _, col := range cols {
 			s.Fields = append(s.Fields, dinosql.GoField{
-				Name:    dinosql.StructName(col.Name.String(), settings),
+				Name:    dinosql.FieldName("", tableName, col.Name.String(), settings),
 				Type:    r.goTypeCol(Column{col, tableName}),
-				Tags:    map[string]string{"json:": col.Name.String()},
+				Tags:    dinosql.FieldTags(col.Name.String(), r.columnTags(col.Name.String(), tableName), settings),
 				Comment: "",
 			})
 		}
 		structs = append(structs, s)
 	}
-	sort.Slice(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
+	sort.SliceStable(structs, func(i, j int) bool { return structs[i].Name < structs[j].Name })
 	return structs
 }
 
 // GoQueries generates parser-agnostic query information for code generation
 func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 	structs := r.Structs(settings)
+	var rows []*dinosql.GoStruct
 
 	qs := make([]dinosql.GoQuery, 0, len(r.Queries))
 	for ix, query := range r.Queries {
@@ -112,26 +121,33 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 			// Comments:     query.Comments,
 		}
 
-		if len(query.Params) == 1 {
+		limit := dinosql.QueryParameterLimit(settings)
+		if len(query.Params) == 1 && limit > 0 {
 			p := query.Params[0]
 			gq.Arg = dinosql.GoQueryValue{
 				Name: p.Name,
 				Typ:  p.Typ,
 			}
-		} else if len(query.Params) > 1 {
+		} else if len(query.Params) > 0 {
 
+			names := make([]string, len(query.Params))
 			structInfo := make([]structParams, len(query.Params))
 			for i := range query.Params {
+				names[i] = query.Params[i].Name
 				structInfo[i] = structParams{
 					originalName: query.Params[i].Name,
 					goType:       query.Params[i].Typ,
 				}
 			}
 
-			gq.Arg = dinosql.GoQueryValue{
-				Emit:   true,
-				Name:   "arg",
-				Struct: r.columnsToStruct(gq.MethodName+"Params", structInfo, settings),
+			if len(query.Params) > 1 && len(query.Params) <= limit {
+				gq.Arg = dinosql.PositionalArgs(names, r.columnsToStruct("", structInfo, settings))
+			} else {
+				gq.Arg = dinosql.GoQueryValue{
+					Emit:   true,
+					Name:   "arg",
+					Struct: r.columnsToStruct(dinosql.ParamsStructName(gq.MethodName, settings), structInfo, settings),
+				}
 			}
 		}
 
@@ -152,10 +168,10 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 				same := true
 				for i, f := range s.Fields {
 					c := query.Columns[i]
-					sameName := f.Name == dinosql.StructName(columnName(c.ColumnDefinition, i), settings)
+					sameName := f.Name == dinosql.FieldName("", c.Table, columnName(c.ColumnDefinition, i), settings)
 					sameType := f.Type == r.goTypeCol(c)
 
-					hackedFQN := core.FQN{c.Table, "", ""} // TODO: only check needed here is equality to see if struct can be reused, this type should be removed or properly used
+					hackedFQN := core.FQN{Catalog: c.Table} // TODO: only check needed here is equality to see if struct can be reused, this type should be removed or properly used
 					sameTable := s.Table.Catalog == hackedFQN.Catalog && s.Table.Schema == hackedFQN.Schema && s.Table.Rel == hackedFQN.Rel
 
 					if !sameName || !sameType || !sameTable {
@@ -174,10 +190,18 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 					structInfo[i] = structParams{
 						originalName: query.Columns[i].Name.String(),
 						goType:       r.goTypeCol(query.Columns[i]),
+						table:        query.Columns[i].Table,
 					}
 				}
 				gs = r.columnsToStruct(gq.MethodName+"Row", structInfo, settings)
 				emit = true
+				if settings.Go.ReuseRowStructs {
+					if row := dinosql.FindStruct(rows, gs); row != nil {
+						gs, emit = row, false
+					} else {
+						rows = append(rows, gs)
+					}
+				}
 			}
 			gq.Ret = dinosql.GoQueryValue{
 				Emit:   emit,
@@ -188,13 +212,15 @@ func (r *Result) GoQueries(settings config.CombinedSettings) []dinosql.GoQuery {
 
 		qs = append(qs, gq)
 	}
-	sort.Slice(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
+	sort.SliceStable(qs, func(i, j int) bool { return qs[i].MethodName < qs[j].MethodName })
 	return qs
 }
 
 type structParams struct {
 	originalName string
 	goType       string
+	tags         map[string]string
+	table        string
 }
 
 func (r *Result) columnsToStruct(name string, items []structParams, settings config.CombinedSettings) *dinosql.GoStruct {
@@ -206,7 +232,7 @@ func (r *Result) columnsToStruct(name string, items []structParams, settings con
 		name := item.originalName
 		typ := item.goType
 		tagName := name
-		fieldName := dinosql.StructName(name, settings)
+		fieldName := dinosql.FieldName("", item.table, name, settings)
 		if v := seen[name]; v > 0 {
 			tagName = fmt.Sprintf("%s_%d", tagName, v+1)
 			fieldName = fmt.Sprintf("%s_%d", fieldName, v+1)
@@ -214,35 +240,59 @@ func (r *Result) columnsToStruct(name string, items []structParams, settings con
 		gs.Fields = append(gs.Fields, dinosql.GoField{
 			Name: fieldName,
 			Type: typ,
-			Tags: map[string]string{"json:": tagName},
+			Tags: dinosql.FieldTags(tagName, item.tags, settings),
 		})
 		seen[name]++
 	}
 	return &gs
 }
 
+// columnTags returns the struct tags configured for a specific column
+func (pGen PackageGenerator) columnTags(colName, table string) map[string]string {
+	tags := map[string]string{}
+	for _, oride := range pGen.Overrides {
+		if oride.ColumnName != "" && oride.ColumnName == colName && oride.Table.Rel == table {
+			for key, val := range oride.GoStructTags {
+				tags[key] = val
+			}
+		}
+	}
+	return tags
+}
+
 func (pGen PackageGenerator) goTypeCol(col Column) string {
 	mySQLType := col.ColumnDefinition.Type.Type
 	notNull := bool(col.Type.NotNull)
 	colName := col.Name.String()
 
 	for _, oride := range pGen.Overrides {
+		if oride.GoTypeName == "" {
+			continue
+		}
 		shouldOverride := (oride.DBType != "" && oride.DBType == mySQLType && oride.Null != notNull) ||
 			(oride.ColumnName != "" && oride.ColumnName == colName && oride.Table.Rel == col.Table)
 		if shouldOverride {
 			return oride.GoTypeName
 		}
 	}
-	switch t := mySQLType; {
+	typ := pGen.goInnerTypeCol(col, notNull)
+	if pGen.Go.EmitPointersForNullTypes && !notNull && dinosql.IsNullType(strings.TrimPrefix(typ, dinosql.ModelsQualifier(pGen.CombinedSettings))) {
+		return "*" + pGen.goInnerTypeCol(col, true)
+	}
+	return typ
+}
+
+func (pGen PackageGenerator) goInnerTypeCol(col Column, notNull bool) string {
+	switch t := col.ColumnDefinition.Type.Type; {
 	case "varchar" == t, "text" == t, "char" == t,
 		"tinytext" == t, "mediumtext" == t, "longtext" == t:
-		if col.Type.NotNull {
+		if notNull {
 			return "string"
 		}
 		return "sql.NullString"
 	case "int" == t, "integer" == t, t == "smallint",
 		"mediumint" == t, "bigint" == t, "year" == t:
-		if col.Type.NotNull {
+		if notNull {
 			return "int"
 		}
 		return "sql.NullInt64"
@@ -250,19 +300,22 @@ func (pGen PackageGenerator) goTypeCol(col Column) string {
 		"mediumblob" == t, "longblob" == t:
 		return "[]byte"
 	case "float" == t, strings.HasPrefix(strings.ToLower(t), "decimal"):
-		if col.Type.NotNull {
+		if notNull {
 			return "float64"
 		}
 		return "sql.NullFloat64"
 	case "enum" == t:
-		return pGen.enumNameFromColDef(col.ColumnDefinition)
+		if notNull {
+			return dinosql.ModelsQualifier(pGen.CombinedSettings) + pGen.enumNameFromColDef(col.ColumnDefinition)
+		}
+		return dinosql.ModelsQualifier(pGen.CombinedSettings) + "Null" + pGen.enumNameFromColDef(col.ColumnDefinition)
 	case "date" == t, "timestamp" == t, "datetime" == t, "time" == t:
-		if col.Type.NotNull {
+		if notNull {
 			return "time.Time"
 		}
 		return "sql.NullTime"
 	case "boolean" == t, "bool" == t, "tinyint" == t:
-		if col.Type.NotNull {
+		if notNull {
 			return "bool"
 		}
 		return "sql.NullBool"
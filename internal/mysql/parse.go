@@ -30,7 +30,7 @@ type Column struct {
 	Table string
 }
 
-func parsePath(sqlPath string, generator PackageGenerator) (*Result, error) {
+func parsePath(sqlPath string, generator PackageGenerator, sources map[string]string) (*Result, error) {
 	files, err := dinosql.ReadSQLFiles(sqlPath)
 	if err != nil {
 		return nil, err
@@ -40,11 +40,15 @@ func parsePath(sqlPath string, generator PackageGenerator) (*Result, error) {
 
 	parsedQueries := []*Query{}
 	for _, filename := range files {
-		blob, err := ioutil.ReadFile(filename)
-		if err != nil {
-			parseErrors.Add(filename, "", 0, err)
+		source, ok := sources[filename]
+		if !ok {
+			blob, err := ioutil.ReadFile(filename)
+			if err != nil {
+				parseErrors.Add(filename, "", 0, err)
+			}
+			source = string(blob)
 		}
-		contents := dinosql.RemoveRollbackStatements(string(blob))
+		contents := dinosql.RemoveRollbackStatements(source)
 		if err != nil {
 			parseErrors.Add(filename, "", 0, err)
 			continue
@@ -464,17 +468,24 @@ func (pGen PackageGenerator) parseSelectAliasExpr(exprs sqlparser.SelectExprs, t
 
 // GeneratePkg is the main entry to mysql generator package
 func GeneratePkg(pkgName, schemaPath, querysPath string, settings config.CombinedSettings) (*Result, error) {
+	return GeneratePkgSources(pkgName, schemaPath, querysPath, settings, nil)
+}
+
+// GeneratePkgSources generates the package like GeneratePkg, but uses the
+// SQL in sources, keyed by file name, in place of the contents of those files
+// on disk. The schema or queries on dinosql.Stdin must be passed here.
+func GeneratePkgSources(pkgName, schemaPath, querysPath string, settings config.CombinedSettings, sources map[string]string) (*Result, error) {
 	s := NewSchema()
 	generator := PackageGenerator{
 		Schema:           s,
 		CombinedSettings: settings,
 		packageName:      pkgName,
 	}
-	_, err := parsePath(schemaPath, generator)
+	_, err := parsePath(schemaPath, generator, sources)
 	if err != nil {
 		return nil, err
 	}
-	result, err := parsePath(querysPath, generator)
+	result, err := parsePath(querysPath, generator, sources)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"fmt"
+	"sort"
 
 	"vitess.io/vitess/go/vt/sqlparser"
 )
@@ -19,6 +20,22 @@ type Schema struct {
 	tables map[string]([]*sqlparser.ColumnDefinition)
 }
 
+// tableNames returns the names of the schema's tables, sorted
+func (s *Schema) tableNames() []string {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Table returns the column definitions of a table, and whether it exists
+func (s *Schema) Table(name string) ([]*sqlparser.ColumnDefinition, bool) {
+	cols, ok := s.tables[name]
+	return cols, ok
+}
+
 // returns a deep copy of the column definition for using as a query return type or param type
 func (s *Schema) getColType(col *sqlparser.ColName, tableAliasMap FromTables, defaultTableName string) (*Column, error) {
 	realTable, err := tableColReferences(col, defaultTableName, tableAliasMap)
@@ -1,9 +1,28 @@
 package pg
 
+import (
+	"sort"
+	"strings"
+)
+
+// OIDs identify the schemas, tables, columns and types of a catalog. User
+// objects are numbered from FirstUserOID in the order they're created, as in
+// PostgreSQL, and keep their OID when they're renamed or altered, so the same
+// migrations always give the same OIDs. Built-in objects use PostgreSQL's
+// OIDs where it has fixed ones, and zero otherwise.
+const (
+	FirstUserOID uint32 = 16384
+
+	pgCatalogOID uint32 = 11
+	publicOID    uint32 = 2200
+)
+
 func NewCatalog() Catalog {
+	public := NewSchema()
+	public.OID = publicOID
 	return Catalog{
 		Schemas: map[string]Schema{
-			"public":     NewSchema(),
+			"public":     public,
 			"pg_catalog": pgCatalog(),
 			"sqlc":       internalSchema(),
 			// Likewise, the current session's temporary-table schema, pg_temp_nnn, is
@@ -46,6 +65,38 @@ func (f FQN) String() string {
 
 type Catalog struct {
 	Schemas map[string]Schema
+
+	// Extensions maps the extensions created by CREATE EXTENSION to the
+	// schema holding their objects
+	Extensions map[string]string `json:",omitempty"`
+
+	// NextOID is the OID given to the next object created
+	NextOID uint32 `json:"-"`
+}
+
+// NewOID returns an OID for a newly created object
+func (c *Catalog) NewOID() uint32 {
+	if c.NextOID < FirstUserOID {
+		c.NextOID = FirstUserOID
+	}
+	oid := c.NextOID
+	c.NextOID++
+	return oid
+}
+
+// Clone returns a deep copy of c, which can be changed without changing c
+func (c Catalog) Clone() Catalog {
+	clone := Catalog{Schemas: make(map[string]Schema, len(c.Schemas)), NextOID: c.NextOID}
+	for name, schema := range c.Schemas {
+		clone.Schemas[name] = schema.clone()
+	}
+	if c.Extensions != nil {
+		clone.Extensions = make(map[string]string, len(c.Extensions))
+		for name, schema := range c.Extensions {
+			clone.Extensions[name] = schema
+		}
+	}
+	return clone
 }
 
 func (c Catalog) LookupFunctions(fqn FQN) ([]Function, error) {
@@ -91,14 +142,133 @@ func (c Catalog) LookupFunctionN(fqn FQN, argn int) (Function, error) {
 	return Function{}, ErrorRelationDoesNotExist(fqn.Rel)
 }
 
+// LookupFunctionArgs returns the function fqn whose arguments have the types
+// args. An empty type matches any argument, and anyrange and anymultirange
+// match any range or multirange, whose type gives the type of a polymorphic
+// result: the range's element type for anyelement.
+func (c Catalog) LookupFunctionArgs(fqn FQN, args []string) (Function, error) {
+	funs, err := c.LookupFunctions(fqn)
+	if err != nil {
+		return Function{}, err
+	}
+	for _, fun := range funs {
+		if fun.Arguments == nil || len(fun.Arguments) != len(args) {
+			continue
+		}
+		match := true
+		var rng string
+		for i, arg := range fun.Arguments {
+			typ := strings.TrimPrefix(args[i], "pg_catalog.")
+			if typ == "" {
+				continue
+			}
+			switch arg.DataType {
+			case "anyrange", "anymultirange":
+				r, ok := rangeOf(typ)
+				multirange := r != typ
+				if !ok || multirange != (arg.DataType == "anymultirange") {
+					match = false
+				}
+				rng = r
+			default:
+				if typ != strings.TrimPrefix(arg.DataType, "pg_catalog.") {
+					match = false
+				}
+			}
+		}
+		if !match {
+			continue
+		}
+		switch fun.ReturnType {
+		case "anyelement", "anyrange", "anymultirange":
+			if rng == "" {
+				// The result's type can't be known without the range's
+				continue
+			}
+			fun.ReturnType = map[string]string{
+				"anyelement":    rangeTypes[rng],
+				"anyrange":      rng,
+				"anymultirange": multirangeOf(rng),
+			}[fun.ReturnType]
+		}
+		return fun, nil
+	}
+	return Function{}, ErrorRelationDoesNotExist(fqn.Rel)
+}
+
+// LookupOperator returns the operator name with a left operand of type left
+// and, unless it's empty, a right operand of type right. Operators are found
+// in pg_catalog and public, as they're rarely schema-qualified. The operand
+// types may be qualified with the operator's schema, and built-in types may
+// be qualified with pg_catalog on either side.
+func (c Catalog) LookupOperator(name, left, right string) (Operator, bool) {
+	for _, schema := range []string{"pg_catalog", "public"} {
+		for _, op := range c.Schemas[schema].Operators {
+			if op.Name != name || !operandType(schema, left, op.Left) {
+				continue
+			}
+			if right == "" || operandType(schema, right, op.Right) {
+				return op, true
+			}
+		}
+	}
+	return Operator{}, false
+}
+
+// operandType reports whether typ is the type want of an operator in schema
+func operandType(schema, typ, want string) bool {
+	typ = strings.TrimPrefix(typ, "pg_catalog.")
+	want = strings.TrimPrefix(want, "pg_catalog.")
+	return typ == want || typ == schema+"."+want
+}
+
 type Schema struct {
-	Name    string
-	Tables  map[string]Table
-	Types   map[string]Type
-	Funcs   map[string][]Function
-	Comment string
+	OID       uint32
+	Name      string
+	Tables    map[string]Table
+	Types     map[string]Type
+	Funcs     map[string][]Function
+	Operators []Operator
+	Comment   string
+}
+
+func (s Schema) clone() Schema {
+	clone := s
+	clone.Tables = make(map[string]Table, len(s.Tables))
+	for name, table := range s.Tables {
+		table.Columns = append([]Column(nil), table.Columns...)
+		table.ForeignKeys = append([]ForeignKey(nil), table.ForeignKeys...)
+		for i, fk := range table.ForeignKeys {
+			table.ForeignKeys[i].Columns = append([]string(nil), fk.Columns...)
+			table.ForeignKeys[i].RefColumns = append([]string(nil), fk.RefColumns...)
+		}
+		table.Indexes = append([]Index(nil), table.Indexes...)
+		for i, index := range table.Indexes {
+			table.Indexes[i].Columns = append([]string(nil), index.Columns...)
+		}
+		clone.Tables[name] = table
+	}
+	clone.Types = make(map[string]Type, len(s.Types))
+	for name, typ := range s.Types {
+		if enum, ok := typ.(Enum); ok {
+			enum.Vals = append([]string(nil), enum.Vals...)
+			typ = enum
+		}
+		clone.Types[name] = typ
+	}
+	clone.Funcs = make(map[string][]Function, len(s.Funcs))
+	for name, funcs := range s.Funcs {
+		funcs = append([]Function(nil), funcs...)
+		for i, fn := range funcs {
+			funcs[i].Arguments = append([]Argument(nil), fn.Arguments...)
+		}
+		clone.Funcs[name] = funcs
+	}
+	clone.Operators = append([]Operator(nil), s.Operators...)
+	return clone
 }
 
+// Enums returns the enums of the schema, sorted by name
 func (s Schema) Enums() []Enum {
 	var enums []Enum
 	for _, typ := range s.Types {
@@ -106,22 +276,68 @@ func (s Schema) Enums() []Enum {
 			enums = append(enums, enum)
 		}
 	}
+	sort.Slice(enums, func(i, j int) bool { return enums[i].Name < enums[j].Name })
 	return enums
 }
 
+// TableNames returns the names of the schema's tables, sorted
+func (s Schema) TableNames() []string {
+	names := make([]string, 0, len(s.Tables))
+	for name := range s.Tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaNames returns the names of the catalog's schemas, sorted
+func (c Catalog) SchemaNames() []string {
+	names := make([]string, 0, len(c.Schemas))
+	for name := range c.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type Table struct {
-	ID      FQN
+	OID         uint32
+	ID          FQN
+	Name        string
+	Columns     []Column
+	ForeignKeys []ForeignKey
+	Indexes     []Index
+	Comment     string
+}
+
+// An Index is created by CREATE INDEX or a PRIMARY KEY or UNIQUE constraint.
+// Columns holds an empty string for each indexed expression.
+type Index struct {
 	Name    string
-	Columns []Column
-	Comment string
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+// ForeignKey records a REFERENCES constraint from Columns to RefColumns of
+// RefTable. RefColumns is empty if the constraint refers to the primary key.
+type ForeignKey struct {
+	Columns    []string
+	RefTable   FQN
+	RefColumns []string
 }
 
 type Column struct {
+	OID      uint32
 	Name     string
 	DataType string
-	NotNull  bool
-	IsArray  bool
-	Comment  string
+	// Typmods holds the type modifiers as written, e.g. 10 and 2 for
+	// numeric(10,2). An interval's first modifier is the mask of its fields.
+	Typmods    []int32
+	NotNull    bool
+	IsArray    bool
+	HasDefault bool
+	Comment    string
 
 	// XXX: Figure out what PostgreSQL calls `foo.id`
 	Scope string
@@ -133,6 +349,7 @@ type Type interface {
 }
 
 type Enum struct {
+	OID     uint32
 	Name    string
 	Vals    []string
 	Comment string
@@ -142,12 +359,36 @@ func (e Enum) isType() {
 }
 
 type CompositeType struct {
+	OID  uint32
 	Name string
 }
 
 func (e CompositeType) isType() {
 }
 
+// A Domain is a type created by CREATE DOMAIN, whose values are those of its
+// base type which pass its constraints
+type Domain struct {
+	OID      uint32
+	Name     string
+	BaseType string
+	IsArray  bool
+	NotNull  bool
+}
+
+func (e Domain) isType() {
+}
+
+// A BaseType is a type created by an extension, such as PostGIS's geometry
+type BaseType struct {
+	OID       uint32
+	Name      string
+	Extension string
+}
+
+func (e BaseType) isType() {
+}
+
 type Function struct {
 	Name       string
 	ArgN       int
@@ -155,6 +396,22 @@ type Function struct {
 	ReturnType string
 	Comment    string
 	Desc       string
+
+	// Nullable is set when the result may be NULL though the arguments
+	// aren't, as for the lower bound of an unbounded range
+	Nullable bool
+}
+
+// An Operator is a binary operator. Array types end in [].
+type Operator struct {
+	Name       string
+	Left       string
+	Right      string
+	ReturnType string
+
+	// Nullable is set when the result may be NULL though neither operand is,
+	// as for the value of a missing key
+	Nullable bool
 }
 
 type Argument struct {
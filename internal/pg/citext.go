@@ -0,0 +1,90 @@
+package pg
+
+// citextExtension returns the type, functions and operators of the citext
+// extension, a case-insensitive character string type. Its functions are the
+// case-insensitive forms of the text functions of the same name.
+//
+// https://www.postgresql.org/docs/current/citext.html
+func citextExtension() Extension {
+	c := func(names ...string) []Argument {
+		args := make([]Argument, len(names))
+		for i, name := range names {
+			args[i] = Argument{Name: name, DataType: "citext"}
+		}
+		return args
+	}
+	text := func(name string) Argument {
+		return Argument{Name: name, DataType: "text"}
+	}
+	var ops []Operator
+	for _, name := range []string{"=", "<>", "<", "<=", ">", ">="} {
+		ops = append(ops,
+			Operator{Name: name, Left: "citext", Right: "citext", ReturnType: "bool"},
+			Operator{Name: name, Left: "citext", Right: "text", ReturnType: "bool"},
+			Operator{Name: name, Left: "text", Right: "citext", ReturnType: "bool"},
+		)
+	}
+	return Extension{
+		Types: []string{"citext"},
+
+		// F.10.3. Function and Operator Behaviors. min and max return NULL
+		// when there are no rows.
+		Funcs: []Function{
+			{
+				Name:       "min",
+				ReturnType: "citext",
+				Arguments:  c("value"),
+				Nullable:   true,
+			},
+			{
+				Name:       "max",
+				ReturnType: "citext",
+				Arguments:  c("value"),
+				Nullable:   true,
+			},
+			{
+				Name:       "strpos",
+				ReturnType: "integer",
+				Arguments:  c("string", "substring"),
+			},
+			{
+				Name:       "replace",
+				ReturnType: "text",
+				Arguments:  append(c("string", "from"), text("to")),
+			},
+			{
+				Name:       "split_part",
+				ReturnType: "text",
+				Arguments:  append(c("string", "delimiter"), Argument{Name: "field", DataType: "integer"}),
+			},
+			{
+				Name:       "translate",
+				ReturnType: "text",
+				Arguments:  append(c("string", "from"), text("to")),
+			},
+			{
+				Name:       "regexp_match",
+				ReturnType: "text[]",
+				Arguments:  c("string", "pattern"),
+			},
+			{
+				Name:       "regexp_matches",
+				ReturnType: "text[]",
+				Arguments:  c("string", "pattern"),
+			},
+			{
+				Name:       "regexp_replace",
+				ReturnType: "text",
+				Arguments:  append(c("string", "pattern"), text("replacement")),
+			},
+			{
+				Name:       "regexp_split_to_array",
+				ReturnType: "text[]",
+				Arguments:  c("string", "pattern"),
+			},
+		},
+
+		// Comparisons ignore case, and return the same types as for text
+		Operators: ops,
+	}
+}
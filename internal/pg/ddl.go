@@ -0,0 +1,56 @@
+package pg
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DDL returns the statements which create the schemas, enums, tables and
+// indexes of c, in an order PostgreSQL and the catalog can both read back.
+// Primary keys are declared with their tables, and other indexes, including
+// those backing unique constraints, are created as indexes. Defaults, checks
+// and foreign keys aren't recorded by the catalog, so are left out, as are
+// indexes on expressions.
+func DDL(c Catalog) []string {
+	var schemas, enums, tables, indexes []string
+	for _, name := range schemaNames(c) {
+		if name != "public" {
+			schemas = append(schemas, "CREATE SCHEMA "+QuoteIdent(name))
+		}
+		schema := c.Schemas[name]
+		for _, enum := range schema.Enums() {
+			fqn := FQN{Schema: name, Rel: enum.Name}
+			enums = append(enums, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", qualify(fqn), literals(enum.Vals)))
+		}
+		for _, table := range sortedTables(schema) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			sorted := append([]Index{}, table.Indexes...)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+			for _, index := range sorted {
+				if index.Primary || index.Name == "" || onExpression(index) {
+					continue
+				}
+				create := "CREATE INDEX "
+				if index.Unique {
+					create = "CREATE UNIQUE INDEX "
+				}
+				indexes = append(indexes, fmt.Sprintf("%s%s ON %s (%s)", create, QuoteIdent(index.Name), qualify(fqn), quoteIdents(index.Columns)))
+			}
+			tables = append(tables, createTable(fqn, table, primaryKey(table)))
+		}
+	}
+	stmts := append(schemas, enums...)
+	stmts = append(stmts, tables...)
+	return append(stmts, indexes...)
+}
+
+// onExpression reports whether an index has a column which is an expression,
+// which the catalog records as an empty name
+func onExpression(index Index) bool {
+	for _, col := range index.Columns {
+		if col == "" {
+			return true
+		}
+	}
+	return false
+}
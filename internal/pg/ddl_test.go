@@ -0,0 +1,47 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDDL(t *testing.T) {
+	users := FQN{Schema: "public", Rel: "users"}
+	c := NewCatalog()
+	c.Schemas["public"].Types["status"] = Enum{Name: "status", Vals: []string{"open", "it's closed"}}
+	c.Schemas["public"].Tables["users"] = Table{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "pg_catalog.int4", NotNull: true, Table: users},
+			{Name: "email", DataType: "text", NotNull: true, Table: users},
+			{Name: "state", DataType: "status", Table: users},
+			{Name: "tags", DataType: "text", IsArray: true, Table: users},
+		},
+		Indexes: []Index{
+			{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+			{Name: "users_lower_email", Columns: []string{""}},
+			{Name: "users_state_tags", Columns: []string{"state", "tags"}},
+		},
+	}
+	c.Schemas["audit"] = NewSchema()
+	c.Schemas["audit"].Tables["events"] = Table{
+		Name: "events",
+		Columns: []Column{
+			{Name: "At", DataType: "pg_catalog.timestamp", NotNull: true},
+		},
+	}
+
+	expected := []string{
+		"CREATE SCHEMA audit",
+		"CREATE TYPE status AS ENUM ('open', 'it''s closed')",
+		"CREATE TABLE audit.events (\n  \"At\" pg_catalog.timestamp NOT NULL\n)",
+		"CREATE TABLE users (\n  id pg_catalog.int4 NOT NULL,\n  email text NOT NULL,\n  state status,\n  tags text[],\n  PRIMARY KEY (id)\n)",
+		"CREATE UNIQUE INDEX users_email_key ON users (email)",
+		"CREATE INDEX users_state_tags ON users (state, tags)",
+	}
+	if diff := cmp.Diff(expected, DDL(c)); diff != "" {
+		t.Errorf("ddl mismatch (-want +got):\n%s", diff)
+	}
+}
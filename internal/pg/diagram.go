@@ -0,0 +1,102 @@
+package pg
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Mermaid renders the tables in c as a Mermaid entity relationship diagram.
+func Mermaid(c Catalog) string {
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+	for _, name := range schemaNames(c) {
+		for _, table := range sortedTables(c.Schemas[name]) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			fmt.Fprintf(&b, "  %s {\n", mermaidName(fqn))
+			for _, col := range table.Columns {
+				fmt.Fprintf(&b, "    %s %s", mermaidName(FQN{Rel: displayType(col)}), col.Name)
+				if isForeignKey(table, col.Name) {
+					b.WriteString(" FK")
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("  }\n")
+		}
+	}
+	for _, name := range schemaNames(c) {
+		for _, table := range sortedTables(c.Schemas[name]) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			for _, fk := range table.ForeignKeys {
+				fmt.Fprintf(&b, "  %s }o--|| %s : %q\n", mermaidName(fqn), mermaidName(fk.RefTable), strings.Join(fk.Columns, ", "))
+			}
+		}
+	}
+	return b.String()
+}
+
+// Dot renders the tables in c as a Graphviz graph, with an edge for each
+// foreign key.
+func Dot(c Catalog) string {
+	var b strings.Builder
+	b.WriteString("digraph schema {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=plaintext];\n")
+	for _, name := range schemaNames(c) {
+		for _, table := range sortedTables(c.Schemas[name]) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			fmt.Fprintf(&b, "  %q [label=<<table border=\"0\" cellborder=\"1\" cellspacing=\"0\">", qualify(fqn))
+			fmt.Fprintf(&b, "<tr><td><b>%s</b></td></tr>", html.EscapeString(qualify(fqn)))
+			for _, col := range table.Columns {
+				fmt.Fprintf(&b, "<tr><td port=%q align=\"left\">%s %s</td></tr>", col.Name, html.EscapeString(col.Name), html.EscapeString(displayType(col)))
+			}
+			b.WriteString("</table>>];\n")
+		}
+	}
+	for _, name := range schemaNames(c) {
+		for _, table := range sortedTables(c.Schemas[name]) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			for _, fk := range table.ForeignKeys {
+				for i, col := range fk.Columns {
+					to := fmt.Sprintf("%q", qualify(fk.RefTable))
+					if i < len(fk.RefColumns) {
+						to += fmt.Sprintf(":%q", fk.RefColumns[i])
+					}
+					fmt.Fprintf(&b, "  %q:%q -> %s;\n", qualify(fqn), col, to)
+				}
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func isForeignKey(table Table, column string) bool {
+	for _, fk := range table.ForeignKeys {
+		for _, col := range fk.Columns {
+			if col == column {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// displayType returns the type of col without the pg_catalog schema.
+func displayType(col Column) string {
+	typ := strings.TrimPrefix(col.DataType, "pg_catalog.")
+	if col.IsArray {
+		typ += "[]"
+	}
+	return typ
+}
+
+// mermaidName returns a name which Mermaid accepts as an entity or attribute
+// type. Mermaid doesn't allow dots or spaces in either.
+func mermaidName(fqn FQN) string {
+	name := fqn.Rel
+	if fqn.Schema != "" && fqn.Schema != "public" {
+		name = fqn.Schema + "_" + fqn.Rel
+	}
+	return strings.NewReplacer(".", "_", " ", "_").Replace(name)
+}
@@ -0,0 +1,51 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiagram(t *testing.T) {
+	c := NewCatalog()
+	c.Schemas["public"].Tables["users"] = Table{
+		Name:    "users",
+		Columns: []Column{{Name: "id", DataType: "serial", NotNull: true}},
+	}
+	c.Schemas["public"].Tables["pets"] = Table{
+		Name: "pets",
+		Columns: []Column{
+			{Name: "owner", DataType: "pg_catalog.int4"},
+			{Name: "tags", DataType: "text", IsArray: true},
+		},
+		ForeignKeys: []ForeignKey{
+			{Columns: []string{"owner"}, RefTable: FQN{Schema: "public", Rel: "users"}, RefColumns: []string{"id"}},
+		},
+	}
+
+	mermaid := `erDiagram
+  pets {
+    int4 owner FK
+    text[] tags
+  }
+  users {
+    serial id
+  }
+  pets }o--|| users : "owner"
+`
+	if diff := cmp.Diff(mermaid, Mermaid(c)); diff != "" {
+		t.Errorf("mermaid mismatch (-want +got):\n%s", diff)
+	}
+
+	dot := `digraph schema {
+  rankdir=LR;
+  node [shape=plaintext];
+  "pets" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td><b>pets</b></td></tr><tr><td port="owner" align="left">owner int4</td></tr><tr><td port="tags" align="left">tags text[]</td></tr></table>>];
+  "users" [label=<<table border="0" cellborder="1" cellspacing="0"><tr><td><b>users</b></td></tr><tr><td port="id" align="left">id serial</td></tr></table>>];
+  "pets":"owner" -> "users":"id";
+}
+`
+	if diff := cmp.Diff(dot, Dot(c)); diff != "" {
+		t.Errorf("dot mismatch (-want +got):\n%s", diff)
+	}
+}
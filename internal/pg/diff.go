@@ -0,0 +1,319 @@
+package pg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/kyleconroy/sqlc/internal/postgres"
+)
+
+// Diff returns the statements which migrate the schemas, tables, columns,
+// primary keys and enums of old to those of new. Functions, composite types
+// and other indexes are ignored, as are defaults, checks and foreign keys,
+// which the catalog doesn't record.
+func Diff(old, new Catalog) []string {
+	var creates, alters, drops []string
+
+	for _, name := range schemaNames(new) {
+		if _, ok := old.Schemas[name]; !ok {
+			creates = append(creates, "CREATE SCHEMA "+QuoteIdent(name))
+		}
+	}
+
+	for _, name := range schemaNames(new) {
+		newSchema, oldSchema := new.Schemas[name], old.Schemas[name]
+		for _, enum := range newSchema.Enums() {
+			fqn := FQN{Schema: name, Rel: enum.Name}
+			prev, ok := oldSchema.Types[enum.Name].(Enum)
+			if !ok {
+				creates = append(creates, fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", qualify(fqn), literals(enum.Vals)))
+				continue
+			}
+			alters = append(alters, diffEnum(fqn, prev, enum)...)
+		}
+		for _, table := range sortedTables(newSchema) {
+			fqn := FQN{Schema: name, Rel: table.Name}
+			prev, ok := oldSchema.Tables[table.Name]
+			if !ok {
+				creates = append(creates, createTable(fqn, table, primaryKey(table)))
+				continue
+			}
+			alters = append(alters, diffTable(fqn, prev, table)...)
+		}
+	}
+
+	for _, name := range schemaNames(old) {
+		newSchema, ok := new.Schemas[name]
+		oldSchema := old.Schemas[name]
+		for _, table := range sortedTables(oldSchema) {
+			if _, exists := newSchema.Tables[table.Name]; !exists {
+				drops = append(drops, "DROP TABLE "+qualify(FQN{Schema: name, Rel: table.Name}))
+			}
+		}
+		for _, enum := range oldSchema.Enums() {
+			if _, exists := newSchema.Types[enum.Name].(Enum); !exists {
+				drops = append(drops, "DROP TYPE "+qualify(FQN{Schema: name, Rel: enum.Name}))
+			}
+		}
+		if !ok {
+			drops = append(drops, "DROP SCHEMA "+QuoteIdent(name))
+		}
+	}
+
+	stmts := append(creates, alters...)
+	return append(stmts, drops...)
+}
+
+func diffEnum(fqn FQN, old, new Enum) []string {
+	var stmts []string
+	existing := map[string]bool{}
+	for _, v := range old.Vals {
+		existing[v] = true
+	}
+	for i, v := range new.Vals {
+		if existing[v] {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", qualify(fqn), literal(v))
+		if i > 0 {
+			stmt += " AFTER " + literal(new.Vals[i-1])
+		} else if len(new.Vals) > 1 {
+			stmt += " BEFORE " + literal(new.Vals[1])
+		}
+		stmts = append(stmts, stmt)
+	}
+	current := map[string]bool{}
+	for _, v := range new.Vals {
+		current[v] = true
+	}
+	for _, v := range old.Vals {
+		if !current[v] {
+			// PostgreSQL has no way to remove a value from an enum
+			stmts = append(stmts, fmt.Sprintf("-- remove value %s from %s", literal(v), qualify(fqn)))
+		}
+	}
+	return stmts
+}
+
+// createTable returns the CREATE TABLE statement for table, with a primary
+// key of the columns in key if there are any
+func createTable(fqn FQN, table Table, key []string) string {
+	cols := make([]string, len(table.Columns))
+	for i, col := range table.Columns {
+		cols[i] = "  " + QuoteIdent(col.Name) + " " + columnType(col, false)
+		if col.NotNull && !isSerial(col.DataType) {
+			cols[i] += " NOT NULL"
+		}
+	}
+	if len(key) > 0 {
+		cols = append(cols, "  PRIMARY KEY ("+quoteIdents(key)+")")
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n)", qualify(fqn), strings.Join(cols, ",\n"))
+}
+
+// primaryKey returns the columns of table's primary key, or nil if it has
+// none or the key includes an expression
+func primaryKey(table Table) []string {
+	for _, index := range table.Indexes {
+		if index.Primary && !onExpression(index) {
+			return index.Columns
+		}
+	}
+	return nil
+}
+
+func diffTable(fqn FQN, old, new Table) []string {
+	alter := "ALTER TABLE " + qualify(fqn) + " "
+	var stmts []string
+	oldKey, newKey := primaryKey(old), primaryKey(new)
+	keyChanged := quoteIdents(oldKey) != quoteIdents(newKey)
+	if keyChanged && oldKey != nil {
+		for _, index := range old.Indexes {
+			if index.Primary && index.Name != "" {
+				stmts = append(stmts, alter+"DROP CONSTRAINT "+QuoteIdent(index.Name))
+			}
+		}
+	}
+	prev := map[string]Column{}
+	for _, col := range old.Columns {
+		prev[col.Name] = col
+	}
+	for _, col := range new.Columns {
+		name := QuoteIdent(col.Name)
+		p, ok := prev[col.Name]
+		if !ok {
+			stmt := alter + "ADD COLUMN " + name + " " + columnType(col, false)
+			if col.NotNull && !isSerial(col.DataType) {
+				stmt += " NOT NULL"
+			}
+			stmts = append(stmts, stmt)
+			continue
+		}
+		if columnType(p, true) != columnType(col, true) {
+			stmts = append(stmts, alter+"ALTER COLUMN "+name+" TYPE "+columnType(col, true))
+		}
+		if p.NotNull != col.NotNull {
+			if col.NotNull {
+				stmts = append(stmts, alter+"ALTER COLUMN "+name+" SET NOT NULL")
+			} else {
+				stmts = append(stmts, alter+"ALTER COLUMN "+name+" DROP NOT NULL")
+			}
+		}
+	}
+	current := map[string]bool{}
+	for _, col := range new.Columns {
+		current[col.Name] = true
+	}
+	for _, col := range old.Columns {
+		if !current[col.Name] {
+			stmts = append(stmts, alter+"DROP COLUMN "+QuoteIdent(col.Name))
+		}
+	}
+	if keyChanged && newKey != nil {
+		stmts = append(stmts, alter+"ADD PRIMARY KEY ("+quoteIdents(newKey)+")")
+	}
+	return stmts
+}
+
+// columnType returns the SQL type of col, including its modifiers. Serial
+// types are only valid when creating a column, so alter uses the underlying
+// integer type instead.
+func columnType(col Column, alter bool) string {
+	typ := col.DataType
+	if alter {
+		switch strings.ToLower(typ) {
+		case "smallserial", "serial2":
+			typ = "pg_catalog.int2"
+		case "serial", "serial4":
+			typ = "pg_catalog.int4"
+		case "bigserial", "serial8":
+			typ = "pg_catalog.int8"
+		}
+	}
+	mods := col.Typmods
+	if typ == "pg_catalog.interval" && len(mods) > 0 {
+		// Interval fields are only understood after the INTERVAL keyword
+		if fields := intervalFields(mods[0]); fields != "" {
+			typ = "interval " + fields
+		}
+		mods = mods[1:]
+	}
+	if len(mods) > 0 {
+		args := make([]string, len(mods))
+		for i, mod := range mods {
+			args[i] = strconv.Itoa(int(mod))
+		}
+		typ += "(" + strings.Join(args, ",") + ")"
+	}
+	if col.IsArray {
+		typ += "[]"
+	}
+	return typ
+}
+
+// The bits of an interval's field mask, in order from YEAR to SECOND
+var intervalBits = []struct {
+	bit  uint
+	name string
+}{
+	{2, "YEAR"},
+	{1, "MONTH"},
+	{3, "DAY"},
+	{10, "HOUR"},
+	{11, "MINUTE"},
+	{12, "SECOND"},
+}
+
+// intervalFields returns the fields of an interval's mask, e.g. DAY TO
+// SECOND, or an empty string for all of them
+func intervalFields(mask int32) string {
+	if mask == 0x7fff {
+		return ""
+	}
+	var first, last string
+	for _, b := range intervalBits {
+		if mask&(1<<b.bit) == 0 {
+			continue
+		}
+		if first == "" {
+			first = b.name
+		}
+		last = b.name
+	}
+	if first == last {
+		return first
+	}
+	return first + " TO " + last
+}
+
+func isSerial(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "smallserial", "serial2", "serial", "serial4", "bigserial", "serial8":
+		return true
+	}
+	return false
+}
+
+// schemaNames returns the user defined schemas in c, in name order
+func schemaNames(c Catalog) []string {
+	var names []string
+	for name := range c.Schemas {
+		switch name {
+		case "pg_catalog", "pg_temp", "sqlc":
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedTables(s Schema) []Table {
+	var tables []Table
+	for _, t := range s.Tables {
+		tables = append(tables, t)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+	return tables
+}
+
+var plainIdent = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// QuoteIdent returns name as an identifier PostgreSQL reads back unchanged,
+// quoting it if it isn't lower case or is a reserved keyword
+func QuoteIdent(name string) string {
+	if plainIdent.MatchString(name) && !postgres.IsReservedKeyword(name) {
+		return name
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+func qualify(fqn FQN) string {
+	if fqn.Schema == "" || fqn.Schema == "public" {
+		return QuoteIdent(fqn.Rel)
+	}
+	return QuoteIdent(fqn.Schema) + "." + QuoteIdent(fqn.Rel)
+}
+
+func quoteIdents(names []string) string {
+	out := make([]string, len(names))
+	for i, name := range names {
+		out[i] = QuoteIdent(name)
+	}
+	return strings.Join(out, ", ")
+}
+
+func literal(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+func literals(vals []string) string {
+	out := make([]string, len(vals))
+	for i, v := range vals {
+		out[i] = literal(v)
+	}
+	return strings.Join(out, ", ")
+}
@@ -0,0 +1,99 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiff(t *testing.T) {
+	users := FQN{Schema: "public", Rel: "users"}
+	old := NewCatalog()
+	old.Schemas["public"].Types["status"] = Enum{Name: "status", Vals: []string{"open", "closed"}}
+	old.Schemas["public"].Tables["users"] = Table{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "serial", NotNull: true, Table: users},
+			{Name: "name", DataType: "text", Table: users},
+			{Name: "age", DataType: "pg_catalog.int4", Table: users},
+		},
+	}
+	old.Schemas["public"].Tables["logs"] = Table{Name: "logs"}
+
+	new := NewCatalog()
+	new.Schemas["public"].Types["status"] = Enum{Name: "status", Vals: []string{"draft", "open", "closed"}}
+	new.Schemas["public"].Tables["users"] = Table{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "pg_catalog.int4", NotNull: true, Table: users},
+			{Name: "name", DataType: "text", NotNull: true, Table: users},
+			{Name: "tags", DataType: "text", NotNull: true, IsArray: true, Table: users},
+		},
+	}
+	new.Schemas["audit"] = NewSchema()
+	new.Schemas["audit"].Tables["events"] = Table{
+		Name: "events",
+		Columns: []Column{
+			{Name: "At", DataType: "pg_catalog.timestamp", NotNull: true},
+		},
+	}
+
+	expected := []string{
+		"CREATE SCHEMA audit",
+		"CREATE TABLE audit.events (\n  \"At\" pg_catalog.timestamp NOT NULL\n)",
+		"ALTER TYPE status ADD VALUE 'draft' BEFORE 'open'",
+		"ALTER TABLE users ALTER COLUMN name SET NOT NULL",
+		"ALTER TABLE users ADD COLUMN tags text[] NOT NULL",
+		"ALTER TABLE users DROP COLUMN age",
+		"DROP TABLE logs",
+	}
+	if diff := cmp.Diff(expected, Diff(old, new)); diff != "" {
+		t.Errorf("diff mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffKeysAndModifiers(t *testing.T) {
+	order := FQN{Schema: "public", Rel: "order"}
+	prices := FQN{Schema: "public", Rel: "prices"}
+	old := NewCatalog()
+	old.Schemas["public"].Tables["prices"] = Table{
+		Name: "prices",
+		Columns: []Column{
+			{Name: "sku", DataType: "pg_catalog.varchar", Typmods: []int32{32}, NotNull: true, Table: prices},
+			{Name: "amount", DataType: "pg_catalog.numeric", Typmods: []int32{10, 2}, Table: prices},
+		},
+	}
+
+	new := NewCatalog()
+	new.Schemas["public"].Tables["order"] = Table{
+		Name: "order",
+		Columns: []Column{
+			{Name: "id", DataType: "bigserial", NotNull: true, Table: order},
+			{Name: "desc", DataType: "pg_catalog.varchar", Typmods: []int32{255}, Table: order},
+			{Name: "placed_at", DataType: "pg_catalog.timestamptz", Typmods: []int32{3}, Table: order},
+			{Name: "window", DataType: "pg_catalog.interval", Typmods: []int32{7176, 2}, Table: order},
+		},
+		Indexes: []Index{
+			{Name: "order_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+		},
+	}
+	new.Schemas["public"].Tables["prices"] = Table{
+		Name: "prices",
+		Columns: []Column{
+			{Name: "sku", DataType: "pg_catalog.varchar", Typmods: []int32{64}, NotNull: true, Table: prices},
+			{Name: "amount", DataType: "pg_catalog.numeric", Typmods: []int32{10, 2}, Table: prices},
+		},
+		Indexes: []Index{
+			{Name: "prices_pkey", Columns: []string{"sku"}, Unique: true, Primary: true},
+		},
+	}
+
+	expected := []string{
+		"CREATE TABLE \"order\" (\n  id bigserial,\n  \"desc\" pg_catalog.varchar(255),\n  placed_at pg_catalog.timestamptz(3),\n  \"window\" interval DAY TO SECOND(2),\n  PRIMARY KEY (id)\n)",
+		"ALTER TABLE prices ALTER COLUMN sku TYPE pg_catalog.varchar(64)",
+		"ALTER TABLE prices ADD PRIMARY KEY (sku)",
+	}
+	if diff := cmp.Diff(expected, Diff(old, new)); diff != "" {
+		t.Errorf("diff mismatch (-want +got):\n%s", diff)
+	}
+}
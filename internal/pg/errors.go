@@ -57,6 +57,13 @@ func ErrorSchemaDoesNotExist(sch string) Error {
 	}
 }
 
+func ErrorExtensionAlreadyExists(ext string) Error {
+	return Error{
+		Code:    "42710",
+		Message: fmt.Sprintf("extension \"%s\" already exists", ext),
+	}
+}
+
 func ErrorTypeAlreadyExists(typ string) Error {
 	return Error{
 		Code:    "42710",
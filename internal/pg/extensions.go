@@ -0,0 +1,27 @@
+package pg
+
+// An Extension holds the types, functions and operators which CREATE
+// EXTENSION adds to a schema
+type Extension struct {
+	Types     []string
+	Funcs     []Function
+	Operators []Operator
+}
+
+// LookupExtension returns the extension name, if the catalog knows about it.
+// Other extensions are ignored.
+func LookupExtension(name string) (Extension, bool) {
+	switch name {
+	case "citext":
+		return citextExtension(), true
+	case "hstore":
+		return hstoreExtension(), true
+	case "ltree":
+		return ltreeExtension(), true
+	case "pgcrypto":
+		return pgcryptoExtension(), true
+	case "postgis":
+		return postgisExtension(), true
+	}
+	return Extension{}, false
+}
@@ -0,0 +1,63 @@
+package pg
+
+// Binary String Functions and Operators
+//
+// https://www.postgresql.org/docs/current/functions-binarystring.html
+//
+// Table 9.12. Other Binary String Functions
+func binaryStringFunctions() []Function {
+	hash := func(name string) Function {
+		return Function{
+			Name:       name,
+			ReturnType: "bytea",
+			Arguments:  []Argument{{Name: "bytes", DataType: "bytea"}},
+		}
+	}
+	return []Function{
+		{
+			Name:       "encode",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "bytes", DataType: "bytea"},
+				{Name: "format", DataType: "text"},
+			},
+		},
+		{
+			Name:       "decode",
+			ReturnType: "bytea",
+			Arguments: []Argument{
+				{Name: "string", DataType: "text"},
+				{Name: "format", DataType: "text"},
+			},
+		},
+		{
+			Name:       "get_byte",
+			ReturnType: "integer",
+			Arguments: []Argument{
+				{Name: "bytes", DataType: "bytea"},
+				{Name: "n", DataType: "integer"},
+			},
+		},
+		hash("sha224"),
+		hash("sha256"),
+		hash("sha384"),
+		hash("sha512"),
+	}
+}
+
+// Table 9.11. SQL Binary String Functions and Operators, and Table 9.14. Bit
+// String Operators
+func binaryStringOperators() []Operator {
+	ops := []Operator{
+		{Name: "||", Left: "bytea", Right: "bytea", ReturnType: "bytea"},
+	}
+	for _, typ := range []string{"bit", "varbit"} {
+		for _, name := range []string{"||", "&", "|", "#"} {
+			ops = append(ops, Operator{Name: name, Left: typ, Right: typ, ReturnType: typ})
+		}
+		for _, name := range []string{"<<", ">>"} {
+			ops = append(ops, Operator{Name: name, Left: typ, Right: "integer", ReturnType: typ})
+		}
+	}
+	return ops
+}
@@ -0,0 +1,68 @@
+package pg
+
+// Date/Time Functions and Operators
+//
+// https://www.postgresql.org/docs/current/functions-datetime.html
+//
+// Table 9.31. Date/Time Functions
+func dateTimeFunctions() []Function {
+	interval := func(name string) Function {
+		return Function{
+			Name:       name,
+			ReturnType: "interval",
+			Arguments:  []Argument{{Name: "span", DataType: "interval"}},
+		}
+	}
+	now := func(name string) Function {
+		return Function{Name: name, ReturnType: "timestamptz"}
+	}
+	return []Function{
+		{
+			Name:       "age",
+			ReturnType: "interval",
+			Arguments:  []Argument{{Name: "ts", DataType: "timestamp"}},
+		},
+		{
+			Name:       "age",
+			ReturnType: "interval",
+			Arguments: []Argument{
+				{Name: "a", DataType: "timestamp"},
+				{Name: "b", DataType: "timestamp"},
+			},
+		},
+		interval("justify_days"),
+		interval("justify_hours"),
+		interval("justify_interval"),
+		now("clock_timestamp"),
+		now("now"),
+		now("statement_timestamp"),
+		now("transaction_timestamp"),
+	}
+}
+
+// Table 9.30. Date/Time Operators
+func dateTimeOperators() []Operator {
+	ops := []Operator{
+		{Name: "+", Left: "date", Right: "interval", ReturnType: "timestamp"},
+		{Name: "-", Left: "date", Right: "interval", ReturnType: "timestamp"},
+		{Name: "+", Left: "time", Right: "interval", ReturnType: "time"},
+		{Name: "-", Left: "time", Right: "interval", ReturnType: "time"},
+		{Name: "+", Left: "interval", Right: "interval", ReturnType: "interval"},
+		{Name: "+", Left: "interval", Right: "date", ReturnType: "timestamp"},
+		{Name: "+", Left: "interval", Right: "time", ReturnType: "time"},
+		{Name: "-", Left: "interval", Right: "interval", ReturnType: "interval"},
+		{Name: "*", Left: "interval", Right: "float8", ReturnType: "interval"},
+		{Name: "/", Left: "interval", Right: "float8", ReturnType: "interval"},
+	}
+	// Subtracting an interval comes first, as it's the usual type of a
+	// parameter on the right
+	for _, typ := range []string{"timestamp", "timestamptz"} {
+		ops = append(ops,
+			Operator{Name: "+", Left: typ, Right: "interval", ReturnType: typ},
+			Operator{Name: "-", Left: typ, Right: "interval", ReturnType: typ},
+			Operator{Name: "-", Left: typ, Right: typ, ReturnType: "interval"},
+			Operator{Name: "+", Left: "interval", Right: typ, ReturnType: typ},
+		)
+	}
+	return ops
+}
@@ -0,0 +1,61 @@
+package pg
+
+// Network Address Functions and Operators
+//
+// https://www.postgresql.org/docs/current/functions-net.html
+//
+// Table 9.40. IP Address Functions
+func networkFunctions() []Function {
+	inet := func(name, ret string) Function {
+		return Function{
+			Name:       name,
+			ReturnType: ret,
+			Arguments:  []Argument{{Name: "addr", DataType: "inet"}},
+		}
+	}
+	return []Function{
+		inet("abbrev", "text"),
+		inet("broadcast", "inet"),
+		inet("family", "integer"),
+		inet("host", "text"),
+		inet("hostmask", "inet"),
+		inet("masklen", "integer"),
+		inet("netmask", "inet"),
+		inet("network", "cidr"),
+		{
+			Name:       "set_masklen",
+			ReturnType: "inet",
+			Arguments: []Argument{
+				{Name: "addr", DataType: "inet"},
+				{Name: "len", DataType: "integer"},
+			},
+		},
+		{
+			Name:       "inet_same_family",
+			ReturnType: "bool",
+			Arguments: []Argument{
+				{Name: "a", DataType: "inet"},
+				{Name: "b", DataType: "inet"},
+			},
+		},
+		{
+			Name:       "inet_merge",
+			ReturnType: "cidr",
+			Arguments: []Argument{
+				{Name: "a", DataType: "inet"},
+				{Name: "b", DataType: "inet"},
+			},
+		},
+	}
+}
+
+// Table 9.39. IP Address Operators
+func networkOperators() []Operator {
+	var ops []Operator
+	for _, typ := range []string{"inet", "cidr"} {
+		for _, name := range []string{"<<", "<<=", ">>", ">>=", "&&"} {
+			ops = append(ops, Operator{Name: name, Left: typ, Right: "inet", ReturnType: "bool"})
+		}
+	}
+	return ops
+}
@@ -0,0 +1,123 @@
+package pg
+
+import "strings"
+
+// rangeTypes maps the built-in range types to the type of their elements
+var rangeTypes = map[string]string{
+	"int4range": "pg_catalog.int4",
+	"int8range": "pg_catalog.int8",
+	"numrange":  "pg_catalog.numeric",
+	"tsrange":   "pg_catalog.timestamp",
+	"tstzrange": "pg_catalog.timestamptz",
+	"daterange": "date",
+}
+
+// rangeNames lists rangeTypes in a fixed order
+var rangeNames = []string{"int4range", "int8range", "numrange", "tsrange", "tstzrange", "daterange"}
+
+// multirangeOf returns the multirange type of a range, such as int4multirange
+// for int4range
+func multirangeOf(rng string) string {
+	return strings.TrimSuffix(rng, "range") + "multirange"
+}
+
+// rangeOf returns the range type of typ, which is either a range or a
+// multirange, and whether it is one
+func rangeOf(typ string) (string, bool) {
+	typ = strings.TrimPrefix(typ, "pg_catalog.")
+	if _, ok := rangeTypes[typ]; ok {
+		return typ, true
+	}
+	rng := strings.TrimSuffix(typ, "multirange") + "range"
+	if _, ok := rangeTypes[rng]; ok && typ == multirangeOf(rng) {
+		return rng, true
+	}
+	return "", false
+}
+
+// Range/Multirange Functions and Operators
+//
+// https://www.postgresql.org/docs/current/functions-range.html
+//
+// Table 9.57. Range Functions and Table 9.58. Multirange Functions, along
+// with the constructors of the built-in range and multirange types. Lower
+// and upper return NULL for an empty or unbounded range.
+func rangeFunctions() []Function {
+	var fs []Function
+	for _, rng := range rangeNames {
+		elem := rangeTypes[rng]
+		bounds := []Argument{
+			{Name: "lower", DataType: elem},
+			{Name: "upper", DataType: elem},
+		}
+		fs = append(fs,
+			Function{Name: rng, ReturnType: rng, Arguments: bounds},
+			Function{
+				Name:       rng,
+				ReturnType: rng,
+				Arguments:  append(bounds[:2:2], Argument{Name: "bounds", DataType: "text"}),
+			},
+			Function{Name: multirangeOf(rng), ReturnType: multirangeOf(rng), Arguments: []Argument{}},
+			Function{
+				Name:       multirangeOf(rng),
+				ReturnType: multirangeOf(rng),
+				Arguments:  []Argument{{Name: "range", DataType: rng}},
+			},
+		)
+	}
+	for _, typ := range []string{"anyrange", "anymultirange"} {
+		arg := []Argument{{Name: "range", DataType: typ}}
+		fs = append(fs,
+			Function{Name: "lower", ReturnType: "anyelement", Arguments: arg, Nullable: true},
+			Function{Name: "upper", ReturnType: "anyelement", Arguments: arg, Nullable: true},
+		)
+		for _, name := range []string{"isempty", "lower_inc", "upper_inc", "lower_inf", "upper_inf"} {
+			fs = append(fs, Function{Name: name, ReturnType: "bool", Arguments: arg})
+		}
+	}
+	return append(fs,
+		Function{
+			Name:       "range_merge",
+			ReturnType: "anyrange",
+			Arguments: []Argument{
+				{Name: "a", DataType: "anyrange"},
+				{Name: "b", DataType: "anyrange"},
+			},
+		},
+		Function{
+			Name:       "range_merge",
+			ReturnType: "anyrange",
+			Arguments:  []Argument{{Name: "range", DataType: "anymultirange"}},
+		},
+		Function{
+			Name:       "multirange",
+			ReturnType: "anymultirange",
+			Arguments:  []Argument{{Name: "range", DataType: "anyrange"}},
+		},
+	)
+}
+
+// Table 9.55. Range Operators and Table 9.56. Multirange Operators. An
+// element on the right of @> comes first, as it's the usual type of a
+// parameter there, such as a time within a tstzrange.
+func rangeOperators() []Operator {
+	var ops []Operator
+	for _, rng := range rangeNames {
+		elem := rangeTypes[rng]
+		for _, typ := range []string{rng, multirangeOf(rng)} {
+			ops = append(ops,
+				Operator{Name: "@>", Left: typ, Right: elem, ReturnType: "bool"},
+				Operator{Name: "@>", Left: typ, Right: typ, ReturnType: "bool"},
+				Operator{Name: "<@", Left: typ, Right: typ, ReturnType: "bool"},
+				Operator{Name: "<@", Left: elem, Right: typ, ReturnType: "bool"},
+			)
+			for _, name := range []string{"&&", "<<", ">>", "&<", "&>", "-|-"} {
+				ops = append(ops, Operator{Name: name, Left: typ, Right: typ, ReturnType: "bool"})
+			}
+			for _, name := range []string{"+", "*", "-"} {
+				ops = append(ops, Operator{Name: name, Left: typ, Right: typ, ReturnType: typ})
+			}
+		}
+	}
+	return ops
+}
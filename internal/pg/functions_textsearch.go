@@ -0,0 +1,118 @@
+package pg
+
+// Text Search Functions and Operators
+//
+// https://www.postgresql.org/docs/current/functions-textsearch.html
+//
+// Table 9.43. Text Search Functions
+func textSearchFunctions() []Function {
+	// Functions which parse text take an optional configuration first, such
+	// as 'english'
+	parse := func(name, ret, arg string) []Function {
+		return []Function{
+			{
+				Name:       name,
+				ReturnType: ret,
+				Arguments:  []Argument{{Name: arg, DataType: "text"}},
+			},
+			{
+				Name:       name,
+				ReturnType: ret,
+				Arguments: []Argument{
+					{Name: "config", DataType: "regconfig"},
+					{Name: arg, DataType: "text"},
+				},
+			},
+		}
+	}
+	rank := func(name string) []Function {
+		vq := []Argument{
+			{Name: "vector", DataType: "tsvector"},
+			{Name: "query", DataType: "tsquery"},
+		}
+		return []Function{
+			{
+				Name:       name,
+				ReturnType: "real",
+				Arguments:  vq,
+			},
+			{
+				Name:       name,
+				ReturnType: "real",
+				Arguments:  append(vq[:2:2], Argument{Name: "normalization", DataType: "integer"}),
+			},
+			{
+				Name:       name,
+				ReturnType: "real",
+				Arguments: append(
+					append([]Argument{{Name: "weights", DataType: "real[]"}}, vq...),
+					Argument{Name: "normalization", DataType: "integer"},
+				),
+			},
+		}
+	}
+	var fs []Function
+	fs = append(fs, parse("to_tsvector", "tsvector", "document")...)
+	fs = append(fs, parse("to_tsquery", "tsquery", "query")...)
+	fs = append(fs, parse("plainto_tsquery", "tsquery", "query")...)
+	fs = append(fs, parse("phraseto_tsquery", "tsquery", "query")...)
+	fs = append(fs, parse("websearch_to_tsquery", "tsquery", "query")...)
+	fs = append(fs, rank("ts_rank")...)
+	fs = append(fs, rank("ts_rank_cd")...)
+	return append(fs,
+		Function{
+			Name:       "ts_headline",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "document", DataType: "text"},
+				{Name: "query", DataType: "tsquery"},
+			},
+		},
+		Function{
+			Name:       "ts_headline",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "config", DataType: "regconfig"},
+				{Name: "document", DataType: "text"},
+				{Name: "query", DataType: "tsquery"},
+			},
+		},
+		Function{
+			Name:       "setweight",
+			ReturnType: "tsvector",
+			Arguments: []Argument{
+				{Name: "vector", DataType: "tsvector"},
+				{Name: "weight", DataType: "text"},
+			},
+		},
+		Function{
+			Name:       "strip",
+			ReturnType: "tsvector",
+			Arguments:  []Argument{{Name: "vector", DataType: "tsvector"}},
+		},
+		Function{
+			Name:       "numnode",
+			ReturnType: "integer",
+			Arguments:  []Argument{{Name: "query", DataType: "tsquery"}},
+		},
+		Function{
+			Name:       "querytree",
+			ReturnType: "text",
+			Arguments:  []Argument{{Name: "query", DataType: "tsquery"}},
+		},
+	)
+}
+
+// Table 9.42. Text Search Operators
+func textSearchOperators() []Operator {
+	return []Operator{
+		{Name: "@@", Left: "tsvector", Right: "tsquery", ReturnType: "bool"},
+		{Name: "@@", Left: "tsquery", Right: "tsvector", ReturnType: "bool"},
+		{Name: "@@", Left: "text", Right: "tsquery", ReturnType: "bool"},
+		{Name: "||", Left: "tsvector", Right: "tsvector", ReturnType: "tsvector"},
+		{Name: "&&", Left: "tsquery", Right: "tsquery", ReturnType: "tsquery"},
+		{Name: "||", Left: "tsquery", Right: "tsquery", ReturnType: "tsquery"},
+		{Name: "@>", Left: "tsquery", Right: "tsquery", ReturnType: "bool"},
+		{Name: "<@", Left: "tsquery", Right: "tsquery", ReturnType: "bool"},
+	}
+}
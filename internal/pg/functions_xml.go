@@ -0,0 +1,51 @@
+package pg
+
+// XML Functions
+//
+// https://www.postgresql.org/docs/current/functions-xml.html
+//
+// XMLELEMENT, XMLFOREST, XMLPARSE and the like have their own syntax, so
+// they aren't functions in the catalog.
+func xmlFunctions() []Function {
+	wellFormed := func(name string) Function {
+		return Function{
+			Name:       name,
+			ReturnType: "bool",
+			Arguments:  []Argument{{Name: "text", DataType: "text"}},
+		}
+	}
+	var fs []Function
+	for _, name := range []string{"xpath", "xpath_exists"} {
+		ret := "xml[]"
+		if name == "xpath_exists" {
+			ret = "bool"
+		}
+		args := []Argument{
+			{Name: "xpath", DataType: "text"},
+			{Name: "xml", DataType: "xml"},
+		}
+		fs = append(fs,
+			Function{Name: name, ReturnType: ret, Arguments: args},
+			Function{
+				Name:       name,
+				ReturnType: ret,
+				Arguments:  append(args[:2:2], Argument{Name: "nsarray", DataType: "text[]"}),
+			},
+		)
+	}
+	return append(fs,
+		Function{
+			Name:       "xmlcomment",
+			ReturnType: "xml",
+			Arguments:  []Argument{{Name: "text", DataType: "text"}},
+		},
+		Function{
+			Name:       "xmlagg",
+			ReturnType: "xml",
+			Arguments:  []Argument{{Name: "xml", DataType: "xml"}},
+		},
+		wellFormed("xml_is_well_formed"),
+		wellFormed("xml_is_well_formed_document"),
+		wellFormed("xml_is_well_formed_content"),
+	)
+}
@@ -0,0 +1,100 @@
+package pg
+
+// hstoreExtension returns the type, functions and operators of the hstore
+// extension, which stores sets of key/value pairs
+//
+// https://www.postgresql.org/docs/current/hstore.html
+func hstoreExtension() Extension {
+	h := func(names ...string) []Argument {
+		args := make([]Argument, len(names))
+		for i, name := range names {
+			args[i] = Argument{Name: name, DataType: "hstore"}
+		}
+		return args
+	}
+	return Extension{
+		Types: []string{"hstore"},
+
+		// Table F.7. hstore Functions
+		Funcs: []Function{
+			{
+				Name:       "hstore",
+				ReturnType: "hstore",
+				Arguments: []Argument{
+					{Name: "key", DataType: "text"},
+					{Name: "value", DataType: "text"},
+				},
+			},
+			{
+				Name:       "hstore",
+				ReturnType: "hstore",
+				Arguments:  []Argument{{Name: "pairs", DataType: "text[]"}},
+			},
+			{
+				Name:       "akeys",
+				ReturnType: "text[]",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "skeys",
+				ReturnType: "text",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "avals",
+				ReturnType: "text[]",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "svals",
+				ReturnType: "text",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "hstore_to_array",
+				ReturnType: "text[]",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "hstore_to_jsonb",
+				ReturnType: "jsonb",
+				Arguments:  h("hstore"),
+			},
+			{
+				Name:       "slice",
+				ReturnType: "hstore",
+				Arguments:  append(h("hstore"), Argument{Name: "keys", DataType: "text[]"}),
+			},
+			{
+				Name:       "exist",
+				ReturnType: "bool",
+				Arguments:  append(h("hstore"), Argument{Name: "key", DataType: "text"}),
+			},
+			{
+				Name:       "defined",
+				ReturnType: "bool",
+				Arguments:  append(h("hstore"), Argument{Name: "key", DataType: "text"}),
+			},
+			{
+				Name:       "delete",
+				ReturnType: "hstore",
+				Arguments:  append(h("hstore"), Argument{Name: "key", DataType: "text"}),
+			},
+		},
+
+		// Table F.6. hstore Operators
+		Operators: []Operator{
+			{Name: "->", Left: "hstore", Right: "text", ReturnType: "text", Nullable: true},
+			{Name: "->", Left: "hstore", Right: "text[]", ReturnType: "text[]", Nullable: true},
+			{Name: "||", Left: "hstore", Right: "hstore", ReturnType: "hstore"},
+			{Name: "?", Left: "hstore", Right: "text", ReturnType: "bool"},
+			{Name: "?&", Left: "hstore", Right: "text[]", ReturnType: "bool"},
+			{Name: "?|", Left: "hstore", Right: "text[]", ReturnType: "bool"},
+			{Name: "@>", Left: "hstore", Right: "hstore", ReturnType: "bool"},
+			{Name: "<@", Left: "hstore", Right: "hstore", ReturnType: "bool"},
+			{Name: "-", Left: "hstore", Right: "text", ReturnType: "hstore"},
+			{Name: "-", Left: "hstore", Right: "text[]", ReturnType: "hstore"},
+			{Name: "-", Left: "hstore", Right: "hstore", ReturnType: "hstore"},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package pg
+
+// ltreeExtension returns the types, functions and operators of the ltree
+// extension, which stores labels of data in a hierarchical tree-like
+// structure
+//
+// https://www.postgresql.org/docs/current/ltree.html
+func ltreeExtension() Extension {
+	l := func(names ...string) []Argument {
+		args := make([]Argument, len(names))
+		for i, name := range names {
+			args[i] = Argument{Name: name, DataType: "ltree"}
+		}
+		return args
+	}
+	integer := func(name string) Argument {
+		return Argument{Name: name, DataType: "integer"}
+	}
+	return Extension{
+		Types: []string{"ltree", "lquery", "ltxtquery"},
+
+		// Table F.13. ltree Functions
+		Funcs: []Function{
+			{
+				Name:       "subltree",
+				ReturnType: "ltree",
+				Arguments:  append(l("ltree"), integer("start"), integer("end")),
+			},
+			{
+				Name:       "subpath",
+				ReturnType: "ltree",
+				Arguments:  append(l("ltree"), integer("offset"), integer("len")),
+			},
+			{
+				Name:       "subpath",
+				ReturnType: "ltree",
+				Arguments:  append(l("ltree"), integer("offset")),
+			},
+			{
+				Name:       "nlevel",
+				ReturnType: "integer",
+				Arguments:  l("ltree"),
+			},
+			{
+				Name:       "index",
+				ReturnType: "integer",
+				Arguments:  l("a", "b"),
+			},
+			{
+				Name:       "index",
+				ReturnType: "integer",
+				Arguments:  append(l("a", "b"), integer("offset")),
+			},
+			{
+				Name:       "text2ltree",
+				ReturnType: "ltree",
+				Arguments:  []Argument{{Name: "text", DataType: "text"}},
+			},
+			{
+				Name:       "ltree2text",
+				ReturnType: "text",
+				Arguments:  l("ltree"),
+			},
+			{
+				Name:       "lca",
+				ReturnType: "ltree",
+				Arguments:  l("a", "b"),
+				Nullable:   true,
+			},
+			{
+				Name:       "lca",
+				ReturnType: "ltree",
+				Arguments:  []Argument{{Name: "paths", DataType: "ltree[]"}},
+				Nullable:   true,
+			},
+		},
+
+		// Table F.12. ltree Operators
+		Operators: []Operator{
+			{Name: "@>", Left: "ltree", Right: "ltree", ReturnType: "bool"},
+			{Name: "<@", Left: "ltree", Right: "ltree", ReturnType: "bool"},
+			{Name: "~", Left: "ltree", Right: "lquery", ReturnType: "bool"},
+			{Name: "~", Left: "lquery", Right: "ltree", ReturnType: "bool"},
+			{Name: "?", Left: "ltree", Right: "lquery[]", ReturnType: "bool"},
+			{Name: "?", Left: "lquery[]", Right: "ltree", ReturnType: "bool"},
+			{Name: "@", Left: "ltree", Right: "ltxtquery", ReturnType: "bool"},
+			{Name: "@", Left: "ltxtquery", Right: "ltree", ReturnType: "bool"},
+			{Name: "||", Left: "ltree", Right: "ltree", ReturnType: "ltree"},
+			{Name: "||", Left: "ltree", Right: "text", ReturnType: "ltree"},
+			{Name: "||", Left: "text", Right: "ltree", ReturnType: "ltree"},
+			{Name: "@>", Left: "ltree[]", Right: "ltree", ReturnType: "bool"},
+			{Name: "<@", Left: "ltree", Right: "ltree[]", ReturnType: "bool"},
+			{Name: "<@", Left: "ltree[]", Right: "ltree", ReturnType: "bool"},
+			{Name: "@>", Left: "ltree", Right: "ltree[]", ReturnType: "bool"},
+			{Name: "~", Left: "ltree[]", Right: "lquery", ReturnType: "bool"},
+			{Name: "~", Left: "lquery", Right: "ltree[]", ReturnType: "bool"},
+			{Name: "?", Left: "ltree[]", Right: "lquery[]", ReturnType: "bool"},
+			{Name: "?", Left: "lquery[]", Right: "ltree[]", ReturnType: "bool"},
+			{Name: "@", Left: "ltree[]", Right: "ltxtquery", ReturnType: "bool"},
+			{Name: "@", Left: "ltxtquery", Right: "ltree[]", ReturnType: "bool"},
+			// The first matching entry of the array, or NULL if none match
+			{Name: "?@>", Left: "ltree[]", Right: "ltree", ReturnType: "ltree", Nullable: true},
+			{Name: "?<@", Left: "ltree[]", Right: "ltree", ReturnType: "ltree", Nullable: true},
+			{Name: "?~", Left: "ltree[]", Right: "lquery", ReturnType: "ltree", Nullable: true},
+			{Name: "?@", Left: "ltree[]", Right: "ltxtquery", ReturnType: "ltree", Nullable: true},
+		},
+	}
+}
@@ -10,7 +10,9 @@ func argN(name string, n int) Function {
 
 func pgCatalog() Schema {
 	s := NewSchema()
+	s.OID = pgCatalogOID
 	s.Name = "pg_catalog"
+	s.Tables = pgCatalogTables()
 	fs := []Function{
 
 		// Table 9.5. Mathematical Functions
@@ -75,6 +77,16 @@ func pgCatalog() Schema {
 
 	fs = append(fs, stringFunctions()...)
 	fs = append(fs, advisoryLockFunctions()...)
+	fs = append(fs, networkFunctions()...)
+	fs = append(fs, dateTimeFunctions()...)
+	fs = append(fs, textSearchFunctions()...)
+	fs = append(fs, binaryStringFunctions()...)
+	fs = append(fs, xmlFunctions()...)
+	fs = append(fs, rangeFunctions()...)
+	s.Operators = append(networkOperators(), dateTimeOperators()...)
+	s.Operators = append(s.Operators, textSearchOperators()...)
+	s.Operators = append(s.Operators, binaryStringOperators()...)
+	s.Operators = append(s.Operators, rangeOperators()...)
 
 	s.Funcs = make(map[string][]Function, len(fs))
 	for _, f := range fs {
@@ -0,0 +1,101 @@
+package pg
+
+// System Catalogs and System Views
+//
+// https://www.postgresql.org/docs/current/catalogs.html
+//
+// Only the commonly queried catalogs and views, and their commonly used
+// columns, are included.
+func pgCatalogTables() map[string]Table {
+	tables := map[string]Table{}
+	add := func(oid uint32, name string, cols ...Column) {
+		fqn := FQN{Schema: "pg_catalog", Rel: name}
+		for i := range cols {
+			cols[i].Table = fqn
+		}
+		tables[name] = Table{OID: oid, ID: fqn, Name: name, Columns: cols}
+	}
+	col := func(name, typ string) Column {
+		return Column{Name: name, DataType: typ, NotNull: true}
+	}
+	null := func(name, typ string) Column {
+		return Column{Name: name, DataType: typ}
+	}
+
+	// Table 52.26. pg_namespace Columns
+	add(2615, "pg_namespace",
+		col("oid", "oid"),
+		col("nspname", "name"),
+		col("nspowner", "oid"),
+	)
+
+	// Table 52.11. pg_class Columns
+	add(1259, "pg_class",
+		col("oid", "oid"),
+		col("relname", "name"),
+		col("relnamespace", "oid"),
+		col("reltype", "oid"),
+		col("relowner", "oid"),
+		col("relpages", "pg_catalog.int4"),
+		col("reltuples", "real"),
+		col("relhasindex", "bool"),
+		col("relpersistence", "char"),
+		col("relkind", "char"),
+		col("relnatts", "pg_catalog.int2"),
+	)
+
+	// Table 52.7. pg_attribute Columns
+	add(1249, "pg_attribute",
+		col("attrelid", "oid"),
+		col("attname", "name"),
+		col("atttypid", "oid"),
+		col("attnum", "pg_catalog.int2"),
+		col("attnotnull", "bool"),
+		col("atthasdef", "bool"),
+		col("attisdropped", "bool"),
+	)
+
+	// Table 52.62. pg_type Columns
+	add(1247, "pg_type",
+		col("oid", "oid"),
+		col("typname", "name"),
+		col("typnamespace", "oid"),
+		col("typowner", "oid"),
+		col("typlen", "pg_catalog.int2"),
+		col("typtype", "char"),
+		col("typcategory", "char"),
+		col("typrelid", "oid"),
+		col("typelem", "oid"),
+		col("typarray", "oid"),
+	)
+
+	// Table 52.39. pg_enum Columns
+	add(3501, "pg_enum",
+		col("oid", "oid"),
+		col("enumtypid", "oid"),
+		col("enumsortorder", "real"),
+		col("enumlabel", "name"),
+	)
+
+	// Table 53.31. pg_tables Columns
+	add(0, "pg_tables",
+		col("schemaname", "name"),
+		col("tablename", "name"),
+		col("tableowner", "name"),
+		null("tablespace", "name"),
+		col("hasindexes", "bool"),
+		col("hasrules", "bool"),
+		col("hastriggers", "bool"),
+		col("rowsecurity", "bool"),
+	)
+
+	// Table 53.13. pg_indexes Columns
+	add(0, "pg_indexes",
+		col("schemaname", "name"),
+		col("tablename", "name"),
+		col("indexname", "name"),
+		null("tablespace", "name"),
+		col("indexdef", "text"),
+	)
+	return tables
+}
@@ -0,0 +1,78 @@
+package pg
+
+// pgcryptoExtension returns the functions of the pgcrypto extension, which
+// hash and encrypt data. It doesn't add any types. Where a function takes
+// either bytea or text, the bytea form comes first, as it's the one found by
+// argument count.
+//
+// https://www.postgresql.org/docs/current/pgcrypto.html
+func pgcryptoExtension() Extension {
+	return Extension{Funcs: []Function{
+		// General Hashing Functions
+		{
+			Name:       "digest",
+			ReturnType: "bytea",
+			Arguments: []Argument{
+				{Name: "data", DataType: "bytea"},
+				{Name: "type", DataType: "text"},
+			},
+		},
+		{
+			Name:       "hmac",
+			ReturnType: "bytea",
+			Arguments: []Argument{
+				{Name: "data", DataType: "bytea"},
+				{Name: "key", DataType: "bytea"},
+				{Name: "type", DataType: "text"},
+			},
+		},
+
+		// Password Hashing Functions
+		{
+			Name:       "crypt",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "password", DataType: "text"},
+				{Name: "salt", DataType: "text"},
+			},
+		},
+		{
+			Name:       "gen_salt",
+			ReturnType: "text",
+			Arguments:  []Argument{{Name: "type", DataType: "text"}},
+		},
+		{
+			Name:       "gen_salt",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "type", DataType: "text"},
+				{Name: "iter_count", DataType: "integer"},
+			},
+		},
+
+		// PGP Encryption Functions
+		{
+			Name:       "pgp_sym_encrypt",
+			ReturnType: "bytea",
+			Arguments: []Argument{
+				{Name: "data", DataType: "text"},
+				{Name: "psw", DataType: "text"},
+			},
+		},
+		{
+			Name:       "pgp_sym_decrypt",
+			ReturnType: "text",
+			Arguments: []Argument{
+				{Name: "msg", DataType: "bytea"},
+				{Name: "psw", DataType: "text"},
+			},
+		},
+
+		// Random-Data Functions
+		{
+			Name:       "gen_random_bytes",
+			ReturnType: "bytea",
+			Arguments:  []Argument{{Name: "count", DataType: "integer"}},
+		},
+	}}
+}
@@ -0,0 +1,141 @@
+package pg
+
+// postgisExtension returns the spatial types and commonly used functions of
+// the PostGIS extension. Where a function takes either geometry or
+// geography, the geometry form comes first, as it's the one found by argument
+// count.
+//
+// https://postgis.net/docs/reference.html
+func postgisExtension() Extension {
+	geom := func(names ...string) []Argument {
+		args := make([]Argument, len(names))
+		for i, name := range names {
+			args[i] = Argument{Name: name, DataType: "geometry"}
+		}
+		return args
+	}
+	return Extension{Types: []string{"geometry", "geography"}, Funcs: []Function{
+		// Geometry Constructors
+		{
+			Name:       "st_makepoint",
+			ReturnType: "geometry",
+			Arguments: []Argument{
+				{Name: "x", DataType: "double precision"},
+				{Name: "y", DataType: "double precision"},
+			},
+		},
+		{
+			Name:       "st_setsrid",
+			ReturnType: "geometry",
+			Arguments:  append(geom("geom"), Argument{Name: "srid", DataType: "integer"}),
+		},
+		{
+			Name:       "st_transform",
+			ReturnType: "geometry",
+			Arguments:  append(geom("geom"), Argument{Name: "to_srid", DataType: "integer"}),
+		},
+		{
+			Name:       "st_geomfromtext",
+			ReturnType: "geometry",
+			Arguments:  []Argument{{Name: "wkt", DataType: "text"}},
+		},
+		{
+			Name:       "st_geomfromtext",
+			ReturnType: "geometry",
+			Arguments: []Argument{
+				{Name: "wkt", DataType: "text"},
+				{Name: "srid", DataType: "integer"},
+			},
+		},
+		{
+			Name:       "st_geomfromgeojson",
+			ReturnType: "geometry",
+			Arguments:  []Argument{{Name: "geomjson", DataType: "text"}},
+		},
+
+		// Geometry Output
+		{
+			Name:       "st_asgeojson",
+			ReturnType: "text",
+			Arguments:  geom("geom"),
+		},
+		{
+			Name:       "st_asgeojson",
+			ReturnType: "text",
+			Arguments:  append(geom("geom"), Argument{Name: "maxdecimaldigits", DataType: "integer"}),
+		},
+		{
+			Name:       "st_astext",
+			ReturnType: "text",
+			Arguments:  geom("geom"),
+		},
+
+		// Geometry Accessors
+		{
+			Name:       "st_x",
+			ReturnType: "double precision",
+			Arguments:  geom("geom"),
+		},
+		{
+			Name:       "st_y",
+			ReturnType: "double precision",
+			Arguments:  geom("geom"),
+		},
+		{
+			Name:       "st_srid",
+			ReturnType: "integer",
+			Arguments:  geom("geom"),
+		},
+
+		// Spatial Relationships
+		{
+			Name:       "st_contains",
+			ReturnType: "bool",
+			Arguments:  geom("geom_a", "geom_b"),
+		},
+		{
+			Name:       "st_intersects",
+			ReturnType: "bool",
+			Arguments:  geom("geom_a", "geom_b"),
+		},
+		{
+			Name:       "st_dwithin",
+			ReturnType: "bool",
+			Arguments:  append(geom("g1", "g2"), Argument{Name: "distance", DataType: "double precision"}),
+		},
+		{
+			Name:       "st_dwithin",
+			ReturnType: "bool",
+			Arguments: []Argument{
+				{Name: "gg1", DataType: "geography"},
+				{Name: "gg2", DataType: "geography"},
+				{Name: "distance_meters", DataType: "double precision"},
+				{Name: "use_spheroid", DataType: "bool"},
+			},
+		},
+
+		// Measurement Functions
+		{
+			Name:       "st_distance",
+			ReturnType: "double precision",
+			Arguments:  geom("g1", "g2"),
+		},
+		{
+			Name:       "st_area",
+			ReturnType: "double precision",
+			Arguments:  geom("geom"),
+		},
+		{
+			Name:       "st_length",
+			ReturnType: "double precision",
+			Arguments:  geom("geom"),
+		},
+
+		// Geometry Processing
+		{
+			Name:       "st_buffer",
+			ReturnType: "geometry",
+			Arguments:  append(geom("geom"), Argument{Name: "radius", DataType: "double precision"}),
+		},
+	}}
+}
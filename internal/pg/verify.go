@@ -0,0 +1,99 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verify compares the catalog built from schema files with one introspected
+// from a live database. It returns an error for each schema, table, column,
+// enum and index of schema missing from the database, and for each column
+// whose type differs. The codes are those PostgreSQL would report when a
+// query used the missing object.
+func Verify(schema, live Catalog) []Error {
+	var errs []Error
+	for _, name := range schemaNames(schema) {
+		want := schema.Schemas[name]
+		got, ok := live.Schemas[name]
+		if !ok {
+			if len(want.Tables) > 0 || len(want.Enums()) > 0 {
+				errs = append(errs, missing(ErrorSchemaDoesNotExist(name)))
+			}
+			continue
+		}
+		for _, enum := range want.Enums() {
+			if _, ok := got.Types[enum.Name].(Enum); !ok {
+				errs = append(errs, missing(ErrorTypeDoesNotExist(enum.Name)))
+			}
+		}
+		for _, table := range sortedTables(want) {
+			liveTable, ok := got.Tables[table.Name]
+			if !ok {
+				errs = append(errs, missing(ErrorRelationDoesNotExist(table.Name)))
+				continue
+			}
+			errs = append(errs, verifyTable(table, liveTable)...)
+		}
+	}
+	return errs
+}
+
+func verifyTable(want, got Table) []Error {
+	var errs []Error
+	columns := map[string]Column{}
+	for _, col := range got.Columns {
+		columns[col.Name] = col
+	}
+	for _, col := range want.Columns {
+		liveCol, ok := columns[col.Name]
+		if !ok {
+			errs = append(errs, missing(ErrorColumnDoesNotExist(want.Name, col.Name)))
+			continue
+		}
+		if a, b := verifyType(col), verifyType(liveCol); a != b {
+			errs = append(errs, Error{
+				Code:    "42804",
+				Message: fmt.Sprintf("column \"%s\" of relation \"%s\" is type %s in the schema but %s in the database", col.Name, want.Name, a, b),
+			})
+		}
+	}
+	for _, index := range want.Indexes {
+		found := false
+		for _, liveIndex := range got.Indexes {
+			found = found || sameColumns(index.Columns, liveIndex.Columns)
+		}
+		if !found {
+			errs = append(errs, Error{
+				Code:    "42704",
+				Message: fmt.Sprintf("index \"%s\" on relation \"%s\" (%s) does not exist in the database", index.Name, want.Name, strings.Join(index.Columns, ", ")),
+			})
+		}
+	}
+	return errs
+}
+
+func missing(e Error) Error {
+	e.Message += " in the database"
+	return e
+}
+
+// verifyType returns the name of a column's type with the pg_catalog schema
+// left out, and serial types replaced by the integer types they create
+func verifyType(col Column) string {
+	typ := strings.TrimPrefix(columnType(col, true), "pg_catalog.")
+	return strings.ToLower(typ)
+}
+
+// sameColumns reports whether two indexes cover the same columns, in order.
+// Expressions are only compared by their position.
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVerify(t *testing.T) {
+	schema := NewCatalog()
+	schema.Schemas["public"].Types["status"] = Enum{Name: "status", Vals: []string{"open"}}
+	schema.Schemas["public"].Tables["users"] = Table{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "serial", NotNull: true},
+			{Name: "email", DataType: "text"},
+			{Name: "age", DataType: "pg_catalog.int4"},
+			{Name: "tags", DataType: "text", IsArray: true},
+			{Name: "status", DataType: "status"},
+		},
+		Indexes: []Index{
+			{Name: "users_pkey", Columns: []string{"id"}, Unique: true, Primary: true},
+			{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+		},
+	}
+	schema.Schemas["public"].Tables["logs"] = Table{Name: "logs"}
+	schema.Schemas["audit"] = Schema{Tables: map[string]Table{"events": {Name: "events"}}}
+
+	live := NewCatalog()
+	live.Schemas["public"].Types["status"] = Enum{Name: "status", Vals: []string{"open"}}
+	live.Schemas["public"].Tables["users"] = Table{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", DataType: "pg_catalog.int4", NotNull: true},
+			{Name: "email", DataType: "pg_catalog.text"},
+			{Name: "age", DataType: "pg_catalog.int8"},
+			{Name: "tags", DataType: "pg_catalog.text", IsArray: true},
+			{Name: "status", DataType: "status"},
+		},
+		Indexes: []Index{
+			{Name: "users_id_idx", Columns: []string{"id"}, Unique: true, Primary: true},
+		},
+	}
+
+	expected := []Error{
+		{Code: "3F000", Message: `schema "audit" does not exist in the database`},
+		{Code: "42P01", Message: `relation "logs" does not exist in the database`},
+		{Code: "42804", Message: `column "age" of relation "users" is type int4 in the schema but int8 in the database`},
+		{Code: "42704", Message: `index "users_email_key" on relation "users" (email) does not exist in the database`},
+	}
+	if diff := cmp.Diff(expected, Verify(schema, live)); diff != "" {
+		t.Errorf("verify mismatch (-want +got):\n%s", diff)
+	}
+}
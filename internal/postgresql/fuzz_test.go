@@ -0,0 +1,34 @@
+//go:build go1.18
+// +build go1.18
+
+package postgresql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/catalog"
+)
+
+// FuzzParse covers the pg_query translation of the ALTER TABLE commands,
+// comments and partitions the catalog builder supports
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"CREATE TABLE venues (id serial PRIMARY KEY, name text NOT NULL, tags text[])",
+		"CREATE TABLE a (id int); ALTER TABLE a ADD COLUMN b text, ALTER COLUMN id TYPE bigint, DROP COLUMN IF EXISTS b",
+		"ALTER TABLE a ALTER COLUMN id SET NOT NULL, ALTER COLUMN id DROP NOT NULL",
+		"CREATE SCHEMA s; CREATE TYPE s.mood AS ENUM ('sad', 'ok'); COMMENT ON TYPE s.mood IS 'x'; DROP SCHEMA s",
+		"COMMENT ON COLUMN a.b IS 'x'; COMMENT ON TABLE public.a IS NULL; COMMENT ON SCHEMA s IS 'y'",
+		"CREATE TABLE p (id int) PARTITION BY RANGE (id); CREATE TABLE p1 PARTITION OF p (id NOT NULL) FOR VALUES FROM (1) TO (10)",
+		"DROP TABLE IF EXISTS a, s.b; SELECT * FROM a",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		stmts, err := NewParser().Parse(strings.NewReader(sql))
+		if err != nil {
+			return
+		}
+		catalog.Build(stmts)
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"io/ioutil"
 	"strings"
 
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
 
 	pg "github.com/lfittl/pg_query_go"
 	nodes "github.com/lfittl/pg_query_go/nodes"
@@ -192,10 +192,11 @@ func translate(node nodes.Node) (ast.Node, error) {
 					}
 
 				case nodes.AT_AlterColumnType:
+					// The column is named by the command, not the definition
 					d := cmd.Def.(nodes.ColumnDef)
 					item.Subtype = ast.AT_AlterColumnType
 					item.Def = &ast.ColumnDef{
-						Colname:   *d.Colname,
+						Colname:   *cmd.Name,
 						TypeName:  &ast.TypeName{Name: join(d.TypeName.Names, ".")},
 						IsNotNull: isNotNull(d),
 					}
@@ -278,6 +279,11 @@ func translate(node nodes.Node) (ast.Node, error) {
 		for _, elt := range n.TableElts.Items {
 			switch n := elt.(type) {
 			case nodes.ColumnDef:
+				// "PARTITION OF p (id NOT NULL)" and "OF t (id NOT NULL)"
+				// list options, not columns, so have no type
+				if n.TypeName == nil {
+					continue
+				}
 				create.Cols = append(create.Cols, &ast.ColumnDef{
 					Colname:   *n.Colname,
 					TypeName:  &ast.TypeName{Name: join(n.TypeName.Names, ".")},
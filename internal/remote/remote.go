@@ -0,0 +1,243 @@
+// Package remote fetches schema files which live outside the project, caching
+// them on disk
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kyleconroy/sqlc/internal/cache"
+)
+
+// IsURL reports whether a schema path should be fetched rather than read from
+// the project directory
+func IsURL(path string) bool {
+	for _, prefix := range []string{"http://", "https://", "s3://", "git+"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// A source is a parsed schema URL
+type source struct {
+	// URL without the checksum fragment, which keys the cache
+	URL string
+	// Name of the cached file or directory
+	Name   string
+	Sha256 string
+}
+
+func parse(raw string) (source, error) {
+	var src source
+	src.URL = raw
+	if i := strings.Index(raw, "#"); i >= 0 {
+		src.URL = raw[:i]
+		frag := raw[i+1:]
+		if !strings.HasPrefix(frag, "sha256=") {
+			return src, fmt.Errorf("invalid checksum %q: must be sha256=<hex>", frag)
+		}
+		src.Sha256 = strings.ToLower(strings.TrimPrefix(frag, "sha256="))
+	}
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return src, err
+	}
+	if strings.HasPrefix(u.Scheme, "git+") {
+		_, subdir := splitGit(u)
+		src.Name = path.Base(subdir)
+		if subdir == "" {
+			return src, fmt.Errorf("%s: git URLs must name a path within the repository, e.g. repo.git//schema.sql", src.URL)
+		}
+	} else {
+		src.Name = path.Base(u.Path)
+	}
+	if src.Name == "" || src.Name == "/" || src.Name == "." {
+		return src, fmt.Errorf("%s: URL doesn't name a file", src.URL)
+	}
+	return src, nil
+}
+
+// CacheDir returns the directory that fetched schemas are kept in, schema
+// within the cache directory
+func CacheDir() (string, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "schema"), nil
+}
+
+// Fetch returns the path of a local copy of the schema at rawurl. The URL may
+// be http, https, s3 or git+ followed by any URL git understands, and may end
+// with a #sha256=<hex> checksum. A cached copy whose checksum matches is used
+// without fetching it again; unpinned schemas are fetched every time.
+//
+// S3 objects are fetched over HTTPS, so must be public. Git URLs name a file
+// or directory in the repository after a double slash, and a ref to check out
+// as a query parameter, e.g. git+https://github.com/org/repo.git//schema.sql?ref=v1.
+func Fetch(rawurl string) (string, error) {
+	src, err := parse(rawurl)
+	if err != nil {
+		return "", err
+	}
+	root, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	key := sha256.Sum256([]byte(src.URL))
+	dir := filepath.Join(root, hex.EncodeToString(key[:]))
+	target := filepath.Join(dir, src.Name)
+
+	if src.Sha256 != "" {
+		if sum, err := checksum(target); err == nil && sum == src.Sha256 {
+			return target, nil
+		}
+	}
+
+	// Fetch into the cache root, so the result can be renamed into place
+	// without crossing filesystems
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempDir(root, ".fetch")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+	fetched := filepath.Join(tmp, src.Name)
+	if strings.HasPrefix(src.URL, "git+") {
+		err = fetchGit(src.URL, fetched)
+	} else {
+		err = fetchHTTP(src.URL, fetched)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if src.Sha256 != "" {
+		sum, err := checksum(fetched)
+		if err != nil {
+			return "", err
+		}
+		if sum != src.Sha256 {
+			return "", fmt.Errorf("%s: checksum mismatch: got sha256=%s", src.URL, sum)
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	if err := os.RemoveAll(target); err != nil {
+		return "", err
+	}
+	if err := os.Rename(fetched, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// client fetches schemas over HTTP, giving up on servers which stall
+var client = &http.Client{Timeout: time.Minute}
+
+func fetchHTTP(rawurl, dest string) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "s3" {
+		host := u.Host + ".s3.amazonaws.com"
+		if region := os.Getenv("AWS_REGION"); region != "" {
+			host = u.Host + ".s3." + region + ".amazonaws.com"
+		}
+		u = &url.URL{Scheme: "https", Host: host, Path: u.Path}
+	}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", rawurl, resp.Status)
+	}
+	blob, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(dest, blob, 0644)
+}
+
+// splitGit returns the repository URL and the path within it of a git+ URL
+func splitGit(u *url.URL) (string, string) {
+	repo := *u
+	repo.Scheme = strings.TrimPrefix(u.Scheme, "git+")
+	repo.RawQuery = ""
+	var subdir string
+	if i := strings.Index(repo.Path, "//"); i >= 0 {
+		repo.Path, subdir = repo.Path[:i], strings.Trim(repo.Path[i+2:], "/")
+	}
+	if repo.Scheme == "file" {
+		return repo.Path, subdir
+	}
+	return repo.String(), subdir
+}
+
+func fetchGit(rawurl, dest string) error {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return err
+	}
+	repo, subdir := splitGit(u)
+	clone := dest + ".git"
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if ref := u.Query().Get("ref"); ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	cmd := exec.Command("git", append(args, repo, clone)...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: git clone: %s", rawurl, strings.TrimSpace(string(out)))
+	}
+	if _, err := os.Stat(filepath.Join(clone, filepath.FromSlash(subdir))); err != nil {
+		return fmt.Errorf("%s: %s does not exist in the repository", rawurl, subdir)
+	}
+	return os.Rename(filepath.Join(clone, filepath.FromSlash(subdir)), dest)
+}
+
+// checksum returns the hex SHA-256 of a file. Directories are summed over the
+// names and contents of the files within them, in lexical order.
+func checksum(name string) (string, error) {
+	h := sha256.New()
+	err := filepath.Walk(name, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		blob, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if p != name {
+			rel, _ := filepath.Rel(name, p)
+			fmt.Fprintf(h, "%s\x00", filepath.ToSlash(rel))
+		}
+		h.Write(blob)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,128 @@
+package remote
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const schema = "CREATE TABLE users (id bigserial PRIMARY KEY);\n"
+
+func cacheDir(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "sqlc-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("SQLC_CACHE_DIR", dir)
+	return func() {
+		os.Unsetenv("SQLC_CACHE_DIR")
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFetchHTTP(t *testing.T) {
+	defer cacheDir(t)()
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/db/schema.sql" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(schema))
+	}))
+	defer srv.Close()
+
+	sum := sha256.Sum256([]byte(schema))
+	pinned := srv.URL + "/db/schema.sql#sha256=" + hex.EncodeToString(sum[:])
+	for i := 0; i < 2; i++ {
+		path, err := Fetch(pinned)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Base(path) != "schema.sql" {
+			t.Errorf("expected the cached copy to keep its name, got %s", path)
+		}
+		blob, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(blob) != schema {
+			t.Errorf("unexpected contents %q", blob)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the pinned schema to be fetched once, got %d requests", requests)
+	}
+	root, err := CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := ioutil.ReadDir(root); err != nil || len(entries) != 1 {
+		t.Errorf("expected the fetch to leave only the cached copy in %s, got %d entries (%v)", root, len(entries), err)
+	}
+
+	_, err = Fetch(srv.URL + "/db/schema.sql#sha256=00")
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("expected a checksum mismatch, got %v", err)
+	}
+	_, err = Fetch(srv.URL + "/db/missing.sql")
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("expected a 404, got %v", err)
+	}
+}
+
+func TestFetchGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	defer cacheDir(t)()
+
+	repo, err := ioutil.TempDir("", "sqlc-repo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(repo)
+	if err := os.MkdirAll(filepath.Join(repo, "db", "migrations"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(repo, "db", "migrations", "001_users.sql"), []byte(schema), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=sqlc", "-c", "user.email=sqlc@example.com", "commit", "--quiet", "-m", "schema"},
+		{"tag", "v1"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %s", args[0], out)
+		}
+	}
+
+	path, err := Fetch("git+file://" + filepath.ToSlash(repo) + "//db/migrations?ref=v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := ioutil.ReadFile(filepath.Join(path, "001_users.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(blob) != schema {
+		t.Errorf("unexpected contents %q", blob)
+	}
+
+	if _, err := Fetch("git+file://" + filepath.ToSlash(repo)); err == nil {
+		t.Errorf("expected an error for a git URL without a path")
+	}
+}
@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/catalog"
+)
+
+// FuzzParse walks the trees the ANTLR grammar recovers from malformed SQL,
+// which lack the contexts a rule failed to match, and builds a catalog
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"CREATE TABLE venues (id integer PRIMARY KEY, name text NOT NULL)",
+		"CREATE TABLE a (id int); ALTER TABLE a ADD COLUMN b text; ALTER TABLE a RENAME TO b",
+		"DROP TABLE IF EXISTS a; SELECT id, name FROM a JOIN b ON a.id = b.id",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, sql string) {
+		stmts, err := NewParser().Parse(strings.NewReader(sql))
+		if err != nil {
+			return
+		}
+		catalog.Build(stmts)
+	})
+}
@@ -1,8 +1,8 @@
 package sqlite
 
 import (
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
 	"github.com/kyleconroy/sqlc/internal/sqlite/parser"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
 )
 
 type listener struct {
@@ -52,7 +52,7 @@ func (l *listener) EnterAlter_table_stmt(c *parser.Alter_table_stmtContext) {
 			Def: &ast.ColumnDef{
 				Colname: name,
 				TypeName: &ast.TypeName{
-					Name: def.Type_name().GetText(),
+					Name: columnType(def),
 				},
 			},
 		})
@@ -77,7 +77,7 @@ func (l *listener) EnterCreate_table_stmt(c *parser.Create_table_stmtContext) {
 			stmt.Cols = append(stmt.Cols, &ast.ColumnDef{
 				Colname: def.Column_name().GetText(),
 				TypeName: &ast.TypeName{
-					Name: def.Type_name().GetText(),
+					Name: columnType(def),
 				},
 			})
 		}
@@ -119,7 +119,8 @@ func (l *listener) EnterFactored_select_stmt(c *parser.Factored_select_stmtConte
 				continue
 			}
 			expr, ok := iexpr.(*parser.ExprContext)
-			if !ok {
+			// Only column references are supported
+			if !ok || expr.Column_name() == nil {
 				continue
 			}
 			cols = append(cols, &ast.ResTarget{
@@ -130,7 +131,8 @@ func (l *listener) EnterFactored_select_stmt(c *parser.Factored_select_stmtConte
 		}
 		for _, ifrom := range core.AllTable_or_subquery() {
 			from, ok := ifrom.(*parser.Table_or_subqueryContext)
-			if !ok {
+			// Subqueries and table functions have no table name
+			if !ok || from.Table_name() == nil {
 				continue
 			}
 			name := ast.TableName{
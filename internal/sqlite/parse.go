@@ -7,8 +7,8 @@ import (
 
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
 	"github.com/kyleconroy/sqlc/internal/sqlite/parser"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
 )
 
 type errorListener struct {
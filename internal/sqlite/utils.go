@@ -1,8 +1,8 @@
 package sqlite
 
 import (
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
 	"github.com/kyleconroy/sqlc/internal/sqlite/parser"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
 )
 
 type tableNamer interface {
@@ -19,3 +19,12 @@ func parseTableName(c tableNamer) *ast.TableName {
 	}
 	return &name
 }
+
+// columnType returns the declared type of a column. SQLite columns may leave
+// out their type, and then hold values of any type.
+func columnType(def *parser.Column_defContext) string {
+	if def.Type_name() == nil {
+		return "any"
+	}
+	return def.Type_name().GetText()
+}
@@ -0,0 +1,202 @@
+// Package golden runs the golden tests sqlc uses for its generators: each
+// test is a directory holding a config, schema and queries along with the
+// files generated from them, which must match what the generator outputs.
+//
+// A SQL file in the directory may end with a `-- stderr` comment, followed by
+// the lines the generator writes to stderr, each as a comment. A test which
+// expects output on stderr may fail to generate.
+package golden
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/kyleconroy/sqlc/internal/cmd"
+)
+
+// DefaultExtensions are the extensions of the files compared whether or not
+// they were generated
+var DefaultExtensions = []string{".go", ".kt"}
+
+// A Generator returns the files generated for the project in dir, keyed by
+// path, writing its diagnostics to stderr
+type Generator func(dir string, stderr io.Writer) (map[string]string, error)
+
+// A Suite compares the output of a generator with the files in test
+// directories.
+type Suite struct {
+	// Generate generates the files of a test. Defaults to `sqlc generate`,
+	// run with the on-disk caches disabled.
+	Generate Generator
+
+	// Extensions are those of the files each test holds which must all have
+	// been generated. Other files, such as the schema, are only compared if
+	// they were generated. Defaults to DefaultExtensions.
+	Extensions []string
+
+	// Skip reports whether a file is left out of the comparison, e.g. a test
+	// written alongside the generated code. Files ending in _test.go are
+	// always skipped.
+	Skip func(path string) bool
+}
+
+// Run runs a subtest for each directory in dir, in parallel
+func (s Suite) Run(t *testing.T, dir string) {
+	t.Helper()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		path, err := filepath.Abs(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Run(file.Name(), func(t *testing.T) {
+			t.Parallel()
+			s.Check(t, path)
+		})
+	}
+}
+
+// Check generates the files of the test in dir, and compares them and the
+// generator's stderr with the test's
+func (s Suite) Check(t *testing.T, dir string) {
+	t.Helper()
+	generate := s.Generate
+	if generate == nil {
+		// Keep `sqlc generate` out of the user's cache directory, and stop it
+		// reading a test's expected files back instead of generating them
+		generate = cmd.GenerateUncached
+	}
+	expected := ExpectedStderr(t, dir)
+	var stderr bytes.Buffer
+	output, err := generate(dir, &stderr)
+	if len(expected) == 0 && err != nil {
+		t.Fatalf("generate failed: %s", stderr.String())
+	}
+	s.Compare(t, dir, output)
+	if diff := cmp.Diff(expected, stderr.String()); diff != "" {
+		t.Errorf("stderr differed (-want +got):\n%s", diff)
+	}
+}
+
+// Compare compares the files in dir with the generated files in actual,
+// keyed by path
+func (s Suite) Compare(t *testing.T, dir string, actual map[string]string) {
+	t.Helper()
+	extensions := s.Extensions
+	if extensions == nil {
+		extensions = DefaultExtensions
+	}
+	expected := map[string]string{}
+	var ff = func(path string, file os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if file.IsDir() {
+			return nil
+		}
+		// Generated SQL files, such as the crud_tables queries, sit next to
+		// the schema and query files, and generated docs next to READMEs
+		_, generated := actual[path]
+		if !generated && !contains(extensions, filepath.Ext(path)) {
+			return nil
+		}
+		if strings.HasSuffix(path, "_test.go") || (s.Skip != nil && s.Skip(path)) {
+			return nil
+		}
+		blob, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		expected[path] = string(blob)
+		return nil
+	}
+	if err := filepath.Walk(dir, ff); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cmp.Equal(expected, actual, cmpopts.EquateEmpty()) {
+		t.Errorf("%s contents differ", dir)
+		for name, contents := range expected {
+			name := name
+			tn := strings.Replace(name, dir+"/", "", -1)
+			t.Run(tn, func(t *testing.T) {
+				if actual[name] == "" {
+					t.Errorf("%s is empty", name)
+					return
+				}
+				if diff := cmp.Diff(contents, actual[name]); diff != "" {
+					t.Errorf("%s differed (-want +got):\n%s", name, diff)
+				}
+			})
+		}
+		for name := range actual {
+			if _, ok := expected[name]; !ok {
+				t.Errorf("%s was generated, but isn't in the test", name)
+			}
+		}
+	}
+}
+
+// ExpectedStderr returns the lines the SQL files in dir expect the generator
+// to write to stderr
+func ExpectedStderr(t *testing.T, dir string) string {
+	t.Helper()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := ""
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(file.Name(), ".sql") {
+			continue
+		}
+		rd, err := os.Open(filepath.Join(dir, file.Name()))
+		if err != nil {
+			t.Fatalf("could not open %s: %v", file.Name(), err)
+		}
+		scanner := bufio.NewScanner(rd)
+		capture := false
+		for scanner.Scan() {
+			text := scanner.Text()
+			if text == "-- stderr" {
+				capture = true
+				continue
+			}
+			if capture && strings.HasPrefix(text, "--") {
+				stderr += strings.TrimPrefix(text, "-- ") + "\n"
+			}
+		}
+		rd.Close()
+		if err := scanner.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return stderr
+}
+
+func contains(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
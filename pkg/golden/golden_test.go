@@ -0,0 +1,54 @@
+package golden
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// listTables writes the name of each table created by schema.sql to
+// tables.txt, standing in for a generator of a new backend
+func listTables(dir string, stderr io.Writer) (map[string]string, error) {
+	f, err := os.Open(filepath.Join(dir, "schema.sql"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var tables []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[0] != "CREATE" || fields[1] != "TABLE" {
+			fmt.Fprintln(stderr, "schema.sql: only CREATE TABLE is supported")
+			return nil, errors.New("unsupported statement")
+		}
+		tables = append(tables, fields[2]+"\n")
+	}
+	return map[string]string{
+		filepath.Join(dir, "tables.txt"): strings.Join(tables, ""),
+	}, scanner.Err()
+}
+
+func TestSuite(t *testing.T) {
+	suite := Suite{Generate: listTables, Extensions: []string{".txt"}}
+	suite.Run(t, "testdata")
+}
+
+func TestExpectedStderr(t *testing.T) {
+	got := ExpectedStderr(t, filepath.Join("testdata", "invalid"))
+	if want := "schema.sql: only CREATE TABLE is supported\n"; got != want {
+		t.Errorf("expected stderr %q; got %q", want, got)
+	}
+	if got := ExpectedStderr(t, filepath.Join("testdata", "tables")); got != "" {
+		t.Errorf("expected no stderr; got %q", got)
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE authors;
+
+-- stderr
+-- schema.sql: only CREATE TABLE is supported
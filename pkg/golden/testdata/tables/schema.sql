@@ -0,0 +1,2 @@
+CREATE TABLE authors (id int);
+CREATE TABLE books (id int);
@@ -1,3 +1,6 @@
+// Package ast defines the engine-neutral syntax tree which sqlc's PostgreSQL,
+// MySQL and SQLite parsers produce. It's part of sqlc's public API: existing
+// nodes and fields are only removed or changed in a new major version.
 package ast
 
 type Node interface {
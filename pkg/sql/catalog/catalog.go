@@ -0,0 +1,529 @@
+// Package catalog builds the schemas, tables, columns and types described by
+// a sequence of DDL statements, as parsed into the nodes of package ast. It's
+// part of sqlc's public API, with the same compatibility promise as ast.
+package catalog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+	sqlerr "github.com/kyleconroy/sqlc/pkg/sql/errors"
+)
+
+var (
+	errEmptyTableName = errors.New("empty table name")
+	errEmptyTypeName  = errors.New("empty type name")
+)
+
+func Build(stmts []ast.Statement) (*Catalog, error) {
+	c := &Catalog{
+		DefaultSchema: "main", // TODO: Needs to be public for PostgreSQL
+		intern:        newInterner(),
+	}
+	c.addSchema(&Schema{Name: "main"})
+	for i := range stmts {
+		if stmts[i].Raw == nil {
+			continue
+		}
+		var err error
+		switch n := stmts[i].Raw.Stmt.(type) {
+		case *ast.AlterTableStmt:
+			err = c.alterTable(n)
+		case *ast.CommentOnColumnStmt:
+			err = c.commentOnColumn(n)
+		case *ast.CommentOnSchemaStmt:
+			err = c.commentOnSchema(n)
+		case *ast.CommentOnTableStmt:
+			err = c.commentOnTable(n)
+		case *ast.CommentOnTypeStmt:
+			err = c.commentOnType(n)
+		case *ast.CreateEnumStmt:
+			err = c.createEnum(n)
+		case *ast.CreateSchemaStmt:
+			err = c.createSchema(n)
+		case *ast.CreateTableStmt:
+			err = c.createTable(n)
+		case *ast.DropSchemaStmt:
+			err = c.dropSchema(n)
+		case *ast.DropTableStmt:
+			err = c.dropTable(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func stringSlice(list *ast.List) []string {
+	items := []string{}
+	if list == nil {
+		return items
+	}
+	for _, item := range list.Items {
+		if n, ok := item.(*ast.String); ok {
+			items = append(items, n.Str)
+		}
+	}
+	return items
+}
+
+func (c *Catalog) getSchema(name string) (*Schema, error) {
+	if s, ok := c.schemaIndex()[name]; ok {
+		return s, nil
+	}
+	return nil, sqlerr.SchemaNotFound(name)
+}
+
+func (c *Catalog) getTable(name *ast.TableName) (*Schema, *Table, error) {
+	if name == nil {
+		return nil, nil, errEmptyTableName
+	}
+	ns := name.Schema
+	if ns == "" {
+		ns = c.DefaultSchema
+	}
+	s, err := c.getSchema(ns)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := s.getTable(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, t, nil
+}
+
+func (c *Catalog) getType(rel *ast.TypeName) (Type, error) {
+	if rel == nil {
+		return nil, errEmptyTypeName
+	}
+	ns := rel.Schema
+	if ns == "" {
+		ns = c.DefaultSchema
+	}
+	s, err := c.getSchema(ns)
+	if err != nil {
+		return nil, err
+	}
+	return s.getType(rel)
+}
+
+// columnDef checks a column definition has the name and type a column needs
+func columnDef(def *ast.ColumnDef) error {
+	if def == nil {
+		return errors.New("empty column definition")
+	}
+	if def.Colname == "" {
+		return errors.New("empty column name")
+	}
+	if def.TypeName == nil {
+		return fmt.Errorf("column %q: empty type", def.Colname)
+	}
+	return nil
+}
+
+func (c *Catalog) alterTable(stmt *ast.AlterTableStmt) error {
+	if stmt.Cmds == nil {
+		return nil
+	}
+	var implemented bool
+	for _, item := range stmt.Cmds.Items {
+		switch cmd := item.(type) {
+		case *ast.AlterTableCmd:
+			switch cmd.Subtype {
+			case ast.AT_AddColumn:
+				implemented = true
+			case ast.AT_AlterColumnType:
+				implemented = true
+			case ast.AT_DropColumn:
+				implemented = true
+			case ast.AT_DropNotNull:
+				implemented = true
+			case ast.AT_SetNotNull:
+				implemented = true
+			}
+		}
+	}
+	if !implemented {
+		return nil
+	}
+	_, table, err := c.getTable(stmt.Table)
+	if err != nil {
+		return fmt.Errorf("alter table: %w", err)
+	}
+
+	for _, cmd := range stmt.Cmds.Items {
+		switch cmd := cmd.(type) {
+		case *ast.AlterTableCmd:
+			var col *Column
+
+			switch cmd.Subtype {
+			case ast.AT_AddColumn, ast.AT_AlterColumnType:
+				if err := columnDef(cmd.Def); err != nil {
+					return fmt.Errorf("alter table: %w", err)
+				}
+			}
+
+			// Lookup column names for column-related commands
+			switch cmd.Subtype {
+			case ast.AT_AlterColumnType,
+				ast.AT_DropColumn,
+				ast.AT_DropNotNull,
+				ast.AT_SetNotNull:
+				if cmd.Name == nil {
+					return fmt.Errorf("alter table: empty column name")
+				}
+				col = table.columnIndex()[*cmd.Name]
+				if col == nil && !cmd.MissingOk {
+					return sqlerr.ColumnNotFound(table.Rel.Name, *cmd.Name)
+				}
+				// If a missing column is allowed, skip this command
+				if col == nil && cmd.MissingOk {
+					continue
+				}
+			}
+
+			switch cmd.Subtype {
+
+			case ast.AT_AddColumn:
+				if _, ok := table.columnIndex()[cmd.Def.Colname]; ok {
+					return sqlerr.ColumnExists(table.Rel.Name, cmd.Def.Colname)
+				}
+				table.addColumn(&Column{
+					Name:      c.intern.string(cmd.Def.Colname),
+					Type:      c.intern.typeName(cmd.Def.TypeName),
+					IsNotNull: cmd.Def.IsNotNull,
+				})
+
+			case ast.AT_AlterColumnType:
+				col.Type = c.intern.typeName(cmd.Def.TypeName)
+				// col.IsArray = isArray(d.TypeName)
+
+			case ast.AT_DropColumn:
+				table.dropColumn(col)
+
+			case ast.AT_DropNotNull:
+				col.IsNotNull = false
+
+			case ast.AT_SetNotNull:
+				col.IsNotNull = true
+
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *Catalog) createEnum(stmt *ast.CreateEnumStmt) error {
+	if stmt.TypeName == nil {
+		return fmt.Errorf("create type: %w", errEmptyTypeName)
+	}
+	ns := stmt.TypeName.Schema
+	if ns == "" {
+		ns = c.DefaultSchema
+	}
+	schema, err := c.getSchema(ns)
+	if err != nil {
+		return err
+	}
+	// Because tables have associated data types, the type name must also
+	// be distinct from the name of any existing table in the same
+	// schema.
+	// https://www.postgresql.org/docs/current/sql-createtype.html
+	tbl := &ast.TableName{
+		Name: stmt.TypeName.Name,
+	}
+	if _, err := schema.getTable(tbl); err == nil {
+		return sqlerr.RelationExists(tbl.Name)
+	}
+	if _, err := schema.getType(stmt.TypeName); err == nil {
+		return sqlerr.TypeExists(tbl.Name)
+	}
+	schema.addType(&Enum{
+		Name: c.intern.string(stmt.TypeName.Name),
+		Vals: c.intern.strs(stringSlice(stmt.Vals)),
+	})
+	return nil
+}
+
+func (c *Catalog) createSchema(stmt *ast.CreateSchemaStmt) error {
+	if stmt.Name == nil {
+		return fmt.Errorf("create schema: empty name")
+	}
+	if _, err := c.getSchema(*stmt.Name); err == nil {
+		if !stmt.IfNotExists {
+			return sqlerr.SchemaExists(*stmt.Name)
+		}
+		return nil
+	}
+	c.addSchema(&Schema{Name: c.intern.string(*stmt.Name)})
+	return nil
+}
+
+func (c *Catalog) createTable(stmt *ast.CreateTableStmt) error {
+	if stmt.Name == nil {
+		return fmt.Errorf("create table: %w", errEmptyTableName)
+	}
+	ns := stmt.Name.Schema
+	if ns == "" {
+		ns = c.DefaultSchema
+	}
+	schema, err := c.getSchema(ns)
+	if err != nil {
+		return err
+	}
+	if _, err := schema.getTable(stmt.Name); err != nil {
+		if !errors.Is(err, sqlerr.NotFound) {
+			return err
+		}
+	} else if stmt.IfNotExists {
+		return nil
+	} else {
+		return sqlerr.RelationExists(stmt.Name.Name)
+	}
+	tbl := &Table{Rel: c.intern.tableName(stmt.Name)}
+	for _, col := range stmt.Cols {
+		if err := columnDef(col); err != nil {
+			return fmt.Errorf("create table %s: %w", stmt.Name.Name, err)
+		}
+		if _, ok := tbl.columnIndex()[col.Colname]; ok {
+			return sqlerr.ColumnExists(stmt.Name.Name, col.Colname)
+		}
+		tbl.addColumn(&Column{
+			Name:      c.intern.string(col.Colname),
+			Type:      c.intern.typeName(col.TypeName),
+			IsNotNull: col.IsNotNull,
+		})
+	}
+	schema.addTable(tbl)
+	return nil
+}
+
+func (c *Catalog) dropSchema(stmt *ast.DropSchemaStmt) error {
+	dropped := map[*Schema]bool{}
+	for _, name := range stmt.Schemas {
+		if name == nil {
+			return fmt.Errorf("drop schema: empty name")
+		}
+		s, ok := c.schemaIndex()[name.Str]
+		if !ok {
+			if stmt.MissingOk {
+				continue
+			}
+			return sqlerr.SchemaNotFound(name.Str)
+		}
+		dropped[s] = true
+		delete(c.schemas, name.Str)
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+	// Remove every dropped schema in one pass, keeping the others in order
+	kept := c.Schemas[:0]
+	for _, s := range c.Schemas {
+		if !dropped[s] {
+			kept = append(kept, s)
+		}
+	}
+	c.Schemas = kept
+	return nil
+}
+
+func (c *Catalog) dropTable(stmt *ast.DropTableStmt) error {
+	for _, name := range stmt.Tables {
+		if name == nil {
+			return fmt.Errorf("drop table: %w", errEmptyTableName)
+		}
+		ns := name.Schema
+		if ns == "" {
+			ns = c.DefaultSchema
+		}
+		schema, err := c.getSchema(ns)
+		if errors.Is(err, sqlerr.NotFound) && stmt.IfExists {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		tbl, err := schema.getTable(name)
+		if errors.Is(err, sqlerr.NotFound) && stmt.IfExists {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		schema.dropTable(tbl)
+	}
+	return nil
+}
+
+// The Schemas, Tables, Types and Columns slices keep the order objects were
+// created in, for output. Lookups by name go through maps kept alongside
+// them, so the slices must only be changed by the catalog itself.
+type Catalog struct {
+	Name    string
+	Schemas []*Schema
+	Comment string
+
+	DefaultSchema string
+
+	schemas map[string]*Schema
+	intern  *interner
+}
+
+// schemaIndex returns the schemas by name, building the map if the catalog
+// wasn't made by Build
+func (c *Catalog) schemaIndex() map[string]*Schema {
+	if c.schemas == nil {
+		c.schemas = make(map[string]*Schema, len(c.Schemas))
+		for _, s := range c.Schemas {
+			if _, ok := c.schemas[s.Name]; !ok {
+				c.schemas[s.Name] = s
+			}
+		}
+	}
+	return c.schemas
+}
+
+func (c *Catalog) addSchema(s *Schema) {
+	c.schemaIndex()[s.Name] = s
+	c.Schemas = append(c.Schemas, s)
+}
+
+type Schema struct {
+	Name    string
+	Tables  []*Table
+	Types   []Type
+	Comment string
+
+	tables map[string]*Table
+	types  map[string]Type
+}
+
+func (s *Schema) tableIndex() map[string]*Table {
+	if s.tables == nil {
+		s.tables = make(map[string]*Table, len(s.Tables))
+		for _, t := range s.Tables {
+			if _, ok := s.tables[t.Rel.Name]; !ok {
+				s.tables[t.Rel.Name] = t
+			}
+		}
+	}
+	return s.tables
+}
+
+func (s *Schema) typeIndex() map[string]Type {
+	if s.types == nil {
+		s.types = make(map[string]Type, len(s.Types))
+		for _, typ := range s.Types {
+			if enum, ok := typ.(*Enum); ok {
+				if _, ok := s.types[enum.Name]; !ok {
+					s.types[enum.Name] = typ
+				}
+			}
+		}
+	}
+	return s.types
+}
+
+func (s *Schema) getType(rel *ast.TypeName) (Type, error) {
+	if typ, ok := s.typeIndex()[rel.Name]; ok {
+		return typ, nil
+	}
+	return nil, sqlerr.TypeNotFound(rel.Name)
+}
+
+func (s *Schema) addType(enum *Enum) {
+	s.typeIndex()[enum.Name] = enum
+	s.Types = append(s.Types, enum)
+}
+
+func (s *Schema) getTable(rel *ast.TableName) (*Table, error) {
+	if t, ok := s.tableIndex()[rel.Name]; ok {
+		return t, nil
+	}
+	return nil, sqlerr.RelationNotFound(rel.Name)
+}
+
+func (s *Schema) addTable(t *Table) {
+	s.tableIndex()[t.Rel.Name] = t
+	s.Tables = append(s.Tables, t)
+}
+
+func (s *Schema) dropTable(t *Table) {
+	delete(s.tableIndex(), t.Rel.Name)
+	for i := range s.Tables {
+		if s.Tables[i] == t {
+			s.Tables = append(s.Tables[:i], s.Tables[i+1:]...)
+			return
+		}
+	}
+}
+
+type Table struct {
+	Rel     *ast.TableName
+	Columns []*Column
+	Comment string
+
+	columns map[string]*Column
+}
+
+func (t *Table) columnIndex() map[string]*Column {
+	if t.columns == nil {
+		t.columns = make(map[string]*Column, len(t.Columns))
+		for _, col := range t.Columns {
+			if _, ok := t.columns[col.Name]; !ok {
+				t.columns[col.Name] = col
+			}
+		}
+	}
+	return t.columns
+}
+
+func (t *Table) addColumn(col *Column) {
+	t.columnIndex()[col.Name] = col
+	t.Columns = append(t.Columns, col)
+}
+
+func (t *Table) dropColumn(col *Column) {
+	delete(t.columnIndex(), col.Name)
+	for i := range t.Columns {
+		if t.Columns[i] == col {
+			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+			return
+		}
+	}
+}
+
+// TODO: Should this just be ast Nodes?
+type Column struct {
+	Name      string
+	IsNotNull bool
+	Comment   string
+
+	// Type is shared by every column of the catalog with the same type, so
+	// it must not be changed, and columns can be compared by pointer
+	Type *ast.TypeName
+}
+
+type Type interface {
+	isType()
+
+	SetComment(string)
+}
+
+type Enum struct {
+	Name    string
+	Vals    []string
+	Comment string
+}
+
+func (e *Enum) SetComment(c string) {
+	e.Comment = c
+}
+
+func (e *Enum) isType() {
+}
@@ -0,0 +1,214 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+	sqlerr "github.com/kyleconroy/sqlc/pkg/sql/errors"
+)
+
+func stmt(n ast.Node) ast.Statement {
+	return ast.Statement{Raw: &ast.RawStmt{Stmt: n}}
+}
+
+func createTable(name string, cols ...string) ast.Statement {
+	create := &ast.CreateTableStmt{Name: &ast.TableName{Name: name}}
+	for _, col := range cols {
+		create.Cols = append(create.Cols, &ast.ColumnDef{Colname: col, TypeName: &ast.TypeName{Name: "text"}})
+	}
+	return stmt(create)
+}
+
+func tableNames(s *Schema) []string {
+	var names []string
+	for _, t := range s.Tables {
+		names = append(names, t.Rel.Name)
+	}
+	return names
+}
+
+func TestBuildKeepsOrder(t *testing.T) {
+	var stmts []ast.Statement
+	for i := 0; i < 5; i++ {
+		stmts = append(stmts, createTable(fmt.Sprintf("t%d", i), "id", "name"))
+	}
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		stmts = append(stmts, stmt(&ast.CreateSchemaStmt{Name: &name}))
+	}
+	stmts = append(stmts,
+		stmt(&ast.DropTableStmt{Tables: []*ast.TableName{{Name: "t1"}, {Name: "t3"}}}),
+		stmt(&ast.DropSchemaStmt{Schemas: []*ast.String{{Str: "a"}, {Str: "c"}}}),
+		createTable("t1", "id"),
+	)
+
+	c, err := Build(stmts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var schemas []string
+	for _, s := range c.Schemas {
+		schemas = append(schemas, s.Name)
+	}
+	if diff := cmp.Diff([]string{"main", "b"}, schemas); diff != "" {
+		t.Errorf("schemas differed (-want +got):\n%s", diff)
+	}
+	main, err := c.getSchema("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"t0", "t2", "t4", "t1"}, tableNames(main)); diff != "" {
+		t.Errorf("tables differed (-want +got):\n%s", diff)
+	}
+	if _, err := c.getSchema("a"); !errors.Is(err, sqlerr.NotFound) {
+		t.Errorf("dropped schema a was found: %v", err)
+	}
+	_, t1, err := c.getTable(&ast.TableName{Name: "t1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(t1.Columns) != 1 {
+		t.Errorf("expected the recreated t1, found %d columns", len(t1.Columns))
+	}
+}
+
+func TestAlterTableColumns(t *testing.T) {
+	name, bio := "name", "bio"
+	c, err := Build([]ast.Statement{
+		createTable("authors", "id", "name"),
+		stmt(&ast.AlterTableStmt{
+			Table: &ast.TableName{Name: "authors"},
+			Cmds: &ast.List{Items: []ast.Node{
+				&ast.AlterTableCmd{Subtype: ast.AT_AddColumn, Def: &ast.ColumnDef{Colname: "bio", TypeName: &ast.TypeName{Name: "text"}}},
+				&ast.AlterTableCmd{Subtype: ast.AT_DropColumn, Name: &name},
+				&ast.AlterTableCmd{Subtype: ast.AT_SetNotNull, Name: &bio},
+			}},
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, table, err := c.getTable(&ast.TableName{Name: "authors"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cols []string
+	for _, col := range table.Columns {
+		cols = append(cols, col.Name)
+	}
+	if diff := cmp.Diff([]string{"id", "bio"}, cols); diff != "" {
+		t.Errorf("columns differed (-want +got):\n%s", diff)
+	}
+	if !table.Columns[1].IsNotNull {
+		t.Errorf("bio should be NOT NULL")
+	}
+
+	_, err = Build([]ast.Statement{
+		createTable("authors", "id"),
+		stmt(&ast.AlterTableStmt{
+			Table: &ast.TableName{Name: "authors"},
+			Cmds: &ast.List{Items: []ast.Node{
+				&ast.AlterTableCmd{Subtype: ast.AT_DropColumn, Name: &name},
+			}},
+		}),
+	})
+	if !errors.Is(err, sqlerr.NotFound) {
+		t.Errorf("expected dropping a missing column to fail, got %v", err)
+	}
+}
+
+func TestCreateTableExists(t *testing.T) {
+	_, err := Build([]ast.Statement{createTable("authors", "id"), createTable("authors", "id")})
+	if !errors.Is(err, sqlerr.Exists) {
+		t.Errorf("expected a duplicate table to fail, got %v", err)
+	}
+	_, err = Build([]ast.Statement{createTable("authors", "id", "id")})
+	if !errors.Is(err, sqlerr.Exists) {
+		t.Errorf("expected a duplicate column to fail, got %v", err)
+	}
+}
+
+func TestInternTypes(t *testing.T) {
+	c, err := Build([]ast.Statement{
+		createTable("authors", "id", "name"),
+		createTable("books", "id", "title"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	main, err := c.getSchema("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	authors, books := main.Tables[0], main.Tables[1]
+	if authors.Columns[0].Type != books.Columns[1].Type {
+		t.Errorf("columns of type text should share a TypeName")
+	}
+	if *authors.Columns[0].Type != (ast.TypeName{Name: "text"}) {
+		t.Errorf("unexpected type %v", *authors.Columns[0].Type)
+	}
+}
+
+func TestBuildMalformed(t *testing.T) {
+	name := "a"
+	for _, test := range []struct {
+		name string
+		stmt ast.Node
+		err  string
+	}{
+		{
+			"alter table without a table",
+			&ast.AlterTableStmt{Cmds: &ast.List{Items: []ast.Node{&ast.AlterTableCmd{Subtype: ast.AT_DropColumn, Name: &name}}}},
+			"alter table: empty table name",
+		},
+		{
+			"drop column without a name",
+			&ast.AlterTableStmt{Table: &ast.TableName{Name: "t"}, Cmds: &ast.List{Items: []ast.Node{&ast.AlterTableCmd{Subtype: ast.AT_DropColumn}}}},
+			"alter table: empty column name",
+		},
+		{
+			"add column without a definition",
+			&ast.AlterTableStmt{Table: &ast.TableName{Name: "t"}, Cmds: &ast.List{Items: []ast.Node{&ast.AlterTableCmd{Subtype: ast.AT_AddColumn}}}},
+			"alter table: empty column definition",
+		},
+		{
+			"create table without a name",
+			&ast.CreateTableStmt{},
+			"create table: empty table name",
+		},
+		{
+			"column without a type",
+			&ast.CreateTableStmt{Name: &ast.TableName{Name: "u"}, Cols: []*ast.ColumnDef{{Colname: "id"}}},
+			`create table u: column "id": empty type`,
+		},
+		{
+			"create enum without a name",
+			&ast.CreateEnumStmt{},
+			"create type: empty type name",
+		},
+		{
+			"drop table without a name",
+			&ast.DropTableStmt{Tables: []*ast.TableName{nil}},
+			"drop table: empty table name",
+		},
+		{
+			"comment on column without a column",
+			&ast.CommentOnColumnStmt{Table: &ast.TableName{Name: "t"}},
+			"comment on column: empty column name",
+		},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Build([]ast.Statement{createTable("t", "id"), stmt(test.stmt)})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.Error() != test.err {
+				t.Errorf("expected error %q; got %q", test.err, err)
+			}
+		})
+	}
+}
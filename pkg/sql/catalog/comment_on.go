@@ -1,8 +1,10 @@
 package catalog
 
 import (
-	"github.com/kyleconroy/sqlc/internal/sql/ast"
-	"github.com/kyleconroy/sqlc/internal/sql/errors"
+	"fmt"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+	"github.com/kyleconroy/sqlc/pkg/sql/errors"
 )
 
 func (c *Catalog) commentOnColumn(stmt *ast.CommentOnColumnStmt) error {
@@ -10,20 +12,25 @@ func (c *Catalog) commentOnColumn(stmt *ast.CommentOnColumnStmt) error {
 	if err != nil {
 		return err
 	}
-	for i := range t.Columns {
-		if t.Columns[i].Name == stmt.Col.Name {
-			if stmt.Comment != nil {
-				t.Columns[i].Comment = *stmt.Comment
-			} else {
-				t.Columns[i].Comment = ""
-			}
-			return nil
-		}
-	}
-	return errors.ColumnNotFound(stmt.Table.Name, stmt.Col.Name)
+	if stmt.Col == nil {
+		return fmt.Errorf("comment on column: empty column name")
+	}
+	col, ok := t.columnIndex()[stmt.Col.Name]
+	if !ok {
+		return errors.ColumnNotFound(stmt.Table.Name, stmt.Col.Name)
+	}
+	if stmt.Comment != nil {
+		col.Comment = *stmt.Comment
+	} else {
+		col.Comment = ""
+	}
+	return nil
 }
 
 func (c *Catalog) commentOnSchema(stmt *ast.CommentOnSchemaStmt) error {
+	if stmt.Schema == nil {
+		return fmt.Errorf("comment on schema: empty name")
+	}
 	s, err := c.getSchema(stmt.Schema.Str)
 	if err != nil {
 		return err
@@ -0,0 +1,146 @@
+//go:build go1.18
+// +build go1.18
+
+package catalog
+
+import (
+	"testing"
+
+	"github.com/kyleconroy/sqlc/pkg/sql/ast"
+)
+
+// fuzzNames are the names statements are built from. There are few, so
+// statements often refer to the same schemas, tables and columns. The empty
+// name stands for a missing one.
+var fuzzNames = []string{"", "main", "a", "b"}
+
+// fuzzStatements decodes data into a sequence of statements. Each statement
+// takes a byte for its kind and a byte for each of its names and flags, so
+// any input decodes to something.
+func fuzzStatements(data []byte) []ast.Statement {
+	next := func() byte {
+		if len(data) == 0 {
+			return 0
+		}
+		b := data[0]
+		data = data[1:]
+		return b
+	}
+	name := func() string { return fuzzNames[int(next())%len(fuzzNames)] }
+	flag := func() bool { return next()%2 == 1 }
+	str := func() *string {
+		if n := name(); n != "" {
+			return &n
+		}
+		return nil
+	}
+	tableName := func() *ast.TableName {
+		if next()%8 == 0 {
+			return nil
+		}
+		return &ast.TableName{Schema: name(), Name: name()}
+	}
+	typeName := func() *ast.TypeName {
+		if next()%8 == 0 {
+			return nil
+		}
+		return &ast.TypeName{Schema: name(), Name: name()}
+	}
+	columnDef := func() *ast.ColumnDef {
+		if next()%8 == 0 {
+			return nil
+		}
+		return &ast.ColumnDef{Colname: name(), TypeName: typeName(), IsNotNull: flag()}
+	}
+	list := func(item func() ast.Node) *ast.List {
+		if next()%8 == 0 {
+			return nil
+		}
+		l := &ast.List{}
+		for n := next() % 4; n > 0; n-- {
+			l.Items = append(l.Items, item())
+		}
+		return l
+	}
+
+	var stmts []ast.Statement
+	for len(data) > 0 {
+		var n ast.Node
+		switch next() % 11 {
+		case 0:
+			n = &ast.AlterTableStmt{Table: tableName(), Cmds: list(func() ast.Node {
+				return &ast.AlterTableCmd{
+					Subtype:   ast.AlterTableType(next() % 5),
+					Name:      str(),
+					Def:       columnDef(),
+					MissingOk: flag(),
+				}
+			})}
+		case 1:
+			n = &ast.CommentOnColumnStmt{Table: tableName(), Col: &ast.ColumnRef{Name: name()}, Comment: str()}
+		case 2:
+			n = &ast.CommentOnSchemaStmt{Schema: &ast.String{Str: name()}, Comment: str()}
+		case 3:
+			n = &ast.CommentOnTableStmt{Table: tableName(), Comment: str()}
+		case 4:
+			n = &ast.CommentOnTypeStmt{Type: typeName(), Comment: str()}
+		case 5:
+			n = &ast.CreateEnumStmt{TypeName: typeName(), Vals: list(func() ast.Node {
+				return &ast.String{Str: name()}
+			})}
+		case 6:
+			n = &ast.CreateSchemaStmt{Name: str(), IfNotExists: flag()}
+		case 7:
+			create := &ast.CreateTableStmt{Name: tableName(), IfNotExists: flag()}
+			for i := next() % 4; i > 0; i-- {
+				create.Cols = append(create.Cols, columnDef())
+			}
+			n = create
+		case 8:
+			drop := &ast.DropSchemaStmt{MissingOk: flag()}
+			for i := next() % 3; i > 0; i-- {
+				drop.Schemas = append(drop.Schemas, &ast.String{Str: name()})
+			}
+			n = drop
+		case 9:
+			drop := &ast.DropTableStmt{IfExists: flag()}
+			for i := next() % 3; i > 0; i-- {
+				drop.Tables = append(drop.Tables, tableName())
+			}
+			n = drop
+		case 10:
+			stmts = append(stmts, ast.Statement{})
+			continue
+		}
+		stmts = append(stmts, ast.Statement{Raw: &ast.RawStmt{Stmt: n}})
+	}
+	return stmts
+}
+
+// FuzzBuild checks Build returns an error, rather than panicking, for any
+// sequence of statements, and that the catalog it returns is consistent
+func FuzzBuild(f *testing.F) {
+	f.Add([]byte{7, 1, 1, 2, 0, 2, 1, 2, 1, 0, 0, 1, 1, 2, 3, 1, 3, 2, 1, 3, 0})
+	f.Add([]byte{6, 2, 0, 7, 1, 2, 2, 0, 1, 1, 2, 1, 1, 0, 8, 0, 1, 2})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, err := Build(fuzzStatements(data))
+		if err != nil {
+			return
+		}
+		for _, s := range c.Schemas {
+			if c.schemaIndex()[s.Name] != s {
+				t.Errorf("schema %q isn't indexed", s.Name)
+			}
+			for _, tbl := range s.Tables {
+				if s.tableIndex()[tbl.Rel.Name] != tbl {
+					t.Errorf("table %q isn't indexed", tbl.Rel.Name)
+				}
+				for _, col := range tbl.Columns {
+					if tbl.columnIndex()[col.Name] != col {
+						t.Errorf("column %q of table %q isn't indexed", col.Name, tbl.Rel.Name)
+					}
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package catalog
+
+import "github.com/kyleconroy/sqlc/pkg/sql/ast"
+
+// An interner shares one copy of each identifier and type name used in a
+// catalog. Schema files repeat the same column names and types across
+// thousands of tables, so keeping each once saves memory, and columns of the
+// same type can be compared by pointer.
+type interner struct {
+	strings map[string]string
+	types   map[ast.TypeName]*ast.TypeName
+}
+
+func newInterner() *interner {
+	return &interner{
+		strings: map[string]string{},
+		types:   map[ast.TypeName]*ast.TypeName{},
+	}
+}
+
+func (in *interner) string(s string) string {
+	if v, ok := in.strings[s]; ok {
+		return v
+	}
+	in.strings[s] = s
+	return s
+}
+
+func (in *interner) strs(list []string) []string {
+	for i := range list {
+		list[i] = in.string(list[i])
+	}
+	return list
+}
+
+// typeName returns the shared copy of typ, which must not be changed
+func (in *interner) typeName(typ *ast.TypeName) *ast.TypeName {
+	key := ast.TypeName{Schema: in.string(typ.Schema), Name: in.string(typ.Name)}
+	if v, ok := in.types[key]; ok {
+		return v
+	}
+	v := &key
+	in.types[key] = v
+	return v
+}
+
+func (in *interner) tableName(rel *ast.TableName) *ast.TableName {
+	return &ast.TableName{
+		Catalog: in.string(rel.Catalog),
+		Schema:  in.string(rel.Schema),
+		Name:    in.string(rel.Name),
+	}
+}
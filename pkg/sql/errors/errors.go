@@ -1,3 +1,5 @@
+// Package errors defines the errors returned by package catalog, which can be
+// matched with errors.Is against Exists and NotFound.
 package errors
 
 import (
@@ -0,0 +1,19 @@
+// Command jsonschema prints the JSON Schema for version 2 configuration files
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/kyleconroy/sqlc/internal/config"
+)
+
+func main() {
+	blob, err := config.JSONSchema()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stdout.Write(blob); err != nil {
+		log.Fatal(err)
+	}
+}